@@ -16,6 +16,13 @@ type DeployManifestRequest struct {
 	Body hvs.DeployManifestRequest
 }
 
+// DeploySoftwareManifest API response payload
+// swagger:parameters DeployManifestResponse
+type DeployManifestResponse struct {
+	// in:body
+	Body hvs.DeployManifestResponse
+}
+
 // ---
 //
 // swagger:operation POST /rpc/deploy-software-manifest Deploy-Software-Manifest Deploy-Software-Manifest
@@ -24,6 +31,7 @@ type DeployManifestRequest struct {
 // description: |
 //              A manifest is a list of files/directories/symlinks that are to be measured. The manifest can be deployed or pushed directly to the host using the REST API described here. The Verification Service exposes this REST API to create manifest from flavor retrieved from database based cn the flavor id provided by the user and deploy it to the host whose information has been provided in the input as host id (if host is already registered to Verification Service).
 //              Creates the manifest from a software flavor which is retrieved using the flavor uuid and deploys it to the host based on the hostId provided as parameter.
+//              Once the manifest is deployed, the host is queued for trust verification against fresh host data. The response has the id of the FLAVOR_VERIFY job tracking the verification, see the Jobs API.
 //
 //
 //
@@ -32,6 +40,8 @@ type DeployManifestRequest struct {
 //  - bearerAuth: []
 // consumes:
 // - application/json
+// produces:
+// - application/json
 // parameters:
 // - name: request body
 //   required: true
@@ -45,13 +55,24 @@ type DeployManifestRequest struct {
 //   required: true
 //   enum:
 //     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
 // responses:
 //   '200':
 //     description: Successfully deployed application manifest to host.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/DeployManifestResponse"
 //   '400':
 //     description: Invalid request body provided
 //   '415':
-//     description: Invalid Content-Type Header
+//     description: Invalid Content-Type or Accept Header
 //   '500':
 //     description: Internal server error
 //
@@ -61,4 +82,8 @@ type DeployManifestRequest struct {
 //         "flavor_id":"436c729a-e3a6-4d71-8ea2-fc3b459bd4b3",
 //         "host_id":"d9d43923-05ae-4c8a-a64f-eba02473010d"
 //      }
+// x-sample-call-output: |
+//      {
+//         "job_id":"7c8a0b1e-3f52-4a3e-9d0b-5e2f3c6a9d41"
+//      }
 // ---
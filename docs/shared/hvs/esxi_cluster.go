@@ -107,6 +107,9 @@ type ESXiClusterCollection struct {
 //    | cluster_name                   | Name of the vCenter cluster. The name needs to be exactly as it appears in vCenter. |
 //    | connection_string 			   | The connection string is of the form <b>https://vCenter-url:443/sdk;u=vCenter-username;p=password"</b>. This is used to connect to vCenter and get the cluster information. |
 //
+//   The hosts of the cluster are registered and queued for trust verification. The job_id of the response is the
+//   FLAVOR_VERIFY job tracking their verification, see the Jobs API.
+//
 // x-permissions: esxi_clusters:create
 // security:
 //  - bearerAuth: []
@@ -158,7 +161,8 @@ type ESXiClusterCollection struct {
 //      {
 //          "id": "9519febc-2c8d-4bb0-afec-b7a23db5735a",
 //          "connection_string": "https://vCenter-url:443/sdk",
-//          "cluster_name": "CSS-Attestation"
+//          "cluster_name": "CSS-Attestation",
+//          "job_id": "0f4b6c1a-5d3e-4a8f-9b2c-7e1d6f3a8c59"
 //      }
 
 // ---
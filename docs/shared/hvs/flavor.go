@@ -17,6 +17,20 @@ type FlavorCreateRequest struct {
 	Body models.FlavorCreateRequest
 }
 
+// Flavors evaluate API request payload
+// swagger:parameters FlavorEvaluateRequest
+type FlavorEvaluateRequest struct {
+	// in:body
+	Body models.FlavorEvaluateRequest
+}
+
+// Flavors evaluate API response payload
+// swagger:parameters TrustReport
+type TrustReport struct {
+	// in:body
+	Body hvs.TrustReport
+}
+
 // Flavors API response payload
 // swagger:parameters Flavors
 type SignedFlavor struct {
@@ -24,6 +38,41 @@ type SignedFlavor struct {
 	Body hvs.SignedFlavor
 }
 
+// Flavor lifecycle API request payload
+// swagger:parameters FlavorLifecycle
+type FlavorLifecycle struct {
+	// in:body
+	Body hvs.FlavorLifecycle
+}
+
+// Flavors export API response and import API request payload
+// swagger:parameters FlavorBundle
+type FlavorBundle struct {
+	// in:body
+	Body hvs.FlavorBundle
+}
+
+// Flavors import API response payload
+// swagger:parameters FlavorBundleImportReport
+type FlavorBundleImportReport struct {
+	// in:body
+	Body hvs.FlavorBundleImportReport
+}
+
+// Flavors capture API request payload
+// swagger:parameters FlavorCaptureRequest
+type FlavorCaptureRequest struct {
+	// in:body
+	Body models.FlavorCaptureRequest
+}
+
+// Flavors capture and candidates API response payload
+// swagger:parameters FlavorCandidateCollection
+type FlavorCandidateCollection struct {
+	// in:body
+	Body hvs.FlavorCandidateCollection
+}
+
 // Flavors API response payload
 // swagger:parameters SignedFlavorCollection
 type SignedFlavorCollection struct {
@@ -40,7 +89,9 @@ type SignedFlavorCollection struct {
 //   A flavor is a set of measurements and metadata organized in a flexible format that allows for ease of further extension. The measurements included in the flavor pertain to various hardware, software and feature categories, and their respective metadata sections provide descriptive information.
 //
 //   The four current flavor categories:
-//   PLATFORM, OS, ASSET_TAG, HOST_UNIQUE, SOFTWARE (See the product guide for a detailed explanation)
+//   PLATFORM, OS, ASSET_TAG, HOST_UNIQUE, SOFTWARE, IMA (See the product guide for a detailed explanation)
+//
+//   IMA flavors hold an allowlist of the files measured by the kernel IMA in PCR 10. They are created from the flavor content, the "ima" section maps the path of each allowed file to its allowed digests.
 //
 //   When a flavor is created, it is associated with a flavor group. This means that the measurements for that flavor type are deemed acceptable to obtain a trusted status. If a host, associated with the same flavor group, matches the measurements contained within that flavor, the host is trusted for that particular flavor category (dependent on the flavor group policy). Searches for Flavor records. The identifying parameter can be specified as query to search flavors which will return flavor collection as a result.
 //
@@ -151,7 +202,9 @@ type SignedFlavorCollection struct {
 //
 //   Partial flavor types can be specified as an array input. In this fashion, the user can choose which flavor types to import from a host. Only flavor types that are defined in the flavor group flavor match policy can be specified. If no partial flavor types are provided, the default action is to attempt retrieval of all flavor types. The response will contain all flavor types that it was able to create.
 //
-//   If generic flavors are created, all hosts in the flavor group will be added to the backend queue, flavor verification process to re-evaluate their trust status. If host unique flavors are created, the individual affected hosts are added to the flavor verification process.
+//   If generic flavors are created, all hosts in the flavor group will be added to the backend queue, flavor verification process to re-evaluate their trust status. If host unique flavors are created, the individual affected hosts are added to the flavor verification process. The job_id of the response is the FLAVOR_VERIFY job tracking the re-evaluation of the hosts, see the Jobs API.
+//
+//   The lifecycle of the flavor content cannot contain an approval, approvals are only recorded by the capture and approve APIs.
 //
 //   The serialized FlavorCreateRequest Go struct object represents the content of the request body.
 //
@@ -383,6 +436,529 @@ type SignedFlavorCollection struct {
 
 // ---
 
+// swagger:operation POST /flavors/evaluate Flavors Evaluate-Flavors
+// ---
+//
+// description: |
+//   Evaluates candidate flavors against a host and returns the trust report the host would get if the flavors were
+//   created. The flavors are verified together with the flavorgroup requirements in the same way as the flavor
+//   verification process does. Nothing is stored, the flavors are not created, the trust cache of the host is not
+//   updated and no report or audit log entry is written.
+//
+//   The serialized FlavorEvaluateRequest Go struct object represents the content of the request body.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | host_id                        | (Optional) ID of a registered host. The latest host manifest of the host is used when host_manifest is not provided. |
+//    | host_manifest                  | (Optional) Host manifest to evaluate the flavors against. Either host_id or host_manifest must be provided. |
+//    | flavor_collection              | (Optional) A collection of candidate flavors in the defined flavor format. |
+//    | flavorgroup                    | (Optional) Flavorgroup providing the flavor match policies and additional candidate flavors. When the id of an existing flavorgroup is given, the flavors of the flavorgroup are evaluated along with the candidates, and its match policies are used unless flavor_match_policy_collection is provided. If no match policies are available, the match policies of the automatic flavorgroup are used. |
+//
+// x-permissions: flavors:evaluate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorEvaluateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully evaluated the flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/TrustReport"
+//   '400':
+//     description: Invalid request body provided, or no host manifest is available for the host
+//   '404':
+//     description: Host or flavorgroup with the given ID does not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/evaluate
+// x-sample-call-input: |
+//    {
+//        "host_id": "a3d3ab6d-1e93-4a68-9a1a-1d2c3a2c6f0b",
+//        "flavor_collection": {
+//            "flavors": [
+//                {
+//                    "flavor": {
+//                        "meta": {
+//                            "description": {
+//                                "flavor_part": "OS",
+//                                "source": "myhost.example.com",
+//                                "label": "CandidateOsFlavor",
+//                                "os_name": "RedHatEnterprise",
+//                                "os_version": "8.1",
+//                                "tpm_version": "2.0",
+//                                "tboot_installed": "true"
+//                            },
+//                            "vendor": "INTEL"
+//                        },
+//                        "pcrs": {
+//                            "SHA256": {
+//                                "pcr_17": {
+//                                    "value": "c20b1aeb4ca1a1b2de07a42d3ab8e8c5e24a3b5ba8b3c5be5f29fbf2e4c6fc0e"
+//                                }
+//                            }
+//                        }
+//                    }
+//                }
+//            ]
+//        },
+//        "flavorgroup": {
+//            "id": "ee37c360-7eae-4250-a677-6ee12adce8e2"
+//        }
+//    }
+// x-sample-call-output: |
+//    {
+//        "policy_name": "Intel Host Trust Policy",
+//        "results": [
+//            {
+//                "rule": {
+//                    "rule_name": "PcrMatchesConstant",
+//                    "markers": [
+//                        "OS"
+//                    ],
+//                    "expected_pcr": {
+//                        "index": 17,
+//                        "value": "c20b1aeb4ca1a1b2de07a42d3ab8e8c5e24a3b5ba8b3c5be5f29fbf2e4c6fc0e",
+//                        "pcr_bank": "SHA256"
+//                    }
+//                },
+//                "flavor_id": "4e6a6c85-9e27-4a4d-8b08-9bd0a0eb3bc1",
+//                "trusted": true
+//            }
+//        ],
+//        "trusted": true,
+//        "host_manifest": {
+//            "host_info": {
+//                "os_name": "RedHatEnterprise",
+//                "os_version": "8.1",
+//                "hardware_uuid": "0005AE6E-36D6-E711-906E-001560A04062"
+//            }
+//        }
+//    }
+
+// ---
+
+// swagger:operation GET /flavors/export Flavors Export-Flavors
+// ---
+//
+// description: |
+//   Exports the flavors matching the search criteria as a flavor bundle, so they can be imported in another HVS.
+//   The bundle contains the signed flavors, the flavorgroups they are linked to along with the flavor match policies
+//   and policy of the flavorgroups, and a manifest. The manifest holds the flavor signing certificate chain of this
+//   HVS, and the id, label, flavor part and content digest of each flavor. The content digest is the SHA384 digest
+//   of the flavor without its id, label and lifecycle.
+//   The search criteria are the same as for the flavor search API, all flavors are exported if none are given.
+// x-permissions: flavors:export
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   description: Flavor ID
+//   in: query
+//   type: string
+//   format: uuid
+// - name: key
+//   description: The key can be any “key” field from the meta description section of a flavor.
+//   in: query
+//   type: string
+// - name: value
+//   description: The value of the key attribute in flavor description.
+//   in: query
+//   type: string
+// - name: flavorgroupId
+//   description: The flavor group ID. Returns all the flavors associated with the flavor group ID.
+//   in: query
+//   type: string
+//   format: uuid
+// - name: flavorParts
+//   description: An array of flavor parts
+//   in: query
+//   type: array
+//   items:
+//     type: string
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully exported the flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorBundle"
+//   '400':
+//     description: Invalid search criteria provided
+//   '404':
+//     description: No flavors found to export.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/export?flavorParts=PLATFORM
+// x-sample-call-output: |
+//  {
+//    "manifest": {
+//        "version": "1.0",
+//        "created_at": "2020-08-01T10:12:34.4563-07:00",
+//        "signing_certificates": [
+//            "MIIEoDCCAwigAwIBAgIBAzANBgkqhkiG9w0BAQwFADBQMQswCQYDVQQGEwJVUzELMAkGA1UECBMCU0YxCzAJBgNVBAcTAlND..."
+//        ],
+//        "flavors": [
+//            {
+//                "flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TPM2.0_08-01-2020",
+//                "flavor_part": "PLATFORM",
+//                "digest": "6b5e5f6cd5d1d95bcae9cbe5d43be8c9b8dbc6d0a1e5fa0f3c1ee1d1e6b2a2c33c3d8b3db1b32fbb8bf4bd2cd0f26e9d"
+//            }
+//        ]
+//    },
+//    "signed_flavors": [
+//        {
+//            "flavor": {
+//                "meta": {
+//                    "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                    "description": {
+//                        "flavor_part": "PLATFORM",
+//                        "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TPM2.0_08-01-2020",
+//                        "bios_name": "Intel Corporation",
+//                        "bios_version": "SE5C620.86B.00.01.0014.070920180847",
+//                        "tpm_version": "2.0",
+//                        "tboot_installed": "true"
+//                    }
+//                },
+//                "pcrs": {
+//                    "SHA256": {
+//                        "pcr_0": {
+//                            "value": "1009d6bc1d92739e4e8e3c6819364f9149ee652804565b83bf731bdb6352b2a6"
+//                        }
+//                    }
+//                }
+//            },
+//            "signature": "EyuFK0QSSHDA3OvQA8u1ryT4nCGUTq8ZFGfMpTHuSD5ZoV82bnkXmxKZW1yhg1wT3eMQnXYKWFeXQFFvEHbfTMGkaWzXQI1e..."
+//        }
+//    ],
+//    "flavorgroups": [
+//        {
+//            "id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//            "name": "automatic",
+//            "flavorIds": [
+//                "f66ac31d-124d-418e-8200-2abf414a9adf"
+//            ],
+//            "flavor_match_policies": [
+//                {
+//                    "flavor_part": "PLATFORM",
+//                    "match_policy": {
+//                        "match_type": "ANY_OF",
+//                        "required": "REQUIRED"
+//                    }
+//                }
+//            ]
+//        }
+//    ]
+//  }
+
+// ---
+
+// swagger:operation POST /flavors/import Flavors Import-Flavors
+// ---
+//
+// description: |
+//   Imports a flavor bundle exported by another HVS. The manifest of the bundle must describe each flavor of the
+//   bundle with its content digest. The flavor signing certificate of the bundle must be issued by a root CA trusted
+//   by this HVS, and the signature of each flavor must verify with it, otherwise the bundle is rejected.
+//   The imported flavors are signed again with the flavor signing key of this HVS.
+//
+//   Each flavor of the bundle is checked against the existing flavors:
+//    | Result                         | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | imported_flavors               | The flavor does not exist and is created with the id of the bundle. |
+//    | existing_flavors               | A flavor with the same label and content digest exists. The existing flavor is linked to the flavorgroups. |
+//    | conflicts, reason ID           | A flavor with the same id but a different label or content exists. The flavor is not imported. |
+//    | conflicts, reason LABEL        | A flavor with the same label but a different content exists. The flavor is not imported. |
+//    | conflicts, reason DIGEST       | A flavor with the same content but a different label exists. The flavor is not imported. |
+//
+//   The flavorgroups of the bundle that do not exist are created with the flavor match policies and policy of the
+//   bundle, existing flavorgroups are not modified. The imported and existing flavors are linked to the flavorgroups
+//   and the hosts of the flavorgroups are queued for trust re-verification. The job_id of the report is the
+//   FLAVOR_VERIFY job tracking the re-verification, see the Jobs API.
+//
+// x-permissions: flavors:import
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorBundle"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully imported the flavor bundle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorBundleImportReport"
+//   '400':
+//     description: Invalid flavor bundle or flavor bundle signature verification failed
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/import
+// x-sample-call-input: |
+//    The flavor bundle returned by the flavors export API.
+// x-sample-call-output: |
+//  {
+//    "existing_flavors": [
+//        "f66ac31d-124d-418e-8200-2abf414a9adf"
+//    ],
+//    "conflicts": [
+//        {
+//            "flavor_id": "71e4c52e-595a-429d-9917-1965b437c353",
+//            "label": "INTEL_RedHatEnterprise_8.1_Tboot_1.9.7_08-01-2020",
+//            "existing_flavor_id": "4cdc5c5f-0e9b-4f28-bfd3-8b2e1b2bbda7",
+//            "reason": "LABEL"
+//        }
+//    ]
+//  }
+
+// ---
+
+// swagger:operation POST /flavors/candidates Flavors Capture-Flavor-Candidates
+// ---
+//
+// description: |
+//   Captures candidate flavors from a golden host. The golden host is given either by the id of a registered host
+//   or by a connection string. The candidate flavors are created in DRAFT state with an approval that records the
+//   user who captured them, so they are not used for verification until they are approved with the approve API
+//   by another user.
+//
+//   The response lists each candidate flavor with the PCR and software measurements that differ from the flavors
+//   of the same flavor part in its flavorgroups.
+//
+//   The serialized FlavorCaptureRequest Go struct object represents the content of the request body.
+//
+//    | Attribute            | Description                                     |
+//    |----------------------|-------------------------------------------------|
+//    | host_id              | (Optional) Id of the registered golden host. Either host_id or connection_string must be given. |
+//    | connection_string    | (Optional) The host connection string of the golden host. |
+//    | flavorgroup_names    | (Optional) Flavorgroup names that the candidate flavors are associated with. Defaults to automatic. |
+//    | partial_flavor_types | (Optional) List of flavor types to capture. Allowed values are PLATFORM, OS and SOFTWARE, all of them by default. |
+//
+// x-permissions: flavors:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorCaptureRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully captured the candidate flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorCandidateCollection"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No host with the provided host ID found.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/candidates
+// x-sample-call-input: |
+//    {
+//        "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//        "partial_flavor_types": ["PLATFORM"]
+//    }
+// x-sample-call-output: |
+//  {
+//    "candidates": [
+//        {
+//            "signed_flavor": {
+//                "flavor": {
+//                    "meta": {
+//                        "id": "6d8d3f4e-2d2e-4b4f-9d61-1d2a2c4b2f7c",
+//                        "description": {
+//                            "flavor_part": "PLATFORM",
+//                            "source": "golden-host",
+//                            "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0016.020120190930_TPM2.0_10-17-2020",
+//                            "bios_name": "Intel Corporation",
+//                            "bios_version": "SE5C620.86B.00.01.0016.020120190930",
+//                            "tpm_version": "2.0",
+//                            "tboot_installed": "true"
+//                        },
+//                        "lifecycle": {
+//                            "state": "DRAFT",
+//                            "approval": {
+//                                "captured_by": "admin",
+//                                "captured_from": "golden-host"
+//                            }
+//                        }
+//                    },
+//                    "pcrs": {
+//                        "SHA256": {
+//                            "pcr_0": {
+//                                "value": "2d8da1ad5bf6f4fdf32e3a4da7ee04a8cb52ebbe0a1a34c5d7ce3b0f0c7d3c3e"
+//                            }
+//                        }
+//                    }
+//                },
+//                "signature": "Lmy9S4Hu6AKsGNmmiM1jxsF8zNK6Ft1clzvnEbDhUYIFjV9LuFmtFr4UCOMUu2ujkN2RzBD7CF6XGqsZPxPHHWpUXz8UuyNHOPUxx8sjY9WTK4Qj0A5zxHzJf/Iv0dTLyKxUUfs7Q3eI0nGmqa0SHsXt7Bv1FuHcz8tAUrlA4HC8NGqMs0iPhrTWl1sO3WN7hD2wGYm0GhvrAsC/VNbWt1LrEyiMYDR1OvtNsc8yuIoGtXngZzj2G6uPJw0C9C0NI1zgwHCf1qQKdRuZRBd3MnFv5kw2qYJbvc06RrXh8b6yDkzzZ3dU9TN4/ffe0XDLPlc5lUCW6bZDtr1cTH3c3QErsWrYxHwrCLMwxJBcvuCkKMmD6bV9NxyvYJ1UtGkdKV0PkDDPdIG5FUQwb1RlMChB7DfNmjk5b3GzZuPFfZuMrJBMlbWoYcWmx2I2YyGGeiaxtt4TAa2H5NCjQPpdsD66pTJCiB+qSz9qAvsNLVXGH9o4pcmDIxB0GoHjGbjA"
+//            },
+//            "diffs": [
+//                {
+//                    "existing_flavor_id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                    "existing_label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TPM2.0_08-01-2020",
+//                    "measurements": [
+//                        {
+//                            "name": "SHA256/pcr_0",
+//                            "existing_value": "1009d6bc1d92739e4e8e3c6819364f9149ee652804565b83bf731bdb6352b2a6",
+//                            "candidate_value": "2d8da1ad5bf6f4fdf32e3a4da7ee04a8cb52ebbe0a1a34c5d7ce3b0f0c7d3c3e"
+//                        }
+//                    ]
+//                }
+//            ]
+//        }
+//    ]
+//  }
+
+// ---
+
+// swagger:operation GET /flavors/candidates Flavors Search-Flavor-Candidates
+// ---
+//
+// description: |
+//   Searches the candidate flavors captured from a golden host that are pending approval. The query parameters are
+//   the same as the flavor search API. Each candidate flavor is returned with the PCR and software measurements that
+//   differ from the flavors of the same flavor part in its flavorgroups.
+//
+// x-permissions: flavors:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: id
+//   description: Flavor ID
+//   in: query
+//   type: string
+//   format: uuid
+// - name: flavorgroupId
+//   description: Flavor group ID
+//   in: query
+//   type: string
+//   format: uuid
+// - name: flavorParts
+//   description: Array of flavor parts
+//   in: query
+//   type: array
+//   items:
+//     type: string
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the candidate flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorCandidateCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/candidates?flavorParts=PLATFORM
+// x-sample-call-output: |
+//  {
+//    "candidates": []
+//  }
+
+// ---
+
 // swagger:operation GET /flavors/{flavor_id} Flavors Retrieve-Flavor
 // ---
 //
@@ -459,6 +1035,198 @@ type SignedFlavorCollection struct {
 
 // ---
 
+// swagger:operation PUT /flavors/{flavor_id}/lifecycle Flavors Update-Flavor-Lifecycle
+// ---
+//
+// description: |
+//   Updates the lifecycle of a flavor. The lifecycle is part of the flavor meta, so the flavor is signed again
+//   with the flavor signing key. The hosts associated with the flavor are queued for trust re-verification.
+//
+//   Only ACTIVE and DEPRECATED flavors are used for verification, and only between not_before and not_after.
+//   Flavors without lifecycle are ACTIVE. A host that is trusted for a flavor part only by DEPRECATED flavors
+//   stays trusted, but the trust report contains a FLAVOR_NOT_DEPRECATED warning and the trust information of
+//   the report is marked with warning.
+//
+//   The approval of a candidate flavor captured from a golden host cannot be updated with this API, and a candidate
+//   flavor cannot be made ACTIVE or DEPRECATED until it is approved with the approve API.
+//
+//   The serialized FlavorLifecycle Go struct object represents the content of the request body.
+//
+//    | Attribute   | Description                                     |
+//    |-------------|-------------------------------------------------|
+//    | state       | (Optional) Lifecycle state of the flavor. Allowed values are DRAFT, ACTIVE, DEPRECATED and REVOKED. Defaults to ACTIVE. |
+//    | not_before  | (Optional) Time before which the flavor is not used for verification. |
+//    | not_after   | (Optional) Time from which the flavor is not used for verification, must be later than not_before. |
+//
+// x-permissions: flavors:update
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorLifecycle"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the flavor lifecycle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavor"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf/lifecycle
+// x-sample-call-input: |
+//    {
+//        "state": "DEPRECATED",
+//        "not_after": "2021-01-01T00:00:00Z"
+//    }
+// x-sample-call-output: |
+//  {
+//    "flavor": {
+//        "meta": {
+//            "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//            "description": {
+//                "flavor_part": "PLATFORM",
+//                "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TPM2.0_08-01-2020",
+//                "bios_name": "Intel Corporation",
+//                "bios_version": "SE5C620.86B.00.01.0014.070920180847",
+//                "tpm_version": "2.0",
+//                "tboot_installed": "true"
+//            },
+//            "lifecycle": {
+//                "state": "DEPRECATED",
+//                "not_after": "2021-01-01T00:00:00Z"
+//            }
+//        },
+//        "pcrs": {
+//            "SHA256": {
+//                "pcr_0": {
+//                    "value": "1009d6bc1d92739e4e8e3c6819364f9149ee652804565b83bf731bdb6352b2a6"
+//                }
+//            }
+//        }
+//    },
+//    "signature": "EyuFK0QSSHDA3OvQA8u1ryT4nCGUTq8ZFGfMpTHuSD5ZoV82bnkXmxKZW1yhg1wT3eMQnXYKWFeXQFFvEHbfTMGkaWzXQI1ezJ4PBGfWdbn+QyN3ikVBUXYWdSfbWYlA4qlq4ja80Ox9VvuBR0ZaRz4W4j8GzAJN5KQRJdJNNgTTE38G9JXUvtQcWIBGvj2zzQ7t5EUeA6gkOKt0DZHfnDmB+YQHSKB3BdAZ0hENYm6yxP7X3UL6F2E5yCvFsrDrQCfmgaqXyaTgl6CNOpsPhZhg3g6Ne5zK9/SMN0iFRDxPuKQxmrcEe7hjKw4J09QqVYNQDwJZuA/74JEy/g0A4X8GJQpHHLbWsGXQlnCZkgBWlqLOo5FG6MnAJcrt6lA0tMDz8qCmPBKoc4lV8DM8lBxzwPNwlqzZJUX2+/75E+37Q3Oa0b4W0N6adWBTe6dj2Q0v9cF0vu4XXsWGkg3yd4TTyQzCtfu9BE5evRkTYT3XSu8jk9Es59qDqc00yVtm"
+//  }
+
+// ---
+
+// swagger:operation POST /flavors/{flavor_id}/approve Flavors Approve-Flavor
+// ---
+//
+// description: |
+//   Approves a candidate flavor captured from a golden host. The flavor must be approved by a user other than the
+//   one who captured it. The approved flavor is made ACTIVE and signed again with the flavor signing key, and the
+//   hosts associated with the flavor are queued for trust re-verification.
+//
+// x-permissions: flavors:approve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully approved the flavor.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavor"
+//   '400':
+//     description: The flavor is not pending approval.
+//   '403':
+//     description: The flavor was captured by the same user.
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/6d8d3f4e-2d2e-4b4f-9d61-1d2a2c4b2f7c/approve
+// x-sample-call-output: |
+//  {
+//    "flavor": {
+//        "meta": {
+//            "id": "6d8d3f4e-2d2e-4b4f-9d61-1d2a2c4b2f7c",
+//            "description": {
+//                "flavor_part": "PLATFORM",
+//                "source": "golden-host",
+//                "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0016.020120190930_TPM2.0_10-17-2020",
+//                "bios_name": "Intel Corporation",
+//                "bios_version": "SE5C620.86B.00.01.0016.020120190930",
+//                "tpm_version": "2.0",
+//                "tboot_installed": "true"
+//            },
+//            "lifecycle": {
+//                "state": "ACTIVE",
+//                "approval": {
+//                    "captured_by": "admin",
+//                    "captured_from": "golden-host",
+//                    "approved_by": "security-officer",
+//                    "approved_at": "2020-10-18T09:12:44.513587-07:00"
+//                }
+//            }
+//        },
+//        "pcrs": {
+//            "SHA256": {
+//                "pcr_0": {
+//                    "value": "2d8da1ad5bf6f4fdf32e3a4da7ee04a8cb52ebbe0a1a34c5d7ce3b0f0c7d3c3e"
+//                }
+//            }
+//        }
+//    },
+//    "signature": "ZGQ3ZmJkNzYxYzE2ZDg1MmE0MjM3MWNiMGQ1YTE3YmUwNmU0YjRjNTI3N2MzZTU0NWI0MjZmYzY3M2Q2ZjgxYjE0OTk0ZDg1YzM3OGRmNzRlMmQ3OTg2YjVkYjdlNmU2YzliN2E1MjYxZTg0NTdlMmI1MDQyMzRmNzIwMzM3Mjc5NGFlZGE5MWYzNjE5NmQ3ZGQ2YjBmNmY5YjkzNGQ4ZTk0ZjhmNWM3MDY1NjIyNGY0YTBiOWZlMmFkMjdmMGQ1"
+//  }
+
+// ---
+
 // swagger:operation DELETE /flavors/{flavor_id} Flavors Delete-Flavor
 // ---
 //
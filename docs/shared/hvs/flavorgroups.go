@@ -61,6 +61,7 @@ type FlavorgroupFlavorLinkCollection struct {
 //       - ASSET_TAG
 //       - HOST_UNIQUE
 //       - SOFTWARE
+//       - IMA
 //
 //   <b>Match Policy</b>: The policy which defines how the host is verified against the flavors in the flavor group for
 //   the specified flavor part.
@@ -82,6 +83,16 @@ type FlavorgroupFlavorLinkCollection struct {
 //      | REQUIRED_IF_DEFINED | If a flavor of this type(flavor part) exists in the flavorgroup, then the corresponding <br> flavor is required/mandatory. If the flavor of this type is not present in the flavorgroup,<br> then flavor part will be ignored and host will still be trusted |
 //
 //
+//   <b>Policy</b>: An optional expression based policy that is evaluated in addition to the match policies.
+//
+//      | Attribute       | Description |
+//      |-----------------|-------------|
+//      | expression      | Boolean combination of flavor parts. Each node has exactly one of <b>all_of</b>, <b>any_of</b>, <br> <b>not</b> or <b>flavor_part</b>. A flavor_part node is satisfied when at least <b>min_trusted</b> <br> (default 1) flavors of the flavor part are trusted. When the expression is satisfied, the <br> faults of the flavor parts it references do not affect the trust status of the host. |
+//      | flavor_validity | List of <b>flavor_id</b> with <b>valid_from</b> and/or <b>valid_until</b> timestamps, outside of which <br> the flavor is not used for verification. |
+//      | pcr_exceptions  | List of <b>flavor_part</b>, <b>pcr_index</b> and optional <b>pcr_bank</b> whose PCR is excluded from <br> the verification of the flavors of the flavor part. |
+//      | required_pcr_banks | List of PCR banks (SHA1, SHA256, SHA384 or SHA512) that the quote of the hosts must <br> include for the hosts to be trusted. |
+//
+//
 //   <b>Default Flavor Groups</b>: Four flavor groups exist by default.
 //
 //      | Flavorgroup         | Description |
@@ -197,7 +208,9 @@ type FlavorgroupFlavorLinkCollection struct {
 //    | Attribute                      | Description|
 //    |--------------------------------|------------|
 //    | name                           | Name of the flavorgroup to be created. |
-//    | flavor_match_policy_collection | Collection of flavor match policies. Each flavor match policy contains two <br> parts: <br><b>flavor_part</b>:The type or classification of the flavor.<br> <b>match_policy</b>:The policy which defines how the host is verified against the <br> flavors in the flavor group for the specified flavor part. |
+//    | flavor_match_policy_collection | Collection of flavor match policies. Each flavor match policy contains two <br> parts: <br><b>flavor_part</b>:The type or classification of the flavor.<br> <b>match_policy</b>:The policy which defines how the host is verified against the <br> flavors in the flavor group for the specified flavor part.<br> It can optionally contain <b>rules</b>, additional verification rules registered in the verifier <br> that are referenced by <b>name</b> along with their <b>parameters</b> and applied to the hosts <br> in the flavor group for the specified flavor part. |
+//    | policy                         | Optional expression based flavor group policy with <b>expression</b>, <b>flavor_validity</b>, <br> <b>pcr_exceptions</b> and <b>required_pcr_banks</b> as described above. |
+//    | host_selector                  | Optional label selector, such as <b>env=prod,dc1/rack in (r11,r12)</b>. The existing hosts with matching labels <br> are associated with the flavor group and queued for verification, and so are the hosts created <br> or updated later with matching labels. |
 //
 // x-permissions: flavorgroups:create
 // security:
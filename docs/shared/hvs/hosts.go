@@ -48,6 +48,20 @@ type HostFlavorgroupCreateRequest struct {
 	Body hvs.HostFlavorgroupCreateRequest
 }
 
+// HostBatchCreateRequest request payload
+// swagger:parameters HostBatchCreateRequest
+type HostBatchCreateRequest struct {
+	// in:body
+	Body hvs.HostBatchCreateRequest
+}
+
+// HostBatchRequest request payload
+// swagger:parameters HostBatchRequest
+type HostBatchRequest struct {
+	// in:body
+	Body hvs.HostBatchRequest
+}
+
 // ---
 
 // swagger:operation POST /hosts Hosts CreateHost
@@ -60,12 +74,18 @@ type HostFlavorgroupCreateRequest struct {
 //   "intel:https://trustagent.server.com:1443"</br>
 //   For VMware, this includes the vCenter and host IP address or DNS host name and credentials. e.g.:
 //   "vmware:https://vCenterServer.com:443/sdk;h=trustagent.server.com;u=vCenterUsername;p=vCenterPassword"</br>
+//   For simulated hosts, when the host simulator is enabled, this includes the simulated host name and optionally the host template, the injected fault and the latency. e.g.:
+//   "simulated:https://sim-0001:1443?template=rhel&fault=PCR_DRIFT&latency=100ms"</br>
+//   For hosts running the Keylime agent, when a Keylime registrar is configured, this includes the Keylime agent URL and optionally the host name. e.g.:
+//   "keylime:https://keylime-agent.server.com:9002;h=hostname"</br>
+//   The agent must be active in the Keylime registrar and its EK certificate must be issued by one of the endorsement CAs of the HVS.</br>
 //   </pre>
 //
 //   <b>Creates a host.</b>
 //   <pre>
 //   A connection string and name for the host must be specified. This name is the value the Host Verification Service (HVS) uses to keep track of the host. It does not have to be the actual host name or IP address of the server.</br>
 //   If a flavor group is not specified, the host created will be assigned to the default “automatic” flavor group. If a flavor group is specified and does not already exist, it will be created with a default flavor match policy.</br>
+//   The host is also associated with the flavor groups whose host selector matches its labels.</br>
 //   Once the host is created, it is added to the flavor verification queue in backend.</br>
 //   </pre>
 //
@@ -77,6 +97,7 @@ type HostFlavorgroupCreateRequest struct {
 //    | connection_string | The host connection string. |
 //    | flavorgroup_names | List of flavor group names that the created host will be associated. |
 //    | description       | Host description. |
+//    | labels            | Labels grouping the host, such as "env": "prod". Keys may have a prefix separated by slashes, such as "dc1/rack". |
 //
 // x-permissions: hosts:create
 // security:
@@ -125,7 +146,11 @@ type HostFlavorgroupCreateRequest struct {
 //        "host_name": "Purley host1",
 //        "connection_string": "intel:https://trustagent.server.com:1443",
 //        "flavorgroup_names": [""],
-//        "description": "RHEL TPM2.0 Purley"
+//        "description": "RHEL TPM2.0 Purley",
+//        "labels": {
+//            "env": "prod",
+//            "dc1/rack": "r12"
+//        }
 //    }
 // x-sample-call-output: |
 //    {
@@ -136,7 +161,11 @@ type HostFlavorgroupCreateRequest struct {
 //        "hardware_uuid": "80ecce40-04b8-e811-906e-00163566263e",
 //        "flavorgroup_names": [
 //            "automatic", "platform_software"
-//        ]
+//        ],
+//        "labels": {
+//            "env": "prod",
+//            "dc1/rack": "r12"
+//        }
 //    }
 
 // ---
@@ -210,6 +239,7 @@ type HostFlavorgroupCreateRequest struct {
 //    | connection_string | The host connection string. |
 //    | flavorgroup_names | List of flavor group names that the created host will be associated. |
 //    | description       | Host description. |
+//    | labels            | Labels grouping the host, they replace the existing labels when specified. The host is associated with the flavor groups whose host selector matches the new labels. |
 //
 //
 //
@@ -324,6 +354,7 @@ type HostFlavorgroupCreateRequest struct {
 //   <b>Searches for hosts.</b>
 //   <pre>
 //   Only one identifying parameter can be specified. The parameters listed here are in the order of priority that will be evaluated.</br>
+//   The labelSelector parameter can be combined with any of them.</br>
 //   </pre>
 //
 //   Returns - The serialized HostCollection Go struct object that was retrieved, which is a collection of serialized Host Go struct objects.
@@ -366,6 +397,14 @@ type HostFlavorgroupCreateRequest struct {
 //   in: query
 //   type: string
 //   required: false
+// - name: labelSelector
+//   description: |
+//     Comma separated list of requirements on the host labels, all of them must be met. A requirement is one of
+//     key=value, key!=value, key in (value1,value2), key notin (value1,value2), key (the label is set) or !key (the label is not set).
+//     e.g. env=prod,dc1/rack in (r11,r12)
+//   in: query
+//   type: string
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -406,6 +445,309 @@ type HostFlavorgroupCreateRequest struct {
 
 // ---
 
+// swagger:operation POST /hosts/batch Hosts CreateHostBatch
+// ---
+//
+// description: |
+//   Registers a batch of hosts with a job and returns the job right away. The hosts are registered, connected to
+//   through the host data fetcher and attested in the background, as many at a time as the number of data
+//   fetchers. Each host is registered as in the Create Host API, the job has the result of every host in the order
+//   of the request. The result of a host that is registered but cannot be connected to fails with its connection
+//   state, the host is attested once it can be connected to. The job is retrieved with the Retrieve Job API.
+//
+//   The hosts are provided as a HostBatchCreateRequest JSON object, with the HostCreateRequest of every host, or
+//   as CSV. The first row of the CSV names the columns, the host_name and connection_string columns are required and
+//   the description, flavorgroup_names and labels columns are optional. Multiple flavorgroup names and labels are
+//   separated by semicolons, such as <b>env=prod;dc1/rack=r12</b>.
+//
+//   At most 5000 hosts can be registered by a batch, and a host can only be specified once.
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// - text/csv
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBatchCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+//     - text/csv
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully created the job registering the hosts.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/batch
+// x-sample-call-input: |
+//    host_name,connection_string,flavorgroup_names,labels
+//    rack12-node01,intel:https://rack12-node01.server.com:1443,automatic,env=prod;dc1/rack=r12
+//    rack12-node02,intel:https://rack12-node02.server.com:1443,automatic,env=prod;dc1/rack=r12
+// x-sample-call-output: |
+//    {
+//        "id": "4a7ab9a3-5e0b-4ed4-9d0a-6b0d1e3e5c7f",
+//        "type": "HOST_CREATE",
+//        "state": "QUEUED",
+//        "results": [
+//            {
+//                "host_name": "rack12-node01",
+//                "state": "PENDING"
+//            },
+//            {
+//                "host_name": "rack12-node02",
+//                "state": "PENDING"
+//            }
+//        ],
+//        "created": "2020-09-03T10:11:12.123456Z"
+//    }
+
+// ---
+
+// swagger:operation POST /hosts/batch/delete Hosts DeleteHostBatch
+// ---
+//
+// description: |
+//   Deletes a batch of hosts with a job and returns the job right away. The hosts are selected by their ids or by a
+//   label selector, such as <b>env=dev</b>, in a HostBatchRequest JSON object. The result of a host that does not
+//   exist fails.
+//
+// x-permissions: hosts:delete
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBatchRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully created the job deleting the hosts.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/batch/delete
+// x-sample-call-input: |
+//    {
+//        "label_selector": "env=dev"
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "5b1c9d2e-3f4a-4b5c-8d6e-7f8091a2b3c4",
+//        "type": "HOST_DELETE",
+//        "state": "QUEUED",
+//        "results": [
+//            {
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "host_name": "dev-node01",
+//                "state": "PENDING"
+//            }
+//        ],
+//        "created": "2020-09-03T10:11:12.123456Z"
+//    }
+
+// ---
+
+// swagger:operation POST /hosts/batch/flavorgroups Hosts AddFlavorgroupHostBatch
+// ---
+//
+// description: |
+//   Associates a batch of hosts with flavorgroups with a job and returns the job right away. The hosts are selected
+//   by their ids or by a label selector in a HostBatchRequest JSON object, the flavorgroup_names are required. The
+//   flavorgroups that do not exist are created. Each host is attested against its new flavorgroups with its latest
+//   host manifest, the result of the host has its trust status.
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBatchRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully created the job associating the hosts with the flavorgroups.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/batch/flavorgroups
+// x-sample-call-input: |
+//    {
+//        "host_ids": [
+//            "fc0cc779-22b6-4741-b0d9-e2e69635ad1e"
+//        ],
+//        "flavorgroup_names": [
+//            "rack12"
+//        ]
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "6c2d0e3f-4a5b-4c6d-9e7f-8091a2b3c4d5",
+//        "type": "HOST_FLAVORGROUP_ADD",
+//        "state": "QUEUED",
+//        "results": [
+//            {
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "state": "PENDING"
+//            }
+//        ],
+//        "created": "2020-09-03T10:11:12.123456Z"
+//    }
+
+// ---
+
+// swagger:operation POST /hosts/batch/verify Hosts VerifyHostBatch
+// ---
+//
+// description: |
+//   Attests a batch of hosts with a job and returns the job right away. The hosts are selected by their ids or by a
+//   label selector in a HostBatchRequest JSON object. A new host manifest is retrieved from each host, and the
+//   result of the host has its trust status.
+//
+// x-permissions: reports:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBatchRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '202':
+//     description: Successfully created the job attesting the hosts.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/batch/verify
+// x-sample-call-input: |
+//    {
+//        "label_selector": "dc1/rack in (r12,r13)"
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "7d3e1f4a-5b6c-4d7e-8f90-a1b2c3d4e5f6",
+//        "type": "HOST_VERIFY",
+//        "state": "QUEUED",
+//        "results": [
+//            {
+//                "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                "host_name": "rack12-node01",
+//                "state": "PENDING"
+//            }
+//        ],
+//        "created": "2020-09-03T10:11:12.123456Z"
+//    }
+
+// ---
+
 // swagger:operation POST /hosts/{host_id}/flavorgroups HostFlavorgroupLinks CreateHostFlavorgroupLink
 // ---
 //
@@ -79,6 +79,11 @@ type HostStatusCollection struct {
 //        - tpm_not_present
 //        - unsupported_tpm
 //      required: false
+//    - name: labelSelector
+//      description: Comma separated list of requirements on the labels of the hosts, such as env=prod,dc1/rack in (r11,r12).
+//      in: query
+//      type: string
+//      required: false
 //    - name: fromDate
 //      description: |
 //        Filters HostStatus records created after this date.
@@ -0,0 +1,236 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// Job response payload
+// swagger:parameters Job
+type Job struct {
+	// in:body
+	Body hvs.Job
+}
+
+// JobCollection response payload
+// swagger:parameters JobCollection
+type JobCollection struct {
+	// in:body
+	Body hvs.JobCollection
+}
+
+// ---
+//
+// swagger:operation GET /jobs Jobs Search-Jobs
+// ---
+//
+// description: |
+//   Searches for jobs, the latest jobs first. The jobs are returned without their results, a job is retrieved
+//   with its results by the Retrieve Job API. Besides the jobs of the batch host operations, every request that
+//   queues hosts for trust verification, such as a flavor import, a flavorgroup change, a host registration or
+//   a report refresh, creates a FLAVOR_VERIFY job. The batch host jobs that did not end when the service stopped
+//   are CANCELLED with their pending results. The jobs are deleted one day after they end.
+//
+// x-permissions: jobs:search
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: type
+//   description: Type of the jobs, one of HOST_CREATE, HOST_DELETE, HOST_FLAVORGROUP_ADD, HOST_VERIFY or FLAVOR_VERIFY.
+//   in: query
+//   type: string
+//   required: false
+// - name: state
+//   description: State of the jobs, one of QUEUED, RUNNING, COMPLETED or CANCELLED.
+//   in: query
+//   type: string
+//   required: false
+// - name: hostId
+//   description: Host ID, the jobs with a result for the host are returned.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched jobs.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/JobCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/jobs?type=FLAVOR_VERIFY&state=RUNNING
+// x-sample-call-output: |
+//      {
+//          "jobs": [
+//              {
+//                  "id": "9e5f3a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7",
+//                  "type": "FLAVOR_VERIFY",
+//                  "state": "RUNNING",
+//                  "created": "2020-09-03T10:20:30.123456Z"
+//              }
+//          ]
+//      }
+
+// ---
+
+// swagger:operation GET /jobs/{job_id} Jobs Retrieve-Job
+// ---
+//
+// description: |
+//   Retrieves a job with the result of every host. The job is QUEUED when it is created, RUNNING while the hosts
+//   are processed and COMPLETED once all the hosts are processed. The result of a host is PENDING until the host is
+//   processed, then SUCCEEDED or FAILED with the error, and has the time the host was processed. The results of the
+//   HOST_CREATE, HOST_FLAVORGROUP_ADD, HOST_VERIFY and FLAVOR_VERIFY jobs have the trust status of the attested
+//   hosts.
+//
+//   A FLAVOR_VERIFY job tracks the hosts queued for trust verification by a request, it is RUNNING from its
+//   creation. The pending results of a running FLAVOR_VERIFY job have the stage of the host in the verification
+//   queue, one of GET_HOST_DATA_QUEUED, GET_HOST_DATA_STARTED, FLAVOR_VERIFY_QUEUED or FLAVOR_VERIFY_STARTED. A host
+//   that cannot be connected to stays in the GET_HOST_DATA stages while the connection is retried.
+//
+// x-permissions: jobs:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: job_id
+//   description: Unique ID of the job.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '404':
+//     description: No relevant job records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/jobs/4a7ab9a3-5e0b-4ed4-9d0a-6b0d1e3e5c7f
+// x-sample-call-output: |
+//      {
+//          "id": "4a7ab9a3-5e0b-4ed4-9d0a-6b0d1e3e5c7f",
+//          "type": "HOST_CREATE",
+//          "state": "COMPLETED",
+//          "results": [
+//              {
+//                  "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                  "host_name": "rack12-node01",
+//                  "state": "SUCCEEDED",
+//                  "trusted": true,
+//                  "completed": "2020-09-03T10:11:30.123456Z"
+//              },
+//              {
+//                  "host_id": "b2a4e7c1-0f3d-4c5e-8a9b-1c2d3e4f5a6b",
+//                  "host_name": "rack12-node02",
+//                  "state": "FAILED",
+//                  "error": "Host was created but could not be connected to: CONNECTION FAILURE",
+//                  "completed": "2020-09-03T10:11:42.654321Z"
+//              }
+//          ],
+//          "created": "2020-09-03T10:11:12.123456Z",
+//          "completed": "2020-09-03T10:11:42.654321Z"
+//      }
+
+// ---
+
+// swagger:operation POST /jobs/{job_id}/cancel Jobs Cancel-Job
+// ---
+//
+// description: |
+//   Cancels a running FLAVOR_VERIFY job. The pending results of the job are CANCELLED and the job is CANCELLED.
+//   The hosts queued for verification by the request of the job are removed from the verification queue unless
+//   another job waits for their verification, the verification of a host that already started still completes. The
+//   hosts that were already queued by another request, such as a report refresh or a flavor change, stay queued.
+//   The jobs of the batch host operations cannot be cancelled.
+//
+// x-permissions: jobs:cancel
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: job_id
+//   description: Unique ID of the job.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully cancelled the job.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Job"
+//   '400':
+//     description: The job is not a running FLAVOR_VERIFY job
+//   '404':
+//     description: No relevant job records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/jobs/9e5f3a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7/cancel
+// x-sample-call-output: |
+//      {
+//          "id": "9e5f3a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7",
+//          "type": "FLAVOR_VERIFY",
+//          "state": "CANCELLED",
+//          "results": [
+//              {
+//                  "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                  "state": "SUCCEEDED",
+//                  "trusted": true,
+//                  "completed": "2020-09-03T10:20:41.654321Z"
+//              },
+//              {
+//                  "host_id": "b2a4e7c1-0f3d-4c5e-8a9b-1c2d3e4f5a6b",
+//                  "state": "CANCELLED",
+//                  "completed": "2020-09-03T10:25:00.123456Z"
+//              }
+//          ],
+//          "created": "2020-09-03T10:20:30.123456Z",
+//          "completed": "2020-09-03T10:25:00.123456Z"
+//      }
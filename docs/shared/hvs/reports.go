@@ -21,6 +21,20 @@ type ReportCollection struct {
 	Body hvs.ReportCollection
 }
 
+// TrustReportDiff response payload
+// swagger:parameters TrustReportDiff
+type TrustReportDiff struct {
+	// in:body
+	Body hvs.TrustReportDiff
+}
+
+// AttestationTokenClaims response payload
+// swagger:parameters AttestationTokenClaims
+type AttestationTokenClaims struct {
+	// in:body
+	Body hvs.AttestationTokenClaims
+}
+
 // Report request payload
 // swagger:parameters ReportCreateRequest
 type ReportCreateRequest struct {
@@ -81,6 +95,11 @@ type ReportCreateRequest struct {
 //   type: string
 //   format: string
 //   required: false
+// - name: labelSelector
+//   description: Comma separated list of requirements on the labels of the hosts, such as env=prod,dc1/rack in (r11,r12).
+//   in: query
+//   type: string
+//   required: false
 // - name: numberOfDays
 //   description: |
 //      Results returned will be restricted to between the current date and number of days prior. This option will override other date options.
@@ -219,12 +238,32 @@ type ReportCreateRequest struct {
 //    | host_name                      | hostname of host |
 //    | hardware_uuid                  | Hardware UUID of host |
 //
+//   The report is returned as a signed JWT attestation token when the application/jwt Accept header is provided.
+//   The token is signed with the SAML key, or with the attestation token signing key when it is configured, and
+//   expires with the report. The key ID (kid) of the token header is the SHA1 hash of the signing certificate.
+//   The claims of the token are modeled after the IETF Entity Attestation Token (EAT), they carry the same
+//   attributes as the SAML report.
+//
+//    | Claim                          | Description|
+//    |--------------------------------|------------|
+//    | sub, host_id                   | ID of host |
+//    | iss, iat, exp                  | Issuer, issue and expiry time of the token |
+//    | eat_profile                    | Profile of the token claims |
+//    | ueid                           | Universal entity ID, derived from the hardware UUID of host |
+//    | trusted                        | Overall trust status of host |
+//    | trust_markers                  | Trust status of each flavor part the host was verified against |
+//    | hardware_features              | Hardware features enabled on host |
+//    | asset_tags                     | Asset tags of host, when they were verified |
+//    | host_info                      | Host information |
+//    | tpm_version, aik_certificate, binding_key_certificate | TPM details of host |
+//    | new_boot_session               | Set when the host was rebooted since its previous attestation |
 //
 // x-permissions: reports:create
 // security:
 //  - bearerAuth: []
 // produces:
 //  - application/json
+//  - application/jwt
 // consumes:
 // - application/json
 // parameters:
@@ -247,6 +286,7 @@ type ReportCreateRequest struct {
 //   required: true
 //   enum:
 //     - application/json
+//     - application/jwt
 // responses:
 //   '201':
 //     description: Successfully created the report.
@@ -323,6 +363,32 @@ type ReportCreateRequest struct {
 //           "created": "2018-07-23T16:39:52-0700",
 //           "expiration": "2018-07-23T17:39:52-0700"
 //     }
+//
+// x-sample-call-output-jwt-claims: |
+//     {
+//           "eat_profile": "urn:intel-secl:hvs:attestation-token:v1",
+//           "ueid": "AQC1u2pE5W1E0jBMZCOGaTk",
+//           "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//           "host_name": "host-1",
+//           "trusted": true,
+//           "trust_markers": {
+//                   "HOST_UNIQUE": true,
+//                   "OS": true,
+//                   "PLATFORM": true,
+//                   "SOFTWARE": true
+//           },
+//           "hardware_features": {
+//                   "TPM": "true",
+//                   "TXT": "true"
+//           },
+//           "host_info": {...},
+//           "tpm_version": "2.0",
+//           "aik_certificate": "MIIDTDCCAbSgAwIBAgIGAXQhzk...",
+//           "exp": 1532392792,
+//           "iat": 1532389162,
+//           "iss": "AttestationService",
+//           "sub": "94824cb6-d6c8-4faf-83b0-125996ceebe2"
+//     }
 
 // ---
 
@@ -423,3 +489,222 @@ type ReportCreateRequest struct {
 //       "expiration": "2018-07-23T17:39:52-0700"
 //     }
 //   }
+
+// ---
+
+// swagger:operation GET /reports/stream Reports Stream-Reports
+// ---
+//
+// description: |
+//   Streams the reports created by the flavor verification process as Server-Sent Events. A <b>report</b> event is sent
+//   every time the verification of a host completes, its data is the serialized Report Go struct object, in the same
+//   format as returned by the report search API, and its id is the ID of the report. Only reports created after the
+//   stream is opened are sent.
+//
+//   Comments are sent periodically to keep idle connections open. The stream is closed by the server before the
+//   configured server write timeout expires, clients are expected to reconnect after the advertised retry interval
+//   with the Last-Event-ID header set to the ID of the last received report. The reports created since then are sent
+//   first, as long as they are among the latest 1000 reports.
+//
+// x-permissions: reports:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - text/event-stream
+// parameters:
+// - name: hostId
+//   description: Only stream reports of the host with this ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: flavorgroupId
+//   description: Only stream reports of hosts associated with the flavorgroup with this ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: trusted
+//   description: Only stream reports with this overall trust status
+//   in: query
+//   type: boolean
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - text/event-stream
+// - name: Last-Event-ID
+//   description: ID of the last report received before reconnecting to the stream
+//   in: header
+//   type: string
+//   format: uuid
+//   required: false
+// responses:
+//   '200':
+//     description: Successfully opened the report stream.
+//     content:
+//       text/event-stream
+//   '400':
+//     description: Invalid filter criteria or Last-Event-ID provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/stream?trusted=false
+// x-sample-call-output: |
+//   retry: 1000
+//
+//   id: 15701f03-7b1d-461c-8295-0ad6a2ef9a0c
+//   event: report
+//   data: {"id":"15701f03-7b1d-461c-8295-0ad6a2ef9a0c","trust_information":{"OVERALL":false,"flavors_trust":{...}},"host_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","host_info":{...},"created":"2020-07-23T16:39:52-07:00","expiration":"2020-07-24T16:39:52-07:00"}
+//
+//   : keep-alive
+
+// ---
+
+// swagger:operation GET /reports/{report_id}/diff Reports Diff-Reports
+// ---
+//
+// description: |
+//   Compares a report against another, usually older, report of the same host. Reports that have since been replaced
+//   by a newer report of the host are retrieved from the audit log.
+//
+//   The difference contains
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | rule_results                   | Rules that were only evaluated in one of the reports, or whose trust status or faults changed. |
+//    | pcrs                           | PCRs whose value changed, or that are only present in one of the host manifests. |
+//    | event_logs                     | Event log entries that were added to or removed from a PCR. |
+//    | host_info                      | Host info fields that changed, nested fields are separated by dots. |
+//
+// x-permissions: reports:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: report_id
+//   description: Unique ID of the Report.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: against
+//   description: Unique ID of the Report to compare against.
+//   in: query
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully compared the Reports.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/TrustReportDiff"
+//   '400':
+//     description: Invalid against report ID provided or the reports belong to different hosts
+//   '404':
+//     description: No relevant Report records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/15701f03-7b1d-461c-8295-0ad6a2ef9a0c/diff?against=a3d3ab6d-1e93-4a68-9a1a-1d2c3a2c6f0b
+// x-sample-call-output: |
+//   {
+//     "report_id": "15701f03-7b1d-461c-8295-0ad6a2ef9a0c",
+//     "against_report_id": "a3d3ab6d-1e93-4a68-9a1a-1d2c3a2c6f0b",
+//     "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//     "trusted": false,
+//     "previously_trusted": true,
+//     "rule_results": [
+//       {
+//         "change": "changed",
+//         "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrEventLogIncludes",
+//         "markers": [
+//           "OS"
+//         ],
+//         "flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//         "pcr_bank": "SHA256",
+//         "pcr_index": "pcr_17",
+//         "trusted": false,
+//         "previously_trusted": true,
+//         "added_faults": [
+//           {
+//             "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrEventLogMissingExpectedEntries",
+//             "description": "Module manifest for PCR 17 missing 1 expected entries",
+//             "pcr_index": "pcr_17",
+//             "missing_entries": [
+//               {
+//                 "digest_type": "com.intel.mtwilson.core.common.model.MeasurementSha256",
+//                 "value": "8a7d5c1e0d2b4f3a...",
+//                 "label": "vmlinuz",
+//                 "info": {
+//                   "ComponentName": "vmlinuz",
+//                   "EventName": "OpenSource.EventName"
+//                 }
+//               }
+//             ]
+//           }
+//         ]
+//       }
+//     ],
+//     "pcrs": [
+//       {
+//         "change": "changed",
+//         "pcr_bank": "SHA256",
+//         "index": "pcr_17",
+//         "value": "1c0b6f5c0d3e0bd6...",
+//         "previous_value": "4d9ca1e3d0e6b84c..."
+//       }
+//     ],
+//     "event_logs": [
+//       {
+//         "pcr_bank": "SHA256",
+//         "pcr_index": "pcr_17",
+//         "added": [
+//           {
+//             "digest_type": "com.intel.mtwilson.core.common.model.MeasurementSha256",
+//             "value": "5c6e3f9a1d7b2e4c...",
+//             "label": "vmlinuz",
+//             "info": {
+//               "ComponentName": "vmlinuz",
+//               "EventName": "OpenSource.EventName"
+//             }
+//           }
+//         ],
+//         "removed": [
+//           {
+//             "digest_type": "com.intel.mtwilson.core.common.model.MeasurementSha256",
+//             "value": "8a7d5c1e0d2b4f3a...",
+//             "label": "vmlinuz",
+//             "info": {
+//               "ComponentName": "vmlinuz",
+//               "EventName": "OpenSource.EventName"
+//             }
+//           }
+//         ]
+//       }
+//     ],
+//     "host_info": [
+//       {
+//         "field": "os_version",
+//         "value": "8.2",
+//         "previous_value": "8.1"
+//       }
+//     ]
+//   }
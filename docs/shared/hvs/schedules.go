@@ -0,0 +1,340 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// Schedule request/response payload
+// swagger:parameters Schedule
+type Schedule struct {
+	// in:body
+	Body hvs.Schedule
+}
+
+// ScheduleCollection response payload
+// swagger:parameters ScheduleCollection
+type ScheduleCollection struct {
+	// in:body
+	Body hvs.ScheduleCollection
+}
+
+// ---
+//
+// swagger:operation POST /schedules Schedules Create-Schedule
+// ---
+//
+// description: |
+//   Creates the attestation schedule of a host, or of all the hosts linked to a flavorgroup. The Host Report
+//   Refresh Service (HRRS) attests the hosts every interval of their schedule, in addition to refreshing the
+//   reports that are about to expire. A random delay of up to the jitter is added to each interval so that the
+//   hosts sharing a schedule are not all attested at once. Hosts are not attested during the maintenance windows
+//   of their schedules, the expired reports of these hosts are refreshed once the maintenance window ends.
+//
+//   A host or a flavorgroup can only have one schedule. The interval of the schedule of a host overrides the
+//   schedules of its flavorgroups, otherwise the shortest interval of its flavorgroups applies. The maintenance
+//   windows of all the schedules of the host apply.
+//
+//   The serialized Schedule Go struct object represents the content of the request body.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | host_id                        | ID of the host. Either host_id or flavorgroup_id must be specified. |
+//    | flavorgroup_id                 | ID of the flavorgroup. |
+//    | interval                       | Attestation interval, such as <b>2m</b> or <b>1h</b>. |
+//    | jitter                         | (Optional) Maximum random delay added to the interval, such as <b>30s</b>. |
+//    | maintenance_windows            | (Optional) List of recurring maintenance windows. |
+//
+//   Each maintenance window starts at the <b>start</b> time of day in UTC (HH:MM) and lasts <b>duration</b>, up
+//   to a week. The window starts every day, or only on the <b>weekdays</b> provided (Sunday to Saturday).
+//
+//   The schedules are reloaded by the HRRS on each refresh, a new schedule applies within the HRRS refresh period.
+//
+// x-permissions: schedules:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/Schedule"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Schedule"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/schedules
+// x-sample-call-input: |
+//      {
+//          "flavorgroup_id": "b7f2b0d7-7a4a-4a1e-8d6a-0c1f6e2f0c6e",
+//          "interval": "2m",
+//          "jitter": "15s",
+//          "maintenance_windows": [
+//              {
+//                  "start": "22:00",
+//                  "duration": "4h",
+//                  "weekdays": ["Saturday"]
+//              }
+//          ]
+//      }
+// x-sample-call-output: |
+//      {
+//          "id": "7a3ee5b0-2bd6-4c9c-9d62-6b3c43f4a1d0",
+//          "flavorgroup_id": "b7f2b0d7-7a4a-4a1e-8d6a-0c1f6e2f0c6e",
+//          "interval": "2m",
+//          "jitter": "15s",
+//          "maintenance_windows": [
+//              {
+//                  "start": "22:00",
+//                  "duration": "4h",
+//                  "weekdays": ["Saturday"]
+//              }
+//          ],
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation GET /schedules Schedules Search-Schedules
+// ---
+//
+// description: |
+//   Searches for schedules. Returns all schedules when no query parameter is provided.
+//
+// x-permissions: schedules:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: id
+//   description: Schedule ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: hostId
+//   description: Host ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: flavorgroupId
+//   description: Flavorgroup ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the schedules.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ScheduleCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/schedules?hostId=ee37c360-7eae-4250-a677-6ee12adce8e2
+// x-sample-call-output: |
+//      {
+//          "schedules": [
+//              {
+//                  "id": "0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c",
+//                  "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                  "interval": "1h",
+//                  "created": "2020-09-03T10:11:12.123456Z"
+//              }
+//          ]
+//      }
+
+// ---
+
+// swagger:operation GET /schedules/{schedule_id} Schedules Retrieve-Schedule
+// ---
+//
+// description: |
+//   Retrieves a schedule.
+//
+// x-permissions: schedules:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Schedule"
+//   '404':
+//     description: No relevant schedule records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/schedules/0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c
+// x-sample-call-output: |
+//      {
+//          "id": "0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c",
+//          "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//          "interval": "1h",
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation PUT /schedules/{schedule_id} Schedules Update-Schedule
+// ---
+//
+// description: |
+//   Replaces the host or flavorgroup, interval, jitter and maintenance windows of a schedule.
+//
+// x-permissions: schedules:store
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/Schedule"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Schedule"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: Schedule record not found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/schedules/0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c
+// x-sample-call-input: |
+//      {
+//          "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//          "interval": "30m",
+//          "jitter": "1m"
+//      }
+// x-sample-call-output: |
+//      {
+//          "id": "0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c",
+//          "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//          "interval": "30m",
+//          "jitter": "1m",
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation DELETE /schedules/{schedule_id} Schedules Delete-Schedule
+// ---
+//
+// description: |
+//   Deletes a schedule. The hosts of the schedule are refreshed when their reports expire.
+//
+// x-permissions: schedules:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the schedule.
+//   '404':
+//     description: The schedule to be deleted was not found.
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/schedules/0b8a8f1e-4f8e-4d8e-9d3c-2b7d2f6e1a5c
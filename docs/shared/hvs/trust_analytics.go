@@ -0,0 +1,350 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+
+// TrustTimeline response payload
+// swagger:parameters TrustTimeline
+type TrustTimeline struct {
+	// in:body
+	Body hvs.TrustTimeline
+}
+
+// TrustAnalytics response payload
+// swagger:parameters TrustAnalytics
+type TrustAnalytics struct {
+	// in:body
+	Body hvs.TrustAnalytics
+}
+
+// ---
+//
+// swagger:operation GET /hosts/{host_id}/trust-timeline Hosts Retrieve-Host-Trust-Timeline
+// ---
+//
+// description: |
+//   Retrieves how the trust status of a host changed over a period of time, computed from the report history kept
+//   in the audit log. Consecutive reports with the same trust status are compacted into an interval, overall and
+//   for each marker the host was verified against. An interval starts with the report that changed the trust
+//   status: an untrusted interval lists the rules that failed in that report with their flavors and faults, a
+//   trusted interval lists the flavors the host matched. The last report created before the period sets the trust
+//   status at the start of the period. The untrusted reports of the period are counted by failed rule and PCR.
+//
+//   The period covers the last 30 days unless fromDate, toDate or numberOfDays are given, it cannot be longer
+//   than 365 days. The report history only goes back as far as the audit log rotation allows.
+//
+// x-permissions: reports:search
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: fromDate
+//   description: |
+//     Start of the period, defaults to 30 days before toDate. Currently the following ISO 8601 date formats are supported for date parameters
+//         date                                   Ex: fromDate=2006-01-02
+//         date+time                              Ex: fromDate=2006-01-02 15:04:05
+//         date+time(with milli seconds)          Ex: fromDate=2006-01-02T15:04:05.000Z
+//         date+time(with micro seconds)          Ex: fromDate=2006-01-02T15:04:05.000000Z
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: End of the period, defaults to now. The date formats of fromDate are supported.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: numberOfDays
+//   description: The period covers this many days before now, cannot be combined with fromDate or toDate.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   maximum: 365
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the trust timeline of the host.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/TrustTimeline"
+//   '400':
+//     description: Invalid period provided
+//   '404':
+//     description: No relevant host records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/trust-timeline?fromDate=2020-09-01&toDate=2020-10-01
+// x-sample-call-output: |
+//      {
+//          "host_id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//          "from": "2020-09-01T00:00:00Z",
+//          "to": "2020-10-01T00:00:00Z",
+//          "reports": 241,
+//          "intervals": [
+//              {
+//                  "trusted": true,
+//                  "start": "2020-09-01T00:00:00Z",
+//                  "end": "2020-09-14T08:02:11.123456Z",
+//                  "report_id": "4b1ea1d1-0f21-4d9e-a1b6-7e3c1b5e2a9f",
+//                  "reports": 107,
+//                  "flavor_ids": [
+//                      "890a5f8d-51b5-4fc4-9a1b-4fdfa2b0ff68",
+//                      "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e"
+//                  ]
+//              },
+//              {
+//                  "trusted": false,
+//                  "start": "2020-09-14T08:02:11.123456Z",
+//                  "end": "2020-09-14T11:02:13.654321Z",
+//                  "report_id": "7d0c4f5a-2b3e-4c6d-9e8f-0a1b2c3d4e5f",
+//                  "reports": 2,
+//                  "flavor_ids": [
+//                      "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e"
+//                  ],
+//                  "failed_rules": [
+//                      {
+//                          "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                          "flavor_id": "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e",
+//                          "pcr_bank": "SHA256",
+//                          "pcr_index": 17,
+//                          "faults": [
+//                              {
+//                                  "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256",
+//                                  "description": "Host PCR 17 with value '7a2b...' does not match expected value '3f1c...'",
+//                                  "pcr_index": 17,
+//                                  "expected_value": "3f1c...",
+//                                  "actual_value": "7a2b..."
+//                              }
+//                          ]
+//                      }
+//                  ]
+//              },
+//              {
+//                  "trusted": true,
+//                  "start": "2020-09-14T11:02:13.654321Z",
+//                  "end": "2020-10-01T00:00:00Z",
+//                  "report_id": "1e2f3a4b-5c6d-4e7f-8a9b-0c1d2e3f4a5b",
+//                  "reports": 133,
+//                  "flavor_ids": [
+//                      "890a5f8d-51b5-4fc4-9a1b-4fdfa2b0ff68",
+//                      "a0c1e2f3-4b5c-4d6e-8f9a-b0c1d2e3f4a5"
+//                  ]
+//              }
+//          ],
+//          "markers": {
+//              "OS": [
+//                  {
+//                      "trusted": true,
+//                      "start": "2020-09-01T00:00:00Z",
+//                      "end": "2020-09-14T08:02:11.123456Z",
+//                      "report_id": "4b1ea1d1-0f21-4d9e-a1b6-7e3c1b5e2a9f",
+//                      "reports": 107,
+//                      "flavor_ids": [
+//                          "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e"
+//                      ]
+//                  },
+//                  {
+//                      "trusted": false,
+//                      "start": "2020-09-14T08:02:11.123456Z",
+//                      "end": "2020-09-14T11:02:13.654321Z",
+//                      "report_id": "7d0c4f5a-2b3e-4c6d-9e8f-0a1b2c3d4e5f",
+//                      "reports": 2,
+//                      "flavor_ids": [
+//                          "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e"
+//                      ],
+//                      "failed_rules": [
+//                          {
+//                              "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                              "flavor_id": "b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e",
+//                              "pcr_bank": "SHA256",
+//                              "pcr_index": 17,
+//                              "faults": [
+//                                  {
+//                                      "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.PcrValueMismatchSHA256",
+//                                      "description": "Host PCR 17 with value '7a2b...' does not match expected value '3f1c...'",
+//                                      "pcr_index": 17,
+//                                      "expected_value": "3f1c...",
+//                                      "actual_value": "7a2b..."
+//                                  }
+//                              ]
+//                          }
+//                      ]
+//                  },
+//                  {
+//                      "trusted": true,
+//                      "start": "2020-09-14T11:02:13.654321Z",
+//                      "end": "2020-10-01T00:00:00Z",
+//                      "report_id": "1e2f3a4b-5c6d-4e7f-8a9b-0c1d2e3f4a5b",
+//                      "reports": 133,
+//                      "flavor_ids": [
+//                          "a0c1e2f3-4b5c-4d6e-8f9a-b0c1d2e3f4a5"
+//                      ]
+//                  }
+//              ],
+//              "PLATFORM": [
+//                  {
+//                      "trusted": true,
+//                      "start": "2020-09-01T00:00:00Z",
+//                      "end": "2020-10-01T00:00:00Z",
+//                      "report_id": "4b1ea1d1-0f21-4d9e-a1b6-7e3c1b5e2a9f",
+//                      "reports": 242,
+//                      "flavor_ids": [
+//                          "890a5f8d-51b5-4fc4-9a1b-4fdfa2b0ff68"
+//                      ]
+//                  }
+//              ]
+//          },
+//          "failed_rules": [
+//              {
+//                  "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                  "reports": 2
+//              }
+//          ],
+//          "failed_pcrs": [
+//              {
+//                  "pcr_bank": "SHA256",
+//                  "pcr_index": 17,
+//                  "reports": 2
+//              }
+//          ]
+//      }
+
+// ---
+
+// swagger:operation GET /trust-analytics TrustAnalytics Retrieve-Trust-Analytics
+// ---
+//
+// description: |
+//   Aggregates the trust timelines of the hosts linked to each flavorgroup over a period of time. For every
+//   flavorgroup the analytics count the hosts, the hosts that were untrusted at some point and their reports, and
+//   give the number and the mean duration in seconds of the intervals during which a host stayed untrusted. The
+//   rules and PCRs that failed in the most untrusted reports are listed with the number of reports and hosts. The
+//   overall trust status of the hosts is considered, a host linked to several flavorgroups is counted in each of
+//   them. The untrusted intervals are cut at the boundaries of the period.
+//
+//   The period covers the last 30 days unless fromDate, toDate or numberOfDays are given, it cannot be longer
+//   than 365 days. The report history only goes back as far as the audit log rotation allows.
+//
+// x-permissions: trust_analytics:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavorgroupId
+//   description: Flavorgroup ID, only the analytics of the flavorgroup are computed.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: fromDate
+//   description: |
+//     Start of the period, defaults to 30 days before toDate. Currently the following ISO 8601 date formats are supported for date parameters
+//         date                                   Ex: fromDate=2006-01-02
+//         date+time                              Ex: fromDate=2006-01-02 15:04:05
+//         date+time(with milli seconds)          Ex: fromDate=2006-01-02T15:04:05.000Z
+//         date+time(with micro seconds)          Ex: fromDate=2006-01-02T15:04:05.000000Z
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: toDate
+//   description: End of the period, defaults to now. The date formats of fromDate are supported.
+//   in: query
+//   type: string
+//   format: date-time
+//   required: false
+// - name: numberOfDays
+//   description: The period covers this many days before now, cannot be combined with fromDate or toDate.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   maximum: 365
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully computed the trust analytics.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/TrustAnalytics"
+//   '400':
+//     description: Invalid period or flavorgroup provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/trust-analytics?fromDate=2020-09-01&toDate=2020-10-01
+// x-sample-call-output: |
+//      {
+//          "from": "2020-09-01T00:00:00Z",
+//          "to": "2020-10-01T00:00:00Z",
+//          "flavorgroups": [
+//              {
+//                  "flavorgroup_id": "c6a1b8d6-9a0e-4a40-8f77-4b4b7f0b6d4a",
+//                  "flavorgroup_name": "automatic",
+//                  "hosts": 120,
+//                  "untrusted_hosts": 7,
+//                  "reports": 28917,
+//                  "untrusted_intervals": 9,
+//                  "mean_time_untrusted": 10802.5,
+//                  "top_failing_rules": [
+//                      {
+//                          "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant",
+//                          "reports": 31,
+//                          "hosts": 6
+//                      },
+//                      {
+//                          "rule_name": "com.intel.mtwilson.core.verifier.policy.rule.PcrEventLogEqualsExcluding",
+//                          "reports": 4,
+//                          "hosts": 1
+//                      }
+//                  ],
+//                  "top_failing_pcrs": [
+//                      {
+//                          "pcr_bank": "SHA256",
+//                          "pcr_index": 0,
+//                          "reports": 27,
+//                          "hosts": 5
+//                      },
+//                      {
+//                          "pcr_bank": "SHA256",
+//                          "pcr_index": 17,
+//                          "reports": 8,
+//                          "hosts": 2
+//                      }
+//                  ]
+//              }
+//          ]
+//      }
@@ -0,0 +1,391 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// Webhook request/response payload
+// swagger:parameters Webhook
+type Webhook struct {
+	// in:body
+	Body hvs.Webhook
+}
+
+// WebhookCollection response payload
+// swagger:parameters WebhookCollection
+type WebhookCollection struct {
+	// in:body
+	Body hvs.WebhookCollection
+}
+
+// TrustChangeEvent notification payload
+// swagger:parameters TrustChangeEvent
+type TrustChangeEvent struct {
+	// in:body
+	Body hvs.TrustChangeEvent
+}
+
+// WebhookDeadLetterCollection response payload
+// swagger:parameters WebhookDeadLetterCollection
+type WebhookDeadLetterCollection struct {
+	// in:body
+	Body models.WebhookDeadLetterCollection
+}
+
+// ---
+//
+// swagger:operation POST /webhooks Webhooks Create-Webhook
+// ---
+//
+// description: |
+//   Registers a webhook that is notified whenever the overall trust status of a host, or the trust status of any
+//   of its flavor markers, changes as a result of a new trust report.
+//
+//   Each notification is a HTTP POST of a TrustChangeEvent to the webhook url with the following headers:
+//
+//    | Header                         | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | X-Hvs-Event                    | Event type, <b>host-trust-changed</b> |
+//    | X-Hvs-Delivery                 | Unique ID of the event |
+//    | X-Hvs-Timestamp                | Unix time at which the notification was sent |
+//    | X-Hvs-Signature                | <b>sha256=</b> followed by the hex encoded HMAC-SHA256 of <b>timestamp.body</b> keyed with the webhook secret |
+//
+//   Failed deliveries are retried with exponential backoff. Notifications that could not be delivered are recorded
+//   as dead letters for the webhook.
+//
+//   The serialized Webhook Go struct object represents the content of the request body.
+//
+//    | Attribute                      | Description                                     |
+//    |--------------------------------|-------------------------------------------------|
+//    | url                            | HTTPS url the notifications are posted to. |
+//    | secret                         | (Optional) Secret used to sign notifications, 16 to 255 characters. A random secret is generated if none is provided. |
+//    | description                    | (Optional) Description of the webhook. |
+//
+//   The secret is only returned in the response of this API.
+//
+// x-permissions: webhooks:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/Webhook"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the webhook.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Webhook"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks
+// x-sample-call-input: |
+//      {
+//          "url": "https://siem.example.com/hvs/events",
+//          "description": "SIEM integration"
+//      }
+// x-sample-call-output: |
+//      {
+//          "id": "1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01",
+//          "url": "https://siem.example.com/hvs/events",
+//          "secret": "5b0f1d1c6c2f4a3e9a7d8e2b4c6f0a1e3d5b7c9e1f3a5c7e9b1d3f5a7c9e1b3d",
+//          "description": "SIEM integration",
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation GET /webhooks Webhooks Search-Webhooks
+// ---
+//
+// description: |
+//   Searches for webhooks. Returns all webhooks when no query parameter is provided.
+//   Webhook secrets are not returned.
+//
+// x-permissions: webhooks:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: id
+//   description: Webhook ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: urlContains
+//   description: Substring of the webhook url
+//   in: query
+//   type: string
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the webhooks.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/WebhookCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks?urlContains=siem
+// x-sample-call-output: |
+//      {
+//          "webhooks": [
+//              {
+//                  "id": "1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01",
+//                  "url": "https://siem.example.com/hvs/events",
+//                  "description": "SIEM integration",
+//                  "created": "2020-09-03T10:11:12.123456Z"
+//              }
+//          ]
+//      }
+
+// ---
+
+// swagger:operation GET /webhooks/{webhook_id} Webhooks Retrieve-Webhook
+// ---
+//
+// description: |
+//   Retrieves a webhook. The webhook secret is not returned.
+//
+// x-permissions: webhooks:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: webhook_id
+//   description: Unique ID of the webhook.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the webhook.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Webhook"
+//   '404':
+//     description: No relevant webhook records found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks/1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01
+// x-sample-call-output: |
+//      {
+//          "id": "1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01",
+//          "url": "https://siem.example.com/hvs/events",
+//          "description": "SIEM integration",
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation PUT /webhooks/{webhook_id} Webhooks Update-Webhook
+// ---
+//
+// description: |
+//   Updates the url, description and optionally the secret of a webhook. The existing secret is retained when
+//   no secret is provided. The webhook secret is not returned.
+//
+// x-permissions: webhooks:store
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: webhook_id
+//   description: Unique ID of the webhook.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/Webhook"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the webhook.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/Webhook"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: Webhook record not found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks/1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01
+// x-sample-call-input: |
+//      {
+//          "url": "https://siem.example.com/hvs/v2/events",
+//          "description": "SIEM integration"
+//      }
+// x-sample-call-output: |
+//      {
+//          "id": "1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01",
+//          "url": "https://siem.example.com/hvs/v2/events",
+//          "description": "SIEM integration",
+//          "created": "2020-09-03T10:11:12.123456Z"
+//      }
+
+// ---
+
+// swagger:operation DELETE /webhooks/{webhook_id} Webhooks Delete-Webhook
+// ---
+//
+// description: |
+//   Deletes a webhook along with its dead letters.
+//
+// x-permissions: webhooks:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: webhook_id
+//   description: Unique ID of the webhook.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the webhook.
+//   '404':
+//     description: The webhook to be deleted was not found.
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks/1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01
+
+// ---
+
+// swagger:operation GET /webhooks/{webhook_id}/dead-letters Webhooks Search-Webhook-Dead-Letters
+// ---
+//
+// description: |
+//   Searches for the notifications that could not be delivered to a webhook after all retries were exhausted.
+//
+// x-permissions: webhooks:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: webhook_id
+//   description: Unique ID of the webhook.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: eventId
+//   description: ID of the trust change event
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: limit
+//   description: Maximum number of dead letters to return
+//   in: query
+//   type: integer
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the dead letters.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/WebhookDeadLetterCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '404':
+//     description: Webhook record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/webhooks/1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01/dead-letters?limit=10
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keylime
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/pkg/errors"
+)
+
+var log = commLog.GetDefaultLogger()
+var secLog = commLog.GetSecurityLogger()
+
+// DefaultApiVersion is the version of the Keylime REST API used when none is configured
+const DefaultApiVersion = "v2.1"
+
+// KeylimeClient retrieves the quotes of a Keylime agent and the attestation key the agent registered with the Keylime
+// registrar
+type KeylimeClient interface {
+	GetAgentInfo() (AgentInfo, error)
+	GetIntegrityQuote(nonce string, pcrMask uint32) (IntegrityQuote, error)
+	GetRegistrarAgent(agentId string) (RegistrarAgent, error)
+	GetBaseURL() *url.URL
+}
+
+// AgentInfo is returned by the info API of a Keylime agent
+type AgentInfo struct {
+	AgentUuid  string `json:"agent_uuid"`
+	TpmHashAlg string `json:"tpm_hash_alg"`
+	TpmEncAlg  string `json:"tpm_enc_alg"`
+	TpmSignAlg string `json:"tpm_sign_alg"`
+	AkHandle   uint32 `json:"ak_handle"`
+}
+
+// IntegrityQuote is returned by the integrity quote API of a Keylime agent
+type IntegrityQuote struct {
+	// Quote is 'r' followed by the base64 encoded TPM2B_ATTEST, TPMT_SIGNATURE and PCR values, separated by ':'
+	Quote    string `json:"quote"`
+	HashAlg  string `json:"hash_alg"`
+	EncAlg   string `json:"enc_alg"`
+	SignAlg  string `json:"sign_alg"`
+	BootTime int64  `json:"boot_time,omitempty"`
+	// ImaMeasurementList is the ascii runtime measurement list of the kernel IMA
+	ImaMeasurementList      string `json:"ima_measurement_list,omitempty"`
+	ImaMeasurementListEntry int    `json:"ima_measurement_list_entry,omitempty"`
+	// MbMeasurementList is the base64 encoded binary TCG event log of the measured boot
+	MbMeasurementList string `json:"mb_measurement_list,omitempty"`
+}
+
+// RegistrarAgent is returned by the agents API of a Keylime registrar
+type RegistrarAgent struct {
+	// AikTpm is the base64 encoded TPM2B_PUBLIC of the attestation key of the agent
+	AikTpm string `json:"aik_tpm"`
+	// EkTpm is the base64 encoded TPM2B_PUBLIC of the endorsement key of the TPM of the agent
+	EkTpm string `json:"ek_tpm"`
+	// EkCert is the base64 encoded DER certificate of the endorsement key
+	EkCert   string `json:"ekcert"`
+	MtlsCert string `json:"mtls_cert,omitempty"`
+	Ip       string `json:"ip"`
+	Port     int    `json:"port"`
+	RegCount int    `json:"regcount"`
+	// Active is set once the agent proved to the registrar that the attestation key is resident in the TPM of the
+	// endorsement key, by activating the credential encrypted with the endorsement key
+	Active bool `json:"active"`
+}
+
+// response is the envelope of the responses of the Keylime REST APIs
+type response struct {
+	Code    int             `json:"code"`
+	Status  string          `json:"status"`
+	Results json.RawMessage `json:"results"`
+}
+
+func NewKeylimeClient(agentApiUrl *url.URL, registrarApiUrl *url.URL, apiVersion string, tlsConfig *tls.Config) (KeylimeClient, error) {
+	if registrarApiUrl == nil {
+		return nil, errors.New("keylime/client:NewKeylimeClient() The Keylime registrar URL must be provided")
+	}
+	if apiVersion == "" {
+		apiVersion = DefaultApiVersion
+	}
+
+	return &keylimeClient{
+		BaseURL:      agentApiUrl,
+		RegistrarURL: registrarApiUrl,
+		ApiVersion:   apiVersion,
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+type keylimeClient struct {
+	BaseURL      *url.URL
+	RegistrarURL *url.URL
+	ApiVersion   string
+	httpClient   *http.Client
+}
+
+func (kc *keylimeClient) GetAgentInfo() (AgentInfo, error) {
+	log.Trace("clients/keylime_client:GetAgentInfo() Entering")
+	defer log.Trace("clients/keylime_client:GetAgentInfo() Leaving")
+
+	var agentInfo AgentInfo
+	requestURL, err := url.Parse(kc.BaseURL.String() + "/" + kc.ApiVersion + "/agent/info")
+	if err != nil {
+		return agentInfo, errors.New("client/keylime_client:GetAgentInfo() error forming GET agent info URL")
+	}
+	err = kc.get(requestURL, &agentInfo)
+	if err != nil {
+		return agentInfo, errors.Wrap(err, "client/keylime_client:GetAgentInfo() Error while getting response"+
+			" from Get agent info from Keylime agent API")
+	}
+	log.Info("client/keylime_client:GetAgentInfo() Successfully received agent info from Keylime agent")
+	return agentInfo, nil
+}
+
+func (kc *keylimeClient) GetIntegrityQuote(nonce string, pcrMask uint32) (IntegrityQuote, error) {
+	log.Trace("clients/keylime_client:GetIntegrityQuote() Entering")
+	defer log.Trace("clients/keylime_client:GetIntegrityQuote() Leaving")
+
+	var quote IntegrityQuote
+	requestURL, err := url.Parse(kc.BaseURL.String() + "/" + kc.ApiVersion + "/quotes/integrity")
+	if err != nil {
+		return quote, errors.New("client/keylime_client:GetIntegrityQuote() error forming GET integrity quote URL")
+	}
+	query := url.Values{}
+	query.Set("nonce", nonce)
+	query.Set("mask", fmt.Sprintf("0x%x", pcrMask))
+	// the public key of the agent is only used by the Keylime verifier to deliver the payload keys
+	query.Set("partial", "1")
+	query.Set("ima_ml_entry", "0")
+	requestURL.RawQuery = query.Encode()
+
+	err = kc.get(requestURL, &quote)
+	if err != nil {
+		return quote, errors.Wrap(err, "client/keylime_client:GetIntegrityQuote() Error while getting response"+
+			" from Get integrity quote from Keylime agent API")
+	}
+	log.Info("client/keylime_client:GetIntegrityQuote() Successfully received integrity quote from Keylime agent")
+	return quote, nil
+}
+
+func (kc *keylimeClient) GetRegistrarAgent(agentId string) (RegistrarAgent, error) {
+	log.Trace("clients/keylime_client:GetRegistrarAgent() Entering")
+	defer log.Trace("clients/keylime_client:GetRegistrarAgent() Leaving")
+
+	var agent RegistrarAgent
+	requestURL, err := url.Parse(kc.RegistrarURL.String() + "/" + kc.ApiVersion + "/agents/" + url.PathEscape(agentId))
+	if err != nil {
+		return agent, errors.New("client/keylime_client:GetRegistrarAgent() error forming GET registrar agent URL")
+	}
+	err = kc.get(requestURL, &agent)
+	if err != nil {
+		return agent, errors.Wrap(err, "client/keylime_client:GetRegistrarAgent() Error while getting response"+
+			" from Get agent from Keylime registrar API")
+	}
+	log.Info("client/keylime_client:GetRegistrarAgent() Successfully received agent from Keylime registrar")
+	return agent, nil
+}
+
+func (kc *keylimeClient) GetBaseURL() *url.URL {
+	return kc.BaseURL
+}
+
+// get sends a GET request to a Keylime API and unmarshals the results of the response
+func (kc *keylimeClient) get(requestURL *url.URL, results interface{}) error {
+	log.Debugf("clients/keylime_client:get() Keylime GET request URL: %s", requestURL.String())
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpResponse, err := kc.httpClient.Do(httpRequest)
+	if err != nil {
+		return errors.Wrap(err, "Error from response")
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return errors.New("HTTP Status :" + strconv.Itoa(httpResponse.StatusCode))
+	}
+
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return errors.Wrap(err, "Error reading response body")
+	}
+	secLog.Debugf("clients/keylime_client:get() Keylime response: %s", string(body))
+
+	var keylimeResponse response
+	if err = json.Unmarshal(body, &keylimeResponse); err != nil {
+		return errors.Wrap(err, "Error unmarshalling response")
+	}
+	if err = json.Unmarshal(keylimeResponse.Results, results); err != nil {
+		return errors.Wrap(err, "Error unmarshalling response results")
+	}
+	return nil
+}
@@ -94,6 +94,8 @@ func (a *App) Run(args []string) error {
 			return errInvalidCmd
 		}
 		return a.configDBRotation()
+	case "verify-manifest":
+		return a.verifyManifest(args[2:])
 	case "uninstall":
 		// the only allowed flag is --purge
 		purge := false
@@ -6,9 +6,11 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/webhook"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -37,6 +39,14 @@ type Configuration struct {
 	DB     commConfig.DBConfig     `yaml:"db" mapstructure:"db"`
 	HRRS   hrrs.HRRSConfig         `yaml:"hrrs" mapstructure:"hrrs"`
 	FVS    FVSConfig               `yaml:"fvs" mapstructure:"fvs"`
+
+	Webhook webhook.WebhookConfig `yaml:"webhook" mapstructure:"webhook"`
+
+	// AttestationToken is the key signing the attestation tokens, the SAML key is used when it is not configured
+	AttestationToken AttestationTokenConfig `yaml:"attestation-token" mapstructure:"attestation-token"`
+
+	HostSimulator HostSimulatorConfig `yaml:"host-simulator" mapstructure:"host-simulator"`
+	Keylime       KeylimeConfig       `yaml:"keylime" mapstructure:"keylime"`
 }
 
 type HVSConfig struct {
@@ -51,12 +61,35 @@ type FVSConfig struct {
 	SkipFlavorSignatureVerification bool `yaml:"skip-flavor-signature-verification" mapstructure:"skip-flavor-signature-verification"`
 }
 
+// HostSimulatorConfig enables the simulated hosts, for load and integration testing only
+type HostSimulatorConfig struct {
+	Enabled     bool          `yaml:"enabled" mapstructure:"enabled"`
+	TemplateDir string        `yaml:"template-dir" mapstructure:"template-dir"`
+	Latency     time.Duration `yaml:"latency" mapstructure:"latency"`
+}
+
+// KeylimeConfig enables the hosts running the Keylime agent, they are attested when a registrar URL is configured
+type KeylimeConfig struct {
+	RegistrarURL   string `yaml:"registrar-url" mapstructure:"registrar-url"`
+	ApiVersion     string `yaml:"api-version" mapstructure:"api-version"`
+	CaCertFile     string `yaml:"ca-cert-file" mapstructure:"ca-cert-file"`
+	ClientCertFile string `yaml:"client-cert-file" mapstructure:"client-cert-file"`
+	ClientKeyFile  string `yaml:"client-key-file" mapstructure:"client-key-file"`
+}
+
 type SAMLConfig struct {
 	CommonConfig    commConfig.SigningCertConfig `yaml:"common" mapstructure:"common"`
 	Issuer          string                       `yaml:"issuer" mapstructure:"issuer"`
 	ValiditySeconds int                          `yaml:"validity-seconds" mapstructure:"validity-seconds"`
 }
 
+// AttestationTokenConfig sets the key and certificate signing the attestation tokens of the reports. The key
+// must be a 3072 or 4096 bits RSA key or a P-256 or P-384 ECDSA key
+type AttestationTokenConfig struct {
+	SigningKeyFile  string `yaml:"signing-key-file" mapstructure:"signing-key-file"`
+	SigningCertFile string `yaml:"signing-cert-file" mapstructure:"signing-cert-file"`
+}
+
 type AuditLogConfig struct {
 	MaxRowCount int `yaml:"max-row-count" mapstructure:"max-row-count"`
 	NumRotated  int `yaml:"number-rotated" mapstructure:"number-rotated"`
@@ -61,6 +61,14 @@ const (
 	PrivacyCACertFile = TrustedCaCertsDir + "privacy-ca/privacy-ca-cert.pem"
 	PrivacyCAKeyFile  = TrustedKeysDir + "privacy-ca.key"
 
+	// host templates of the host simulator
+	DefaultHostSimulatorTemplateDir = ConfigDir + "host-templates/"
+
+	// mTLS certificates of the Keylime agents and registrar
+	DefaultKeylimeCaCertFile     = TrustedCaCertsDir + "keylime/cacert.crt"
+	DefaultKeylimeClientCertFile = ConfigDir + "keylime/client-cert.crt"
+	DefaultKeylimeClientKeyFile  = TrustedKeysDir + "keylime-client.key"
+
 	//TODO remove or dont use temporary files
 	AikRequestsDir            = HomeDir + "privacyca-aik-requests/"
 	EndorsementCACertDir      = ConfigDir + "certs/endorsement/"
@@ -123,6 +131,36 @@ const (
 	DefaultMaxHeaderBytes    = 1 << 20
 )
 
+// report stream constants
+const (
+	DefaultReportStreamBufferSize = 100
+	ReportStreamKeepAliveInterval = 10 * time.Second
+	// the latest reports are kept to be replayed to the clients reconnecting with the Last-Event-ID header
+	ReportStreamHistorySize = 1000
+	// the report stream is closed this long before the server write timeout expires, clients
+	// reconnect after ReportStreamRetryMillis
+	ReportStreamCloseMargin = 5 * time.Second
+	ReportStreamRetryMillis = 1000
+)
+
+// job constants
+const (
+	// MaxHostBatchSize limits the number of hosts of a batch host operation
+	MaxHostBatchSize = 5000
+	// the completed and cancelled jobs are deleted once they are older than JobRetention, the deletion is
+	// attempted at most every JobCleanupInterval
+	JobRetention       = 24 * time.Hour
+	JobCleanupInterval = time.Hour
+)
+
+// trust analytics constants
+const (
+	// the trust timeline and analytics cover the last DefaultTrustHistoryDays unless a period is given
+	DefaultTrustHistoryDays = 30
+	// TrustAnalyticsTopFailures limits the failing rules and PCRs listed for each flavorgroup
+	TrustAnalyticsTopFailures = 10
+)
+
 // db constants
 const (
 	DBTypePostgres = "postgres"
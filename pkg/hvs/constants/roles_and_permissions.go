@@ -33,6 +33,11 @@ const (
 	FlavorRetrieve = "flavors:retrieve"
 	FlavorSearch   = "flavors:search"
 	FlavorDelete   = "flavors:delete"
+	FlavorEvaluate = "flavors:evaluate"
+	FlavorUpdate   = "flavors:update"
+	FlavorExport   = "flavors:export"
+	FlavorImport   = "flavors:import"
+	FlavorApprove  = "flavors:approve"
 
 	TagFlavorCreate = "tag_flavors:create"
 	HostUniqueFlavorCreate = "host_unique_flavors:create"
@@ -55,6 +60,24 @@ const (
 	ReportRetrieve = "reports:retrieve"
 	ReportSearch   = "reports:search"
 
+	WebhookCreate   = "webhooks:create"
+	WebhookStore    = "webhooks:store"
+	WebhookRetrieve = "webhooks:retrieve"
+	WebhookSearch   = "webhooks:search"
+	WebhookDelete   = "webhooks:delete"
+
+	ScheduleCreate   = "schedules:create"
+	ScheduleStore    = "schedules:store"
+	ScheduleRetrieve = "schedules:retrieve"
+	ScheduleSearch   = "schedules:search"
+	ScheduleDelete   = "schedules:delete"
+
+	JobRetrieve = "jobs:retrieve"
+	JobSearch   = "jobs:search"
+	JobCancel   = "jobs:cancel"
+
+	TrustAnalyticsRetrieve = "trust_analytics:retrieve"
+
 	// AssetTagAPI
 	TagCertificateCreate = "tag_certificates:create"
 	TagCertificateDelete = "tag_certificates:delete"
@@ -13,11 +13,18 @@ const (
 	RuleAikCertificateTrusted       = RulePrefix + "AikCertificateTrusted"
 	RuleAssetTagMatches             = RulePrefix + "AssetTagMatches"
 	RuleFlavorTrusted               = RulePrefix + "FlavorTrusted"
+	RuleFlavorGroupPolicy           = RulePrefix + "FlavorGroupPolicy"
+	RuleFlavorNotDeprecated         = RulePrefix + "FlavorNotDeprecated"
+	RuleImaLogIncludes              = RulePrefix + "ImaLogIncludes"
+	RuleImaLogIntegrity             = RulePrefix + "ImaLogIntegrity"
 	RulePcrEventLogEquals           = RulePrefix + "PcrEventLogEquals"
 	RulePcrEventLogIncludes         = RulePrefix + "PcrEventLogIncludes"
 	RulePcrEventLogIntegrity        = RulePrefix + "PcrEventLogIntegrity"
 	RulePcrMatchesConstant          = RulePrefix + "PcrMatchesConstant"
+	RuleQuoteFreshness              = RulePrefix + "QuoteFreshness"
+	RuleRequiredPcrBanks            = RulePrefix + "RequiredPcrBanks"
 	RuleTagCertificateTrusted       = RulePrefix + "TagCertificateTrusted"
+	RuleUefiSecureBoot              = RulePrefix + "UefiSecureBoot"
 	RuleXmlMeasurementsDigestEquals = RulePrefix + "XmlMeasurementsDigestEquals"
 	RuleXmlMeasurementLogEquals     = RulePrefix + "XmlMeasurementLogEquals"
 	RulePcrEventLogEqualsExcluding  = RulePrefix + "PcrEventLogEqualsExcluding"
@@ -35,19 +42,36 @@ const (
 	FaultAssetTagMismatch                           = FaultPrefix + "AssetTagMismatch"
 	FaultAssetTagMissing                            = FaultPrefix + "AssetTagMissing"
 	FaultAssetTagNotProvisioned                     = FaultPrefix + "AssetTagNotProvisioned"
+	FaultFlavorDeprecated                           = FaultPrefix + "FlavorDeprecated"
+	FaultFlavorGroupPolicyNotSatisfied              = FaultPrefix + "FlavorGroupPolicyNotSatisfied"
 	FaultFlavorSignatureMissing                     = FaultPrefix + "FlavorSignatureMissing"
 	FaultRequiredFlavorTypeMissing                  = FaultPrefix + "RequiredFlavorTypeMissing"
 	FaultFlavorSignatureNotTrusted                  = FaultPrefix + "FlavorSignatureNotTrusted"
 	FaultFlavorSignatureVerificationFailed          = FaultPrefix + "FlavorSignatureVerificationFailed"
+	FaultImaLogContainsUnexpectedEntries            = FaultPrefix + "ImaLogContainsUnexpectedEntries"
+	FaultImaLogInvalid                              = FaultPrefix + "ImaLogInvalid"
+	FaultImaLogMissing                              = FaultPrefix + "ImaLogMissing"
+	FaultImaLogMissingExpectedEntries               = FaultPrefix + "ImaLogMissingExpectedEntries"
 	FaultPcrEventLogContainsUnexpectedEntries       = FaultPrefix + "PcrEventLogContainsUnexpectedEntries"
 	FaultPcrEventLogInvalid                         = FaultPrefix + "PcrEventLogInvalid"
 	FaultPcrEventLogMissing                         = FaultPrefix + "PcrEventLogMissing"
 	FaultPcrEventLogMissingExpectedEntries          = FaultPrefix + "PcrEventLogMissingExpectedEntries"
+	FaultPcrBankMissing                             = FaultPrefix + "PcrBankMissing"
 	FaultPcrManifestMissing                         = FaultPrefix + "PcrManifestMissing"
 	FaultPcrValueMismatch                           = FaultPrefix + "PcrValueMismatch"
 	FaultPcrValueMismatchSHA1                       = FaultPcrValueMismatch + "SHA1"
 	FaultPcrValueMismatchSHA256                     = FaultPcrValueMismatch + "SHA256"
 	FaultPcrValueMissing                            = FaultPrefix + "PcrValueMissing"
+	FaultQuoteClockRollback                         = FaultPrefix + "QuoteClockRollback"
+	FaultQuoteNonceReused                           = FaultPrefix + "QuoteNonceReused"
+	FaultQuoteResetCountRollback                    = FaultPrefix + "QuoteResetCountRollback"
+	FaultQuoteRestartCountRollback                  = FaultPrefix + "QuoteRestartCountRollback"
+	FaultQuoteTpmReset                              = FaultPrefix + "QuoteTpmReset"
+	FaultSecureBootDbxOutdated                      = FaultPrefix + "SecureBootDbxOutdated"
+	FaultSecureBootDisabled                         = FaultPrefix + "SecureBootDisabled"
+	FaultSecureBootIssuerMismatch                   = FaultPrefix + "SecureBootIssuerMismatch"
+	FaultSecureBootVariableInvalid                  = FaultPrefix + "SecureBootVariableInvalid"
+	FaultSecureBootVariableMissing                  = FaultPrefix + "SecureBootVariableMissing"
 	FaultTagCertificateExpired                      = FaultPrefix + "TagCertificateExpired"
 	FaultTagCertificateMissing                      = FaultPrefix + "TagCertificateMissing"
 	FaultTagCertificateNotTrusted                   = FaultPrefix + "TagCertificateNotTrusted"
@@ -92,7 +92,15 @@ func (controller *DeploySoftwareManifestController) DeployManifest(w http.Respon
 			"DeployManifest() %s : Failed to deploy manifest to host", commLogMsg.AppRuntimeErr)
 		return nil, httpStatus, &commErr.ResourceError{Message: "Failed to deploy manifest to host"}
 	}
-	return nil, httpStatus, nil
+
+	// the measurements of the host change with the deployed manifest, the host is verified against fresh host data
+	jobId, err := controller.HController.HTManager.VerifyHostsAsync([]uuid.UUID{reqDeployManifest.HostId}, true, false)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/deploy_software_manifest_controller:"+
+			"DeployManifest() %s : Host to Flavor Verify Queue addition failed", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
+	}
+	return hvs.DeployManifestResponse{JobId: jobIdRef(jobId)}, httpStatus, nil
 }
 
 func (controller *DeploySoftwareManifestController) deployManifestToHost(hostId uuid.UUID, manifest model.Manifest) (int, error) {
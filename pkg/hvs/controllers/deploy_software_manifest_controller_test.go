@@ -6,6 +6,8 @@
 package controllers_test
 
 import (
+	"encoding/json"
+
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
@@ -14,6 +16,7 @@ import (
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"net/http"
@@ -85,6 +88,9 @@ var _ = Describe("DeploySoftwareManifestController", func() {
 				w = httptest.NewRecorder()
 				router.ServeHTTP(w, req)
 				Expect(w.Code).To(Equal(http.StatusOK))
+				var deployManifestResponse hvs.DeployManifestResponse
+				Expect(json.Unmarshal(w.Body.Bytes(), &deployManifestResponse)).To(Succeed())
+				Expect(deployManifestResponse.JobId).NotTo(BeNil())
 			})
 		})
 	})
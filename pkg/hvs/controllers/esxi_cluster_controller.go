@@ -17,10 +17,10 @@ import (
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	hcUtil "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
-	"github.com/vmware/govmomi/vim25/mo"
 	"net/http"
 	"net/url"
 	"strings"
@@ -100,6 +100,7 @@ func (controller ESXiClusterController) Create(w http.ResponseWriter, r *http.Re
 	}
 
 	var hostNames []string
+	var hostIds []uuid.UUID
 	for _, hostInfo := range hostInfoList {
 		description := hostInfo.Name + " in ESX Cluster " + reqESXiCluster.ClusterName
 
@@ -108,7 +109,7 @@ func (controller ESXiClusterController) Create(w http.ResponseWriter, r *http.Re
 			Description:      description,
 			ConnectionString: reqESXiCluster.ConnectionString + ";h=" + hostInfo.Name,
 		}
-		_, _, err := controller.HController.CreateHost(reqHost)
+		createdHost, _, err := controller.HController.createHostWithoutVerify(reqHost)
 		if err != nil {
 			defaultLog.WithError(err).Errorf("controllers/esxi_cluster_controller:Create() ESXi host registration "+
 				"failed for host : %s", hostInfo.Name)
@@ -116,6 +117,18 @@ func (controller ESXiClusterController) Create(w http.ResponseWriter, r *http.Re
 				"failed for host : " + hostInfo.Name}
 		}
 		hostNames = append(hostNames, hostInfo.Name)
+		hostIds = append(hostIds, createdHost.Id)
+	}
+
+	// the hosts of the cluster are verified by a single FLAVOR_VERIFY job, the forceUpdate flag is set so that
+	// the latest host manifest of the new hosts is verified
+	if len(hostIds) > 0 {
+		jobId, err := controller.HController.HTManager.VerifyHostsAsync(hostIds, true, false)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/esxi_cluster_controller:Create() Host to Flavor Verify Queue addition failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add ESXi hosts to Flavor Verify Queue"}
+		}
+		newESXiCluster.JobId = jobIdRef(jobId)
 	}
 	newESXiCluster.ConnectionString = utils.GetConnectionStringWithoutCredentials(newESXiCluster.ConnectionString)
 
@@ -309,7 +322,7 @@ func getECCriteria(params url.Values) (*models.ESXiClusterFilterCriteria, error)
 	return &ecfc, nil
 }
 
-func (controller *ESXiClusterController) getHostsFromCluster(reqESXiCluster *hvs.ESXiClusterCreateRequest) ([]mo.HostSystem, error) {
+func (controller *ESXiClusterController) getHostsFromCluster(reqESXiCluster *hvs.ESXiClusterCreateRequest) ([]hcTypes.ClusterHost, error) {
 	defaultLog.Trace("controllers/esxi_cluster_controller:getHostsFromCluster() Entering")
 	defer defaultLog.Trace("controllers/esxi_cluster_controller:getHostsFromCluster() Leaving")
 	hostConnectorFactory, err := controller.HController.HCConfig.HostConnectorProvider.NewHostConnector(reqESXiCluster.ConnectionString)
@@ -208,6 +208,9 @@ var _ = Describe("ESXiClusterController", func() {
 				w = httptest.NewRecorder()
 				router.ServeHTTP(w, req)
 				Expect(w.Code).To(Equal(http.StatusCreated))
+				var esxiCluster hvs.ESXiCluster
+				Expect(json.Unmarshal(w.Body.Bytes(), &esxiCluster)).To(Succeed())
+				Expect(esxiCluster.JobId).NotTo(BeNil())
 			})
 		})
 		Context("Provide an invalid request body to create a new ESXi cluster record", func() {
@@ -19,6 +19,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/auth"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	comctx "github.com/intel-secl/intel-secl/v3/pkg/lib/common/context"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	ct "github.com/intel-secl/intel-secl/v3/pkg/lib/common/types/aas"
@@ -30,11 +31,13 @@ import (
 	fType "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
 	fu "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/util"
 	hcType "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type FlavorController struct {
@@ -49,6 +52,10 @@ type FlavorController struct {
 
 var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true}
 
+// goldenHostFlavorParts are the flavor parts captured from a golden host by default. The host unique and asset tag
+// flavor parts are specific to a host and cannot be captured from a golden host
+var goldenHostFlavorParts = []fc.FlavorPart{fc.FlavorPartPlatform, fc.FlavorPartOs, fc.FlavorPartSoftware}
+
 func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *dm.CertificatesStore, hcConfig domain.HostControllerConfig) *FlavorController {
 	// certStore should have an entry for Flavor Signing CA
 	if _, found := (*certStore)[dm.CertTypesFlavorSigning.String()]; !found {
@@ -126,7 +133,7 @@ func (fcon *FlavorController) Create(w http.ResponseWriter, r *http.Request) (in
 		}
 	}
 
-	signedFlavors, err = fcon.createFlavors(flavorCreateReq)
+	signedFlavors, jobId, err := fcon.createFlavors(flavorCreateReq)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/flavor_controller:Create() Error creating flavors")
 		if strings.Contains(err.Error(), "duplicate key") {
@@ -142,11 +149,12 @@ func (fcon *FlavorController) Create(w http.ResponseWriter, r *http.Request) (in
 	if flavorCreateReq.FlavorParts != nil && len(flavorCreateReq.FlavorParts) > 0 {
 		signedFlavorCollection = orderFlavorsPerFlavorParts(flavorCreateReq.FlavorParts, signedFlavorCollection)
 	}
+	signedFlavorCollection.JobId = jobIdRef(jobId)
 	secLog.Info("Flavors created successfully")
 	return signedFlavorCollection, http.StatusCreated, nil
 }
 
-func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([]hvs.SignedFlavor, error) {
+func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([]hvs.SignedFlavor, uuid.UUID, error) {
 	defaultLog.Trace("controllers/flavor_controller:createFlavors() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:createFlavors() Leaving")
 
@@ -156,52 +164,11 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 
 	if flavorReq.ConnectionString != "" {
 		// get flavor from host
-		// get host manifest from the host
 		defaultLog.Debug("Host connection string given, trying to create flavors from host")
-		connectionString, _, err := GenerateConnectionString(flavorReq.ConnectionString,
-			fcon.HostCon.HCConfig.Username,
-			fcon.HostCon.HCConfig.Password,
-			fcon.HostCon.HCStore)
-
-		if err != nil {
-			defaultLog.Error("controllers/flavor_controller:CreateFlavors() Could not generate formatted connection string")
-			return nil, errors.Wrap(err, "Error while generating a formatted connection string")
-		}
-		defaultLog.Debug("Getting manifest from host...")
-		hostManifest, err := fcon.getHostManifest(connectionString)
-		if err != nil {
-			defaultLog.Error("controllers/flavor_controller:CreateFlavors() Error getting host manifest")
-			return nil, errors.Wrap(err, "Error getting host manifest")
-		}
-		tagCertificate := hvs.TagCertificate{}
-		var tagX509Certificate *x509.Certificate
-		tcFilterCriteria := dm.TagCertificateFilterCriteria{
-			HardwareUUID: uuid.MustParse(hostManifest.HostInfo.HardwareUUID),
-		}
-		tagCertificates, err := fcon.TCStore.Search(&tcFilterCriteria)
-		if err != nil {
-			defaultLog.Debugf("Unable to retrieve tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
-		}
-		if len(tagCertificates) >= 1 {
-			tagCertificate = *tagCertificates[0]
-			tagX509Certificate, err = x509.ParseCertificate(tagCertificate.Certificate)
-			if err != nil {
-				defaultLog.Errorf("controllers/flavor_controller: Failed to parse x509.Certificate from tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
-				return nil, errors.Wrapf(err, "Failed to parse x509.Certificate from tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
-			}
-			defaultLog.Debugf("Tag attribute certificate exists for the host with hardware UUID: %s", hostManifest.HostInfo.HardwareUUID)
-		}
-		// create a platform flavor with the host manifest information
-		defaultLog.Debug("Creating flavor from host manifest using flavor library")
-		newPlatformFlavor, err := flavor.NewPlatformFlavorProvider(hostManifest, tagX509Certificate)
-		if err != nil {
-			defaultLog.Errorf("controllers/flavor_controller:createFlavors() Error while creating platform flavor instance from host manifest and tag certificate")
-			return nil, errors.Wrap(err, "Error while creating platform flavor instance from host manifest and tag certificate")
-		}
-		platformFlavor, err = newPlatformFlavor.GetPlatformFlavor()
+		var err error
+		platformFlavor, err = fcon.getPlatformFlavor(flavorReq.ConnectionString)
 		if err != nil {
-			defaultLog.Errorf("controllers/flavor_controller:createFlavors() Error while creating platform flavors for host %s", hostManifest.HostInfo.HardwareUUID)
-			return nil, errors.Wrapf(err, " Error while creating platform flavors for host %s", hostManifest.HostInfo.HardwareUUID)
+			return nil, uuid.Nil, err
 		}
 		// add all the flavor parts from create request to the list flavor parts to be associated with a flavorgroup
 		if len(flavorReq.FlavorParts) >= 1 {
@@ -221,13 +188,19 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 			defaultLog.Debug("Validating flavor meta content for flavor part")
 			if err := validateFlavorMetaContent(&flavor.Flavor.Meta); err != nil {
 				defaultLog.Error("controllers/flavor_controller:createFlavors() Valid flavor content must be given, invalid flavor meta data")
-				return nil, errors.Wrap(err, "Invalid flavor content")
+				return nil, uuid.Nil, errors.Wrap(err, "Invalid flavor content")
 			}
 			// get flavor part form the content
 			var fp fc.FlavorPart
 			if err := (&fp).Parse(flavor.Flavor.Meta.Description.FlavorPart); err != nil {
 				defaultLog.Error("controllers/flavor_controller:createFlavors() Valid flavor part must be given")
-				return nil, errors.Wrap(err, "Error parsing flavor part")
+				return nil, uuid.Nil, errors.Wrap(err, "Error parsing flavor part")
+			}
+			if fp == fc.FlavorPartIma {
+				if err := validateImaFlavorContent(&flavor.Flavor); err != nil {
+					defaultLog.Error("controllers/flavor_controller:createFlavors() Valid flavor content must be given, invalid IMA allowlist")
+					return nil, uuid.Nil, errors.Wrap(err, "Invalid flavor content")
+				}
 			}
 			// check if flavor part already exists in flavor-flavorPart map, else sign the flavor and add it to the map
 			var platformFlavorUtil fu.PlatformFlavorUtil
@@ -236,7 +209,7 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 			signedFlavor, err := platformFlavorUtil.GetSignedFlavor(&flavor.Flavor, flavorSignKey.(*rsa.PrivateKey))
 			if err != nil {
 				defaultLog.Error("controllers/flavor_controller:createFlavors() Error getting signed flavor from flavor library")
-				return nil, errors.Wrap(err, "Error getting signed flavor from flavor library")
+				return nil, uuid.Nil, errors.Wrap(err, "Error getting signed flavor from flavor library")
 			}
 
 			if _, ok := flavorFlavorPartMap[fp]; ok {
@@ -250,7 +223,7 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 		}
 		if len(flavorFlavorPartMap) == 0 {
 			defaultLog.Error("controllers/flavor_controller:createFlavors() Valid flavor content must be given")
-			return nil, errors.New("Valid flavor content must be given")
+			return nil, uuid.Nil, errors.New("Valid flavor content must be given")
 		}
 	}
 	var err error
@@ -268,21 +241,75 @@ func (fcon *FlavorController) createFlavors(flavorReq dm.FlavorCreateRequest) ([
 	flavorgroups, err := CreateMissingFlavorgroups(fcon.FGStore, flavorReq.FlavorgroupNames)
 	if err != nil {
 		defaultLog.Error("controllers/flavor_controller:createFlavors() Error getting flavorgroups")
-		return nil, err
+		return nil, uuid.Nil, err
 	}
 
 	// if platform flavor was retrieved from host, break it into the flavor part flavor map using the flavorgroups
 	if platformFlavor != nil {
-		flavorFlavorPartMap = fcon.retrieveFlavorCollection(platformFlavor, flavorgroups, flavorParts)
+		flavorFlavorPartMap = fcon.retrieveFlavorCollection(platformFlavor, flavorgroups, flavorParts, nil)
 	}
 
 	if flavorFlavorPartMap == nil || len(flavorFlavorPartMap) == 0 {
 		defaultLog.Error("controllers/flavor_controller:createFlavors() Cannot create flavors")
-		return nil, errors.New("Unable to create Flavors")
+		return nil, uuid.Nil, errors.New("Unable to create Flavors")
 	}
 	return fcon.addFlavorToFlavorgroup(flavorFlavorPartMap, flavorgroups)
 }
 
+// getPlatformFlavor creates the platform flavor of the host with the given connection string
+func (fcon *FlavorController) getPlatformFlavor(cs string) (*fType.PlatformFlavor, error) {
+	defaultLog.Trace("controllers/flavor_controller:getPlatformFlavor() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getPlatformFlavor() Leaving")
+
+	// get host manifest from the host
+	connectionString, _, err := GenerateConnectionString(cs,
+		fcon.HostCon.HCConfig.Username,
+		fcon.HostCon.HCConfig.Password,
+		fcon.HostCon.HCStore)
+
+	if err != nil {
+		defaultLog.Error("controllers/flavor_controller:getPlatformFlavor() Could not generate formatted connection string")
+		return nil, errors.Wrap(err, "Error while generating a formatted connection string")
+	}
+	defaultLog.Debug("Getting manifest from host...")
+	hostManifest, err := fcon.getHostManifest(connectionString)
+	if err != nil {
+		defaultLog.Error("controllers/flavor_controller:getPlatformFlavor() Error getting host manifest")
+		return nil, errors.Wrap(err, "Error getting host manifest")
+	}
+	tagCertificate := hvs.TagCertificate{}
+	var tagX509Certificate *x509.Certificate
+	tcFilterCriteria := dm.TagCertificateFilterCriteria{
+		HardwareUUID: uuid.MustParse(hostManifest.HostInfo.HardwareUUID),
+	}
+	tagCertificates, err := fcon.TCStore.Search(&tcFilterCriteria)
+	if err != nil {
+		defaultLog.Debugf("Unable to retrieve tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
+	}
+	if len(tagCertificates) >= 1 {
+		tagCertificate = *tagCertificates[0]
+		tagX509Certificate, err = x509.ParseCertificate(tagCertificate.Certificate)
+		if err != nil {
+			defaultLog.Errorf("controllers/flavor_controller: Failed to parse x509.Certificate from tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
+			return nil, errors.Wrapf(err, "Failed to parse x509.Certificate from tag certificate for host with hardware UUID %s", hostManifest.HostInfo.HardwareUUID)
+		}
+		defaultLog.Debugf("Tag attribute certificate exists for the host with hardware UUID: %s", hostManifest.HostInfo.HardwareUUID)
+	}
+	// create a platform flavor with the host manifest information
+	defaultLog.Debug("Creating flavor from host manifest using flavor library")
+	newPlatformFlavor, err := flavor.NewPlatformFlavorProvider(hostManifest, tagX509Certificate)
+	if err != nil {
+		defaultLog.Errorf("controllers/flavor_controller:getPlatformFlavor() Error while creating platform flavor instance from host manifest and tag certificate")
+		return nil, errors.Wrap(err, "Error while creating platform flavor instance from host manifest and tag certificate")
+	}
+	platformFlavor, err := newPlatformFlavor.GetPlatformFlavor()
+	if err != nil {
+		defaultLog.Errorf("controllers/flavor_controller:getPlatformFlavor() Error while creating platform flavors for host %s", hostManifest.HostInfo.HardwareUUID)
+		return nil, errors.Wrapf(err, " Error while creating platform flavors for host %s", hostManifest.HostInfo.HardwareUUID)
+	}
+	return platformFlavor, nil
+}
+
 func getFlavorCreateReq(r *http.Request) (dm.FlavorCreateRequest, error) {
 	defaultLog.Trace("controllers/flavor_controller:getFlavorCreateReq() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:getFlavorCreateReq() Leaving")
@@ -342,7 +369,7 @@ func (fcon *FlavorController) getHostManifest(cs string) (*hcType.HostManifest,
 	return &hostManifest, err
 }
 
-func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.FlavorPart][]hvs.SignedFlavor, fgs []hvs.FlavorGroup) ([]hvs.SignedFlavor, error) {
+func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.FlavorPart][]hvs.SignedFlavor, fgs []hvs.FlavorGroup) ([]hvs.SignedFlavor, uuid.UUID, error) {
 	defaultLog.Trace("controllers/flavor_controller:addFlavorToFlavorgroup() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:addFlavorToFlavorgroup() Leaving")
 
@@ -367,7 +394,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 						"Error cleaning up already existing flavors on flavor creation failure")
 				}
 
-				return nil, err
+				return nil, uuid.Nil, err
 			}
 			// if the flavor is created, associate it with an appropriate flavorgroup
 			if signedFlavorCreated != nil && signedFlavorCreated.Flavor.Meta.ID.String() != "" {
@@ -381,7 +408,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 							defaultLog.WithError(cleanUpErr).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : " +
 								"Error cleaning up already existing flavors on flavor creation failure")
 						}
-						return nil, err
+						return nil, uuid.Nil, err
 					}
 					flavorgroupsForQueue = append(flavorgroupsForQueue, *flavorgroup)
 					// get hostId
@@ -396,7 +423,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 							defaultLog.WithError(cleanUpErr).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : " +
 								"Error cleaning up already existing flavors on flavor creation failure")
 						}
-						return nil, errors.New("hardware UUID must be specified in the HOST_UNIQUE flavor")
+						return nil, uuid.Nil, errors.New("hardware UUID must be specified in the HOST_UNIQUE flavor")
 					}
 
 					hosts, err := fcon.HStore.Search(&dm.HostFilterCriteria{
@@ -431,7 +458,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 								defaultLog.WithError(cleanUpErr).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : " +
 									"Error cleaning up already existing flavors on flavor creation failure")
 							}
-							return nil, err
+							return nil, uuid.Nil, err
 						}
 						flavorgroupsForQueue = append(flavorgroupsForQueue, *flavorgroup)
 						flavorgroups = []hvs.FlavorGroup{*flavorgroup}
@@ -451,7 +478,7 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 					defaultLog.WithError(cleanUpErr).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : " +
 						"Error cleaning up already existing flavors on flavor creation failure")
 				}
-				return nil, errors.New("Unable to create flavors")
+				return nil, uuid.Nil, errors.New("Unable to create flavors")
 			}
 			for _, flavorgroup := range flavorgroups {
 				if _, ok := flavorgroupFlavorMap[flavorgroup.ID]; ok {
@@ -475,19 +502,19 @@ func (fcon *FlavorController) addFlavorToFlavorgroup(flavorFlavorPartMap map[fc.
 		}
 	}
 	// get all the hosts that belong to the same flavor group and add them to flavor-verify queue
-	err := fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, fgHostIds, fetchHostData)
+	jobId, err := fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, fgHostIds, fetchHostData)
 	if err != nil {
 		defaultLog.Errorf("controllers/flavor_controller: addFlavorToFlavorgroup(): Error while adding hosts to flavor-verify queue")
 		if cleanUpErr := fcon.createCleanUp(flavorgroupFlavorMap); cleanUpErr != nil {
 			defaultLog.WithError(cleanUpErr).Errorf("controllers/flavor_controller: addFlavorToFlavorgroup() : " +
 				"Error cleaning up already existing flavors on flavor creation failure")
 		}
-		return nil, err
+		return nil, uuid.Nil, err
 	}
-	return returnSignedFlavors, nil
+	return returnSignedFlavors, jobId, nil
 }
 
-func (fcon FlavorController) addFlavorgroupHostsToFlavorVerifyQueue(fgs []hvs.FlavorGroup, hostIds []uuid.UUID, forceUpdate bool) error {
+func (fcon FlavorController) addFlavorgroupHostsToFlavorVerifyQueue(fgs []hvs.FlavorGroup, hostIds []uuid.UUID, forceUpdate bool) (uuid.UUID, error) {
 	defaultLog.Trace("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue() Leaving")
 	fgHosts := make(map[uuid.UUID]bool)
@@ -506,7 +533,7 @@ func (fcon FlavorController) addFlavorgroupHostsToFlavorVerifyQueue(fgs []hvs.Fl
 			hIds, err := fcon.FGStore.SearchHostsByFlavorGroup(fg.ID)
 			if err != nil {
 				defaultLog.Errorf("controllers/flavor_controller:addFlavorgroupHostsToFlavorVerifyQueue(): Failed to fetch hosts linked to FlavorGroup")
-				return err
+				return uuid.Nil, err
 			}
 			for _, hId := range hIds {
 				// adding to the list only if not already added
@@ -525,16 +552,19 @@ func (fcon FlavorController) addFlavorgroupHostsToFlavorVerifyQueue(fgs []hvs.Fl
 	defaultLog.Debugf("Found %v hosts to be added to flavor-verify queue", len(hostIdsForQueue))
 	// adding all the host linked to flavorgroup to flavor-verify queue
 	if len(hostIdsForQueue) >= 1 {
-		err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, forceUpdate, false)
+		jobId, err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, forceUpdate, false)
 		if err != nil {
 			defaultLog.Error("controllers/flavor_controller:addFlavorToFlavorgroup() Host to Flavor Verify Queue addition failed")
-			return err
+			return uuid.Nil, err
 		}
+		return jobId, nil
 	}
-	return nil
+	return uuid.Nil, nil
 }
 
-func (fcon FlavorController) retrieveFlavorCollection(platformFlavor *fType.PlatformFlavor, fgs []hvs.FlavorGroup, flavorParts []fc.FlavorPart) map[fc.FlavorPart][]hvs.SignedFlavor {
+// retrieveFlavorCollection signs the flavor parts of the platform flavor. The lifecycle, if given, is set on the
+// flavors before they are signed
+func (fcon FlavorController) retrieveFlavorCollection(platformFlavor *fType.PlatformFlavor, fgs []hvs.FlavorGroup, flavorParts []fc.FlavorPart, lifecycle *fm.Lifecycle) map[fc.FlavorPart][]hvs.SignedFlavor {
 	defaultLog.Trace("controllers/flavor_controller:retrieveFlavorCollection() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:retrieveFlavorCollection() Leaving")
 
@@ -556,6 +586,12 @@ func (fcon FlavorController) retrieveFlavorCollection(platformFlavor *fType.Plat
 			defaultLog.Errorf("controllers/flavor_controller:retrieveFlavorCollection() Error building a flavor for flavor part %s", flavorPart)
 			return flavorFlavorPartMap
 		}
+		if lifecycle != nil {
+			for i := range unsignedFlavors {
+				flavorLifecycle := *lifecycle
+				unsignedFlavors[i].Meta.Lifecycle = &flavorLifecycle
+			}
+		}
 
 		signedFlavors, err := fu.PlatformFlavorUtil{}.GetSignedFlavorList(unsignedFlavors, flavorSignKey.(*rsa.PrivateKey))
 		if err != nil {
@@ -645,7 +681,7 @@ func (fcon *FlavorController) Delete(w http.ResponseWriter, r *http.Request) (in
 	defaultLog.Debugf("Found %v hosts to be added to flavor-verify queue", len(hostIdsForQueue))
 	// adding all the host linked to flavor to flavor-verify queue
 	if len(hostIdsForQueue) >= 1 {
-		err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, false, false)
+		_, err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, false, false)
 		if err != nil {
 			defaultLog.Error("controllers/flavor_controller:Delete() Host to Flavor Verify Queue addition failed")
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to re-verify hosts " +
@@ -712,6 +748,929 @@ func (fcon *FlavorController) Retrieve(w http.ResponseWriter, r *http.Request) (
 	return flavor, http.StatusOK, nil
 }
 
+// UpdateLifecycle replaces the lifecycle of a flavor. The approval of a flavor captured from a golden host is kept,
+// and the flavor cannot be made active or deprecated until it is approved
+func (fcon *FlavorController) UpdateLifecycle(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:UpdateLifecycle() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:UpdateLifecycle() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:UpdateLifecycle() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:UpdateLifecycle() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var lifecycle hvs.FlavorLifecycle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&lifecycle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:UpdateLifecycle() %s :  Failed to decode request body as FlavorLifecycle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+	if err := lifecycle.Validate(); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:UpdateLifecycle() %s : Invalid flavor lifecycle", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	signedFlavor, err := fcon.FStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/flavor_controller:UpdateLifecycle() Flavor with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/flavor_controller:UpdateLifecycle() Failed to retrieve Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor with the given ID"}
+	}
+
+	if lifecycle.Approval != nil {
+		secLog.Errorf("controllers/flavor_controller:UpdateLifecycle() %s : Flavor approval cannot be updated", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor approval cannot be updated, use the approve API"}
+	}
+	if signedFlavor.Flavor.Meta.Lifecycle != nil {
+		lifecycle.Approval = signedFlavor.Flavor.Meta.Lifecycle.Approval
+	}
+	if lifecycle.IsPendingApproval() && (lifecycle.GetState() == fm.FlavorStateActive || lifecycle.IsDeprecated()) {
+		secLog.WithField("id", id).Errorf("controllers/flavor_controller:UpdateLifecycle() %s : Flavor is pending approval", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor is pending approval and cannot be used for verification"}
+	}
+
+	updatedFlavor, httpStatus, err := fcon.setFlavorLifecycle(signedFlavor, &lifecycle)
+	if err != nil {
+		return nil, httpStatus, err
+	}
+
+	secLog.WithField("id", id).Infof("%s: Flavor lifecycle updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return updatedFlavor, http.StatusOK, nil
+}
+
+// setFlavorLifecycle sets the lifecycle of a flavor. The flavor is signed again since the lifecycle is part of the
+// flavor content, and the hosts associated with the flavor are queued for trust re-verification
+func (fcon *FlavorController) setFlavorLifecycle(signedFlavor *hvs.SignedFlavor, lifecycle *fm.Lifecycle) (*hvs.SignedFlavor, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:setFlavorLifecycle() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:setFlavorLifecycle() Leaving")
+
+	flavorSignKey, _, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	signingKey, ok := flavorSignKey.(*rsa.PrivateKey)
+	if !ok {
+		defaultLog.Errorf("controllers/flavor_controller:setFlavorLifecycle() %s : Flavor Signing Key not found in CertStore", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update Flavor lifecycle"}
+	}
+	flavor := signedFlavor.Flavor
+	flavor.Meta.Lifecycle = lifecycle
+	updatedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&flavor, signingKey)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:setFlavorLifecycle() Error signing flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update Flavor lifecycle"}
+	}
+	if updatedFlavor, err = fcon.FStore.Update(updatedFlavor); err != nil {
+		defaultLog.WithError(err).WithField("id", flavor.Meta.ID).Error(
+			"controllers/flavor_controller:setFlavorLifecycle() Failed to update Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update Flavor lifecycle"}
+	}
+
+	hostIdsForQueue, err := getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, updatedFlavor)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:setFlavorLifecycle() Failed to retrieve hosts " +
+			"associated with flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts " +
+			"associated with flavor for trust re-verification"}
+	}
+	defaultLog.Debugf("Found %v hosts to be added to flavor-verify queue", len(hostIdsForQueue))
+	if len(hostIdsForQueue) >= 1 {
+		// the trust cache of the hosts must be ignored since the flavor may no longer be usable
+		if _, err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, true, false); err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:setFlavorLifecycle() Host to Flavor Verify Queue addition failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to re-verify hosts " +
+				"associated with the updated Flavor"}
+		}
+	}
+	return updatedFlavor, http.StatusOK, nil
+}
+
+// Export returns a flavor bundle with the flavors matching the search criteria, the flavorgroups they are linked
+// to and the flavor signing certificate chain needed to verify them on import
+func (fcon *FlavorController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Export() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Export() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorSearchParams); err != nil {
+		secLog.Errorf("controllers/flavor_controller:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filterCriteria, err := validateFlavorFilterCriteria(r.URL.Query().Get("key"), r.URL.Query().Get("value"),
+		r.URL.Query().Get("flavorgroupId"), r.URL.Query()["id"], r.URL.Query()["flavorParts"])
+	if err != nil {
+		secLog.Errorf("controllers/flavor_controller:Export()  %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+		FlavorFC: *filterCriteria,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Export() Flavor search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavors"}
+	}
+	if len(signedFlavors) == 0 {
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "No flavors found to export"}
+	}
+
+	_, signingCerts, err := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	if err != nil || len(signingCerts) == 0 {
+		defaultLog.Errorf("controllers/flavor_controller:Export() %s : Flavor Signing Certificate not found in CertStore", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export Flavors"}
+	}
+
+	bundle := hvs.FlavorBundle{
+		Manifest: hvs.FlavorBundleManifest{
+			Version:   hvs.FlavorBundleVersion,
+			CreatedAt: time.Now(),
+		},
+		SignedFlavors: signedFlavors,
+	}
+	for _, cert := range signingCerts {
+		bundle.Manifest.SigningCertificates = append(bundle.Manifest.SigningCertificates, cert.Raw)
+	}
+	exportedFlavors := make(map[uuid.UUID]bool, len(signedFlavors))
+	for _, signedFlavor := range signedFlavors {
+		digest, err := signedFlavor.Flavor.GetContentDigest()
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:Export() Failed to compute flavor digest")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to export Flavors"}
+		}
+		meta := signedFlavor.Flavor.Meta
+		bundle.Manifest.Flavors = append(bundle.Manifest.Flavors, hvs.FlavorBundleEntry{
+			FlavorId:   meta.ID,
+			Label:      meta.Description.Label,
+			FlavorPart: meta.Description.FlavorPart,
+			Digest:     digest,
+		})
+		exportedFlavors[meta.ID] = true
+	}
+
+	flavorGroups, err := fcon.FGStore.Search(nil)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Export() Flavorgroup search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroups"}
+	}
+	for _, flavorGroup := range flavorGroups {
+		flavorIds, err := fcon.FGStore.SearchFlavors(flavorGroup.ID)
+		if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).Error("controllers/flavor_controller:Export() Flavorgroup flavors search failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroup links"}
+		}
+		var linkedFlavorIds []uuid.UUID
+		for _, flavorId := range flavorIds {
+			if exportedFlavors[flavorId] {
+				linkedFlavorIds = append(linkedFlavorIds, flavorId)
+			}
+		}
+		if len(linkedFlavorIds) == 0 {
+			continue
+		}
+		bundle.FlavorGroups = append(bundle.FlavorGroups, hvs.FlavorGroup{
+			ID:            flavorGroup.ID,
+			Name:          flavorGroup.Name,
+			FlavorIds:     linkedFlavorIds,
+			MatchPolicies: flavorGroup.MatchPolicies,
+			Policy:        flavorGroup.Policy,
+		})
+	}
+
+	secLog.Infof("%s: %d flavors exported to: %s", commLogMsg.AuthorizedAccess, len(signedFlavors), r.RemoteAddr)
+	return bundle, http.StatusOK, nil
+}
+
+// Import creates the flavors of a flavor bundle, along with the flavorgroups and links they are exported with.
+// The signature of each flavor is verified with the signing certificate of the bundle, which must be issued by a
+// trusted root CA. The imported flavors are signed again with the flavor signing key of this HVS. Flavors that
+// already exist with the same content are only linked to the flavorgroups, flavors conflicting with an existing
+// flavor by id, label or content are reported and not imported
+func (fcon *FlavorController) Import(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Import() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Import() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:Import() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Import() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var bundle hvs.FlavorBundle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&bundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Import() %s :  Failed to decode request body as FlavorBundle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+	if err := validateFlavorBundle(&bundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Import() %s : Invalid flavor bundle", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	_, rootCAs, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CaCertTypesRootCa.String())
+	if err := verifyFlavorBundleSignatures(&bundle, rootCAs); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Import() %s : Flavor bundle signature verification failed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	flavorSignKey, _, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	signingKey, ok := flavorSignKey.(*rsa.PrivateKey)
+	if !ok {
+		defaultLog.Errorf("controllers/flavor_controller:Import() %s : Flavor Signing Key not found in CertStore", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import Flavors"}
+	}
+
+	report, err := fcon.importFlavorBundle(&bundle, signingKey)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Import() Error importing flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import Flavors"}
+	}
+
+	secLog.Infof("%s: %d flavors imported by: %s", commLogMsg.PrivilegeModified, len(report.ImportedFlavors), r.RemoteAddr)
+	return report, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) importFlavorBundle(bundle *hvs.FlavorBundle, signingKey *rsa.PrivateKey) (*hvs.FlavorBundleImportReport, error) {
+	defaultLog.Trace("controllers/flavor_controller:importFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:importFlavorBundle() Leaving")
+
+	report := hvs.FlavorBundleImportReport{}
+	// local ids of the flavors of the bundle that are imported or already exist
+	flavorIds := make(map[uuid.UUID]uuid.UUID)
+	var signedFlavors []hvs.SignedFlavor
+	for _, signedFlavor := range bundle.SignedFlavors {
+		meta := signedFlavor.Flavor.Meta
+		existingFlavorId, reason, err := fcon.findFlavorBundleConflict(&signedFlavor.Flavor)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			defaultLog.Debugf("Flavor %s with label %s conflicts with flavor %s by %s", meta.ID, meta.Description.Label, existingFlavorId, reason)
+			report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{
+				FlavorId:         meta.ID,
+				Label:            meta.Description.Label,
+				ExistingFlavorId: existingFlavorId,
+				Reason:           reason,
+			})
+		} else if existingFlavorId != uuid.Nil {
+			report.ExistingFlavors = append(report.ExistingFlavors, meta.ID)
+			flavorIds[meta.ID] = existingFlavorId
+		} else {
+			signedFlavors = append(signedFlavors, signedFlavor)
+		}
+	}
+
+	// flavors created so far, deleted if the import fails
+	createdFlavors := make(map[uuid.UUID][]uuid.UUID)
+	for _, signedFlavor := range signedFlavors {
+		resignedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&signedFlavor.Flavor, signingKey)
+		if err == nil {
+			resignedFlavor, err = fcon.FStore.Create(resignedFlavor)
+		}
+		if err != nil {
+			if cleanUpErr := fcon.createCleanUp(createdFlavors); cleanUpErr != nil {
+				defaultLog.WithError(cleanUpErr).Error("controllers/flavor_controller:importFlavorBundle() Error cleaning up imported flavors")
+			}
+			return nil, errors.Wrapf(err, "Error creating flavor %s", signedFlavor.Flavor.Meta.ID)
+		}
+		createdFlavors[uuid.Nil] = append(createdFlavors[uuid.Nil], resignedFlavor.Flavor.Meta.ID)
+		flavorIds[signedFlavor.Flavor.Meta.ID] = resignedFlavor.Flavor.Meta.ID
+		report.ImportedFlavors = append(report.ImportedFlavors, *resignedFlavor)
+	}
+
+	var linkedFlavorGroups []hvs.FlavorGroup
+	for _, bundleFlavorGroup := range bundle.FlavorGroups {
+		var linkFlavorIds []uuid.UUID
+		for _, flavorId := range bundleFlavorGroup.FlavorIds {
+			if localId, ok := flavorIds[flavorId]; ok {
+				linkFlavorIds = append(linkFlavorIds, localId)
+			}
+		}
+		if len(linkFlavorIds) == 0 {
+			continue
+		}
+
+		flavorGroup, created, err := fcon.findOrCreateBundleFlavorGroup(bundleFlavorGroup)
+		if err != nil {
+			if cleanUpErr := fcon.createCleanUp(createdFlavors); cleanUpErr != nil {
+				defaultLog.WithError(cleanUpErr).Error("controllers/flavor_controller:importFlavorBundle() Error cleaning up imported flavors")
+			}
+			return nil, err
+		}
+		if created {
+			report.CreatedFlavorGroups = append(report.CreatedFlavorGroups, flavorGroup.Name)
+		}
+
+		var newLinks []uuid.UUID
+		for _, flavorId := range linkFlavorIds {
+			if _, err := fcon.FGStore.RetrieveFlavor(flavorGroup.ID, flavorId); err != nil {
+				newLinks = append(newLinks, flavorId)
+			}
+		}
+		if len(newLinks) == 0 {
+			continue
+		}
+		if _, err := fcon.FGStore.AddFlavors(flavorGroup.ID, newLinks); err != nil {
+			if cleanUpErr := fcon.createCleanUp(createdFlavors); cleanUpErr != nil {
+				defaultLog.WithError(cleanUpErr).Error("controllers/flavor_controller:importFlavorBundle() Error cleaning up imported flavors")
+			}
+			return nil, errors.Wrapf(err, "Error linking flavors to flavorgroup %s", flavorGroup.Name)
+		}
+		linkedFlavorGroups = append(linkedFlavorGroups, *flavorGroup)
+	}
+
+	jobId, err := fcon.addFlavorgroupHostsToFlavorVerifyQueue(linkedFlavorGroups, nil, false)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:importFlavorBundle() Error adding hosts to flavor verify queue")
+	}
+	report.JobId = jobIdRef(jobId)
+	return &report, nil
+}
+
+// findFlavorBundleConflict looks for an existing flavor with the same id, label or content as the flavor of a
+// bundle. If the existing flavor has the same content, its id is returned without conflict reason
+func (fcon *FlavorController) findFlavorBundleConflict(flavor *hvs.Flavor) (uuid.UUID, hvs.FlavorBundleConflictReason, error) {
+	defaultLog.Trace("controllers/flavor_controller:findFlavorBundleConflict() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:findFlavorBundleConflict() Leaving")
+
+	digest, err := flavor.GetContentDigest()
+	if err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "Failed to compute flavor digest")
+	}
+	label := flavor.Meta.Description.Label
+
+	existingFlavor, err := fcon.FStore.Retrieve(flavor.Meta.ID)
+	if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return uuid.Nil, "", errors.Wrapf(err, "Failed to retrieve flavor %s", flavor.Meta.ID)
+	}
+	if existingFlavor != nil {
+		existingDigest, err := existingFlavor.Flavor.GetContentDigest()
+		if err != nil {
+			return uuid.Nil, "", errors.Wrap(err, "Failed to compute flavor digest")
+		}
+		if existingDigest != digest || existingFlavor.Flavor.Meta.Description.Label != label {
+			return existingFlavor.Flavor.Meta.ID, hvs.FlavorBundleConflictId, nil
+		}
+		return existingFlavor.Flavor.Meta.ID, "", nil
+	}
+
+	var flavorPart fc.FlavorPart
+	if err := (&flavorPart).Parse(flavor.Meta.Description.FlavorPart); err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "Error parsing flavor part")
+	}
+	existingFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+		FlavorFC: dm.FlavorFilterCriteria{
+			FlavorParts: []fc.FlavorPart{flavorPart},
+		},
+	})
+	if err != nil {
+		return uuid.Nil, "", errors.Wrap(err, "Failed to search flavors")
+	}
+	for _, existingFlavor := range existingFlavors {
+		existingDigest, err := existingFlavor.Flavor.GetContentDigest()
+		if err != nil {
+			return uuid.Nil, "", errors.Wrap(err, "Failed to compute flavor digest")
+		}
+		existingLabel := existingFlavor.Flavor.Meta.Description.Label
+		if existingLabel == label && existingDigest == digest {
+			return existingFlavor.Flavor.Meta.ID, "", nil
+		} else if existingLabel == label {
+			return existingFlavor.Flavor.Meta.ID, hvs.FlavorBundleConflictLabel, nil
+		} else if existingDigest == digest {
+			return existingFlavor.Flavor.Meta.ID, hvs.FlavorBundleConflictDigest, nil
+		}
+	}
+	return uuid.Nil, "", nil
+}
+
+// findOrCreateBundleFlavorGroup returns the flavorgroup with the name of a bundle flavorgroup, creating it with the
+// match policies and policy of the bundle flavorgroup if it does not exist
+func (fcon *FlavorController) findOrCreateBundleFlavorGroup(bundleFlavorGroup hvs.FlavorGroup) (*hvs.FlavorGroup, bool, error) {
+	defaultLog.Trace("controllers/flavor_controller:findOrCreateBundleFlavorGroup() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:findOrCreateBundleFlavorGroup() Leaving")
+
+	flavorGroups, err := fcon.FGStore.Search(&dm.FlavorGroupFilterCriteria{
+		NameEqualTo: bundleFlavorGroup.Name,
+	})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "Error searching for flavorgroup with name %s", bundleFlavorGroup.Name)
+	}
+	if len(flavorGroups) > 0 && flavorGroups[0].ID != uuid.Nil {
+		return &flavorGroups[0], false, nil
+	}
+	if bundleFlavorGroup.Name == dm.FlavorGroupsHostUnique.String() {
+		flavorGroup, err := fcon.createFGIfNotExists(bundleFlavorGroup.Name)
+		return flavorGroup, err == nil, err
+	}
+
+	flavorGroup, err := fcon.FGStore.Create(&hvs.FlavorGroup{
+		Name:          bundleFlavorGroup.Name,
+		MatchPolicies: bundleFlavorGroup.MatchPolicies,
+		Policy:        bundleFlavorGroup.Policy,
+	})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "Unable to create flavorgroup %s", bundleFlavorGroup.Name)
+	}
+	return flavorGroup, true, nil
+}
+
+// validateFlavorBundle validates the manifest, the flavor meta content and the flavorgroups of a flavor bundle
+func validateFlavorBundle(bundle *hvs.FlavorBundle) error {
+	defaultLog.Trace("controllers/flavor_controller:validateFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorBundle() Leaving")
+
+	if err := bundle.Validate(); err != nil {
+		return err
+	}
+	for _, signedFlavor := range bundle.SignedFlavors {
+		if err := validateFlavorMetaContent(&signedFlavor.Flavor.Meta); err != nil {
+			return errors.Wrapf(err, "Invalid content for flavor %s", signedFlavor.Flavor.Meta.ID)
+		}
+	}
+	for _, flavorGroup := range bundle.FlavorGroups {
+		// the host_unique flavorgroup does not have match policies
+		if flavorGroup.Name == dm.FlavorGroupsHostUnique.String() {
+			continue
+		}
+		if err := ValidateFlavorGroup(flavorGroup); err != nil {
+			return errors.Wrapf(err, "Invalid flavorgroup %s", flavorGroup.Name)
+		}
+	}
+	return nil
+}
+
+// verifyFlavorBundleSignatures verifies that the signing certificate of a flavor bundle is issued by one of the
+// root CAs, and that every flavor of the bundle is signed with it
+func verifyFlavorBundleSignatures(bundle *hvs.FlavorBundle, rootCAs []x509.Certificate) error {
+	defaultLog.Trace("controllers/flavor_controller:verifyFlavorBundleSignatures() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:verifyFlavorBundleSignatures() Leaving")
+
+	var signingCerts []x509.Certificate
+	for _, certBytes := range bundle.Manifest.SigningCertificates {
+		cert, err := x509.ParseCertificate(certBytes)
+		if err != nil {
+			return errors.Wrap(err, "Failed to parse flavor bundle signing certificate")
+		}
+		signingCerts = append(signingCerts, *cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         crypt.GetCertPool(rootCAs),
+		Intermediates: crypt.GetCertPool(signingCerts[1:]),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err := signingCerts[0].Verify(opts); err != nil {
+		return errors.Wrap(err, "Flavor bundle signing certificate is not issued by a trusted CA")
+	}
+
+	publicKey, ok := signingCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("Flavor bundle signing certificate does not have an RSA public key")
+	}
+	for _, signedFlavor := range bundle.SignedFlavors {
+		if err := signedFlavor.Verify(publicKey); err != nil {
+			return errors.Wrapf(err, "Signature of flavor %s is not trusted", signedFlavor.Flavor.Meta.ID)
+		}
+	}
+	return nil
+}
+
+// Capture creates candidate flavors from a golden host. The candidates are created in draft along with the name of
+// the user who captured them, and are only used for verification once they are approved by another user
+func (fcon *FlavorController) Capture(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Capture() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Capture() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:Capture() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Capture() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var captureReq dm.FlavorCaptureRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&captureReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Capture() %s :  Failed to decode request body as FlavorCaptureRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+	if err := validateFlavorCaptureRequest(captureReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Capture() %s : Invalid flavor capture request", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	capturedBy, err := comctx.GetUserName(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Capture() %s", commLogMsg.AuthenticationFailed)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Could not get user name from http context"}
+	}
+
+	connectionString := captureReq.ConnectionString
+	var capturedFrom string
+	if captureReq.HostId != uuid.Nil {
+		host, err := fcon.HStore.Retrieve(captureReq.HostId)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", captureReq.HostId).Info(
+					"controllers/flavor_controller:Capture() Host with given ID does not exist")
+				return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", captureReq.HostId).Error(
+				"controllers/flavor_controller:Capture() Failed to retrieve Host")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host with the given ID"}
+		}
+		connectionString = host.ConnectionString
+		capturedFrom = host.HostName
+	}
+
+	platformFlavor, err := fcon.getPlatformFlavor(connectionString)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Capture() Error creating flavors from host")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error capturing flavors from host"}
+	}
+
+	flavorParts := captureReq.FlavorParts
+	if len(flavorParts) == 0 {
+		flavorParts = goldenHostFlavorParts
+	}
+	flavorgroupNames := captureReq.FlavorgroupNames
+	if len(flavorgroupNames) == 0 {
+		flavorgroupNames = []string{dm.FlavorGroupsAutomatic.String()}
+	}
+	flavorgroups, err := CreateMissingFlavorgroups(fcon.FGStore, flavorgroupNames)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Capture() Error getting flavorgroups")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error capturing flavors from host"}
+	}
+
+	lifecycle := fm.Lifecycle{
+		State: fm.FlavorStateDraft,
+		Approval: &fm.Approval{
+			CapturedBy:   capturedBy,
+			CapturedFrom: capturedFrom,
+		},
+	}
+	flavorFlavorPartMap := fcon.retrieveFlavorCollection(platformFlavor, flavorgroups, flavorParts, &lifecycle)
+	if len(flavorFlavorPartMap) == 0 {
+		defaultLog.Error("controllers/flavor_controller:Capture() Cannot create flavors")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error capturing flavors from host"}
+	}
+	signedFlavors, _, err := fcon.addFlavorToFlavorgroup(flavorFlavorPartMap, flavorgroups)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Capture() Error creating flavors")
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with same id/label already exists"}
+		}
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error capturing flavors from host"}
+	}
+
+	candidates, err := fcon.getFlavorCandidates(signedFlavors)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Capture() Error comparing candidate flavors with existing flavors")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error comparing candidate flavors with existing flavors"}
+	}
+
+	secLog.Infof("%s: Candidate flavors captured by %s from: %s", commLogMsg.PrivilegeModified, capturedBy, r.RemoteAddr)
+	return candidates, http.StatusCreated, nil
+}
+
+// SearchCandidates returns the flavors pending approval that match the search criteria, along with their
+// differences with the existing flavors of their flavorgroups
+func (fcon *FlavorController) SearchCandidates(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:SearchCandidates() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:SearchCandidates() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorSearchParams); err != nil {
+		secLog.Errorf("controllers/flavor_controller:SearchCandidates() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filterCriteria, err := validateFlavorFilterCriteria(r.URL.Query().Get("key"), r.URL.Query().Get("value"),
+		r.URL.Query().Get("flavorgroupId"), r.URL.Query()["id"], r.URL.Query()["flavorParts"])
+	if err != nil {
+		secLog.Errorf("controllers/flavor_controller:SearchCandidates()  %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+		FlavorFC: *filterCriteria,
+	})
+	if err != nil {
+		secLog.WithError(err).Error("controllers/flavor_controller:SearchCandidates() Flavor search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavors"}
+	}
+	var pendingFlavors []hvs.SignedFlavor
+	for _, signedFlavor := range signedFlavors {
+		if signedFlavor.Flavor.Meta.Lifecycle.IsPendingApproval() {
+			pendingFlavors = append(pendingFlavors, signedFlavor)
+		}
+	}
+
+	candidates, err := fcon.getFlavorCandidates(pendingFlavors)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:SearchCandidates() Error comparing candidate flavors with existing flavors")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error comparing candidate flavors with existing flavors"}
+	}
+
+	secLog.Infof("%s: Return candidate flavor query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return candidates, http.StatusOK, nil
+}
+
+// Approve activates a flavor captured from a golden host. The flavor must be approved by a user other than the one
+// who captured it
+func (fcon *FlavorController) Approve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Approve() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Approve() Leaving")
+
+	approvedBy, err := comctx.GetUserName(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Approve() %s", commLogMsg.AuthenticationFailed)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Could not get user name from http context"}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	signedFlavor, err := fcon.FStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/flavor_controller:Approve() Flavor with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/flavor_controller:Approve() Failed to retrieve Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor with the given ID"}
+	}
+
+	currentLifecycle := signedFlavor.Flavor.Meta.Lifecycle
+	if !currentLifecycle.IsPendingApproval() || currentLifecycle.GetState() == fm.FlavorStateRevoked {
+		secLog.WithField("id", id).Errorf("controllers/flavor_controller:Approve() %s : Flavor is not pending approval", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor is not pending approval"}
+	}
+	if currentLifecycle.Approval.CapturedBy == approvedBy {
+		secLog.WithField("id", id).Warnf("controllers/flavor_controller:Approve() %s : User %s cannot approve a flavor "+
+			"captured by the same user", commLogMsg.UnauthorizedAccess, approvedBy)
+		return nil, http.StatusForbidden, &commErr.ResourceError{Message: "Flavor must be approved by a user other than the one who captured it"}
+	}
+
+	approvedAt := time.Now()
+	approval := *currentLifecycle.Approval
+	approval.ApprovedBy = approvedBy
+	approval.ApprovedAt = &approvedAt
+	lifecycle := *currentLifecycle
+	lifecycle.State = fm.FlavorStateActive
+	lifecycle.Approval = &approval
+
+	approvedFlavor, httpStatus, err := fcon.setFlavorLifecycle(signedFlavor, &lifecycle)
+	if err != nil {
+		return nil, httpStatus, err
+	}
+
+	secLog.WithField("id", id).Infof("%s: Flavor approved by %s from: %s", commLogMsg.PrivilegeModified, approvedBy, r.RemoteAddr)
+	return approvedFlavor, http.StatusOK, nil
+}
+
+// getFlavorCandidates compares each candidate flavor with the approved flavors of the same flavor part in its
+// flavorgroups
+func (fcon *FlavorController) getFlavorCandidates(signedFlavors []hvs.SignedFlavor) (*hvs.FlavorCandidateCollection, error) {
+	defaultLog.Trace("controllers/flavor_controller:getFlavorCandidates() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getFlavorCandidates() Leaving")
+
+	candidates := hvs.FlavorCandidateCollection{Candidates: []hvs.FlavorCandidate{}}
+	for _, signedFlavor := range signedFlavors {
+		candidate := hvs.FlavorCandidate{SignedFlavor: signedFlavor}
+		id := signedFlavor.Flavor.Meta.ID
+		flavorgroups, err := fcon.FGStore.Search(&dm.FlavorGroupFilterCriteria{FlavorId: &id})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to retrieve flavorgroups of flavor %s", id)
+		}
+
+		linkedFlavors := make(map[uuid.UUID]bool)
+		var flavorIds []uuid.UUID
+		for _, flavorgroup := range flavorgroups {
+			fIds, err := fcon.FGStore.SearchFlavors(flavorgroup.ID)
+			if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+				return nil, errors.Wrapf(err, "Failed to retrieve flavors of flavorgroup %s", flavorgroup.ID)
+			}
+			for _, fId := range fIds {
+				if fId != id && !linkedFlavors[fId] {
+					linkedFlavors[fId] = true
+					flavorIds = append(flavorIds, fId)
+				}
+			}
+		}
+
+		if len(flavorIds) > 0 {
+			existingFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+				FlavorFC: dm.FlavorFilterCriteria{Ids: flavorIds},
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to retrieve flavors linked with flavor %s", id)
+			}
+			for _, existingFlavor := range existingFlavors {
+				if existingFlavor.Flavor.Meta.Description.FlavorPart != signedFlavor.Flavor.Meta.Description.FlavorPart ||
+					existingFlavor.Flavor.Meta.Lifecycle.IsPendingApproval() {
+					continue
+				}
+				candidate.Diffs = append(candidate.Diffs, hvs.NewFlavorDiff(&signedFlavor.Flavor, &existingFlavor.Flavor))
+			}
+		}
+		candidates.Candidates = append(candidates.Candidates, candidate)
+	}
+	return &candidates, nil
+}
+
+func validateFlavorCaptureRequest(captureReq dm.FlavorCaptureRequest) error {
+	defaultLog.Trace("controllers/flavor_controller:validateFlavorCaptureRequest() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorCaptureRequest() Leaving")
+
+	if (captureReq.HostId == uuid.Nil) == (captureReq.ConnectionString == "") {
+		return errors.New("Either host_id or connection_string of the golden host must be given")
+	}
+	if captureReq.ConnectionString != "" {
+		if err := utils.ValidateConnectionString(captureReq.ConnectionString); err != nil {
+			return errors.New("Invalid host connection string")
+		}
+	}
+	for _, flavorgroup := range captureReq.FlavorgroupNames {
+		if flavorgroup == "" {
+			return errors.New("Valid Flavorgroup Names must be specified, empty name is not allowed")
+		}
+	}
+	if err := validation.ValidateStrings(captureReq.FlavorgroupNames); err != nil {
+		return errors.New("Invalid flavorgroup name given as a flavor capture criteria")
+	}
+	for _, flavorPart := range captureReq.FlavorParts {
+		if flavorPart == fc.FlavorPartHostUnique || flavorPart == fc.FlavorPartAssetTag {
+			return errors.Errorf("%s flavors cannot be captured from a golden host", flavorPart)
+		}
+		var fp fc.FlavorPart
+		if err := (&fp).Parse(flavorPart.String()); err != nil {
+			return errors.New("Valid flavor parts must be given as a flavor capture criteria")
+		}
+	}
+	return nil
+}
+
+func (fcon *FlavorController) Evaluate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Evaluate() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Evaluate() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:Evaluate() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Evaluate() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var evaluateReq dm.FlavorEvaluateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&evaluateReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Evaluate() %s :  Failed to decode request body as FlavorEvaluateRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if evaluateReq.HostId == uuid.Nil && evaluateReq.HostManifest == nil {
+		secLog.Errorf("controllers/flavor_controller:Evaluate() %s : Host ID or host manifest must be specified", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Either host_id or host_manifest must be specified"}
+	}
+	if evaluateReq.HostManifest != nil {
+		if _, err := uuid.Parse(evaluateReq.HostManifest.HostInfo.HardwareUUID); err != nil {
+			secLog.WithError(err).Errorf("controllers/flavor_controller:Evaluate() %s : Invalid hardware UUID in host manifest", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Valid hardware UUID must be specified in host manifest"}
+		}
+	}
+	if evaluateReq.HostId != uuid.Nil {
+		if _, err := fcon.HStore.Retrieve(evaluateReq.HostId); err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", evaluateReq.HostId).Info(
+					"controllers/flavor_controller:Evaluate() Host with given ID does not exist")
+				return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", evaluateReq.HostId).Error(
+				"controllers/flavor_controller:Evaluate() Failed to retrieve Host")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host with the given ID"}
+		}
+	}
+
+	flavorgroup, status, err := fcon.getEvaluationFlavorgroup(evaluateReq.Flavorgroup)
+	if err != nil {
+		return nil, status, err
+	}
+
+	flavors := make([]hvs.Flavor, 0, len(evaluateReq.FlavorCollection.Flavors))
+	for _, f := range evaluateReq.FlavorCollection.Flavors {
+		flavors = append(flavors, f.Flavor)
+	}
+	if evaluateReq.Flavorgroup != nil {
+		flavors = append(flavors, evaluateReq.Flavorgroup.Flavors...)
+	}
+	if len(flavors) == 0 && flavorgroup.ID == uuid.Nil {
+		secLog.Errorf("controllers/flavor_controller:Evaluate() %s : No flavors to evaluate", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Candidate flavors or the id of an existing flavorgroup must be specified"}
+	}
+
+	flavorSignKey, _, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	signingKey, ok := flavorSignKey.(*rsa.PrivateKey)
+	if !ok {
+		defaultLog.Errorf("controllers/flavor_controller:Evaluate() %s : Flavor Signing Key not found in CertStore", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error evaluating flavors"}
+	}
+	signedFlavors, err := signEvaluationFlavors(flavors, signingKey)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Evaluate() %s : Invalid candidate flavors", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Valid flavor content must be given"}
+	}
+
+	trustReport, err := fcon.HTManager.EvaluateHost(evaluateReq.HostId, evaluateReq.HostManifest, *flavorgroup, signedFlavors)
+	if err != nil {
+		if strings.Contains(err.Error(), "could not retrieve host manifest") {
+			defaultLog.WithError(err).Info("controllers/flavor_controller:Evaluate() Host manifest is not available")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host manifest is not available for the given host, host_manifest must be specified"}
+		}
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Evaluate() Error evaluating flavors")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error evaluating flavors"}
+	}
+
+	secLog.Infof("%s: Flavors evaluated by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return trustReport, http.StatusOK, nil
+}
+
+// getEvaluationFlavorgroup returns the flavorgroup used for the evaluation. An existing flavorgroup is retrieved when
+// the id is given, match policies in the request override the ones of the flavorgroup. The automatic flavorgroup
+// match policies are used when none are available
+func (fcon *FlavorController) getEvaluationFlavorgroup(fg *hvs.FlavorGroup) (*hvs.FlavorGroup, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:getEvaluationFlavorgroup() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:getEvaluationFlavorgroup() Leaving")
+
+	flavorgroup := hvs.FlavorGroup{}
+	if fg != nil && fg.ID != uuid.Nil {
+		existingFlavorgroup, err := fcon.FGStore.Retrieve(fg.ID)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", fg.ID).Info(
+					"controllers/flavor_controller:getEvaluationFlavorgroup() FlavorGroup with given ID does not exist")
+				return nil, http.StatusNotFound, &commErr.ResourceError{Message: "FlavorGroup with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", fg.ID).Error(
+				"controllers/flavor_controller:getEvaluationFlavorgroup() Failed to retrieve FlavorGroup")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve FlavorGroup"}
+		}
+		flavorgroup = *existingFlavorgroup
+		flavorgroup.Flavors = nil
+	}
+	if fg != nil && len(fg.MatchPolicies) > 0 {
+		flavorgroup.MatchPolicies = fg.MatchPolicies
+	}
+	if len(flavorgroup.MatchPolicies) == 0 {
+		flavorgroup.MatchPolicies = utils.GetAutomaticFlavorMatchPolicy()
+	}
+	return &flavorgroup, http.StatusOK, nil
+}
+
+// signEvaluationFlavors signs the candidate flavors with the flavor signing key so that they pass the signature
+// verification like the flavors in the store. The signed flavors are not stored
+func signEvaluationFlavors(flavors []hvs.Flavor, signingKey *rsa.PrivateKey) ([]hvs.SignedFlavor, error) {
+	defaultLog.Trace("controllers/flavor_controller:signEvaluationFlavors() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:signEvaluationFlavors() Leaving")
+
+	var platformFlavorUtil fu.PlatformFlavorUtil
+	signedFlavors := make([]hvs.SignedFlavor, 0, len(flavors))
+	for _, flavor := range flavors {
+		if err := validateFlavorMetaContent(&flavor.Meta); err != nil {
+			return nil, errors.Wrap(err, "Invalid flavor content")
+		}
+		if flavor.Meta.ID == uuid.Nil {
+			flavor.Meta.ID = uuid.New()
+		}
+		signedFlavor, err := platformFlavorUtil.GetSignedFlavor(&flavor, signingKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting signed flavor from flavor library")
+		}
+		signedFlavors = append(signedFlavors, *signedFlavor)
+	}
+	return signedFlavors, nil
+}
+
 func validateFlavorFilterCriteria(key, value, flavorgroupId string, ids, flavorParts []string) (*dm.FlavorFilterCriteria, error) {
 	defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Leaving")
@@ -789,6 +1748,17 @@ func validateFlavorCreateRequest(criteria dm.FlavorCreateRequest) error {
 			return errors.New("Valid flavor parts must be given as a flavor create criteria")
 		}
 	}
+	// the approval is only recorded by the capture and approve APIs, from the user of the request
+	for _, flavor := range criteria.FlavorCollection.Flavors {
+		if flavor.Flavor.Meta.Lifecycle != nil && flavor.Flavor.Meta.Lifecycle.Approval != nil {
+			return errors.New("The approval of a flavor cannot be given on create")
+		}
+	}
+	for _, signedFlavor := range criteria.SignedFlavorCollection.SignedFlavors {
+		if signedFlavor.Flavor.Meta.Lifecycle != nil && signedFlavor.Flavor.Meta.Lifecycle.Approval != nil {
+			return errors.New("The approval of a flavor cannot be given on create")
+		}
+	}
 
 	return nil
 }
@@ -801,11 +1771,37 @@ func validateFlavorMetaContent(meta *fm.Meta) error {
 	}
 	var fp fc.FlavorPart
 	if err := (&fp).Parse(meta.Description.FlavorPart); err != nil {
-		return errors.New("Flavor Part must be ASSET_TAG, SOFTWARE, HOST_UNIQUE, PLATFORM or OS")
+		return errors.New("Flavor Part must be ASSET_TAG, SOFTWARE, HOST_UNIQUE, PLATFORM, OS or IMA")
+	}
+	if meta.RuleBuilder != "" {
+		if err := verifier.ValidateRuleBuilder(meta.RuleBuilder); err != nil {
+			return errors.Wrap(err, "Invalid flavor meta content")
+		}
+	}
+	if err := verifier.ValidateRuleReferences(meta.Rules); err != nil {
+		return errors.Wrap(err, "Invalid flavor meta content")
+	}
+	if meta.Lifecycle != nil {
+		if err := meta.Lifecycle.Validate(); err != nil {
+			return errors.Wrap(err, "Invalid flavor meta content")
+		}
 	}
 	return nil
 }
 
+// validateImaFlavorContent returns an error if the IMA flavor does not hold a valid allowlist
+func validateImaFlavorContent(flavor *hvs.Flavor) error {
+	defaultLog.Trace("controllers/flavor_controller:validateImaFlavorContent() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:validateImaFlavorContent() Leaving")
+	if flavor.Ima == nil || len(flavor.Ima.Files) == 0 {
+		return errors.New("IMA flavor must contain the allowlist of the files")
+	}
+	if _, err := hcType.GetSHAAlgorithm(flavor.Ima.PcrBank); err != nil {
+		return errors.Errorf("IMA flavor PCR bank must be one of %v", hcType.SupportedPcrBanks)
+	}
+	return flavor.Ima.Validate()
+}
+
 func parseFlavorParts(flavorParts []string) ([]fc.FlavorPart, error) {
 	defaultLog.Trace("controllers/flavor_controller:parseFlavorParts() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:parseFlavorParts() Leaving")
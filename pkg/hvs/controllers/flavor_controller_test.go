@@ -5,15 +5,26 @@
 package controllers_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	comctx "github.com/intel-secl/intel-secl/v3/pkg/lib/common/context"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	fc "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	fu "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/util"
 	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
@@ -93,8 +104,7 @@ var _ = Describe("FlavorController", func() {
 				var sfs *hvs.SignedFlavorCollection
 				err = json.Unmarshal(w.Body.Bytes(), &sfs)
 				Expect(err).ToNot(HaveOccurred())
-				//TODO Requires changes in mock flavor search method for this criteria
-				Expect(len(sfs.SignedFlavors)).To(Equal(0))
+				Expect(len(sfs.SignedFlavors)).To(Equal(1))
 			})
 		})
 		Context("When filtered by Flavor id", func() {
@@ -187,6 +197,344 @@ var _ = Describe("FlavorController", func() {
 		})
 	})
 
+	// Specs for HTTP Put to "/flavors/{flavorId}/lifecycle"
+	Describe("Update Flavor lifecycle", func() {
+		BeforeEach(func() {
+			flavorSigningKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			(*flavorController.CertStore)[models.CertTypesFlavorSigning.String()].Key = flavorSigningKey
+		})
+
+		Context("Deprecate Flavor by valid ID", func() {
+			It("Should return 200 response code and the signed flavor with the lifecycle", func() {
+				router.Handle("/flavors/{id}/lifecycle", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.UpdateLifecycle))).Methods("PUT")
+				lifecycleJson := `{
+									"state": "DEPRECATED",
+									"not_after": "2021-01-01T00:00:00Z"
+								}`
+				req, err := http.NewRequest("PUT", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/lifecycle", strings.NewReader(lifecycleJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var sf hvs.SignedFlavor
+				err = json.Unmarshal(w.Body.Bytes(), &sf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sf.Flavor.Meta.Lifecycle.IsDeprecated()).To(BeTrue())
+				Expect(sf.Signature).NotTo(BeEmpty())
+
+				storedFlavor, err := flavorStore.Retrieve(sf.Flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(storedFlavor.Flavor.Meta.Lifecycle.NotAfter).NotTo(BeNil())
+				Expect(storedFlavor.Signature).To(Equal(sf.Signature))
+			})
+		})
+		Context("Provide an unknown lifecycle state", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/{id}/lifecycle", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.UpdateLifecycle))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/lifecycle", strings.NewReader(`{"state": "RETIRED"}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Update the lifecycle of a non-existent Flavor", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/{id}/lifecycle", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.UpdateLifecycle))).Methods("PUT")
+				req, err := http.NewRequest("PUT", "/flavors/73755fda-c910-46be-821f-e8ddeab189e9/lifecycle", strings.NewReader(`{"state": "REVOKED"}`))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/flavors/export" and HTTP Post to "/flavors/import"
+	Describe("Export and import flavor bundles", func() {
+		var signingKey *rsa.PrivateKey
+		var signingCert *x509.Certificate
+		bundleFlavor := `{
+							"meta": {
+								"id": "4fcd1a8c-2bc9-4a60-9a42-2a1b9f1a4e75",
+								"description": {
+									"flavor_part": "PLATFORM",
+									"label": "BundlePlatformFlavor",
+									"bios_name": "Intel Corporation",
+									"bios_version": "SE5C620.86B.02.01.0009.092820190230",
+									"tpm_version": "2.0",
+									"tboot_installed": "true"
+								},
+								"vendor": "INTEL"
+							},
+							"pcrs": {
+								"SHA256": {
+									"pcr_0": {
+										"value": "1009d6bc1d92739e4e8e3c6819364f9149ee652804565b83bf731bdb6352b2a6"
+									}
+								}
+							}
+						}`
+
+		// newFlavorBundle returns a bundle with the flavors signed by the signing key of the spec
+		newFlavorBundle := func(flavors ...hvs.Flavor) hvs.FlavorBundle {
+			bundle := hvs.FlavorBundle{
+				Manifest: hvs.FlavorBundleManifest{
+					Version:             hvs.FlavorBundleVersion,
+					SigningCertificates: [][]byte{signingCert.Raw},
+				},
+				FlavorGroups: []hvs.FlavorGroup{
+					{
+						Name: "hvs_flavorgroup_imported",
+						MatchPolicies: []hvs.FlavorMatchPolicy{
+							hvs.NewFlavorMatchPolicy(fc.FlavorPartPlatform, hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired)),
+						},
+					},
+				},
+			}
+			for _, flavor := range flavors {
+				signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&flavor, signingKey)
+				Expect(err).NotTo(HaveOccurred())
+				digest, err := flavor.GetContentDigest()
+				Expect(err).NotTo(HaveOccurred())
+				bundle.SignedFlavors = append(bundle.SignedFlavors, *signedFlavor)
+				bundle.Manifest.Flavors = append(bundle.Manifest.Flavors, hvs.FlavorBundleEntry{
+					FlavorId:   flavor.Meta.ID,
+					Label:      flavor.Meta.Description.Label,
+					FlavorPart: flavor.Meta.Description.FlavorPart,
+					Digest:     digest,
+				})
+				bundle.FlavorGroups[0].FlavorIds = append(bundle.FlavorGroups[0].FlavorIds, flavor.Meta.ID)
+			}
+			return bundle
+		}
+
+		importFlavorBundle := func(bundle hvs.FlavorBundle) *httptest.ResponseRecorder {
+			router.Handle("/flavors/import", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Import))).Methods("POST")
+			bundleJson, err := json.Marshal(bundle)
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequest("POST", "/flavors/import", bytes.NewReader(bundleJson))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			var certPem string
+			var err error
+			signingKey, certPem, err = crypt.CreateSelfSignedCertAndRSAPrivKeys(2048)
+			Expect(err).NotTo(HaveOccurred())
+			signingCert, err = crypt.GetCertFromPem([]byte(certPem))
+			Expect(err).NotTo(HaveOccurred())
+			(*flavorController.CertStore)[models.CertTypesFlavorSigning.String()].Key = signingKey
+			(*flavorController.CertStore)[models.CertTypesFlavorSigning.String()].Certificates = []x509.Certificate{*signingCert}
+			(*flavorController.CertStore)[models.CaCertTypesRootCa.String()].Certificates = []x509.Certificate{*signingCert}
+		})
+
+		Context("Export all flavors", func() {
+			It("Should return 200 response code and a bundle with the signing certificate and the flavor digests", func() {
+				router.Handle("/flavors/export", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Export))).Methods("GET")
+				req, err := http.NewRequest("GET", "/flavors/export", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var bundle hvs.FlavorBundle
+				err = json.Unmarshal(w.Body.Bytes(), &bundle)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bundle.Manifest.SigningCertificates).To(Equal([][]byte{signingCert.Raw}))
+				Expect(bundle.SignedFlavors).To(HaveLen(1))
+				Expect(bundle.Manifest.Flavors).To(HaveLen(1))
+				Expect(bundle.Validate()).To(Succeed())
+			})
+		})
+		Context("Import a bundle with a new flavor", func() {
+			It("Should return 200 response code, create the flavor and the flavorgroup", func() {
+				var flavor hvs.Flavor
+				Expect(json.Unmarshal([]byte(bundleFlavor), &flavor)).To(Succeed())
+				w = importFlavorBundle(newFlavorBundle(flavor))
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var report hvs.FlavorBundleImportReport
+				err := json.Unmarshal(w.Body.Bytes(), &report)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.ImportedFlavors).To(HaveLen(1))
+				Expect(report.Conflicts).To(BeEmpty())
+				Expect(report.CreatedFlavorGroups).To(Equal([]string{"hvs_flavorgroup_imported"}))
+
+				_, err = flavorStore.Retrieve(flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+				flavorGroups, err := flavorGroupStore.Search(&models.FlavorGroupFilterCriteria{NameEqualTo: "hvs_flavorgroup_imported"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(flavorGroups).To(HaveLen(1))
+				_, err = flavorGroupStore.RetrieveFlavor(flavorGroups[0].ID, flavor.Meta.ID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		Context("Import a bundle with a flavor conflicting by label", func() {
+			It("Should return 200 response code and report the conflict", func() {
+				existingFlavor, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+				Expect(err).NotTo(HaveOccurred())
+				var flavor hvs.Flavor
+				Expect(json.Unmarshal([]byte(bundleFlavor), &flavor)).To(Succeed())
+				flavor.Meta.Description.Label = existingFlavor.Flavor.Meta.Description.Label
+				w = importFlavorBundle(newFlavorBundle(flavor))
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var report hvs.FlavorBundleImportReport
+				err = json.Unmarshal(w.Body.Bytes(), &report)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.ImportedFlavors).To(BeEmpty())
+				Expect(report.Conflicts).To(HaveLen(1))
+				Expect(report.Conflicts[0].Reason).To(Equal(hvs.FlavorBundleConflictLabel))
+				Expect(report.Conflicts[0].ExistingFlavorId).To(Equal(existingFlavor.Flavor.Meta.ID))
+			})
+		})
+		Context("Import a bundle with a tampered flavor", func() {
+			It("Should return 400 response code", func() {
+				var flavor hvs.Flavor
+				Expect(json.Unmarshal([]byte(bundleFlavor), &flavor)).To(Succeed())
+				bundle := newFlavorBundle(flavor)
+				bundle.SignedFlavors[0].Flavor.Meta.Description.BiosVersion = "SE5C620.86B.02.01.0010.010620200716"
+				bundle.Manifest.Flavors[0].Digest, _ = bundle.SignedFlavors[0].Flavor.GetContentDigest()
+				w = importFlavorBundle(bundle)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Import a bundle signed by an untrusted certificate", func() {
+			It("Should return 400 response code", func() {
+				var flavor hvs.Flavor
+				Expect(json.Unmarshal([]byte(bundleFlavor), &flavor)).To(Succeed())
+				bundle := newFlavorBundle(flavor)
+				(*flavorController.CertStore)[models.CaCertTypesRootCa.String()].Certificates = nil
+				w = importFlavorBundle(bundle)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/flavors/candidates" and "/flavors/{id}/approve"
+	Describe("Capture and approve candidate flavors", func() {
+		var flavorgroupId = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+		var existingFlavorId = uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+
+		BeforeEach(func() {
+			flavorSigningKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			(*flavorController.CertStore)[models.CertTypesFlavorSigning.String()].Key = flavorSigningKey
+			_, err = flavorGroupStore.AddFlavors(flavorgroupId, []uuid.UUID{existingFlavorId})
+			Expect(err).NotTo(HaveOccurred())
+
+			router.Handle("/flavors/candidates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Capture))).Methods("POST")
+			router.Handle("/flavors/{id}/approve", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Approve))).Methods("POST")
+		})
+
+		captureFlavor := func(userName string) hvs.FlavorCandidate {
+			captureJson := `{
+								"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+								"flavorgroup_names": ["hvs_flavorgroup_test1"],
+								"partial_flavor_types": ["PLATFORM"]
+							}`
+			req, err := http.NewRequest("POST", "/flavors/candidates", strings.NewReader(captureJson))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			req = comctx.SetUserName(req, userName)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusCreated))
+
+			var candidates hvs.FlavorCandidateCollection
+			err = json.Unmarshal(w.Body.Bytes(), &candidates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates.Candidates).To(HaveLen(1))
+			return candidates.Candidates[0]
+		}
+
+		approveFlavor := func(flavorId uuid.UUID, userName string) {
+			req, err := http.NewRequest("POST", "/flavors/"+flavorId.String()+"/approve", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req = comctx.SetUserName(req, userName)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Capture flavors from a registered golden host", func() {
+			It("Should return 201 response code and the draft flavors with their differences", func() {
+				candidate := captureFlavor("admin")
+				lifecycle := candidate.SignedFlavor.Flavor.Meta.Lifecycle
+				Expect(lifecycle.GetState()).To(Equal(fm.FlavorStateDraft))
+				Expect(lifecycle.IsPendingApproval()).To(BeTrue())
+				Expect(lifecycle.Approval.CapturedBy).To(Equal("admin"))
+				Expect(lifecycle.Approval.CapturedFrom).To(Equal("localhost1"))
+				Expect(candidate.Diffs).To(HaveLen(1))
+				Expect(candidate.Diffs[0].ExistingFlavorId).To(Equal(existingFlavorId))
+				Expect(candidate.Diffs[0].Measurements).NotTo(BeEmpty())
+			})
+		})
+		Context("Capture host unique flavors from a golden host", func() {
+			It("Should return 400 response code", func() {
+				captureJson := `{
+									"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+									"partial_flavor_types": ["HOST_UNIQUE"]
+								}`
+				req, err := http.NewRequest("POST", "/flavors/candidates", strings.NewReader(captureJson))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				req = comctx.SetUserName(req, "admin")
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Approve a flavor captured by the same user", func() {
+			It("Should return 403 response code", func() {
+				candidate := captureFlavor("admin")
+				approveFlavor(candidate.SignedFlavor.Flavor.Meta.ID, "admin")
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+		Context("Approve a flavor captured by another user", func() {
+			It("Should return 200 response code and the active flavor", func() {
+				candidate := captureFlavor("admin")
+				flavorId := candidate.SignedFlavor.Flavor.Meta.ID
+				approveFlavor(flavorId, "security_officer")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var sf hvs.SignedFlavor
+				err := json.Unmarshal(w.Body.Bytes(), &sf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sf.Flavor.Meta.Lifecycle.GetState()).To(Equal(fm.FlavorStateActive))
+				Expect(sf.Flavor.Meta.Lifecycle.Approval.ApprovedBy).To(Equal("security_officer"))
+				Expect(sf.Flavor.Meta.Lifecycle.Approval.ApprovedAt).NotTo(BeNil())
+
+				approveFlavor(flavorId, "another_officer")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Approve a non-existent Flavor", func() {
+			It("Should return 404 response code", func() {
+				approveFlavor(uuid.MustParse("73755fda-c910-46be-821f-e8ddeab189e9"), "security_officer")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	// Specs for HTTP Post to "/flavor"
 	Describe("Create a new flavor", func() {
 		Context("Provide a invalid Create request with XSS Attack Strings", func() {
@@ -216,6 +564,44 @@ var _ = Describe("FlavorController", func() {
 			})
 		})
 
+		Context("Provide a Create request with the approval of the flavor", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Create))).Methods("POST")
+				flavorJson := `{
+						"flavor_collection": {
+							"flavors": [{
+								"flavor": {
+									"meta": {
+										"description": {
+											"label": "approved_software_flavor",
+											"flavor_part": "SOFTWARE"
+										},
+										"lifecycle": {
+											"state": "ACTIVE",
+											"approval": {
+												"captured_by": "golden_host_admin",
+												"approved_by": "security_admin"
+											}
+										}
+									}
+								}
+							}]
+						}
+					}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors",
+					strings.NewReader(flavorJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("Provide a Create request without Accept header", func() {
 			It("Should return 415 response code", func() {
 				flavorJson := `{
@@ -1009,4 +1395,169 @@ var _ = Describe("FlavorController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Post to "/flavors/evaluate"
+	Describe("Evaluate flavors", func() {
+		candidateFlavor := `{
+							"flavor": {
+								"meta": {
+									"description": {
+										"flavor_part": "PLATFORM",
+										"label": "CandidatePlatformFlavor",
+										"bios_name": "Intel Corporation",
+										"bios_version": "SE5C620.86B.02.01.0009.092820190230",
+										"tpm_version": "2.0",
+										"tboot_installed": "true"
+									},
+									"vendor": "INTEL"
+								},
+								"pcrs": {
+									"SHA256": {
+										"pcr_0": {
+											"value": "1009d6bc1d92739e4e8e3c6819364f9149ee652804565b83bf731bdb6352b2a6"
+										}
+									}
+								}
+							}
+						}`
+		hostManifest := `{
+							"host_info": {
+								"host_name": "localhost",
+								"hardware_uuid": "0005AE6E-36D6-E711-906E-001560A04062"
+							}
+						}`
+
+		BeforeEach(func() {
+			// the candidate flavors are signed with the flavor signing key before they are evaluated
+			flavorSigningKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			(*flavorController.CertStore)[models.CertTypesFlavorSigning.String()].Key = flavorSigningKey
+		})
+
+		Context("Provide a valid evaluate request with an inline host manifest", func() {
+			It("Should return 200 response code and the trust report", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+									"host_manifest": ` + hostManifest + `,
+									"flavor_collection": {"flavors": [` + candidateFlavor + `]}
+								}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(evaluateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var trustReport hvs.TrustReport
+				err = json.Unmarshal(w.Body.Bytes(), &trustReport)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(trustReport.Trusted).To(BeTrue())
+				Expect(trustReport.HostManifest.HostInfo.HostName).To(Equal("localhost"))
+			})
+		})
+
+		Context("Provide an evaluate request for a host without a host manifest", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+									"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+									"flavor_collection": {"flavors": [` + candidateFlavor + `]}
+								}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(evaluateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide an evaluate request for a non-existent host", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+									"host_id": "73755fda-c910-46be-821f-e8ddeab189e9",
+									"flavor_collection": {"flavors": [` + candidateFlavor + `]}
+								}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(evaluateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Provide an evaluate request with a non-existent flavorgroup", func() {
+			It("Should return 404 response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+									"host_manifest": ` + hostManifest + `,
+									"flavorgroup": {"id": "73755fda-c910-46be-821f-e8ddeab189e9"}
+								}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(evaluateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Provide an evaluate request without a host", func() {
+			It("Should return 400 response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				evaluateJson := `{
+									"flavor_collection": {"flavors": [` + candidateFlavor + `]}
+								}`
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(evaluateJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide an evaluate request without Content-Type header", func() {
+			It("Should return 415 response code", func() {
+				router.Handle("/flavors/evaluate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Evaluate))).Methods("POST")
+				req, err := http.NewRequest(
+					"POST",
+					"/flavors/evaluate",
+					strings.NewReader(`{"host_manifest": `+hostManifest+`}`),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
 })
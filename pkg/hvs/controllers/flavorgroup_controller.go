@@ -15,6 +15,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"net/http"
@@ -74,6 +75,13 @@ func (controller FlavorgroupController) Create(w http.ResponseWriter, r *http.Re
 		defaultLog.WithError(err).Error("controllers/flavorgroup_controller:Create() Flavorgroup save failed")
 		return nil, http.StatusInternalServerError, errors.Errorf("Error while inserting a new Flavorgroup")
 	}
+
+	if newFlavorGroup.HostSelector != "" {
+		if err := controller.linkSelectedHosts(newFlavorGroup); err != nil {
+			defaultLog.WithError(err).Error("controllers/flavorgroup_controller:Create() Host FlavorGroup association by host selector failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate the selected Hosts with the Flavorgroup"}
+		}
+	}
 	secLog.WithField("Name", reqFlavorGroup.Name).Infof("%s: FlavorGroup created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
 	return newFlavorGroup, http.StatusCreated, nil
 }
@@ -202,6 +210,54 @@ func ValidateFlavorGroup(flavorGroup hvs.FlavorGroup) error {
 	if len(flavorGroup.MatchPolicies) == 0 {
 		return errors.New("Flavor Type Match Policy Collection must be specified")
 	}
+	for _, flvMatchPolicy := range flavorGroup.MatchPolicies {
+		if err := verifier.ValidateRuleReferences(flvMatchPolicy.Rules); err != nil {
+			return errors.Wrapf(err, "Valid rules must be specified for %s flavor match policy", flvMatchPolicy.FlavorPart)
+		}
+	}
+	if flavorGroup.Policy != nil {
+		if err := flavorGroup.Policy.Validate(); err != nil {
+			return errors.Wrap(err, "Valid FlavorGroup Policy must be specified")
+		}
+	}
+	if flavorGroup.HostSelector != "" {
+		if _, err := hvs.ParseLabelSelector(flavorGroup.HostSelector); err != nil {
+			return errors.Wrap(err, "Valid FlavorGroup Host Selector must be specified")
+		}
+	}
+	return nil
+}
+
+// linkSelectedHosts links the hosts matching the host selector of a new flavorgroup to it and queues them
+// for verification
+func (controller FlavorgroupController) linkSelectedHosts(flavorGroup *hvs.FlavorGroup) error {
+	defaultLog.Trace("controllers/flavorgroup_controller:linkSelectedHosts() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_controller:linkSelectedHosts() Leaving")
+
+	selector, err := hvs.ParseLabelSelector(flavorGroup.HostSelector)
+	if err != nil {
+		return errors.Wrap(err, "Could not parse the host selector")
+	}
+	hosts, err := controller.HostStore.Search(&models.HostFilterCriteria{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrap(err, "Could not search the selected hosts")
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	var hostIds []uuid.UUID
+	for _, host := range hosts {
+		if err := controller.HostStore.AddFlavorgroups(host.Id, []uuid.UUID{flavorGroup.ID}); err != nil {
+			return errors.Wrapf(err, "Could not link host %s with the flavorgroup", host.Id)
+		}
+		hostIds = append(hostIds, host.Id)
+	}
+
+	defaultLog.Debugf("Adding hosts %v selected by flavorgroup %s to flavor-verify queue", hostIds, flavorGroup.Name)
+	if _, err := controller.HTManager.VerifyHostsAsync(hostIds, false, false); err != nil {
+		return errors.Wrap(err, "Could not add the selected hosts to the flavor-verify queue")
+	}
 	return nil
 }
 
@@ -310,7 +366,7 @@ func (controller FlavorgroupController) AddFlavor(w http.ResponseWriter, r *http
 	}
 
 	// Since the host has been updated, add it to the verify queue
-	err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
+	_, err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
 	if err != nil {
 		defaultLog.WithError(err).WithField("linkedHosts", linkedHosts).Error("controllers/host_controller:AddFlavor() Addition of Host to Flavor Verify Queue failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while inserting a new Flavorgroup-Flavor link"}
@@ -366,7 +422,7 @@ func (controller FlavorgroupController) RemoveFlavor(w http.ResponseWriter, r *h
 	}
 
 	// Since the host has been updated, add it to the verify queue
-	err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
+	_, err = controller.HTManager.VerifyHostsAsync(linkedHosts, false, false)
 	if err != nil {
 		defaultLog.WithError(err).WithField("linkedHosts", linkedHosts).Error("controllers/host_controller:RemoveFlavor() Addition of Host to Flavor Verify Queue failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while removing Flavorgroup-Flavor links"}
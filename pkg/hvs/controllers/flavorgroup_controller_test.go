@@ -14,6 +14,7 @@ import (
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"net/http"
 	"net/http/httptest"
@@ -220,6 +221,81 @@ var _ = Describe("FlavorgroupController", func() {
 			})
 		})
 
+		Context("Provide a valid Flavorgroup data with a host selector", func() {
+			It("Should create a new Flavorgroup linked to the selected Hosts and get HTTP Status: 201", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_prod",
+								"host_selector": "env=prod",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(201))
+
+				var fg hvs.FlavorGroup
+				json.Unmarshal(w.Body.Bytes(), &fg)
+				Expect(fg.HostSelector).To(Equal("env=prod"))
+				// only localhost1 of the mocked hosts is labeled env=prod
+				fgIds, _ := hostStore.SearchFlavorgroups(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(fgIds).To(ContainElement(fg.ID))
+				fgIds, _ = hostStore.SearchFlavorgroups(uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d"))
+				Expect(fgIds).NotTo(ContainElement(fg.ID))
+			})
+		})
+
+		Context("Provide a Flavorgroup data that contains an invalid host selector", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_prod",
+								"host_selector": "env=prod,,rack=r12",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+
 		Context("Provide a Flavorgroup data that contains duplicate flavorgroup name", func() {
 			It("Should get HTTP Status: 400", func() {
 				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods("POST")
@@ -381,6 +457,66 @@ var _ = Describe("FlavorgroupController", func() {
 				Ω(err).Should(HaveOccurred())
 			})
 		})
+		Context("FlavorGroup with a policy rule that is not registered", func() {
+			It("should fail flavorGroup validation", func() {
+				flavorGroup := hvs.FlavorGroup{
+					Name: "hvs_flavorgroup_test1",
+					MatchPolicies: hvs.FlavorMatchPolicies{
+						{
+							FlavorPart:  cf.FlavorPartOs,
+							MatchPolicy: hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired),
+							Rules:       []hvs.RuleReference{{Name: "not-registered"}},
+						},
+					},
+				}
+				err := controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+		Context("FlavorGroup with a policy expression", func() {
+			It("should pass flavorGroup validation", func() {
+				flavorGroup := hvs.FlavorGroup{
+					Name: "hvs_flavorgroup_test1",
+					MatchPolicies: hvs.FlavorMatchPolicies{
+						{
+							FlavorPart:  cf.FlavorPartOs,
+							MatchPolicy: hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired),
+						},
+					},
+					Policy: &hvs.FlavorGroupPolicy{
+						Expression: &hvs.PolicyExpression{
+							AnyOf: []hvs.PolicyExpression{
+								{FlavorPart: cf.FlavorPartOs, MinTrusted: 2},
+								{FlavorPart: cf.FlavorPartPlatform},
+							},
+						},
+					},
+				}
+				err := controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+		})
+		Context("FlavorGroup with an invalid policy expression", func() {
+			It("should fail flavorGroup validation", func() {
+				flavorGroup := hvs.FlavorGroup{
+					Name: "hvs_flavorgroup_test1",
+					MatchPolicies: hvs.FlavorMatchPolicies{
+						{
+							FlavorPart:  cf.FlavorPartOs,
+							MatchPolicy: hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequired),
+						},
+					},
+					Policy: &hvs.FlavorGroupPolicy{
+						Expression: &hvs.PolicyExpression{
+							FlavorPart: cf.FlavorPartOs,
+							Not:        &hvs.PolicyExpression{FlavorPart: cf.FlavorPartPlatform},
+						},
+					},
+				}
+				err := controllers.ValidateFlavorGroup(flavorGroup)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
 	})
 
 	// Specs for FlavorGroupFilterCriteria validation
@@ -0,0 +1,567 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// the columns of a CSV batch of hosts, the flavorgroup names and the labels ("env=prod;rack=r12") are separated by
+// semicolons
+var hostBatchCsvColumns = map[string]bool{"host_name": true, "connection_string": true, "description": true,
+	"flavorgroup_names": true, "labels": true}
+
+// CreateBatch registers the hosts of a JSON or CSV batch with a job. The hosts are connected to through the host data
+// fetcher and attested as soon as they are registered, the job has the result of every host
+func (hc *HostController) CreateBatch(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:CreateBatch() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:CreateBatch() Leaving")
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != consts.HTTPMediaTypeJson && contentType != consts.HTTPMediaTypeCsv {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_batch_controller:CreateBatch() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	var reqHosts []hvs.HostCreateRequest
+	if contentType == consts.HTTPMediaTypeCsv {
+		var err error
+		reqHosts, err = parseHostBatchCsv(r.Body)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_batch_controller:CreateBatch() %s : Failed to parse request body as CSV", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+
+		var reqBatch hvs.HostBatchCreateRequest
+		if err := dec.Decode(&reqBatch); err != nil {
+			secLog.WithError(err).Errorf("controllers/host_batch_controller:CreateBatch() %s : Failed to decode request body as HostBatchCreateRequest", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+		}
+		reqHosts = reqBatch.Hosts
+	}
+
+	if err := validateBatchSize(len(reqHosts)); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_batch_controller:CreateBatch() %s : Invalid batch size", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	// the hosts are registered concurrently, the duplicate check of a single host does not see the other hosts of the batch
+	results := make([]hvs.JobResult, len(reqHosts))
+	hostNames := make(map[string]bool, len(reqHosts))
+	for i, reqHost := range reqHosts {
+		if reqHost.HostName != "" && hostNames[reqHost.HostName] {
+			secLog.Errorf("controllers/host_batch_controller:CreateBatch() %s : Duplicate host %s in batch", commLogMsg.InvalidInputBadParam, reqHost.HostName)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host " + reqHost.HostName + " is specified more than once"}
+		}
+		hostNames[reqHost.HostName] = true
+		results[i].HostName = reqHost.HostName
+	}
+
+	job, status, err := hc.startJob(hvs.JobTypeHostCreate, results, func(position int, result *hvs.JobResult) {
+		hc.createBatchHost(reqHosts[position], result)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("job", job.Id).Infof("%s: Batch of %d hosts created by: %s", commLogMsg.PrivilegeModified, len(reqHosts), r.RemoteAddr)
+	return job, status, nil
+}
+
+// DeleteBatch deletes the hosts selected by their ids or by a label selector with a job
+func (hc *HostController) DeleteBatch(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:DeleteBatch() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:DeleteBatch() Leaving")
+
+	reqBatch, results, status, err := hc.getHostBatch(r)
+	if err != nil {
+		return nil, status, err
+	}
+	if len(reqBatch.FlavorgroupNames) != 0 {
+		secLog.Errorf("controllers/host_batch_controller:DeleteBatch() %s : Flavorgroup names specified for batch delete", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup names cannot be specified for a batch delete"}
+	}
+
+	job, status, err := hc.startJob(hvs.JobTypeHostDelete, results, func(position int, result *hvs.JobResult) {
+		if !hc.retrieveBatchHost(result) {
+			return
+		}
+		if err := hc.HStore.Delete(*result.HostId); err != nil {
+			defaultLog.WithError(err).WithField("id", *result.HostId).Error("controllers/host_batch_controller:DeleteBatch() Host delete failed")
+			failJobResult(result, "Failed to delete Host")
+		}
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("job", job.Id).Infof("Batch of %d hosts deleted by: %s", len(results), r.RemoteAddr)
+	return job, status, nil
+}
+
+// AddFlavorgroupBatch links the flavorgroups, which are created when they do not exist, to the selected hosts with a
+// job. The hosts are attested against their new flavorgroups
+func (hc *HostController) AddFlavorgroupBatch(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:AddFlavorgroupBatch() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:AddFlavorgroupBatch() Leaving")
+
+	reqBatch, results, status, err := hc.getHostBatch(r)
+	if err != nil {
+		return nil, status, err
+	}
+	if len(reqBatch.FlavorgroupNames) == 0 {
+		secLog.Errorf("controllers/host_batch_controller:AddFlavorgroupBatch() %s : Flavorgroup names not specified", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup names must be specified"}
+	}
+	if err := validateHostCreateCriteria(hvs.HostCreateRequest{FlavorgroupNames: reqBatch.FlavorgroupNames}); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_batch_controller:AddFlavorgroupBatch() %s : Invalid flavorgroup names", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	// the flavorgroups are created before the job, the hosts are linked to them concurrently
+	if _, err := CreateMissingFlavorgroups(hc.FGStore, reqBatch.FlavorgroupNames); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_batch_controller:AddFlavorgroupBatch() Flavorgroup create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Flavorgroups"}
+	}
+
+	job, status, err := hc.startJob(hvs.JobTypeHostFlavorgroupAdd, results, func(position int, result *hvs.JobResult) {
+		if !hc.retrieveBatchHost(result) {
+			return
+		}
+		if err := hc.linkFlavorgroupsToHost(reqBatch.FlavorgroupNames, *result.HostId); err != nil {
+			defaultLog.WithError(err).WithField("id", *result.HostId).Error("controllers/host_batch_controller:AddFlavorgroupBatch() Host FlavorGroup association failed")
+			failJobResult(result, "Failed to associate Host with flavorgroups")
+			return
+		}
+		hc.verifyBatchHost(result, false)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("job", job.Id).Infof("%s: Flavorgroups %+q linked to batch of %d hosts by: %s", commLogMsg.PrivilegeModified, reqBatch.FlavorgroupNames, len(results), r.RemoteAddr)
+	return job, status, nil
+}
+
+// VerifyBatch attests the selected hosts with a new host manifest with a job, the job has the trust status of every host
+func (hc *HostController) VerifyBatch(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:VerifyBatch() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:VerifyBatch() Leaving")
+
+	reqBatch, results, status, err := hc.getHostBatch(r)
+	if err != nil {
+		return nil, status, err
+	}
+	if len(reqBatch.FlavorgroupNames) != 0 {
+		secLog.Errorf("controllers/host_batch_controller:VerifyBatch() %s : Flavorgroup names specified for batch verify", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup names cannot be specified for a batch verify"}
+	}
+
+	job, status, err := hc.startJob(hvs.JobTypeHostVerify, results, func(position int, result *hvs.JobResult) {
+		if !hc.retrieveBatchHost(result) {
+			return
+		}
+		hc.verifyBatchHost(result, true)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("job", job.Id).Infof("%s: Batch of %d hosts verified by: %s", commLogMsg.AuthorizedAccess, len(results), r.RemoteAddr)
+	return job, status, nil
+}
+
+// createBatchHost registers a host of a batch without connecting to it, then retrieves the host manifest through the
+// host data fetcher to link the host to its default software flavorgroups and attest it
+func (hc *HostController) createBatchHost(reqHost hvs.HostCreateRequest, result *hvs.JobResult) {
+	defaultLog.Trace("controllers/host_batch_controller:createBatchHost() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:createBatchHost() Leaving")
+
+	connectionString, credential, _, err := hc.prepareHostCreate(reqHost)
+	if err != nil {
+		failJobResult(result, err.Error())
+		return
+	}
+	createdHost, _, err := hc.registerHost(reqHost, connectionString, credential, nil)
+	if err != nil {
+		failJobResult(result, err.Error())
+		return
+	}
+	result.HostId = &createdHost.Id
+
+	hostData, err := hc.HCConfig.HostDataFetcher.Retrieve(context.Background(), *createdHost)
+	if err != nil {
+		hostState := utils.DetermineHostState(err)
+		defaultLog.Warnf("controllers/host_batch_controller:createBatchHost() Could not connect to host %s : %s", createdHost.HostName, hostState.String())
+		failJobResult(result, "Host was created but could not be connected to: "+hostState.String())
+
+		// the host is attested once it can be connected to, as the hosts registered one at a time
+		if _, err := hc.HTManager.VerifyHostsAsync([]uuid.UUID{createdHost.Id}, true, false); err != nil {
+			defaultLog.WithError(err).Error("controllers/host_batch_controller:createBatchHost() Host to Flavor Verify Queue addition failed")
+		}
+		return
+	}
+
+	// Link to default software and workload groups if host is linux
+	if utils.IsLinuxHost(&hostData.HostInfo) {
+		swFgs := utils.GetDefaultSoftwareFlavorGroups(hostData.HostInfo.InstalledComponents)
+		if len(swFgs) != 0 {
+			defaultLog.Debugf("Associating host %s with default software flavorgroups %+q", createdHost.HostName, swFgs)
+			if err := hc.linkFlavorgroupsToHost(swFgs, createdHost.Id); err != nil {
+				defaultLog.WithError(err).Error("controllers/host_batch_controller:createBatchHost() Host FlavorGroup association failed")
+				failJobResult(result, "Failed to associate Host with flavorgroups")
+				return
+			}
+		}
+	}
+
+	// the host manifest was just retrieved, it is verified without connecting to the host again
+	hc.verifyBatchHost(result, false)
+}
+
+// verifyBatchHost attests the host of the job result and records its trust status
+func (hc *HostController) verifyBatchHost(result *hvs.JobResult, fetchHostData bool) {
+	defaultLog.Trace("controllers/host_batch_controller:verifyBatchHost() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:verifyBatchHost() Leaving")
+
+	report, err := hc.HTManager.VerifyHost(*result.HostId, fetchHostData, false)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", *result.HostId).Error("controllers/host_batch_controller:verifyBatchHost() Host verification failed")
+		failJobResult(result, "Failed to verify Host: "+err.Error())
+		return
+	}
+	trusted := report.TrustReport.IsTrusted()
+	result.Trusted = &trusted
+}
+
+// retrieveBatchHost retrieves the host of the job result and sets its name in the result, the result fails when the
+// host does not exist
+func (hc *HostController) retrieveBatchHost(result *hvs.JobResult) bool {
+	defaultLog.Trace("controllers/host_batch_controller:retrieveBatchHost() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:retrieveBatchHost() Leaving")
+
+	host, _, err := hc.retrieveHost(*result.HostId)
+	if err != nil {
+		failJobResult(result, err.Error())
+		return false
+	}
+	result.HostName = host.(*hvs.Host).HostName
+	return true
+}
+
+// getHostBatch decodes a HostBatchRequest and returns a pending job result for every host it selects
+func (hc *HostController) getHostBatch(r *http.Request) (*hvs.HostBatchRequest, []hvs.JobResult, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:getHostBatch() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:getHostBatch() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_batch_controller:getHostBatch() The request body was not provided")
+		return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	var reqBatch hvs.HostBatchRequest
+	if err := dec.Decode(&reqBatch); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_batch_controller:getHostBatch() %s : Failed to decode request body as HostBatchRequest", commLogMsg.InvalidInputBadEncoding)
+		return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if (len(reqBatch.HostIds) == 0) == (reqBatch.LabelSelector == "") {
+		secLog.Errorf("controllers/host_batch_controller:getHostBatch() %s : Host ids or label selector must be specified", commLogMsg.InvalidInputBadParam)
+		return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Either host ids or a label selector must be specified"}
+	}
+
+	var results []hvs.JobResult
+	if reqBatch.LabelSelector != "" {
+		selector, err := hvs.ParseLabelSelector(reqBatch.LabelSelector)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/host_batch_controller:getHostBatch() %s : Invalid label selector", commLogMsg.InvalidInputBadParam)
+			return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid label selector"}
+		}
+		hosts, err := hc.HStore.Search(&models.HostFilterCriteria{LabelSelector: selector})
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/host_batch_controller:getHostBatch() Host search failed")
+			return nil, nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search Hosts"}
+		}
+		for _, host := range hosts {
+			hostId := host.Id
+			results = append(results, hvs.JobResult{HostId: &hostId, HostName: host.HostName})
+		}
+	} else {
+		hostIds := make(map[uuid.UUID]bool, len(reqBatch.HostIds))
+		for i := range reqBatch.HostIds {
+			if reqBatch.HostIds[i] == uuid.Nil || hostIds[reqBatch.HostIds[i]] {
+				secLog.Errorf("controllers/host_batch_controller:getHostBatch() %s : Invalid or duplicate host id", commLogMsg.InvalidInputBadParam)
+				return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host ids must be valid and unique"}
+			}
+			hostIds[reqBatch.HostIds[i]] = true
+			results = append(results, hvs.JobResult{HostId: &reqBatch.HostIds[i]})
+		}
+	}
+
+	if err := validateBatchSize(len(results)); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_batch_controller:getHostBatch() %s : Invalid batch size", commLogMsg.InvalidInputBadParam)
+		return nil, nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	return &reqBatch, results, http.StatusOK, nil
+}
+
+// startJob stores a job with a pending result for every host and runs the task of each result in the background,
+// at most NumberOfDataFetchers at a time. The results that are still pending once their task is done succeeded
+func (hc *HostController) startJob(jobType hvs.JobType, results []hvs.JobResult, task func(position int, result *hvs.JobResult)) (*hvs.Job, int, error) {
+	defaultLog.Trace("controllers/host_batch_controller:startJob() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:startJob() Leaving")
+
+	for i := range results {
+		results[i].State = hvs.JobResultStatePending
+	}
+	job, err := hc.JobStore.Create(&hvs.Job{
+		Type:    jobType,
+		State:   hvs.JobStateQueued,
+		Results: results,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_batch_controller:startJob() Job create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Job"}
+	}
+
+	workers := hc.HCConfig.NumberOfDataFetchers
+	if workers <= 0 {
+		workers = constants.DefaultFvsNumberOfDataFetchers
+	}
+	// the job returned to the caller is not modified by the runner
+	runningJob := hvs.Job{Id: job.Id, Type: job.Type}
+	if hc.HCConfig.BatchJobsWg != nil {
+		hc.HCConfig.BatchJobsWg.Add(1)
+	}
+	go func() {
+		if hc.HCConfig.BatchJobsWg != nil {
+			defer hc.HCConfig.BatchJobsWg.Done()
+		}
+		runningJob.State = hvs.JobStateRunning
+		if err := hc.JobStore.Update(&runningJob); err != nil {
+			defaultLog.WithError(err).WithField("id", runningJob.Id).Error("controllers/host_batch_controller:startJob() Job update failed")
+		}
+
+		positions := make(chan int)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for position := range positions {
+					result := results[position]
+					task(position, &result)
+					if result.State == hvs.JobResultStatePending {
+						result.State = hvs.JobResultStateSucceeded
+					}
+					completedAt := time.Now()
+					result.CompletedAt = &completedAt
+					if err := hc.JobStore.UpdateResult(runningJob.Id, position, &result); err != nil {
+						defaultLog.WithError(err).WithField("id", runningJob.Id).Error("controllers/host_batch_controller:startJob() Job result update failed")
+					}
+				}
+			}()
+		}
+		// the hosts are no longer started once the service stops, the hosts already started still complete
+		started := 0
+	dispatch:
+		for ; started < len(results); started++ {
+			select {
+			case <-hc.HCConfig.BatchJobsQuit:
+				break dispatch
+			default:
+			}
+			select {
+			case positions <- started:
+			case <-hc.HCConfig.BatchJobsQuit:
+				break dispatch
+			}
+		}
+		close(positions)
+		wg.Wait()
+
+		completedAt := time.Now()
+		runningJob.State = hvs.JobStateCompleted
+		runningJob.CompletedAt = &completedAt
+		if started < len(results) {
+			runningJob.State = hvs.JobStateCancelled
+			for position := started; position < len(results); position++ {
+				result := results[position]
+				cancelJobResult(&result, completedAt)
+				if err := hc.JobStore.UpdateResult(runningJob.Id, position, &result); err != nil {
+					defaultLog.WithError(err).WithField("id", runningJob.Id).Error("controllers/host_batch_controller:startJob() Job result update failed")
+				}
+			}
+		}
+		if err := hc.JobStore.Update(&runningJob); err != nil {
+			defaultLog.WithError(err).WithField("id", runningJob.Id).Error("controllers/host_batch_controller:startJob() Job update failed")
+		}
+		defaultLog.Infof("controllers/host_batch_controller:startJob() Job %s with %d hosts %s", runningJob.Id,
+			len(results), strings.ToLower(string(runningJob.State)))
+	}()
+
+	return job, http.StatusAccepted, nil
+}
+
+func failJobResult(result *hvs.JobResult, message string) {
+	result.State = hvs.JobResultStateFailed
+	result.Error = message
+}
+
+// cancelJobResult cancels the result of a host that was not processed because the service stopped
+func cancelJobResult(result *hvs.JobResult, cancelledAt time.Time) {
+	result.State = hvs.JobResultStateCancelled
+	result.Error = "The service stopped before the host was processed"
+	result.CompletedAt = &cancelledAt
+}
+
+// CancelUnfinishedHostBatchJobs cancels the batch host jobs that were still queued or running when the service stopped,
+// their pending results are cancelled. The FLAVOR_VERIFY jobs are restored from the queue by the host trust manager
+func CancelUnfinishedHostBatchJobs(jobStore domain.JobStore) error {
+	defaultLog.Trace("controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Leaving")
+
+	cancelledAt := time.Now()
+	for _, jobType := range []hvs.JobType{hvs.JobTypeHostCreate, hvs.JobTypeHostDelete, hvs.JobTypeHostFlavorgroupAdd, hvs.JobTypeHostVerify} {
+		for _, jobState := range []hvs.JobState{hvs.JobStateQueued, hvs.JobStateRunning} {
+			jobs, err := jobStore.Search(&models.JobFilterCriteria{Type: jobType, State: jobState})
+			if err != nil {
+				return errors.Wrapf(err, "controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Could not search %s %s jobs", jobState, jobType)
+			}
+			for _, j := range jobs {
+				job, err := jobStore.Retrieve(j.Id)
+				if err != nil {
+					return errors.Wrap(err, "controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Could not retrieve job "+j.Id.String())
+				}
+				for position, result := range job.Results {
+					if result.State != hvs.JobResultStatePending {
+						continue
+					}
+					cancelJobResult(&result, cancelledAt)
+					if err := jobStore.UpdateResult(job.Id, position, &result); err != nil {
+						return errors.Wrap(err, "controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Could not cancel job result")
+					}
+				}
+				job.State = hvs.JobStateCancelled
+				job.CompletedAt = &cancelledAt
+				if err := jobStore.Update(job); err != nil {
+					return errors.Wrap(err, "controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Could not cancel job "+job.Id.String())
+				}
+				defaultLog.Infof("controllers/host_batch_controller:CancelUnfinishedHostBatchJobs() Cancelled unfinished job %s", job.Id)
+			}
+		}
+	}
+	return nil
+}
+
+func validateBatchSize(size int) error {
+	if size == 0 {
+		return errors.New("At least one host must be specified")
+	}
+	if size > constants.MaxHostBatchSize {
+		return errors.Errorf("At most %d hosts can be specified", constants.MaxHostBatchSize)
+	}
+	return nil
+}
+
+// parseHostBatchCsv reads the hosts of a CSV batch, the first row has the names of the columns. The host_name and
+// connection_string columns are required
+func parseHostBatchCsv(body io.Reader) ([]hvs.HostCreateRequest, error) {
+	defaultLog.Trace("controllers/host_batch_controller:parseHostBatchCsv() Entering")
+	defer defaultLog.Trace("controllers/host_batch_controller:parseHostBatchCsv() Leaving")
+
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse CSV request body")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("The CSV request body must have a header row")
+	}
+
+	columns := make(map[string]int)
+	for i, column := range records[0] {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := columns[column]; ok || !hostBatchCsvColumns[column] {
+			return nil, errors.Errorf("Invalid or duplicate CSV column %q", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["host_name"]; !ok {
+		return nil, errors.New("The CSV request body must have a host_name column")
+	}
+	if _, ok := columns["connection_string"]; !ok {
+		return nil, errors.New("The CSV request body must have a connection_string column")
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var reqHosts []hvs.HostCreateRequest
+	for row, record := range records[1:] {
+		reqHost := hvs.HostCreateRequest{
+			HostName:         field(record, "host_name"),
+			ConnectionString: field(record, "connection_string"),
+			Description:      field(record, "description"),
+		}
+		for _, name := range strings.Split(field(record, "flavorgroup_names"), ";") {
+			if name = strings.TrimSpace(name); name != "" {
+				reqHost.FlavorgroupNames = append(reqHost.FlavorgroupNames, name)
+			}
+		}
+		for _, label := range strings.Split(field(record, "labels"), ";") {
+			if label = strings.TrimSpace(label); label == "" {
+				continue
+			}
+			parts := strings.SplitN(label, "=", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("Invalid label %q in CSV row %d, labels must be specified as key=value", label, row+2)
+			}
+			if reqHost.Labels == nil {
+				reqHost.Labels = make(map[string]string)
+			}
+			reqHost.Labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+		reqHosts = append(reqHosts, reqHost)
+	}
+	return reqHosts, nil
+}
@@ -0,0 +1,341 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	mocks2 "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostBatchController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostStore *mocks.MockHostStore
+	var flavorGroupStore *mocks.MockFlavorgroupStore
+	var jobStore *mocks.MockJobStore
+	var hostController *controllers.HostController
+	var hostTrustManager *smocks.MockHostTrustManager
+	var hostConnectorProvider mocks2.MockHostConnectorFactory
+
+	prodHostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	devHostId := uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostStore = mocks.NewMockHostStore()
+		flavorGroupStore = mocks.NewFakeFlavorgroupStore()
+		jobStore = mocks.NewMockJobStore()
+
+		dek, _ := base64.StdEncoding.DecodeString("gcXqH8YwuJZ3Rx4qVzA/zhVvkTw2TL+iRAC9T3E6lII=")
+		hostController = &controllers.HostController{
+			HStore:    hostStore,
+			HSStore:   mocks.NewMockHostStatusStore(),
+			FGStore:   flavorGroupStore,
+			HCStore:   mocks.NewMockHostCredentialStore(),
+			HTManager: hostTrustManager,
+			HCConfig: domain.HostControllerConfig{
+				HostConnectorProvider: hostConnectorProvider,
+				DataEncryptionKey:     dek,
+				Username:              "fakeuser",
+				Password:              "fakepassword",
+				HostDataFetcher:       &mocks.MockHostDataFetcher{UnreachableHosts: map[string]bool{"localhost4": true}},
+				// the mock stores cannot be used concurrently
+				NumberOfDataFetchers: 1,
+			},
+			JobStore: jobStore,
+		}
+		router.Handle("/hosts/batch", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.CreateBatch))).Methods("POST")
+		router.Handle("/hosts/batch/delete", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.DeleteBatch))).Methods("POST")
+		router.Handle("/hosts/batch/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.AddFlavorgroupBatch))).Methods("POST")
+		router.Handle("/hosts/batch/verify", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.VerifyBatch))).Methods("POST")
+	})
+
+	postBatch := func(path, contentType, body string) {
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", contentType)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	// waits for the job of the response to complete and returns it
+	completedJob := func() *hvs.Job {
+		Expect(w.Code).To(Equal(http.StatusAccepted))
+		var job hvs.Job
+		Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+		Expect(job.Id).NotTo(Equal(uuid.Nil))
+
+		var storedJob *hvs.Job
+		Eventually(func() hvs.JobState {
+			storedJob, _ = jobStore.Retrieve(job.Id)
+			return storedJob.State
+		}, "5s", "10ms").Should(Equal(hvs.JobStateCompleted))
+		Expect(storedJob.CompletedAt).NotTo(BeNil())
+		Expect(storedJob.Results).To(HaveLen(len(job.Results)))
+		return storedJob
+	}
+
+	// Specs for HTTP Post to "/hosts/batch"
+	Describe("Create a batch of Hosts", func() {
+		Context("Provide a valid JSON batch with an unreachable host", func() {
+			It("Should create all the Hosts and only attest the reachable ones", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeJson, `{"hosts": [
+					{"host_name": "localhost3", "connection_string": "intel:https://ta3.ip.com:1443"},
+					{"host_name": "localhost4", "connection_string": "intel:https://ta4.ip.com:1443"}
+				]}`)
+				job := completedJob()
+				Expect(job.Type).To(Equal(hvs.JobTypeHostCreate))
+
+				Expect(job.Results[0].HostName).To(Equal("localhost3"))
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateSucceeded))
+				Expect(job.Results[0].Trusted).NotTo(BeNil())
+				softwareFlavorgroups, _ := flavorGroupStore.Search(&models.FlavorGroupFilterCriteria{
+					NameEqualTo: models.FlavorGroupsPlatformSoftware.String(),
+				})
+				Expect(softwareFlavorgroups).To(HaveLen(1))
+				fgIds, _ := hostStore.SearchFlavorgroups(*job.Results[0].HostId)
+				Expect(fgIds).To(ContainElement(softwareFlavorgroups[0].ID))
+
+				Expect(job.Results[1].HostName).To(Equal("localhost4"))
+				Expect(job.Results[1].State).To(Equal(hvs.JobResultStateFailed))
+				Expect(job.Results[1].Error).To(ContainSubstring("CONNECTION_FAILURE"))
+				Expect(job.Results[1].HostId).NotTo(BeNil())
+				host, err := hostStore.Retrieve(*job.Results[1].HostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.HostName).To(Equal("localhost4"))
+			})
+		})
+
+		Context("Provide a valid CSV batch", func() {
+			It("Should create the Hosts with their labels and flavorgroups", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeCsv, "host_name,connection_string,flavorgroup_names,labels\n"+
+					"localhost3,intel:https://ta3.ip.com:1443,hvs_flavorgroup_test1,env=prod;dc1/rack=r13\n"+
+					"localhost5,\"intel:https://ta5.ip.com:1443\",,\n")
+				job := completedJob()
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateSucceeded))
+				Expect(job.Results[1].State).To(Equal(hvs.JobResultStateSucceeded))
+
+				host, err := hostStore.Retrieve(*job.Results[0].HostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.Labels).To(Equal(map[string]string{"env": "prod", "dc1/rack": "r13"}))
+				Expect(host.FlavorgroupNames).To(Equal([]string{"hvs_flavorgroup_test1"}))
+				host, err = hostStore.Retrieve(*job.Results[1].HostId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host.FlavorgroupNames).To(Equal([]string{models.FlavorGroupsAutomatic.String()}))
+			})
+		})
+
+		Context("Provide a batch with an existing and an invalid host", func() {
+			It("Should fail the results of these Hosts", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeJson, `{"hosts": [
+					{"host_name": "localhost1", "connection_string": "intel:https://ta.ip.com:1443"},
+					{"host_name": "localhost3", "connection_string": "intel:https://ta3.ip.com:1443", "labels": {"env": "prod env"}}
+				]}`)
+				job := completedJob()
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateFailed))
+				Expect(job.Results[0].Error).To(Equal("Host with this name already exist"))
+				Expect(job.Results[0].HostId).To(BeNil())
+				Expect(job.Results[1].State).To(Equal(hvs.JobResultStateFailed))
+				Expect(job.Results[1].Error).To(Equal("Invalid host data"))
+			})
+		})
+
+		Context("Provide a batch with a duplicate host", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeJson, `{"hosts": [
+					{"host_name": "localhost3", "connection_string": "intel:https://ta3.ip.com:1443"},
+					{"host_name": "localhost3", "connection_string": "intel:https://ta4.ip.com:1443"}
+				]}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide an empty batch", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeJson, `{"hosts": []}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a CSV batch with an unknown column", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypeCsv, "host_name,connection_string,tpm\n"+
+					"localhost3,intel:https://ta3.ip.com:1443,2.0\n")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a batch with an unsupported content type", func() {
+			It("Should get a HTTP unsupported media type status", func() {
+				postBatch("/hosts/batch", consts.HTTPMediaTypePlain, "localhost3")
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/hosts/batch/delete"
+	Describe("Delete a batch of Hosts", func() {
+		Context("Select the Hosts by label selector", func() {
+			It("Should delete the selected Hosts", func() {
+				postBatch("/hosts/batch/delete", consts.HTTPMediaTypeJson, `{"label_selector": "env=dev"}`)
+				job := completedJob()
+				Expect(job.Type).To(Equal(hvs.JobTypeHostDelete))
+				Expect(job.Results).To(HaveLen(1))
+				Expect(*job.Results[0].HostId).To(Equal(devHostId))
+				Expect(job.Results[0].HostName).To(Equal("localhost2"))
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateSucceeded))
+
+				_, err := hostStore.Retrieve(devHostId)
+				Expect(err).To(HaveOccurred())
+				_, err = hostStore.Retrieve(prodHostId)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("Select a non-existent Host by id", func() {
+			It("Should fail the result of the Host", func() {
+				postBatch("/hosts/batch/delete", consts.HTTPMediaTypeJson, `{"host_ids": ["73755fda-c910-46be-821f-e8ddeab189e9"]}`)
+				job := completedJob()
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateFailed))
+				Expect(job.Results[0].Error).To(Equal("Host with specified id does not exist"))
+			})
+		})
+
+		Context("Select the Hosts by id and label selector", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch/delete", consts.HTTPMediaTypeJson, `{"host_ids": ["`+prodHostId.String()+`"], "label_selector": "env=dev"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Select no Hosts", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch/delete", consts.HTTPMediaTypeJson, `{"label_selector": "env=test"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/hosts/batch/flavorgroups"
+	Describe("Link Flavorgroups to a batch of Hosts", func() {
+		Context("Provide new and existing Flavorgroup names", func() {
+			It("Should link the Flavorgroups to the Hosts and attest them", func() {
+				postBatch("/hosts/batch/flavorgroups", consts.HTTPMediaTypeJson, `{
+					"host_ids": ["`+prodHostId.String()+`", "`+devHostId.String()+`"],
+					"flavorgroup_names": ["hvs_flavorgroup_test2", "hvs_flavorgroup_new"]
+				}`)
+				job := completedJob()
+				Expect(job.Type).To(Equal(hvs.JobTypeHostFlavorgroupAdd))
+				newFlavorgroups, _ := flavorGroupStore.Search(&models.FlavorGroupFilterCriteria{NameEqualTo: "hvs_flavorgroup_new"})
+				Expect(newFlavorgroups).To(HaveLen(1))
+				for _, result := range job.Results {
+					Expect(result.State).To(Equal(hvs.JobResultStateSucceeded))
+					Expect(result.Trusted).NotTo(BeNil())
+					fgIds, _ := hostStore.SearchFlavorgroups(*result.HostId)
+					Expect(fgIds).To(ContainElement(newFlavorgroups[0].ID))
+				}
+			})
+		})
+
+		Context("Provide no Flavorgroup names", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch/flavorgroups", consts.HTTPMediaTypeJson, `{"label_selector": "env"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/hosts/batch/verify"
+	Describe("Verify a batch of Hosts", func() {
+		Context("Select the Hosts by label selector", func() {
+			It("Should record the trust status of the Hosts", func() {
+				postBatch("/hosts/batch/verify", consts.HTTPMediaTypeJson, `{"label_selector": "env in (prod,dev)"}`)
+				job := completedJob()
+				Expect(job.Type).To(Equal(hvs.JobTypeHostVerify))
+				Expect(job.Results).To(HaveLen(2))
+				for _, result := range job.Results {
+					Expect(result.State).To(Equal(hvs.JobResultStateSucceeded))
+					Expect(result.Trusted).NotTo(BeNil())
+				}
+			})
+		})
+
+		Context("Provide Flavorgroup names", func() {
+			It("Should get a HTTP bad request status", func() {
+				postBatch("/hosts/batch/verify", consts.HTTPMediaTypeJson, `{"label_selector": "env", "flavorgroup_names": ["automatic"]}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Stop the batch jobs", func() {
+		Context("Post a batch after the service stopped", func() {
+			It("Should cancel the results of the Hosts that were not started", func() {
+				quit := make(chan struct{})
+				close(quit)
+				hostController.HCConfig.BatchJobsQuit = quit
+				postBatch("/hosts/batch/verify", consts.HTTPMediaTypeJson, `{"label_selector": "env in (prod,dev)"}`)
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				var job hvs.Job
+				Expect(json.Unmarshal(w.Body.Bytes(), &job)).To(Succeed())
+
+				var storedJob *hvs.Job
+				Eventually(func() hvs.JobState {
+					storedJob, _ = jobStore.Retrieve(job.Id)
+					return storedJob.State
+				}, "5s", "10ms").Should(Equal(hvs.JobStateCancelled))
+				Expect(storedJob.CompletedAt).NotTo(BeNil())
+				for _, result := range storedJob.Results {
+					Expect(result.State).NotTo(Equal(hvs.JobResultStatePending))
+				}
+			})
+		})
+
+		Context("Start the service with unfinished batch jobs", func() {
+			It("Should cancel the unfinished batch jobs and their pending results", func() {
+				hostId := prodHostId
+				queued, _ := jobStore.Create(&hvs.Job{Type: hvs.JobTypeHostVerify, State: hvs.JobStateQueued,
+					Results: []hvs.JobResult{{HostId: &hostId, State: hvs.JobResultStatePending}}})
+				running, _ := jobStore.Create(&hvs.Job{Type: hvs.JobTypeHostCreate, State: hvs.JobStateRunning,
+					Results: []hvs.JobResult{{HostName: "localhost3", State: hvs.JobResultStateSucceeded},
+						{HostName: "localhost4", State: hvs.JobResultStatePending}}})
+				flavorVerify, _ := jobStore.Create(&hvs.Job{Type: hvs.JobTypeFlavorVerify, State: hvs.JobStateRunning,
+					Results: []hvs.JobResult{{HostId: &hostId, State: hvs.JobResultStatePending}}})
+
+				Expect(controllers.CancelUnfinishedHostBatchJobs(jobStore)).To(Succeed())
+
+				job, _ := jobStore.Retrieve(queued.Id)
+				Expect(job.State).To(Equal(hvs.JobStateCancelled))
+				Expect(job.CompletedAt).NotTo(BeNil())
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateCancelled))
+				job, _ = jobStore.Retrieve(running.Id)
+				Expect(job.State).To(Equal(hvs.JobStateCancelled))
+				Expect(job.Results[0].State).To(Equal(hvs.JobResultStateSucceeded))
+				Expect(job.Results[1].State).To(Equal(hvs.JobResultStateCancelled))
+				// the FLAVOR_VERIFY jobs are restored by the host trust manager
+				job, _ = jobStore.Retrieve(flavorVerify.Id)
+				Expect(job.State).To(Equal(hvs.JobStateRunning))
+			})
+		})
+	})
+})
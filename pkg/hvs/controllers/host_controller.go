@@ -33,6 +33,8 @@ type HostController struct {
 	HCStore   domain.HostCredentialStore
 	HTManager domain.HostTrustManager
 	HCConfig  domain.HostControllerConfig
+	// JobStore tracks the batch host operations
+	JobStore domain.JobStore
 }
 
 func NewHostController(hs domain.HostStore, hss domain.HostStatusStore,
@@ -49,7 +51,7 @@ func NewHostController(hs domain.HostStore, hss domain.HostStatusStore,
 }
 
 var hostSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "hostHardwareId": true,
-	"key": true, "value": true}
+	"key": true, "value": true, "labelSelector": true}
 
 func (hc *HostController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/host_controller:Create() Entering")
@@ -125,6 +127,7 @@ func (hc *HostController) Update(w http.ResponseWriter, r *http.Request) (interf
 		Description:      reqHost.Description,
 		ConnectionString: reqHost.ConnectionString,
 		FlavorgroupNames: reqHost.FlavorgroupNames,
+		Labels:           reqHost.Labels,
 	}
 
 	if err := validateHostCreateCriteria(criteria); err != nil {
@@ -140,7 +143,7 @@ func (hc *HostController) Update(w http.ResponseWriter, r *http.Request) (interf
 
 	defaultLog.Debugf("Adding host %v to flavor-verify queue", reqHost.Id)
 	// Since the host has been updated, add it to the verify queue
-	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{reqHost.Id}, true, false)
+	_, err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{reqHost.Id}, true, false)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:Update() Host to Flavor Verify Queue addition failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
@@ -214,27 +217,71 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 	defaultLog.Trace("controllers/host_controller:CreateHost() Entering")
 	defer defaultLog.Trace("controllers/host_controller:CreateHost() Leaving")
 
+	createdHost, status, err := hc.createHostWithoutVerify(reqHost)
+	if err != nil {
+		return nil, status, err
+	}
+
+	defaultLog.Debugf("Adding host %s to flavor-verify queue", reqHost.HostName)
+	// Since we are adding a new host, the forceUpdate flag should be set to true so that
+	// we connect to the host and get the latest host manifest to verify against.
+	_, err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{createdHost.Id}, true, false)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host to Flavor Verify Queue addition failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
+	}
+
+	return createdHost, http.StatusCreated, nil
+}
+
+// createHostWithoutVerify registers the host without adding it to the flavor-verify queue, the caller queues it
+func (hc *HostController) createHostWithoutVerify(reqHost hvs.HostCreateRequest) (*hvs.Host, int, error) {
+	defaultLog.Trace("controllers/host_controller:createHostWithoutVerify() Entering")
+	defer defaultLog.Trace("controllers/host_controller:createHostWithoutVerify() Leaving")
+
+	connectionString, credential, status, err := hc.prepareHostCreate(reqHost)
+	if err != nil {
+		return nil, status, err
+	}
+
+	defaultLog.Debugf("Connecting to host to get the hardware UUID of the host : %s", reqHost.HostName)
+	// connect to the host and retrieve the host info
+	hostInfo, err := hc.getHostInfo(connectionString)
+	if err != nil {
+		hostState := utils.DetermineHostState(err)
+		defaultLog.Warnf("Could not connect to host, hardware UUID will not be set: %s", hostState.String())
+	}
+
+	return hc.registerHost(reqHost, connectionString, credential, hostInfo)
+}
+
+// prepareHostCreate validates the host create request and returns the connection string of the host with its
+// credential
+func (hc *HostController) prepareHostCreate(reqHost hvs.HostCreateRequest) (string, string, int, error) {
+	defaultLog.Trace("controllers/host_controller:prepareHostCreate() Entering")
+	defer defaultLog.Trace("controllers/host_controller:prepareHostCreate() Leaving")
+
 	if reqHost.HostName == "" || reqHost.ConnectionString == "" {
-		secLog.Error("controllers/host_controller:CreateHost() Host connection string and host name must be specified")
-		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host connection string and host name must be specified"}
+		secLog.Error("controllers/host_controller:prepareHostCreate() Host connection string and host name must be specified")
+		return "", "", http.StatusBadRequest, &commErr.ResourceError{Message: "Host connection string and host name must be specified"}
 	}
 
 	if err := validateHostCreateCriteria(reqHost); err != nil {
-		secLog.WithError(err).Errorf("controllers/host_controller:CreateHost() %s Invalid host data", commLogMsg.InvalidInputBadParam)
-		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid host data"}
+		secLog.WithError(err).Errorf("controllers/host_controller:prepareHostCreate() %s Invalid host data", commLogMsg.InvalidInputBadParam)
+		return "", "", http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid host data"}
 	}
 
 	existingHosts, err := hc.HStore.Search(&models.HostFilterCriteria{
 		NameEqualTo: reqHost.HostName,
 	})
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host search failed")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host"}
+		defaultLog.WithError(err).Error("controllers/host_controller:prepareHostCreate() Host search failed")
+		return "", "", http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host"}
 	}
 
 	if existingHosts != nil && len(existingHosts) > 0 {
 		secLog.WithField("Name", existingHosts[0].HostName).Warningf("%s: Trying to create duplicate Host", commLogMsg.InvalidInputBadParam)
-		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host with this name already exist"}
+		return "", "", http.StatusBadRequest, &commErr.ResourceError{Message: "Host with this name already exist"}
 	}
 
 	connectionString, credential, err := GenerateConnectionString(reqHost.ConnectionString,
@@ -243,17 +290,17 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		hc.HCStore)
 
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Could not generate formatted connection string")
-		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+		defaultLog.WithError(err).Error("controllers/host_controller:prepareHostCreate() Could not generate formatted connection string")
+		return "", "", http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
+	return connectionString, credential, http.StatusOK, nil
+}
 
-	defaultLog.Debugf("Connecting to host to get the hardware UUID of the host : %s", reqHost.HostName)
-	// connect to the host and retrieve the host info
-	hostInfo, err := hc.getHostInfo(connectionString)
-	if err != nil {
-		hostState := utils.DetermineHostState(err)
-		defaultLog.Warnf("Could not connect to host, hardware UUID will not be set: %s", hostState.String())
-	}
+// registerHost stores the host and its credential, and links it to its flavorgroups. The hardware UUID and the
+// default software flavorgroups of the host are only set when the host info is known
+func (hc *HostController) registerHost(reqHost hvs.HostCreateRequest, connectionString, credential string, hostInfo *model.HostInfo) (*hvs.Host, int, error) {
+	defaultLog.Trace("controllers/host_controller:registerHost() Entering")
+	defer defaultLog.Trace("controllers/host_controller:registerHost() Leaving")
 
 	var hwUuid *uuid.UUID = nil
 	if hostInfo != nil && hostInfo.HardwareUUID != "" {
@@ -288,11 +335,12 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 		ConnectionString: csWithoutCredentials,
 		HardwareUuid:     hwUuid,
 		FlavorgroupNames: fgNames,
+		Labels:           reqHost.Labels,
 	}
 
 	createdHost, err := hc.HStore.Create(host)
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host create failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host create failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host"}
 	}
 
@@ -307,25 +355,20 @@ func (hc *HostController) CreateHost(reqHost hvs.HostCreateRequest) (interface{}
 
 	_, err = hc.HCStore.Create(&hostCredential)
 	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host Credential create failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host Credential create failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create Host Credential"}
 	}
 
 	defaultLog.Debugf("Associating host %s with flavorgroups %+q", reqHost.HostName, fgNames)
 	if err := hc.linkFlavorgroupsToHost(fgNames, createdHost.Id); err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host FlavorGroup association failed")
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host FlavorGroup association failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
 	}
 
-	defaultLog.Debugf("Adding host %s to flavor-verify queue", reqHost.HostName)
-	// Since we are adding a new host, the forceUpdate flag should be set to true so that
-	// we connect to the host and get the latest host manifest to verify against.
-	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{createdHost.Id}, true, false)
-	if err != nil {
-		defaultLog.WithError(err).Error("controllers/host_controller:CreateHost() Host to Flavor Verify Queue addition failed")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
+	if err := hc.linkSelectedFlavorgroupsToHost(createdHost); err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:registerHost() Host FlavorGroup association by host selector failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
 	}
-
 	return createdHost, http.StatusCreated, nil
 }
 
@@ -396,6 +439,14 @@ func (hc *HostController) UpdateHost(reqHost hvs.Host) (interface{}, int, error)
 		updatedHost.FlavorgroupNames = reqHost.FlavorgroupNames
 	}
 
+	// the flavorgroups linked by their host selector are kept when the labels no longer match it
+	if reqHost.Labels != nil {
+		if err := hc.linkSelectedFlavorgroupsToHost(updatedHost); err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:UpdateHost() Host FlavorGroup association by host selector failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to associate Host with flavorgroups"}
+		}
+	}
+
 	return updatedHost, http.StatusOK, nil
 }
 
@@ -520,6 +571,49 @@ func (hc *HostController) linkFlavorgroupsToHost(flavorgroupNames []string, host
 	return nil
 }
 
+// linkSelectedFlavorgroupsToHost links the host to the flavorgroups whose host selector matches the labels of the host
+func (hc *HostController) linkSelectedFlavorgroupsToHost(host *hvs.Host) error {
+	defaultLog.Trace("controllers/host_controller:linkSelectedFlavorgroupsToHost() Entering")
+	defer defaultLog.Trace("controllers/host_controller:linkSelectedFlavorgroupsToHost() Leaving")
+
+	// the hosts without labels are selected by the selectors that only exclude labels
+	flavorgroups, err := hc.FGStore.Search(nil)
+	if err != nil {
+		return errors.Wrap(err, "Could not search flavorgroups")
+	}
+
+	flavorgroupIds := []uuid.UUID{}
+	for _, flavorgroup := range flavorgroups {
+		if flavorgroup.HostSelector == "" {
+			continue
+		}
+		selector, err := hvs.ParseLabelSelector(flavorgroup.HostSelector)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/host_controller:linkSelectedFlavorgroupsToHost() Invalid host selector of flavorgroup %s", flavorgroup.Name)
+			continue
+		}
+		if !selector.Matches(host.Labels) {
+			continue
+		}
+		linkExists, err := hc.flavorGroupHostLinkExists(host.Id, flavorgroup.ID)
+		if err != nil {
+			return errors.Wrap(err, "Could not check host-flavorgroup link existence")
+		}
+		if !linkExists {
+			flavorgroupIds = append(flavorgroupIds, flavorgroup.ID)
+		}
+	}
+	if len(flavorgroupIds) == 0 {
+		return nil
+	}
+
+	defaultLog.Debugf("Linking host %v with flavorgroups %+q selecting it", host.Id, flavorgroupIds)
+	if err := hc.HStore.AddFlavorgroups(host.Id, flavorgroupIds); err != nil {
+		return errors.Wrap(err, "Could not create host-flavorgroup links")
+	}
+	return nil
+}
+
 func CreateMissingFlavorgroups(fGStore domain.FlavorGroupStore, flavorgroupNames []string,) ([]hvs.FlavorGroup, error) {
 	flavorgroups := []hvs.FlavorGroup{}
 	for _, flavorgroupName := range flavorgroupNames {
@@ -598,6 +692,9 @@ func validateHostCreateCriteria(host hvs.HostCreateRequest) error {
 			return errors.Wrap(err, "Valid Flavorgroup Names must be specified")
 		}
 	}
+	if err := hvs.ValidateLabels(host.Labels); err != nil {
+		return errors.Wrap(err, "Valid Host Labels must be specified")
+	}
 	return nil
 }
 
@@ -645,6 +742,15 @@ func populateHostFilterCriteria(params url.Values) (*models.HostFilterCriteria,
 		criteria.Value = value
 	}
 
+	// the label selector narrows down the hosts found by the other filter criteria
+	if params.Get("labelSelector") != "" {
+		labelSelector, err := hvs.ParseLabelSelector(params.Get("labelSelector"))
+		if err != nil {
+			return nil, errors.Wrap(err, "Valid contents for labelSelector must be specified")
+		}
+		criteria.LabelSelector = labelSelector
+	}
+
 	return &criteria, nil
 }
 
@@ -716,7 +822,7 @@ func (hc *HostController) AddFlavorgroup(w http.ResponseWriter, r *http.Request)
 	}
 
 	defaultLog.Debugf("Adding host %v to flavor-verify queue", hId)
-	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{hId}, false, false)
+	_, err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{hId}, false, false)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:AddFlavorgroup() Host to Flavor Verify Queue addition failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
@@ -758,7 +864,7 @@ func (hc *HostController) RemoveFlavorgroup(w http.ResponseWriter, r *http.Reque
 	}
 
 	defaultLog.Debugf("Adding host %v to flavor-verify queue", hId)
-	err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{hId}, false, false)
+	_, err = hc.HTManager.VerifyHostsAsync([]uuid.UUID{hId}, false, false)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/host_controller:RemoveFlavorgroup() Host to Flavor Verify Queue addition failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to add Host to Flavor Verify Queue"}
@@ -85,6 +85,92 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusCreated))
 			})
 		})
+		Context("Provide a valid Create request with labels matching the host selector of a flavorgroup", func() {
+			It("Should create a new Host linked to the flavorgroup", func() {
+				selectorFlavorgroup, _ := flavorGroupStore.Create(&hvs.FlavorGroup{
+					Name:         "hvs_flavorgroup_prod",
+					HostSelector: "env=prod,rack notin (r1,r2)",
+				})
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "intel:https://another.ta.ip.com:1443",
+								"description": "Another Intel Host",
+								"labels": {"env": "prod", "rack": "r12"}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var host hvs.Host
+				json.Unmarshal(w.Body.Bytes(), &host)
+				Expect(host.Labels["env"]).To(Equal("prod"))
+				fgIds, _ := hostStore.SearchFlavorgroups(host.Id)
+				Expect(fgIds).To(ContainElement(selectorFlavorgroup.ID))
+			})
+		})
+		Context("Provide a valid Create request without labels for a host selector excluding a label", func() {
+			It("Should create a new Host linked to the flavorgroup", func() {
+				selectorFlavorgroup, _ := flavorGroupStore.Create(&hvs.FlavorGroup{
+					Name:         "hvs_flavorgroup_not_dev",
+					HostSelector: "!env",
+				})
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "intel:https://another.ta.ip.com:1443",
+								"description": "Another Intel Host"
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var host hvs.Host
+				json.Unmarshal(w.Body.Bytes(), &host)
+				fgIds, _ := hostStore.SearchFlavorgroups(host.Id)
+				Expect(fgIds).To(ContainElement(selectorFlavorgroup.ID))
+			})
+		})
+		Context("Provide a Create request that contains invalid labels", func() {
+			It("Should fail to create new Host", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
+				hostJson := `{
+								"host_name": "localhost3",
+								"connection_string": "intel:https://another.ta.ip.com:1443",
+								"labels": {"env": "prod env"}
+							}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Provide a Create request that contains duplicate hostname", func() {
 			It("Should fail to create new Host", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods("POST")
@@ -440,6 +526,51 @@ var _ = Describe("HostController", func() {
 				Expect(len(hostCollection.Hosts)).To(Equal(2))
 			})
 		})
+		Context("Get all the Hosts with valid labelSelector param", func() {
+			It("Should get list of all the Hosts matching the label selector", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?labelSelector=env+in+(prod,test),dc1/rack", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				// Verifying mocked data of 1 host
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Hosts[0].HostName).To(Equal("localhost1"))
+			})
+		})
+		Context("Get all the Hosts with nameContains and labelSelector params", func() {
+			It("Should get list of the filtered Hosts matching the label selector", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?nameContains=localhost&labelSelector=env!%3Dprod", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				// Verifying mocked data of 1 host
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Hosts[0].HostName).To(Equal("localhost2"))
+			})
+		})
+		Context("Get all the Hosts with invalid labelSelector param", func() {
+			It("Should fail to get Hosts", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/hosts?labelSelector=env%3D%3Dprod+env", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Get all the Hosts with invalid nameEqualTo param", func() {
 			It("Should fail to get Hosts", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods("GET")
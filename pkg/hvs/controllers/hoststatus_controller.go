@@ -30,7 +30,7 @@ type HostStatusController struct {
 }
 
 var hostStatusSearchParams = map[string]bool{"id": true, "hostId": true, "hostHardwareId": true, "hostName": true, "hostStatus": true,
-	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true}
+	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true, "labelSelector": true}
 
 // Search returns a collection of HostStatus based on HostStatusFilter criteria
 func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -142,6 +142,16 @@ func getHSFilterCriteria(params url.Values) (*models.HostStatusFilterCriteria, e
 		hfc.HostStatus = hostState
 	}
 
+	// Host Labels
+	labelSelector := strings.TrimSpace(params.Get("labelSelector"))
+	if labelSelector != "" {
+		ls, err := hvs.ParseLabelSelector(labelSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "Valid contents for LabelSelector must be specified")
+		}
+		hfc.LabelSelector = ls
+	}
+
 	// fromDate
 	fromDate := strings.TrimSpace(params.Get("fromDate"))
 	if fromDate != "" {
@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// JobController serves the jobs of the batch host operations and of the flavor verifications queued by the host
+// trust manager
+type JobController struct {
+	Store     domain.JobStore
+	HTManager domain.HostTrustManager
+}
+
+var jobSearchParams = map[string]bool{"type": true, "state": true, "hostId": true}
+
+var jobTypes = map[hvs.JobType]bool{
+	hvs.JobTypeHostCreate:         true,
+	hvs.JobTypeHostDelete:         true,
+	hvs.JobTypeHostFlavorgroupAdd: true,
+	hvs.JobTypeHostVerify:         true,
+	hvs.JobTypeFlavorVerify:       true,
+}
+
+var jobStates = map[hvs.JobState]bool{
+	hvs.JobStateQueued:    true,
+	hvs.JobStateRunning:   true,
+	hvs.JobStateCompleted: true,
+	hvs.JobStateCancelled: true,
+}
+
+// Retrieve returns the job with its results, the pending results of a running FLAVOR_VERIFY job have the stage of
+// their host in the flavor verification queue
+func (controller JobController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/job_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/job_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	job, status, err := controller.retrieveJob(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if job.Type == hvs.JobTypeFlavorVerify && job.State == hvs.JobStateRunning && controller.HTManager != nil {
+		stages := controller.HTManager.JobStages(job.Id)
+		for i, result := range job.Results {
+			if stage, ok := stages[*result.HostId]; ok && result.State == hvs.JobResultStatePending {
+				job.Results[i].Stage = stage.String()
+			}
+		}
+	}
+
+	secLog.WithField("job", job.Id).Infof("%s: Job retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return job, http.StatusOK, nil
+}
+
+// Search returns the jobs matching the query parameters without their results, the latest jobs first
+func (controller JobController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/job_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/job_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), jobSearchParams); err != nil {
+		secLog.Errorf("controllers/job_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria, err := getJobFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/job_controller:Search() %s Invalid filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	jobs, err := controller.Store.Search(criteria)
+	if err != nil {
+		secLog.WithError(err).Error("controllers/job_controller:Search() Job search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Jobs"}
+	}
+
+	secLog.Infof("%s: Return job query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.JobCollection{Jobs: jobs}, http.StatusOK, nil
+}
+
+// Cancel cancels a running FLAVOR_VERIFY job, the hosts queued by the job that no other job waits for are removed from
+// the flavor verification queue
+func (controller JobController) Cancel(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/job_controller:Cancel() Entering")
+	defer defaultLog.Trace("controllers/job_controller:Cancel() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	job, status, err := controller.retrieveJob(id)
+	if err != nil {
+		return nil, status, err
+	}
+	if job.Type != hvs.JobTypeFlavorVerify {
+		secLog.WithField("id", id).Errorf("controllers/job_controller:Cancel() %s : Job cannot be cancelled", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Only FLAVOR_VERIFY jobs can be cancelled"}
+	}
+	if job.State != hvs.JobStateRunning {
+		secLog.WithField("id", id).Errorf("controllers/job_controller:Cancel() %s : Job is not running", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Job is already " + string(job.State)}
+	}
+
+	if err := controller.HTManager.CancelJob(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/job_controller:Cancel() Failed to cancel Job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to cancel Job"}
+	}
+	job, status, err = controller.retrieveJob(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.WithField("job", job.Id).Infof("Job cancelled by: %s", r.RemoteAddr)
+	return job, http.StatusOK, nil
+}
+
+func (controller JobController) retrieveJob(id uuid.UUID) (*hvs.Job, int, error) {
+	job, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Error("controllers/job_controller:retrieveJob() Job with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Job with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/job_controller:retrieveJob() Failed to retrieve Job")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Job"}
+	}
+	return job, http.StatusOK, nil
+}
+
+func getJobFilterCriteria(params url.Values) (*models.JobFilterCriteria, error) {
+	defaultLog.Trace("controllers/job_controller:getJobFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/job_controller:getJobFilterCriteria() Leaving")
+
+	criteria := models.JobFilterCriteria{}
+	if jobType := params.Get("type"); jobType != "" {
+		criteria.Type = hvs.JobType(strings.ToUpper(jobType))
+		if !jobTypes[criteria.Type] {
+			return nil, errors.New("Invalid type query param value, must be one of HOST_CREATE, HOST_DELETE, " +
+				"HOST_FLAVORGROUP_ADD, HOST_VERIFY or FLAVOR_VERIFY")
+		}
+	}
+	if state := params.Get("state"); state != "" {
+		criteria.State = hvs.JobState(strings.ToUpper(state))
+		if !jobStates[criteria.State] {
+			return nil, errors.New("Invalid state query param value, must be one of QUEUED, RUNNING, COMPLETED or CANCELLED")
+		}
+	}
+	if hostId := params.Get("hostId"); hostId != "" {
+		parsedId, err := uuid.Parse(hostId)
+		if err != nil {
+			return nil, errors.New("Invalid hostId query param value, must be UUID")
+		}
+		criteria.HostId = parsedId
+	}
+	return &criteria, nil
+}
+
+// jobIdRef returns the id of the FLAVOR_VERIFY job of a request for its response, nil when no job tracks the request
+func jobIdRef(jobId uuid.UUID) *uuid.UUID {
+	if jobId == uuid.Nil {
+		return nil
+	}
+	return &jobId
+}
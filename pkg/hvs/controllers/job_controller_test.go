@@ -0,0 +1,194 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var jobStore *mocks.MockJobStore
+	var jobController *controllers.JobController
+	var job *hvs.Job
+	var flavorVerifyJob *hvs.Job
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		jobStore = mocks.NewMockJobStore()
+		job, _ = jobStore.Create(&hvs.Job{
+			Type:  hvs.JobTypeHostDelete,
+			State: hvs.JobStateRunning,
+			Results: []hvs.JobResult{
+				{HostId: &hostId, HostName: "localhost1", State: hvs.JobResultStateSucceeded},
+			},
+		})
+		flavorVerifyJob, _ = jobStore.Create(&hvs.Job{
+			Type:  hvs.JobTypeFlavorVerify,
+			State: hvs.JobStateRunning,
+			Results: []hvs.JobResult{
+				{HostId: &hostId, State: hvs.JobResultStatePending},
+			},
+		})
+		jobController = &controllers.JobController{Store: jobStore, HTManager: &smocks.MockHostTrustManager{}}
+		router.Handle("/jobs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Search))).Methods("GET")
+		router.Handle("/jobs/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Retrieve))).Methods("GET")
+		router.Handle("/jobs/{id}/cancel", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(jobController.Cancel))).Methods("POST")
+	})
+
+	// Specs for HTTP Get to "/jobs"
+	Describe("Search Jobs", func() {
+		Context("Search Jobs by type and state", func() {
+			It("Should return the matching Jobs without their results", func() {
+				req, err := http.NewRequest("GET", "/jobs?type=flavor_verify&state=RUNNING", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var jobCollection hvs.JobCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &jobCollection)).To(Succeed())
+				Expect(jobCollection.Jobs).To(HaveLen(1))
+				Expect(jobCollection.Jobs[0].Id).To(Equal(flavorVerifyJob.Id))
+				Expect(jobCollection.Jobs[0].Results).To(BeEmpty())
+			})
+		})
+
+		Context("Search Jobs by host", func() {
+			It("Should return the Jobs of the host", func() {
+				req, err := http.NewRequest("GET", "/jobs?hostId="+hostId.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var jobCollection hvs.JobCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &jobCollection)).To(Succeed())
+				Expect(jobCollection.Jobs).To(HaveLen(2))
+			})
+		})
+
+		Context("Search Jobs with an invalid state", func() {
+			It("Should fail to search the Jobs", func() {
+				req, err := http.NewRequest("GET", "/jobs?state=DONE", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Search Jobs with an unknown query parameter", func() {
+			It("Should fail to search the Jobs", func() {
+				req, err := http.NewRequest("GET", "/jobs?flavorId="+hostId.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/jobs/{id}"
+	Describe("Retrieve a Job", func() {
+		Context("Retrieve Job by ID", func() {
+			It("Should retrieve the Job with its results", func() {
+				req, err := http.NewRequest("GET", "/jobs/"+job.Id.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var retrievedJob hvs.Job
+				Expect(json.Unmarshal(w.Body.Bytes(), &retrievedJob)).To(Succeed())
+				Expect(retrievedJob.Id).To(Equal(job.Id))
+				Expect(retrievedJob.State).To(Equal(hvs.JobStateRunning))
+				Expect(retrievedJob.Results).To(HaveLen(1))
+				Expect(*retrievedJob.Results[0].HostId).To(Equal(hostId))
+			})
+		})
+
+		Context("Retrieve Job by non-existent ID", func() {
+			It("Should fail to retrieve the Job", func() {
+				req, err := http.NewRequest("GET", "/jobs/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/jobs/{id}/cancel"
+	Describe("Cancel a Job", func() {
+		Context("Cancel a running FLAVOR_VERIFY Job", func() {
+			It("Should cancel the Job", func() {
+				req, err := http.NewRequest("POST", "/jobs/"+flavorVerifyJob.Id.String()+"/cancel", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("Cancel a batch host operation Job", func() {
+			It("Should fail to cancel the Job", func() {
+				req, err := http.NewRequest("POST", "/jobs/"+job.Id.String()+"/cancel", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Cancel a completed FLAVOR_VERIFY Job", func() {
+			It("Should fail to cancel the Job", func() {
+				flavorVerifyJob.State = hvs.JobStateCompleted
+				Expect(jobStore.Update(flavorVerifyJob)).To(Succeed())
+				req, err := http.NewRequest("POST", "/jobs/"+flavorVerifyJob.Id.String()+"/cancel", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Cancel a non-existent Job", func() {
+			It("Should fail to cancel the Job", func() {
+				req, err := http.NewRequest("POST", "/jobs/73755fda-c910-46be-821f-e8ddeab189e9/cancel", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
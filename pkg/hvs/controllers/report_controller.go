@@ -25,15 +25,19 @@ import (
 	"strings"
 )
 
+var reportDiffParams = map[string]bool{"against": true}
+
 type ReportController struct {
 	ReportStore     domain.ReportStore
 	HostStore       domain.HostStore
 	HostStatusStore domain.HostStatusStore
 	HTManager       domain.HostTrustManager
+	// TokenReportGenerator signs the reports created with the application/jwt Accept header
+	TokenReportGenerator domain.TokenReportGenerator
 }
 
 func NewReportController(rs domain.ReportStore, hs domain.HostStore, hsts domain.HostStatusStore, ht domain.HostTrustManager) *ReportController {
-	return &ReportController{rs, hs, hsts, ht}
+	return &ReportController{ReportStore: rs, HostStore: hs, HostStatusStore: hsts, HTManager: ht}
 }
 
 func (controller ReportController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -157,6 +161,62 @@ func (controller ReportController) CreateSaml(w http.ResponseWriter, r *http.Req
 	return hvsReport.Saml, http.StatusCreated, nil
 }
 
+func (controller ReportController) CreateJwt(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:CreateJwt() Entering")
+	defer defaultLog.Trace("controllers/report_controller:CreateJwt() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+	if r.Header.Get("Accept") != constants.HTTPMediaTypeJwt {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{
+			Message: "Invalid Accept type",
+		}
+	}
+	if controller.TokenReportGenerator == nil {
+		defaultLog.Error("controllers/report_controller:CreateJwt() The attestation token signing key is not configured")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Attestation tokens are not supported"}
+	}
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/report_controller:CreateJwt() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqReportCreateRequest hvs.ReportCreateRequest
+	// Decode the incoming json data to note struct
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&reqReportCreateRequest)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/report_controller:CreateJwt() %s :  Failed to decode request body as Report Create Criteria", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateReportCreateCriteria(reqReportCreateRequest); err != nil {
+		secLog.WithError(err).Errorf("controllers/report_controller:CreateJwt() %s : Error validating report create criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Bad input given in input request"}
+	}
+
+	hvsReport, err := controller.createReport(reqReportCreateRequest)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() Error while creating report")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if hvsReport == nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() The report was not created")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating report"}
+	}
+	token, err := controller.TokenReportGenerator.GenerateTokenReport(hvsReport)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:CreateJwt() Error while signing the attestation token")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while creating attestation token"}
+	}
+	secLog.WithField("Host Name", hvsReport.TrustReport.HostManifest.HostInfo.HostName).Infof("%s: attestation token created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	w.Header().Set("Content-Type", constants.HTTPMediaTypeJwt)
+	return token, http.StatusCreated, nil
+}
+
 func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/report_controller:Retrieve() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Retrieve() Leaving")
@@ -181,6 +241,79 @@ func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Reque
 	return report, http.StatusOK, nil
 }
 
+// Diff compares the report with the given ID against an older report of the same host
+func (controller ReportController) Diff(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:Diff() Entering")
+	defer defaultLog.Trace("controllers/report_controller:Diff() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportDiffParams); err != nil {
+		secLog.Errorf("controllers/report_controller:Diff() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	againstId, err := uuid.Parse(strings.TrimSpace(r.URL.Query().Get("against")))
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_controller:Diff() %s : Invalid against report ID", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Valid report ID must be specified for against"}
+	}
+
+	hvsReport, status, err := controller.retrieveReport(id)
+	if err != nil {
+		return nil, status, err
+	}
+	againstReport, status, err := controller.retrieveReport(againstId)
+	if err != nil {
+		return nil, status, err
+	}
+	if hvsReport.HostID != againstReport.HostID {
+		secLog.Warnf("controllers/report_controller:Diff() %s : Reports %s and %s belong to different hosts", commLogMsg.InvalidInputBadParam, id, againstId)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Reports must belong to the same host"}
+	}
+
+	diff, err := hvs.DiffTrustReports(&againstReport.TrustReport, &hvsReport.TrustReport)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/report_controller:Diff() Error while comparing reports")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while comparing reports"}
+	}
+	diff.ReportId = hvsReport.ID
+	diff.AgainstReportId = againstReport.ID
+	diff.HostId = hvsReport.HostID
+
+	secLog.Infof("%s: Report %s compared against %s by: %s", commLogMsg.AuthorizedAccess, id, againstId, r.RemoteAddr)
+	return diff, http.StatusOK, nil
+}
+
+// retrieveReport looks up a report by ID. Reports that were replaced by a newer report of the host are
+// retrieved from the audit log
+func (controller ReportController) retrieveReport(id uuid.UUID) (*models.HVSReport, int, error) {
+	defaultLog.Trace("controllers/report_controller:retrieveReport() Entering")
+	defer defaultLog.Trace("controllers/report_controller:retrieveReport() Leaving")
+
+	hvsReport, err := controller.ReportStore.Retrieve(id)
+	if err == nil {
+		return hvsReport, http.StatusOK, nil
+	}
+	if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		secLog.WithError(err).WithField("id", id).Info(
+			"controllers/report_controller:retrieveReport() failed to retrieve Report")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
+	}
+
+	hvsReports, err := controller.ReportStore.Search(&models.ReportFilterCriteria{ID: id, LatestPerHost: false})
+	if err != nil {
+		secLog.WithError(err).WithField("id", id).Info(
+			"controllers/report_controller:retrieveReport() failed to search Report history")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
+	}
+	if len(hvsReports) == 0 {
+		secLog.WithField("id", id).Info(
+			"controllers/report_controller:retrieveReport() Report with given ID does not exist")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Report with given ID does not exist"}
+	}
+	return &hvsReports[0], http.StatusOK, nil
+}
+
 func (controller ReportController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/report_controller:Search() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Search() Leaving")
@@ -307,6 +440,16 @@ func getReportFilterCriteria(params url.Values) (*models.ReportFilterCriteria, e
 		rfc.HostStatus = hostState
 	}
 
+	// Host Labels
+	labelSelector := strings.TrimSpace(params.Get("labelSelector"))
+	if labelSelector != "" {
+		ls, err := hvs.ParseLabelSelector(labelSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "Valid contents for LabelSelector must be specified")
+		}
+		rfc.LabelSelector = ls
+	}
+
 	// fromDate
 	fromDate := strings.TrimSpace(params.Get("fromDate"))
 	if fromDate != "" {
@@ -403,11 +546,13 @@ func buildTrustInformation(trustReport hvs.TrustReport) *hvs.TrustInformation {
 		if len(tr.GetResultsForMarker(flavorPart.String())) > 0 {
 			flavorsTrustStatus[flavorPart] = hvs.FlavorTrustStatus{
 				Trust:                tr.IsTrustedForMarker(flavorPart.String()),
+				Warning:              tr.HasWarningsForMarker(flavorPart.String()),
 				RuleResultCollection: tr.GetResultsForMarker(flavorPart.String()),
 			}
 		}
 	}
-	return &hvs.TrustInformation{Overall: tr.IsTrusted(), FlavorTrust: flavorsTrustStatus}
+	return &hvs.TrustInformation{Overall: tr.IsTrusted(), Warning: tr.HasWarnings(), NewBootSession: trustReport.NewBootSession,
+		FlavorTrust: flavorsTrustStatus}
 }
 
 func getHostFilterCriteria(rsCriteria hvs.ReportCreateRequest) models.HostFilterCriteria {
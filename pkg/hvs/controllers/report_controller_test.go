@@ -7,9 +7,11 @@ package controllers_test
 import (
 	"encoding/json"
 	"encoding/xml"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
@@ -174,6 +176,88 @@ var _ = Describe("ReportController", func() {
 		})
 	})
 
+	// Specs for HTTP Get to "/reports/{id}/diff"
+	Describe("Compare two Reports", func() {
+		Context("Compare a Report against an older Report of the same host", func() {
+			It("Should return the changes between the Reports", func() {
+				olderReport, _ := reportStore.Create(&models.HVSReport{
+					ID:     uuid.MustParse("a3d3ab6d-1e93-4a68-9a1a-1d2c3a2c6f0b"),
+					HostID: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+				})
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against="+olderReport.ID.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var diff hvs.TrustReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &diff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff.ReportId).To(Equal(uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb3")))
+				Expect(diff.AgainstReportId).To(Equal(olderReport.ID))
+				Expect(diff.RuleResults).NotTo(BeEmpty())
+			})
+		})
+
+		Context("Compare a Report against itself", func() {
+			It("Should not return any changes", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var diff hvs.TrustReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &diff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff.RuleResults).To(BeEmpty())
+				Expect(diff.Pcrs).To(BeEmpty())
+				Expect(diff.EventLogs).To(BeEmpty())
+				Expect(diff.HostInfo).To(BeEmpty())
+			})
+		})
+
+		Context("Compare Reports of different hosts", func() {
+			It("Should return bad request", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=15701f03-7b1d-49f9-ac62-6b9b0728bdb4", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Compare a Report without specifying the Report to compare against", func() {
+			It("Should return bad request", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Compare a Report against a non-existent Report", func() {
+			It("Should return not found", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods("GET")
+				req, err := http.NewRequest("GET", "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/reports"
 	Describe("Search for all the Reports", func() {
 		Context("Get all the Reports", func() {
@@ -321,6 +405,54 @@ var _ = Describe("ReportController", func() {
 		})
 	})
 
+	// Specs for HTTP Post to "/reports" for accept:application/jwt
+	Describe("Create a new attestation token", func() {
+		Context("Provide a valid Create request", func() {
+			It("Should create a new attestation token", func() {
+				reportController.TokenReportGenerator = &smocks.MockTokenReportGenerator{}
+				router.Handle("/reports", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.CreateJwt))).Methods("POST")
+				body := `{
+							"host_name": "localhost1"
+						}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/reports",
+					strings.NewReader(body),
+				)
+				req.Header.Set("Accept", constants.HTTPMediaTypeJwt)
+				req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeJwt))
+				Expect(strings.Count(w.Body.String(), ".")).To(Equal(2))
+			})
+		})
+
+		Context("Provide a Create request when the attestation tokens are not configured", func() {
+			It("Should fail to create the attestation token", func() {
+				router.Handle("/reports", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.CreateJwt))).Methods("POST")
+				body := `{
+							"host_name": "localhost1"
+						}`
+
+				req, err := http.NewRequest(
+					"POST",
+					"/reports",
+					strings.NewReader(body),
+				)
+				req.Header.Set("Accept", constants.HTTPMediaTypeJwt)
+				req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/reports" for accept:samlassertion+xml
 	Describe("Search for all Saml Reports", func() {
 		Context("Get all the Reports", func() {
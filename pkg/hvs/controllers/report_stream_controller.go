@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/pkg/errors"
+)
+
+const reportStreamEvent = "report"
+
+var reportStreamParams = map[string]bool{"hostId": true, "flavorgroupId": true, "trusted": true}
+
+// ReportStreamController streams the reports created by the host trust manager as Server-Sent Events
+type ReportStreamController struct {
+	Broadcaster domain.ReportBroadcaster
+	HostStore   domain.HostStore
+	// KeepAliveInterval is the interval at which comments are sent to keep idle connections open
+	KeepAliveInterval time.Duration
+	// StreamTimeout closes the stream before the server write timeout does, zero streams until the client disconnects
+	StreamTimeout time.Duration
+}
+
+func NewReportStreamController(rb domain.ReportBroadcaster, hs domain.HostStore, keepAlive, timeout time.Duration) *ReportStreamController {
+	return &ReportStreamController{rb, hs, keepAlive, timeout}
+}
+
+func (controller ReportStreamController) Stream(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_stream_controller:Stream() Entering")
+	defer defaultLog.Trace("controllers/report_stream_controller:Stream() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportStreamParams); err != nil {
+		secLog.Errorf("controllers/report_stream_controller:Stream() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria, err := getReportStreamFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Warnf("controllers/report_stream_controller:Stream() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Input given in request"}
+	}
+
+	// the client reconnecting gets the reports published since the last report it received
+	var lastReportId uuid.UUID
+	if lastEventId := strings.TrimSpace(r.Header.Get("Last-Event-ID")); lastEventId != "" {
+		if lastReportId, err = uuid.Parse(lastEventId); err != nil {
+			secLog.WithError(err).Warnf("controllers/report_stream_controller:Stream() %s Invalid Last-Event-ID", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid Last-Event-ID given in request"}
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		defaultLog.Error("controllers/report_stream_controller:Stream() Response writer does not support flushing")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Streaming is not supported"}
+	}
+
+	reports, unsubscribe := controller.Broadcaster.SubscribeAfter(lastReportId)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", constants.HTTPMediaTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", consts.ReportStreamRetryMillis)
+	flusher.Flush()
+	secLog.Infof("%s: Report stream opened by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+
+	keepAlive := time.NewTicker(controller.KeepAliveInterval)
+	defer keepAlive.Stop()
+
+	var timeout <-chan time.Time
+	if controller.StreamTimeout > 0 {
+		timer := time.NewTimer(controller.StreamTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			defaultLog.Debugf("controllers/report_stream_controller:Stream() Report stream closed by: %s", r.RemoteAddr)
+			return nil, http.StatusOK, nil
+
+		case <-timeout:
+			return nil, http.StatusOK, nil
+
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+
+		case hvsReport, ok := <-reports:
+			if !ok {
+				return nil, http.StatusOK, nil
+			}
+			if !controller.matchesCriteria(criteria, hvsReport) {
+				continue
+			}
+			data, err := json.Marshal(ConvertToReport(hvsReport))
+			if err != nil {
+				defaultLog.WithError(err).Errorf("controllers/report_stream_controller:Stream() Failed to marshal report %s", hvsReport.ID)
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", hvsReport.ID, reportStreamEvent, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// matchesCriteria checks if the report satisfies the stream filter. The flavorgroups of the host are looked up
+// for every report so that changes to the host flavorgroup associations are picked up by open streams
+func (controller ReportStreamController) matchesCriteria(criteria *models.ReportStreamFilterCriteria, hvsReport *models.HVSReport) bool {
+	if criteria.HostID != uuid.Nil && criteria.HostID != hvsReport.HostID {
+		return false
+	}
+	if criteria.Trusted != nil && *criteria.Trusted != hvsReport.TrustReport.IsTrusted() {
+		return false
+	}
+	if criteria.FlavorgroupID != uuid.Nil {
+		fgIds, err := controller.HostStore.SearchFlavorgroups(hvsReport.HostID)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("controllers/report_stream_controller:matchesCriteria() Failed to retrieve flavorgroups of host %s", hvsReport.HostID)
+			return false
+		}
+		for _, fgId := range fgIds {
+			if fgId == criteria.FlavorgroupID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// getReportStreamFilterCriteria checks for set filter params in the Stream request and returns a valid ReportStreamFilterCriteria
+func getReportStreamFilterCriteria(params url.Values) (*models.ReportStreamFilterCriteria, error) {
+	defaultLog.Trace("controllers/report_stream_controller:getReportStreamFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/report_stream_controller:getReportStreamFilterCriteria() Leaving")
+
+	criteria := models.ReportStreamFilterCriteria{}
+
+	if hostId := strings.TrimSpace(params.Get("hostId")); hostId != "" {
+		id, err := uuid.Parse(hostId)
+		if err != nil {
+			return nil, errors.New("Invalid UUID format of the Host Identifier specified")
+		}
+		criteria.HostID = id
+	}
+
+	if flavorgroupId := strings.TrimSpace(params.Get("flavorgroupId")); flavorgroupId != "" {
+		id, err := uuid.Parse(flavorgroupId)
+		if err != nil {
+			return nil, errors.New("Invalid UUID format of the Flavorgroup Identifier specified")
+		}
+		criteria.FlavorgroupID = id
+	}
+
+	if trusted := strings.TrimSpace(strings.ToLower(params.Get("trusted"))); trusted != "" {
+		t, err := strconv.ParseBool(trusted)
+		if err != nil {
+			return nil, errors.Wrap(err, "trusted must be true or false")
+		}
+		criteria.Trusted = &t
+	}
+
+	return &criteria, nil
+}
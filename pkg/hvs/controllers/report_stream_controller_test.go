@@ -0,0 +1,178 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// publishOnSubscribe publishes the given reports as soon as the stream subscribes, so that the specs do
+// not depend on timing
+type publishOnSubscribe struct {
+	*hosttrust.ReportBroadcaster
+	reports []*models.HVSReport
+}
+
+func (p publishOnSubscribe) SubscribeAfter(lastReportId uuid.UUID) (<-chan *models.HVSReport, func()) {
+	reports, unsubscribe := p.ReportBroadcaster.SubscribeAfter(lastReportId)
+	for _, report := range p.reports {
+		p.Publish(report)
+	}
+	return reports, unsubscribe
+}
+
+var _ = Describe("ReportStreamController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostStore *mocks.MockHostStore
+	var reportStreamController *controllers.ReportStreamController
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	otherHostId := uuid.MustParse("e1a1c631-e006-40df-b7a7-2b7c5a5e5c2e")
+	flavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e3")
+
+	trustedReport := &models.HVSReport{
+		ID:          uuid.MustParse("15701f03-7b1d-461c-8295-0ad6a2ef9a0c"),
+		HostID:      hostId,
+		TrustReport: hvs.TrustReport{Results: []hvs.RuleResult{{}}},
+	}
+	untrustedReport := &models.HVSReport{
+		ID:     uuid.MustParse("fc0cc779-22b6-4741-b0d9-e2e69635ad1e"),
+		HostID: hostId,
+	}
+	otherHostReport := &models.HVSReport{
+		ID:          uuid.MustParse("4b9b6b2d-8b2b-4a6a-9cd5-7ce0e4b5e0a5"),
+		HostID:      otherHostId,
+		TrustReport: hvs.TrustReport{Results: []hvs.RuleResult{{}}},
+	}
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostStore = mocks.NewMockHostStore()
+		hostStore.AddFlavorgroups(hostId, []uuid.UUID{flavorgroupId})
+		broadcaster := publishOnSubscribe{
+			ReportBroadcaster: hosttrust.NewReportBroadcaster(10, 10),
+			reports:           []*models.HVSReport{trustedReport, untrustedReport, otherHostReport},
+		}
+		reportStreamController = controllers.NewReportStreamController(broadcaster, hostStore, time.Minute, 100*time.Millisecond)
+		router.Handle("/reports/stream", hvsRoutes.ErrorHandler(hvsRoutes.EventStreamResponseHandler(reportStreamController.Stream))).Methods("GET")
+	})
+
+	// Specs for HTTP Get to "/reports/stream"
+	Describe("Stream Reports", func() {
+		Context("Stream all reports", func() {
+			It("Should stream every published report", func() {
+				req, err := http.NewRequest("GET", "/reports/stream", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeEventStream))
+				Expect(w.Body.String()).To(ContainSubstring("event: report"))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + trustedReport.ID.String()))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + untrustedReport.ID.String()))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + otherHostReport.ID.String()))
+			})
+		})
+
+		Context("Stream reports filtered by host id and trust state", func() {
+			It("Should stream only the matching reports", func() {
+				req, err := http.NewRequest("GET", "/reports/stream?hostId="+hostId.String()+"&trusted=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + trustedReport.ID.String()))
+				Expect(w.Body.String()).NotTo(ContainSubstring("id: " + untrustedReport.ID.String()))
+				Expect(w.Body.String()).NotTo(ContainSubstring("id: " + otherHostReport.ID.String()))
+			})
+		})
+
+		Context("Stream reports filtered by flavorgroup id", func() {
+			It("Should stream only the reports of hosts in the flavorgroup", func() {
+				req, err := http.NewRequest("GET", "/reports/stream?flavorgroupId="+flavorgroupId.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + trustedReport.ID.String()))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + untrustedReport.ID.String()))
+				Expect(w.Body.String()).NotTo(ContainSubstring("id: " + otherHostReport.ID.String()))
+			})
+		})
+
+		Context("Reconnect to the stream with the Last-Event-ID header", func() {
+			It("Should replay the reports published after the last received report", func() {
+				broadcaster := hosttrust.NewReportBroadcaster(10, 10)
+				broadcaster.Publish(trustedReport)
+				broadcaster.Publish(untrustedReport)
+				broadcaster.Publish(otherHostReport)
+				reportStreamController = controllers.NewReportStreamController(broadcaster, hostStore, time.Minute, 100*time.Millisecond)
+				router.Handle("/reports/replay", hvsRoutes.ErrorHandler(hvsRoutes.EventStreamResponseHandler(reportStreamController.Stream))).Methods("GET")
+
+				req, err := http.NewRequest("GET", "/reports/replay", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				req.Header.Set("Last-Event-ID", trustedReport.ID.String())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).NotTo(ContainSubstring("id: " + trustedReport.ID.String()))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + untrustedReport.ID.String()))
+				Expect(w.Body.String()).To(ContainSubstring("id: " + otherHostReport.ID.String()))
+			})
+		})
+
+		Context("Stream reports with invalid filter", func() {
+			It("Should return bad request", func() {
+				req, err := http.NewRequest("GET", "/reports/stream?trusted=maybe", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Stream reports with unknown query parameter", func() {
+			It("Should return bad request", func() {
+				req, err := http.NewRequest("GET", "/reports/stream?hostName=localhost1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeEventStream)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Stream reports with invalid Accept header", func() {
+			It("Should return unsupported media type", func() {
+				req, err := http.NewRequest("GET", "/reports/stream", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+})
@@ -0,0 +1,283 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type ScheduleController struct {
+	Store            domain.ScheduleStore
+	HostStore        domain.HostStore
+	FlavorGroupStore domain.FlavorGroupStore
+}
+
+var scheduleSearchParams = map[string]bool{"id": true, "hostId": true, "flavorgroupId": true}
+
+func (controller ScheduleController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/schedule_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:Create() Leaving")
+
+	reqSchedule, err := getSchedule(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/schedule_controller:Create() %s : Failed to decode request body as Schedule", commLogMsg.InvalidInputBadEncoding)
+		if strings.Contains(err.Error(), "Invalid Content-Type") {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: err.Error()}
+		}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	if status, err := controller.validateScheduleTarget(reqSchedule, uuid.Nil); err != nil {
+		return nil, status, err
+	}
+
+	newSchedule, err := controller.Store.Create(reqSchedule)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/schedule_controller:Create() Schedule create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error creating Schedule"}
+	}
+	secLog.WithField("Id", newSchedule.Id).Infof("%s: Schedule created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return newSchedule, http.StatusCreated, nil
+}
+
+func (controller ScheduleController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/schedule_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:Update() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	reqSchedule, err := getSchedule(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/schedule_controller:Update() %s : Failed to decode request body as Schedule", commLogMsg.InvalidInputBadEncoding)
+		if strings.Contains(err.Error(), "Invalid Content-Type") {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: err.Error()}
+		}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+	if status, err := controller.validateScheduleTarget(reqSchedule, id); err != nil {
+		return nil, status, err
+	}
+	schedule.HostId = reqSchedule.HostId
+	schedule.FlavorgroupId = reqSchedule.FlavorgroupId
+	schedule.Interval = reqSchedule.Interval
+	schedule.Jitter = reqSchedule.Jitter
+	schedule.MaintenanceWindows = reqSchedule.MaintenanceWindows
+
+	updatedSchedule, err := controller.Store.Update(schedule)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/schedule_controller:Update() Schedule update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error updating Schedule"}
+	}
+	secLog.WithField("Id", updatedSchedule.Id).Infof("%s: Schedule updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return updatedSchedule, http.StatusOK, nil
+}
+
+func (controller ScheduleController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/schedule_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+	return schedule, http.StatusOK, nil
+}
+
+func (controller ScheduleController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/schedule_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), scheduleSearchParams); err != nil {
+		secLog.Errorf("controllers/schedule_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria, err := getScheduleFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/schedule_controller:Search() %s Invalid filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	schedules, err := controller.Store.Search(criteria)
+	if err != nil {
+		secLog.WithError(err).Error("controllers/schedule_controller:Search() Schedule search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Schedules"}
+	}
+
+	secLog.Infof("%s: Return schedule query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.ScheduleCollection{Schedules: schedules}, http.StatusOK, nil
+}
+
+func (controller ScheduleController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/schedule_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := controller.retrieveSchedule(id); err != nil {
+		return nil, status, err
+	}
+
+	if err := controller.Store.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/schedule_controller:Delete() Failed to delete Schedule")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete Schedule"}
+	}
+	secLog.WithField("Id", id).Infof("Schedule deleted by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+func (controller ScheduleController) retrieveSchedule(id uuid.UUID) (*hvs.Schedule, int, error) {
+	schedule, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Error("controllers/schedule_controller:retrieveSchedule() Schedule with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Schedule with given ID does not exist"}
+		}
+		secLog.WithError(err).WithField("id", id).Error("controllers/schedule_controller:retrieveSchedule() Failed to retrieve Schedule")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Schedule"}
+	}
+	return schedule, http.StatusOK, nil
+}
+
+// validateScheduleTarget checks that the host or flavorgroup of the schedule exists and does not have another
+// schedule, a host or a flavorgroup can only have one schedule
+func (controller ScheduleController) validateScheduleTarget(schedule *hvs.Schedule, scheduleId uuid.UUID) (int, error) {
+	defaultLog.Trace("controllers/schedule_controller:validateScheduleTarget() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:validateScheduleTarget() Leaving")
+
+	criteria := models.ScheduleFilterCriteria{}
+	if schedule.HostId != nil {
+		if _, err := controller.HostStore.Retrieve(*schedule.HostId); err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", *schedule.HostId).Error("controllers/schedule_controller:validateScheduleTarget() Host with given ID does not exist")
+				return http.StatusBadRequest, &commErr.ResourceError{Message: "Host with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", *schedule.HostId).Error("controllers/schedule_controller:validateScheduleTarget() Failed to retrieve Host")
+			return http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host"}
+		}
+		criteria.HostId = *schedule.HostId
+	} else {
+		if _, err := controller.FlavorGroupStore.Retrieve(*schedule.FlavorgroupId); err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", *schedule.FlavorgroupId).Error("controllers/schedule_controller:validateScheduleTarget() FlavorGroup with given ID does not exist")
+				return http.StatusBadRequest, &commErr.ResourceError{Message: "FlavorGroup with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", *schedule.FlavorgroupId).Error("controllers/schedule_controller:validateScheduleTarget() Failed to retrieve FlavorGroup")
+			return http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve FlavorGroup"}
+		}
+		criteria.FlavorgroupId = *schedule.FlavorgroupId
+	}
+
+	existingSchedules, err := controller.Store.Search(&criteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/schedule_controller:validateScheduleTarget() Schedule search operation failed")
+		return http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Schedules"}
+	}
+	for _, existingSchedule := range existingSchedules {
+		if existingSchedule.Id != scheduleId {
+			secLog.WithField("id", existingSchedule.Id).Errorf("controllers/schedule_controller:validateScheduleTarget() %s : Schedule already exists", commLogMsg.InvalidInputBadParam)
+			return http.StatusBadRequest, &commErr.ResourceError{Message: "A Schedule already exists for the given host or flavorgroup"}
+		}
+	}
+	return http.StatusOK, nil
+}
+
+func getSchedule(r *http.Request) (*hvs.Schedule, error) {
+	defaultLog.Trace("controllers/schedule_controller:getSchedule() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:getSchedule() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, errors.New("Invalid Content-Type")
+	}
+	if r.ContentLength == 0 {
+		return nil, errors.New("The request body is not provided")
+	}
+
+	var schedule hvs.Schedule
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&schedule); err != nil {
+		return nil, errors.New("Unable to decode JSON request body")
+	}
+	if schedule.Id != uuid.Nil || !schedule.CreatedAt.IsZero() {
+		return nil, errors.New("Schedule id and created time cannot be specified")
+	}
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func validateSchedule(schedule hvs.Schedule) error {
+	defaultLog.Trace("controllers/schedule_controller:validateSchedule() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:validateSchedule() Leaving")
+
+	if (schedule.HostId == nil) == (schedule.FlavorgroupId == nil) {
+		return errors.New("Either host_id or flavorgroup_id must be specified")
+	}
+	if schedule.Interval == "" {
+		return errors.New("Schedule interval must be specified")
+	}
+	if _, err := schedule.GetInterval(); err != nil {
+		return errors.New("Schedule interval must be a positive duration such as 2m or 1h")
+	}
+	if _, err := schedule.GetJitter(); err != nil {
+		return errors.New("Schedule jitter must be a duration such as 30s")
+	}
+	for _, window := range schedule.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getScheduleFilterCriteria(params url.Values) (*models.ScheduleFilterCriteria, error) {
+	defaultLog.Trace("controllers/schedule_controller:getScheduleFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/schedule_controller:getScheduleFilterCriteria() Leaving")
+
+	criteria := models.ScheduleFilterCriteria{}
+	if id := params.Get("id"); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("Invalid id query param value, must be UUID")
+		}
+		criteria.Id = parsedId
+	}
+	if hostId := params.Get("hostId"); hostId != "" {
+		parsedId, err := uuid.Parse(hostId)
+		if err != nil {
+			return nil, errors.New("Invalid hostId query param value, must be UUID")
+		}
+		criteria.HostId = parsedId
+	}
+	if flavorgroupId := params.Get("flavorgroupId"); flavorgroupId != "" {
+		parsedId, err := uuid.Parse(flavorgroupId)
+		if err != nil {
+			return nil, errors.New("Invalid flavorgroupId query param value, must be UUID")
+		}
+		criteria.FlavorgroupId = parsedId
+	}
+	return &criteria, nil
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScheduleController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var scheduleStore *mocks.MockScheduleStore
+	var scheduleController *controllers.ScheduleController
+	existingScheduleId := "7a3ee5b0-2bd6-4c9c-9d62-6b3c43f4a1d0"
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		scheduleStore = mocks.NewMockScheduleStore()
+		scheduleController = &controllers.ScheduleController{
+			Store:            scheduleStore,
+			HostStore:        mocks.NewMockHostStore(),
+			FlavorGroupStore: mocks.NewFakeFlavorgroupStore(),
+		}
+	})
+
+	// Specs for HTTP Post to "/schedules"
+	Describe("Create Schedule", func() {
+		Context("Provide a valid Schedule for a host", func() {
+			It("Should create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "interval": "2m", "jitter": "10s",
+					"maintenance_windows": [{"start": "02:00", "duration": "1h", "weekdays": ["Sunday"]}]}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var schedule hvs.Schedule
+				err = json.Unmarshal(w.Body.Bytes(), &schedule)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schedule.Id).NotTo(Equal(uuid.Nil))
+				Expect(len(schedule.MaintenanceWindows)).To(Equal(1))
+			})
+		})
+		Context("Provide a Schedule for both a host and a flavorgroup", func() {
+			It("Should fail to create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "flavorgroup_id": "e57e5ea0-d465-461e-882d-1600090caa0d", "interval": "2m"}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a Schedule with an invalid interval", func() {
+			It("Should fail to create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "interval": "every minute"}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a Schedule with an invalid maintenance window", func() {
+			It("Should fail to create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "interval": "2m",
+					"maintenance_windows": [{"start": "2am", "duration": "1h"}]}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a second Schedule for a flavorgroup", func() {
+			It("Should fail to create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "interval": "2m"}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a Schedule for a non-existent flavorgroup", func() {
+			It("Should fail to create the Schedule", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Create))).Methods("POST")
+				body := `{"flavorgroup_id": "73755fda-c910-46be-821f-e8ddeab189e9", "interval": "2m"}`
+				req, err := http.NewRequest("POST", "/schedules", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/schedules"
+	Describe("Search Schedules", func() {
+		Context("Search schedules by flavorgroup", func() {
+			It("Should return the schedule of the flavorgroup", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/schedules?flavorgroupId=ee37c360-7eae-4250-a677-6ee12adce8e2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var scheduleCollection hvs.ScheduleCollection
+				err = json.Unmarshal(w.Body.Bytes(), &scheduleCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(scheduleCollection.Schedules)).To(Equal(1))
+			})
+		})
+		Context("Search schedules with an invalid hostId", func() {
+			It("Should fail with bad request", func() {
+				router.Handle("/schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/schedules?hostId=abc", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/schedules/{id}"
+	Describe("Retrieve Schedule", func() {
+		Context("Retrieve a non-existent schedule", func() {
+			It("Should fail with not found", func() {
+				router.Handle("/schedules/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/schedules/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Put to "/schedules/{id}"
+	Describe("Update Schedule", func() {
+		Context("Update the interval of an existing schedule", func() {
+			It("Should update the schedule", func() {
+				router.Handle("/schedules/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(scheduleController.Update))).Methods("PUT")
+				body := `{"flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2", "interval": "30m"}`
+				req, err := http.NewRequest("PUT", "/schedules/"+existingScheduleId, strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				schedule, err := scheduleStore.Retrieve(uuid.MustParse(existingScheduleId))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schedule.Interval).To(Equal("30m"))
+				Expect(schedule.MaintenanceWindows).To(BeEmpty())
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/schedules/{id}"
+	Describe("Delete Schedule", func() {
+		Context("Delete an existing schedule", func() {
+			It("Should delete the schedule", func() {
+				router.Handle("/schedules/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(scheduleController.Delete))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/schedules/"+existingScheduleId, nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+})
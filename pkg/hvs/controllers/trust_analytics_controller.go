@@ -0,0 +1,200 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// TrustAnalyticsController computes the trust timelines of hosts and the trust analytics of flavorgroups from the
+// report history kept in the audit log
+type TrustAnalyticsController struct {
+	ReportStore      domain.ReportStore
+	HostStore        domain.HostStore
+	FlavorGroupStore domain.FlavorGroupStore
+}
+
+var trustTimelineParams = map[string]bool{"fromDate": true, "toDate": true, "numberOfDays": true}
+var trustAnalyticsParams = map[string]bool{"fromDate": true, "toDate": true, "numberOfDays": true, "flavorgroupId": true}
+
+// Timeline returns the intervals during which the host was trusted or untrusted, overall and for each marker
+func (controller TrustAnalyticsController) Timeline(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/trust_analytics_controller:Timeline() Entering")
+	defer defaultLog.Trace("controllers/trust_analytics_controller:Timeline() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), trustTimelineParams); err != nil {
+		secLog.Errorf("controllers/trust_analytics_controller:Timeline() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	fromDate, toDate, err := getTrustHistoryPeriod(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/trust_analytics_controller:Timeline() %s Invalid period", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["hId"])
+	if _, err := controller.HostStore.Retrieve(id); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Error("controllers/trust_analytics_controller:Timeline() Host with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/trust_analytics_controller:Timeline() Failed to retrieve Host")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Host"}
+	}
+
+	timeline, err := controller.hostTrustTimeline(id, fromDate, toDate)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/trust_analytics_controller:Timeline() Failed to retrieve the report history of the Host")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve the trust timeline of the Host"}
+	}
+
+	secLog.WithField("host", id).Infof("%s: Trust timeline retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return timeline, http.StatusOK, nil
+}
+
+// Analytics aggregates the trust history of the hosts of each flavorgroup, or of the flavorgroup given by
+// flavorgroupId
+func (controller TrustAnalyticsController) Analytics(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/trust_analytics_controller:Analytics() Entering")
+	defer defaultLog.Trace("controllers/trust_analytics_controller:Analytics() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), trustAnalyticsParams); err != nil {
+		secLog.Errorf("controllers/trust_analytics_controller:Analytics() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	fromDate, toDate, err := getTrustHistoryPeriod(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/trust_analytics_controller:Analytics() %s Invalid period", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	var flavorgroups []hvs.FlavorGroup
+	if fgId := strings.TrimSpace(r.URL.Query().Get("flavorgroupId")); fgId != "" {
+		id, err := uuid.Parse(fgId)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/trust_analytics_controller:Analytics() %s Invalid flavorgroupId", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid UUID format of the FlavorGroup Identifier specified"}
+		}
+		flavorgroup, err := controller.FlavorGroupStore.Retrieve(id)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				secLog.WithError(err).WithField("id", id).Error("controllers/trust_analytics_controller:Analytics() FlavorGroup with given ID does not exist")
+				return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "FlavorGroup with given ID does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", id).Error("controllers/trust_analytics_controller:Analytics() Failed to retrieve FlavorGroup")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve FlavorGroup"}
+		}
+		flavorgroups = append(flavorgroups, *flavorgroup)
+	} else {
+		flavorgroups, err = controller.FlavorGroupStore.Search(nil)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/trust_analytics_controller:Analytics() FlavorGroup search operation failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search FlavorGroups"}
+		}
+	}
+	sort.Slice(flavorgroups, func(i, j int) bool {
+		return flavorgroups[i].Name < flavorgroups[j].Name
+	})
+
+	// the trust history is aggregated by the report store so that the reports of the hosts are not loaded
+	analytics := hvs.TrustAnalytics{From: fromDate, To: toDate, Flavorgroups: []hvs.FlavorgroupTrustAnalytics{}}
+	for i := range flavorgroups {
+		hostIds, err := controller.FlavorGroupStore.SearchHostsByFlavorGroup(flavorgroups[i].ID)
+		if err != nil {
+			defaultLog.WithError(err).WithField("id", flavorgroups[i].ID).Error("controllers/trust_analytics_controller:Analytics() Failed to retrieve the Hosts of the FlavorGroup")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to compute trust analytics"}
+		}
+
+		fgAnalytics, err := controller.ReportStore.SearchTrustAnalytics(hostIds, fromDate, toDate, constants.TrustAnalyticsTopFailures)
+		if err != nil {
+			defaultLog.WithError(err).WithField("id", flavorgroups[i].ID).Error("controllers/trust_analytics_controller:Analytics() Failed to aggregate the report history of the Hosts of the FlavorGroup")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to compute trust analytics"}
+		}
+		fgAnalytics.FlavorgroupId = flavorgroups[i].ID
+		fgAnalytics.FlavorgroupName = flavorgroups[i].Name
+		analytics.Flavorgroups = append(analytics.Flavorgroups, *fgAnalytics)
+	}
+
+	secLog.Infof("%s: Trust analytics retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return analytics, http.StatusOK, nil
+}
+
+func (controller TrustAnalyticsController) hostTrustTimeline(hostId uuid.UUID, fromDate, toDate time.Time) (*hvs.TrustTimeline, error) {
+	reports, err := controller.ReportStore.SearchTrustHistory(hostId, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	timeline := hvs.NewTrustTimeline(hostId, fromDate, toDate)
+	for i := range reports {
+		timeline.AddReport(reports[i].ID, reports[i].CreatedAt, &reports[i].TrustReport)
+	}
+	return timeline, nil
+}
+
+// getTrustHistoryPeriod returns the period given by fromDate and toDate, or by numberOfDays before now. The period
+// ends now and covers DefaultTrustHistoryDays unless specified otherwise
+func getTrustHistoryPeriod(params url.Values) (time.Time, time.Time, error) {
+	defaultLog.Trace("controllers/trust_analytics_controller:getTrustHistoryPeriod() Entering")
+	defer defaultLog.Trace("controllers/trust_analytics_controller:getTrustHistoryPeriod() Leaving")
+
+	fromDate := strings.TrimSpace(params.Get("fromDate"))
+	toDate := strings.TrimSpace(params.Get("toDate"))
+	numberOfDays := strings.TrimSpace(params.Get("numberOfDays"))
+
+	to := time.Now().UTC()
+	days := constants.DefaultTrustHistoryDays
+	if numberOfDays != "" {
+		if fromDate != "" || toDate != "" {
+			return time.Time{}, time.Time{}, errors.New("numberOfDays cannot be combined with fromDate or toDate")
+		}
+		numDays, err := strconv.Atoi(numberOfDays)
+		if err != nil || numDays <= 0 || numDays > constants.MaxNumDaysSearchLimit {
+			return time.Time{}, time.Time{}, errors.Errorf("NumberOfDays must be an integer > 0 and <= %d", constants.MaxNumDaysSearchLimit)
+		}
+		days = numDays
+	}
+
+	if toDate != "" {
+		pTime, err := utils.ParseDateQueryParam(toDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrap(err, "Invalid toDate specified")
+		}
+		to = pTime
+	}
+	from := to.AddDate(0, 0, -days)
+	if fromDate != "" {
+		pTime, err := utils.ParseDateQueryParam(fromDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrap(err, "Invalid fromDate specified")
+		}
+		from = pTime
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("fromDate must be before toDate")
+	}
+	if from.AddDate(0, 0, constants.MaxNumDaysSearchLimit).Before(to) {
+		return time.Time{}, time.Time{}, errors.Errorf("The period cannot be longer than %d days", constants.MaxNumDaysSearchLimit)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrustAnalyticsController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var trustAnalyticsController *controllers.TrustAnalyticsController
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	flavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		flavorgroupStore := mocks.NewFakeFlavorgroupStore()
+		flavorgroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{{HostId: hostId, FlavorgroupId: flavorgroupId}}
+		trustAnalyticsController = &controllers.TrustAnalyticsController{
+			ReportStore:      mocks.NewMockReportStore(),
+			HostStore:        mocks.NewMockHostStore(),
+			FlavorGroupStore: flavorgroupStore,
+		}
+		router.Handle("/hosts/{hId}/trust-timeline", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(trustAnalyticsController.Timeline))).Methods("GET")
+		router.Handle("/trust-analytics", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(trustAnalyticsController.Analytics))).Methods("GET")
+	})
+
+	// Specs for HTTP Get to "/hosts/{hId}/trust-timeline"
+	Describe("Retrieve the trust timeline of a Host", func() {
+		Context("Retrieve the trust timeline for a period", func() {
+			It("Should return the trust intervals of the Host", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/trust-timeline?fromDate=2020-06-01&toDate=2020-07-01", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var timeline hvs.TrustTimeline
+				Expect(json.Unmarshal(w.Body.Bytes(), &timeline)).To(Succeed())
+				Expect(timeline.HostId).To(Equal(hostId))
+				Expect(timeline.Reports).To(Equal(1))
+				Expect(timeline.Intervals).To(HaveLen(1))
+				Expect(timeline.Intervals[0].Trusted).To(BeTrue())
+				Expect(timeline.Markers).To(HaveKey("PLATFORM"))
+			})
+		})
+
+		Context("Retrieve the trust timeline with numberOfDays and toDate", func() {
+			It("Should fail to retrieve the trust timeline", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/trust-timeline?numberOfDays=30&toDate=2020-07-01", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Retrieve the trust timeline with fromDate after toDate", func() {
+			It("Should fail to retrieve the trust timeline", func() {
+				req, err := http.NewRequest("GET", "/hosts/"+hostId.String()+"/trust-timeline?fromDate=2020-07-01&toDate=2020-06-01", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Retrieve the trust timeline of a non-existent Host", func() {
+			It("Should fail to retrieve the trust timeline", func() {
+				req, err := http.NewRequest("GET", "/hosts/73755fda-c910-46be-821f-e8ddeab189e9/trust-timeline", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/trust-analytics"
+	Describe("Retrieve trust analytics", func() {
+		Context("Retrieve the trust analytics of a FlavorGroup", func() {
+			It("Should aggregate the trust timelines of the Hosts of the FlavorGroup", func() {
+				req, err := http.NewRequest("GET", "/trust-analytics?flavorgroupId="+flavorgroupId.String()+"&fromDate=2020-06-01&toDate=2020-07-01", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var analytics hvs.TrustAnalytics
+				Expect(json.Unmarshal(w.Body.Bytes(), &analytics)).To(Succeed())
+				Expect(analytics.Flavorgroups).To(HaveLen(1))
+				Expect(analytics.Flavorgroups[0].FlavorgroupId).To(Equal(flavorgroupId))
+				Expect(analytics.Flavorgroups[0].Hosts).To(Equal(1))
+				Expect(analytics.Flavorgroups[0].Reports).To(Equal(1))
+				Expect(analytics.Flavorgroups[0].UntrustedHosts).To(Equal(0))
+			})
+		})
+
+		Context("Retrieve the trust analytics of all the FlavorGroups", func() {
+			It("Should return the trust analytics of every FlavorGroup", func() {
+				req, err := http.NewRequest("GET", "/trust-analytics?numberOfDays=30", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var analytics hvs.TrustAnalytics
+				Expect(json.Unmarshal(w.Body.Bytes(), &analytics)).To(Succeed())
+				Expect(analytics.Flavorgroups).To(HaveLen(2))
+			})
+		})
+
+		Context("Retrieve the trust analytics of a non-existent FlavorGroup", func() {
+			It("Should fail to retrieve the trust analytics", func() {
+				req, err := http.NewRequest("GET", "/trust-analytics?flavorgroupId=73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Retrieve the trust analytics with an unknown query parameter", func() {
+			It("Should fail to retrieve the trust analytics", func() {
+				req, err := http.NewRequest("GET", "/trust-analytics?hostId="+hostId.String(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -0,0 +1,299 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	webhookSecretMinLength   = 16
+	webhookSecretMaxLength   = 255
+	webhookGeneratedSecretSz = 32
+)
+
+type WebhookController struct {
+	Store   domain.WebhookStore
+	DLStore domain.WebhookDeadLetterStore
+}
+
+var webhookSearchParams = map[string]bool{"id": true, "urlContains": true}
+var webhookDeadLetterSearchParams = map[string]bool{"eventId": true, "limit": true}
+
+func (controller WebhookController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:Create() Leaving")
+
+	reqWebhook, err := getWebhook(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/webhook_controller:Create() %s : Failed to decode request body as Webhook", commLogMsg.InvalidInputBadEncoding)
+		if strings.Contains(err.Error(), "Invalid Content-Type") {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: err.Error()}
+		}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	if reqWebhook.Secret == "" {
+		reqWebhook.Secret, err = generateWebhookSecret()
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/webhook_controller:Create() Error while generating webhook secret")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error while generating webhook secret"}
+		}
+	}
+
+	newWebhook, err := controller.Store.Create(reqWebhook)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/webhook_controller:Create() Webhook create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error creating Webhook"}
+	}
+	secLog.WithField("Id", newWebhook.Id).Infof("%s: Webhook created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	// the secret is only returned once, on creation
+	return newWebhook, http.StatusCreated, nil
+}
+
+func (controller WebhookController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:Update() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	reqWebhook, err := getWebhook(r)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/webhook_controller:Update() %s : Failed to decode request body as Webhook", commLogMsg.InvalidInputBadEncoding)
+		if strings.Contains(err.Error(), "Invalid Content-Type") {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: err.Error()}
+		}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	webhook, status, err := controller.retrieveWebhook(id)
+	if err != nil {
+		return nil, status, err
+	}
+	webhook.Url = reqWebhook.Url
+	webhook.Description = reqWebhook.Description
+	if reqWebhook.Secret != "" {
+		webhook.Secret = reqWebhook.Secret
+	}
+
+	updatedWebhook, err := controller.Store.Update(webhook)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/webhook_controller:Update() Webhook update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error updating Webhook"}
+	}
+	secLog.WithField("Id", updatedWebhook.Id).Infof("%s: Webhook updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	updatedWebhook.Secret = ""
+	return updatedWebhook, http.StatusOK, nil
+}
+
+func (controller WebhookController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	webhook, status, err := controller.retrieveWebhook(id)
+	if err != nil {
+		return nil, status, err
+	}
+	webhook.Secret = ""
+	return webhook, http.StatusOK, nil
+}
+
+func (controller WebhookController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), webhookSearchParams); err != nil {
+		secLog.Errorf("controllers/webhook_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria, err := getWebhookFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/webhook_controller:Search() %s Invalid filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
+	}
+
+	webhooks, err := controller.Store.Search(criteria)
+	if err != nil {
+		secLog.WithError(err).Error("controllers/webhook_controller:Search() Webhook search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Webhooks"}
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	secLog.Infof("%s: Return webhook query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.WebhookCollection{Webhooks: webhooks}, http.StatusOK, nil
+}
+
+func (controller WebhookController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := controller.retrieveWebhook(id); err != nil {
+		return nil, status, err
+	}
+
+	if err := controller.Store.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/webhook_controller:Delete() Failed to delete Webhook")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete Webhook"}
+	}
+	secLog.WithField("Id", id).Infof("Webhook deleted by: %s", r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+// SearchDeadLetters returns the notifications that could not be delivered to the webhook
+func (controller WebhookController) SearchDeadLetters(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/webhook_controller:SearchDeadLetters() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:SearchDeadLetters() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), webhookDeadLetterSearchParams); err != nil {
+		secLog.Errorf("controllers/webhook_controller:SearchDeadLetters() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := controller.retrieveWebhook(id); err != nil {
+		return nil, status, err
+	}
+
+	criteria := models.WebhookDeadLetterFilterCriteria{WebhookId: id}
+	if eventId := r.URL.Query().Get("eventId"); eventId != "" {
+		parsedId, err := uuid.Parse(eventId)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/webhook_controller:SearchDeadLetters() %s Invalid eventId query param value, must be UUID", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid eventId query param value, must be UUID"}
+		}
+		criteria.EventId = parsedId
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 {
+			secLog.Errorf("controllers/webhook_controller:SearchDeadLetters() %s Invalid limit query param value, must be a positive integer", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid limit query param value, must be a positive integer"}
+		}
+		criteria.Limit = parsedLimit
+	}
+
+	deadLetters, err := controller.DLStore.Search(&criteria)
+	if err != nil {
+		secLog.WithError(err).Error("controllers/webhook_controller:SearchDeadLetters() Dead letter search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Webhook dead letters"}
+	}
+	return models.WebhookDeadLetterCollection{DeadLetters: deadLetters}, http.StatusOK, nil
+}
+
+func (controller WebhookController) retrieveWebhook(id uuid.UUID) (*hvs.Webhook, int, error) {
+	webhook, err := controller.Store.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Error("controllers/webhook_controller:retrieveWebhook() Webhook with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Webhook with given ID does not exist"}
+		}
+		secLog.WithError(err).WithField("id", id).Error("controllers/webhook_controller:retrieveWebhook() Failed to retrieve Webhook")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Webhook"}
+	}
+	return webhook, http.StatusOK, nil
+}
+
+func getWebhook(r *http.Request) (*hvs.Webhook, error) {
+	defaultLog.Trace("controllers/webhook_controller:getWebhook() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:getWebhook() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, errors.New("Invalid Content-Type")
+	}
+	if r.ContentLength == 0 {
+		return nil, errors.New("The request body is not provided")
+	}
+
+	var webhook hvs.Webhook
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&webhook); err != nil {
+		return nil, errors.New("Unable to decode JSON request body")
+	}
+	if webhook.Id != uuid.Nil || !webhook.CreatedAt.IsZero() {
+		return nil, errors.New("Webhook id and created time cannot be specified")
+	}
+	if err := validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func validateWebhook(webhook hvs.Webhook) error {
+	defaultLog.Trace("controllers/webhook_controller:validateWebhook() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:validateWebhook() Leaving")
+
+	if webhook.Url == "" {
+		return errors.New("Webhook url must be specified")
+	}
+	webhookUrl, err := url.ParseRequestURI(webhook.Url)
+	if err != nil || webhookUrl.Host == "" {
+		return errors.New("Valid contents for url must be specified")
+	}
+	if webhookUrl.Scheme != "https" {
+		return errors.New("Webhook url must use https")
+	}
+	if webhook.Secret != "" && (len(webhook.Secret) < webhookSecretMinLength || len(webhook.Secret) > webhookSecretMaxLength) {
+		return errors.Errorf("Webhook secret must be between %d and %d characters", webhookSecretMinLength, webhookSecretMaxLength)
+	}
+	if webhook.Description != "" {
+		if err := validation.ValidateStrings(strings.Split(webhook.Description, " ")); err != nil {
+			return errors.New("Valid contents for description must be specified")
+		}
+	}
+	return nil
+}
+
+func getWebhookFilterCriteria(params url.Values) (*models.WebhookFilterCriteria, error) {
+	defaultLog.Trace("controllers/webhook_controller:getWebhookFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/webhook_controller:getWebhookFilterCriteria() Leaving")
+
+	criteria := models.WebhookFilterCriteria{}
+	if id := params.Get("id"); id != "" {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("Invalid id query param value, must be UUID")
+		}
+		criteria.Id = parsedId
+	}
+	if urlContains := params.Get("urlContains"); urlContains != "" {
+		if _, err := url.Parse(urlContains); err != nil {
+			return nil, errors.Wrap(err, "Valid contents for urlContains must be specified")
+		}
+		criteria.UrlContains = urlContains
+	}
+	return &criteria, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookGeneratedSecretSz)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "failed to read random bytes")
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v3/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v3/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var webhookStore *mocks.MockWebhookStore
+	var deadLetterStore *mocks.MockWebhookDeadLetterStore
+	var webhookController *controllers.WebhookController
+	existingWebhookId := "1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01"
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		webhookStore = mocks.NewMockWebhookStore()
+		deadLetterStore = mocks.NewMockWebhookDeadLetterStore()
+		webhookController = &controllers.WebhookController{Store: webhookStore, DLStore: deadLetterStore}
+	})
+
+	// Specs for HTTP Post to "/webhooks"
+	Describe("Create Webhook", func() {
+		Context("Provide a valid Webhook without a secret", func() {
+			It("Should create the Webhook and return a generated secret", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Create))).Methods("POST")
+				body := `{"url": "https://ihub2.com:19082/webhook", "description": "second hub"}`
+				req, err := http.NewRequest("POST", "/webhooks", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var webhook hvs.Webhook
+				err = json.Unmarshal(w.Body.Bytes(), &webhook)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(webhook.Id).NotTo(Equal(uuid.Nil))
+				Expect(len(webhook.Secret)).To(Equal(64))
+			})
+		})
+		Context("Provide a Webhook with a non https url", func() {
+			It("Should fail to create the Webhook", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Create))).Methods("POST")
+				body := `{"url": "http://ihub2.com:19082/webhook"}`
+				req, err := http.NewRequest("POST", "/webhooks", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a Webhook with a secret that is too short", func() {
+			It("Should fail to create the Webhook", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Create))).Methods("POST")
+				body := `{"url": "https://ihub2.com:19082/webhook", "secret": "short"}`
+				req, err := http.NewRequest("POST", "/webhooks", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an invalid Content-Type", func() {
+			It("Should fail with unsupported media type", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Create))).Methods("POST")
+				body := `{"url": "https://ihub2.com:19082/webhook"}`
+				req, err := http.NewRequest("POST", "/webhooks", strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeXml)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/webhooks"
+	Describe("Search Webhooks", func() {
+		Context("Search webhooks without filter", func() {
+			It("Should return all webhooks without their secrets", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/webhooks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var webhookCollection hvs.WebhookCollection
+				err = json.Unmarshal(w.Body.Bytes(), &webhookCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(webhookCollection.Webhooks)).To(Equal(1))
+				Expect(webhookCollection.Webhooks[0].Secret).To(BeEmpty())
+			})
+		})
+		Context("Search webhooks with an invalid query parameter", func() {
+			It("Should fail with bad request", func() {
+				router.Handle("/webhooks", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Search))).Methods("GET")
+				req, err := http.NewRequest("GET", "/webhooks?badParam=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/webhooks/{id}"
+	Describe("Retrieve Webhook", func() {
+		Context("Retrieve an existing webhook", func() {
+			It("Should return the webhook", func() {
+				router.Handle("/webhooks/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/webhooks/"+existingWebhookId, nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("Retrieve a non-existent webhook", func() {
+			It("Should fail with not found", func() {
+				router.Handle("/webhooks/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Retrieve))).Methods("GET")
+				req, err := http.NewRequest("GET", "/webhooks/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Put to "/webhooks/{id}"
+	Describe("Update Webhook", func() {
+		Context("Update the url of an existing webhook", func() {
+			It("Should update the webhook and keep its secret", func() {
+				router.Handle("/webhooks/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.Update))).Methods("PUT")
+				body := `{"url": "https://ihub.com:19083/webhook"}`
+				req, err := http.NewRequest("PUT", "/webhooks/"+existingWebhookId, strings.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				webhook, err := webhookStore.Retrieve(uuid.MustParse(existingWebhookId))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(webhook.Url).To(Equal("https://ihub.com:19083/webhook"))
+				Expect(webhook.Secret).To(Equal("0123456789abcdef0123456789abcdef"))
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/webhooks/{id}"
+	Describe("Delete Webhook", func() {
+		Context("Delete an existing webhook", func() {
+			It("Should delete the webhook", func() {
+				router.Handle("/webhooks/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(webhookController.Delete))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/webhooks/"+existingWebhookId, nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+		Context("Delete a non-existent webhook", func() {
+			It("Should fail with not found", func() {
+				router.Handle("/webhooks/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(webhookController.Delete))).Methods("DELETE")
+				req, err := http.NewRequest("DELETE", "/webhooks/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/webhooks/{id}/dead-letters"
+	Describe("Search Webhook dead letters", func() {
+		Context("Search dead letters of an existing webhook", func() {
+			It("Should return the dead letters of the webhook", func() {
+				deadLetterStore.Create(&models.WebhookDeadLetter{
+					WebhookID: uuid.MustParse(existingWebhookId),
+					EventID:   uuid.New(),
+					Payload:   `{}`,
+					Attempts:  6,
+					LastError: "webhook endpoint returned status 500",
+				})
+				router.Handle("/webhooks/{id}/dead-letters", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(webhookController.SearchDeadLetters))).Methods("GET")
+				req, err := http.NewRequest("GET", "/webhooks/"+existingWebhookId+"/dead-letters", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var deadLetters models.WebhookDeadLetterCollection
+				err = json.Unmarshal(w.Body.Bytes(), &deadLetters)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(deadLetters.DeadLetters)).To(Equal(1))
+			})
+		})
+	})
+})
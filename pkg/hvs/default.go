@@ -7,9 +7,11 @@ package hvs
 import (
 	"os"
 
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/keylime"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/webhook"
 	commConfig "github.com/intel-secl/intel-secl/v3/pkg/lib/common/config"
 	"github.com/spf13/viper"
 )
@@ -19,6 +21,21 @@ const (
 	fvsNumberOfDataFetchers            = "fvs-number-of-data-fetchers"
 	fvsSkipFlavorSignatureVerification = "fvs-skip-flavor-signature-verification"
 	hrrsRefreshPeriod                  = "hrrs-refresh-period"
+	webhookMaxRetries                  = "webhook-max-retries"
+	webhookRetryBackoff                = "webhook-retry-backoff"
+	webhookRequestTimeout              = "webhook-request-timeout"
+	webhookBufferSize                  = "webhook-buffer-size"
+	webhookWorkers                     = "webhook-workers"
+	hostSimulatorEnabled               = "host-simulator-enabled"
+	hostSimulatorTemplateDir           = "host-simulator-template-dir"
+	hostSimulatorLatency               = "host-simulator-latency"
+	keylimeRegistrarURL                = "keylime-registrar-url"
+	keylimeApiVersion                  = "keylime-api-version"
+	keylimeCaCertFile                  = "keylime-ca-cert-file"
+	keylimeClientCertFile              = "keylime-client-cert-file"
+	keylimeClientKeyFile               = "keylime-client-key-file"
+	attestationTokenSigningKeyFile     = "attestation-token-signing-key-file"
+	attestationTokenSigningCertFile    = "attestation-token-signing-cert-file"
 )
 
 // this func sets the default values for viper keys
@@ -99,6 +116,24 @@ func init() {
 	viper.SetDefault(fvsSkipFlavorSignatureVerification, constants.DefaultSkipFlavorSignatureVerification)
 
 	viper.SetDefault(hrrsRefreshPeriod, hrrs.DefaultRefreshPeriod)
+
+	// set default for webhook notifications
+	viper.SetDefault(webhookMaxRetries, webhook.DefaultMaxRetries)
+	viper.SetDefault(webhookRetryBackoff, webhook.DefaultRetryBackoff)
+	viper.SetDefault(webhookRequestTimeout, webhook.DefaultRequestTimeout)
+	viper.SetDefault(webhookBufferSize, webhook.DefaultBufferSize)
+	viper.SetDefault(webhookWorkers, webhook.DefaultWorkers)
+
+	// the host simulator is disabled by default
+	viper.SetDefault(hostSimulatorEnabled, false)
+	viper.SetDefault(hostSimulatorTemplateDir, constants.DefaultHostSimulatorTemplateDir)
+	viper.SetDefault(hostSimulatorLatency, 0)
+
+	// the keylime hosts are disabled until a registrar is configured
+	viper.SetDefault(keylimeApiVersion, keylime.DefaultApiVersion)
+	viper.SetDefault(keylimeCaCertFile, constants.DefaultKeylimeCaCertFile)
+	viper.SetDefault(keylimeClientCertFile, constants.DefaultKeylimeClientCertFile)
+	viper.SetDefault(keylimeClientKeyFile, constants.DefaultKeylimeClientKeyFile)
 }
 
 func defaultConfig() *config.Configuration {
@@ -134,6 +169,10 @@ func defaultConfig() *config.Configuration {
 			Issuer:          viper.GetString("saml-issuer-name"),
 			ValiditySeconds: viper.GetInt("saml-validity-seconds"),
 		},
+		AttestationToken: config.AttestationTokenConfig{
+			SigningKeyFile:  viper.GetString(attestationTokenSigningKeyFile),
+			SigningCertFile: viper.GetString(attestationTokenSigningCertFile),
+		},
 		FlavorSigning: commConfig.SigningCertConfig{
 			CertFile:   viper.GetString("flavor-signing-cert-file"),
 			KeyFile:    viper.GetString("flavor-signing-key-file"),
@@ -173,6 +212,25 @@ func defaultConfig() *config.Configuration {
 			NumberOfDataFetchers:            viper.GetInt(fvsNumberOfDataFetchers),
 			SkipFlavorSignatureVerification: viper.GetBool(fvsSkipFlavorSignatureVerification),
 		},
+		Webhook: webhook.WebhookConfig{
+			MaxRetries:     viper.GetInt(webhookMaxRetries),
+			RetryBackoff:   viper.GetDuration(webhookRetryBackoff),
+			RequestTimeout: viper.GetDuration(webhookRequestTimeout),
+			BufferSize:     viper.GetInt(webhookBufferSize),
+			Workers:        viper.GetInt(webhookWorkers),
+		},
+		HostSimulator: config.HostSimulatorConfig{
+			Enabled:     viper.GetBool(hostSimulatorEnabled),
+			TemplateDir: viper.GetString(hostSimulatorTemplateDir),
+			Latency:     viper.GetDuration(hostSimulatorLatency),
+		},
+		Keylime: config.KeylimeConfig{
+			RegistrarURL:   viper.GetString(keylimeRegistrarURL),
+			ApiVersion:     viper.GetString(keylimeApiVersion),
+			CaCertFile:     viper.GetString(keylimeCaCertFile),
+			ClientCertFile: viper.GetString(keylimeClientCertFile),
+			ClientKeyFile:  viper.GetString(keylimeClientKeyFile),
+		},
 	}
 }
 
@@ -6,6 +6,8 @@
 package domain
 
 import (
+	"sync"
+
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
@@ -21,6 +23,7 @@ type HostTrustVerifierConfig struct {
 	CertsStore                      models.CertificatesStore
 	SamlIssuerConfig                saml.IssuerConfiguration
 	SkipFlavorSignatureVerification bool
+	TrustChangeNotifier             TrustChangeNotifier
 }
 
 type HostTrustMgrConfig struct {
@@ -30,6 +33,9 @@ type HostTrustMgrConfig struct {
 	HostFetcher       HostDataFetcher
 	Verifiers         int
 	HostTrustVerifier HostTrustVerifier
+	ReportBroadcaster ReportBroadcaster
+	// JobStore tracks the hosts queued by each VerifyHostsAsync call as a FLAVOR_VERIFY job when set
+	JobStore JobStore
 }
 
 type HostDataFetcherConfig struct {
@@ -45,6 +51,13 @@ type HostControllerConfig struct {
 	DataEncryptionKey     []byte
 	Username              string
 	Password              string
+	// HostDataFetcher connects to the hosts of the batch host operations, at most NumberOfDataFetchers at a time
+	HostDataFetcher      HostDataFetcher
+	NumberOfDataFetchers int
+	// BatchJobsQuit is closed when the service stops, the batch host jobs then cancel the hosts they did not start.
+	// BatchJobsWg waits for the hosts the batch host jobs already started
+	BatchJobsQuit <-chan struct{}
+	BatchJobsWg   *sync.WaitGroup
 }
 
 type TagCertControllerConfig struct {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
@@ -58,6 +59,7 @@ type (
 		GetUniqueFlavorTypesThatExistForHost(hwId uuid.UUID) (map[cf.FlavorPart]bool, error)
 		GetFlavorTypesInFlavorgroup(flvGrpId uuid.UUID, flvParts []cf.FlavorPart) (map[cf.FlavorPart]bool, error)
 		Create(*hvs.SignedFlavor) (*hvs.SignedFlavor, error)
+		Update(*hvs.SignedFlavor) (*hvs.SignedFlavor, error)
 		Retrieve(uuid.UUID) (*hvs.SignedFlavor, error)
 		Search(*models.FlavorVerificationFC) ([]hvs.SignedFlavor, error)
 		Delete(uuid.UUID) error
@@ -96,6 +98,12 @@ type (
 		Update(*models.HVSReport) (*models.HVSReport, error)
 		Delete(uuid.UUID) error
 		FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error)
+		// SearchTrustHistory returns the reports of a host created between fromDate and toDate, and the latest
+		// report created before fromDate, in the order they were created. The host manifests are not retrieved
+		SearchTrustHistory(hostId uuid.UUID, fromDate, toDate time.Time) ([]models.HVSReport, error)
+		// SearchTrustAnalytics aggregates the trust history of the hosts between fromDate and toDate, only the top
+		// most failing rules and PCRs are returned. The flavorgroup of the analytics is left to the caller
+		SearchTrustAnalytics(hostIds []uuid.UUID, fromDate, toDate time.Time, top int) (*hvs.FlavorgroupTrustAnalytics, error)
 	}
 
 	ESXiClusterStore interface {
@@ -115,6 +123,60 @@ type (
 		Search(*models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error)
 	}
 
+	WebhookStore interface {
+		Create(*hvs.Webhook) (*hvs.Webhook, error)
+		Retrieve(uuid.UUID) (*hvs.Webhook, error)
+		Update(*hvs.Webhook) (*hvs.Webhook, error)
+		Search(*models.WebhookFilterCriteria) ([]hvs.Webhook, error)
+		Delete(uuid.UUID) error
+	}
+
+	WebhookDeadLetterStore interface {
+		Create(*models.WebhookDeadLetter) (*models.WebhookDeadLetter, error)
+		Search(*models.WebhookDeadLetterFilterCriteria) ([]models.WebhookDeadLetter, error)
+	}
+
+	ScheduleStore interface {
+		Create(*hvs.Schedule) (*hvs.Schedule, error)
+		Retrieve(uuid.UUID) (*hvs.Schedule, error)
+		Update(*hvs.Schedule) (*hvs.Schedule, error)
+		Search(*models.ScheduleFilterCriteria) ([]hvs.Schedule, error)
+		Delete(uuid.UUID) error
+	}
+
+	// JobStore persists the jobs of the batch host operations and the flavor verifications, and their per host results
+	JobStore interface {
+		Create(*hvs.Job) (*hvs.Job, error)
+		Retrieve(uuid.UUID) (*hvs.Job, error)
+		// Search returns the jobs without their results
+		Search(*models.JobFilterCriteria) ([]hvs.Job, error)
+		Update(*hvs.Job) error
+		UpdateResult(jobId uuid.UUID, position int, result *hvs.JobResult) error
+		// DeleteCompletedBefore deletes the completed and cancelled jobs that ended before the given time
+		DeleteCompletedBefore(time.Time) error
+	}
+
+	// TrustChangeNotifier is notified by the host trust verifier whenever the trust status of a host changes
+	TrustChangeNotifier interface {
+		Notify(*hvs.TrustChangeEvent)
+	}
+
+	// ReportBroadcaster fans out the reports created by the host trust manager to live subscribers
+	ReportBroadcaster interface {
+		Publish(*models.HVSReport)
+		// Subscribe returns the channel on which published reports are delivered and a function
+		// that has to be called to stop receiving them
+		Subscribe() (<-chan *models.HVSReport, func())
+		// SubscribeAfter also delivers the reports published after the report with the given ID that are still
+		// kept, so that subscribers reconnecting do not miss reports
+		SubscribeAfter(lastReportId uuid.UUID) (<-chan *models.HVSReport, func())
+	}
+
+	// TokenReportGenerator signs the reports as attestation tokens, an alternative to their SAML assertion
+	TokenReportGenerator interface {
+		GenerateTokenReport(*models.HVSReport) (string, error)
+	}
+
 	HostTrustManager interface {
 		// Verify the trust of the a host.
 		//Returns the host trust report. For now marking this as interface since we have not defined the report structure
@@ -127,10 +189,22 @@ type (
 		// fetchHostData - Fetch a new Manifest/Data from the host.
 		// preferHashMatch - Can attempt to do match a cumulative hash from the Host Manifest/ Data rather than
 		//                   doing a full report.
-		VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) error
+		// Returns the id of the FLAVOR_VERIFY job tracking the hosts, nil when the hosts are not tracked by a job
+		VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) (uuid.UUID, error)
+
+		// Evaluate the trust of a host against a flavorgroup and candidate flavors without storing the report
+		// or updating the trust cache. The latest host manifest in the store is used when hostData is nil
+		EvaluateHost(hostId uuid.UUID, hostData *types.HostManifest, fg hvs.FlavorGroup, flavors []hvs.SignedFlavor) (*hvs.TrustReport, error)
 
 		//Process all records stuck in queue post service restart
 		ProcessQueue() error
+
+		// Returns the stage of the hosts of a FLAVOR_VERIFY job that are still queued for trust verification
+		JobStages(jobId uuid.UUID) map[uuid.UUID]taskstage.Stage
+
+		// Cancels the queued trust verifications of the hosts of a FLAVOR_VERIFY job. The verification of a host
+		// is only cancelled when the job queued it and no other job is waiting for it
+		CancelJob(jobId uuid.UUID) error
 	}
 
 	HostDataReceiver interface {
@@ -153,6 +227,7 @@ type (
 
 	HostTrustVerifier interface {
 		Verify(uuid.UUID, *types.HostManifest, bool) (*models.HVSReport, error)
+		Evaluate(uuid.UUID, *types.HostManifest, hvs.FlavorGroup, []hvs.SignedFlavor) (*hvs.TrustReport, error)
 	}
 
 	AuditLogWriter interface {
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"io/ioutil"
 	"reflect"
+	"time"
 )
 
 // MockFlavorStore provides a mocked implementation of interface hvs.FlavorStore
@@ -395,7 +396,7 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 	}
 
 	// return all entries
-	if reflect.DeepEqual(*criteria, models.FlavorFilterCriteria{}) {
+	if reflect.DeepEqual(*criteria, models.FlavorVerificationFC{}) {
 		return store.flavorStore, nil
 	}
 
@@ -411,6 +412,17 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 			}
 		}
 		sfs = sfFiltered
+	} else if criteria.FlavorFC.FlavorgroupID == uuid.Nil && len(criteria.FlavorFC.FlavorParts) >= 1 &&
+		len(criteria.FlavorPartsWithLatest) == 0 {
+		// Flavor part filter without flavorgroup
+		for _, f := range store.flavorStore {
+			for _, flavorPart := range criteria.FlavorFC.FlavorParts {
+				if f.Flavor.Meta.Description.FlavorPart == flavorPart.String() {
+					sfs = append(sfs, f)
+					break
+				}
+			}
+		}
 	} else if criteria.FlavorFC.FlavorgroupID != uuid.Nil ||
 		len(criteria.FlavorFC.FlavorParts) >= 1 || len(criteria.FlavorPartsWithLatest) >= 1 {
 		flavorPartsWithLatestMap := getFlavorPartsWithLatestMap(criteria.FlavorFC.FlavorParts, criteria.FlavorPartsWithLatest)
@@ -429,9 +441,32 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 			}
 		}
 	}
+	if criteria.UsableAt != nil {
+		var usableFlavors []hvs.SignedFlavor
+		for _, sf := range sfs {
+			if sf.Flavor.Meta.Lifecycle.IsUsable(*criteria.UsableAt) {
+				usableFlavors = append(usableFlavors, sf)
+			}
+		}
+		sfs = usableFlavors
+	}
 	return sfs, nil
 }
 
+// Update replaces a Flavor
+func (store *MockFlavorStore) Update(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	for i, f := range store.flavorStore {
+		if f.Flavor.Meta.ID == sf.Flavor.Meta.ID {
+			store.flavorStore[i] = hvs.SignedFlavor{
+				Flavor:    sf.Flavor,
+				Signature: sf.Signature,
+			}
+			return sf, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
 // Create inserts a Flavor
 func (store *MockFlavorStore) Create(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
 	//It is not right way to directly append the pointer, reference will be copied. Copy only the values.
@@ -447,9 +482,10 @@ func (store *MockFlavorStore) GetUniqueFlavorTypesThatExistForHost(hwId uuid.UUI
 	flavorParts := make(map[cf.FlavorPart]bool)
 	//find flavors for given hwId and either flavorPart is host_unique or asset_tag
 	var hwMatchedFlavors []hvs.SignedFlavor
+	now := time.Now()
 	for _, flavor := range store.flavorStore {
 		//skip for flavor with not matching hwuuid
-		if flavor.Flavor.Meta.Description.HardwareUUID == nil {
+		if flavor.Flavor.Meta.Description.HardwareUUID == nil || !flavor.Flavor.Meta.Lifecycle.IsUsable(now) {
 			continue
 		}
 		if *flavor.Flavor.Meta.Description.HardwareUUID == hwId && (flavor.Flavor.Meta.Description.FlavorPart == cf.FlavorPartHostUnique.String() ||
@@ -492,9 +528,10 @@ func (store *MockFlavorStore) flavorgroupContainsFlavorType(flvrPart cf.FlavorPa
 	fgStore := store.FlavorgroupStore
 	flvrMap := make(map[uuid.UUID]hvs.SignedFlavor)
 
-	//Get flavors for given flavor part and store it in flvrMap
+	//Get flavors usable for verification for given flavor part and store it in flvrMap
+	now := time.Now()
 	for _, flvr := range store.flavorStore {
-		if flvr.Flavor.Meta.Description.FlavorPart == flvrPart.String() {
+		if flvr.Flavor.Meta.Description.FlavorPart == flvrPart.String() && flvr.Flavor.Meta.Lifecycle.IsUsable(now) {
 			flvrMap[flvr.Flavor.Meta.ID] = flvr
 		}
 	}
@@ -56,6 +56,19 @@ func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCrit
 			flavorgroups = append(flavorgroups, *fg)
 		}
 		return flavorgroups, nil
+	} else if criteria.FlavorId != nil {
+		var flavorgroups []hvs.FlavorGroup
+		for fgId, fIds := range store.FlavorgroupFlavorStore {
+			for _, fId := range fIds {
+				if fId == *criteria.FlavorId {
+					if fg, ok := store.FlavorgroupStore[fgId]; ok {
+						flavorgroups = append(flavorgroups, *fg)
+					}
+					break
+				}
+			}
+		}
+		return flavorgroups, nil
 	} else if criteria.NameEqualTo != "" {
 		for _, fg := range store.FlavorgroupStore {
 			if fg.Name == criteria.NameEqualTo {
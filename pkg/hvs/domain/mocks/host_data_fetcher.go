@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	model "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// MockHostDataFetcher provides a mocked implementation of interface domain.HostDataFetcher, it returns the manifest
+// of a linux host running the trust agent for every host but the UnreachableHosts
+type MockHostDataFetcher struct {
+	UnreachableHosts map[string]bool
+}
+
+// Retrieve returns the host manifest, or a connection error when the host is unreachable
+func (fetcher *MockHostDataFetcher) Retrieve(ctx context.Context, host hvs.Host) (*types.HostManifest, error) {
+	if fetcher.UnreachableHosts[host.HostName] {
+		return nil, errors.New("dial tcp: connect: connection refused")
+	}
+	return &types.HostManifest{
+		HostInfo: model.HostInfo{
+			HostName:            host.HostName,
+			OSName:              "RedHatEnterprise",
+			HardwareUUID:        uuid.New().String(),
+			InstalledComponents: []string{"tagent"},
+		},
+	}, nil
+}
+
+// RetrieveAsync passes the host manifest to the receivers
+func (fetcher *MockHostDataFetcher) RetrieveAsync(ctx context.Context, host hvs.Host, rcvrs ...domain.HostDataReceiver) error {
+	hostData, err := fetcher.Retrieve(ctx, host)
+	for _, rcv := range rcvrs {
+		_ = rcv.ProcessHostData(ctx, host, hostData, err)
+	}
+	return nil
+}
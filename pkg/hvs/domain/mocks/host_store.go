@@ -22,6 +22,9 @@ type MockHostStore struct {
 
 // Create inserts a Host
 func (store *MockHostStore) Create(host *hvs.Host) (*hvs.Host, error) {
+	if host.Id == uuid.Nil {
+		host.Id = uuid.New()
+	}
 	store.hostStore = append(store.hostStore, host)
 	return host, nil
 }
@@ -88,6 +91,18 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria) ([]*hvs.
 				hosts = append(hosts, h)
 			}
 		}
+	} else if len(criteria.LabelSelector) > 0 {
+		hosts = store.hostStore
+	}
+
+	if len(criteria.LabelSelector) > 0 {
+		var selectedHosts []*hvs.Host
+		for _, h := range hosts {
+			if criteria.LabelSelector.Matches(h.Labels) {
+				selectedHosts = append(selectedHosts, h)
+			}
+		}
+		hosts = selectedHosts
 	}
 	return hosts, nil
 }
@@ -167,6 +182,7 @@ func NewMockHostStore() *MockHostStore {
 		HardwareUuid:     &uuid1,
 		ConnectionString: "intel:https://ta.ip.com:1443",
 		Description:      "Intel Host",
+		Labels:           map[string]string{"env": "prod", "dc1/rack": "r12"},
 	})
 
 	store.Create(&hvs.Host{
@@ -175,6 +191,7 @@ func NewMockHostStore() *MockHostStore {
 		HardwareUuid:     &uuid2,
 		ConnectionString: "vmware:https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password",
 		Description:      "Vmware Host",
+		Labels:           map[string]string{"env": "dev"},
 	})
 
 	store.AddFlavorgroups(uuid2, []uuid.UUID{uuid1})
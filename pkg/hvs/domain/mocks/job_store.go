@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockJobStore provides a mocked implementation of interface domain.JobStore
+type MockJobStore struct {
+	lock     sync.Mutex
+	jobStore map[uuid.UUID]hvs.Job
+}
+
+// Create inserts a Job with its results
+func (store *MockJobStore) Create(j *hvs.Job) (*hvs.Job, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if j.Id == uuid.Nil {
+		j.Id = uuid.New()
+	}
+	j.CreatedAt = time.Now()
+	stored := *j
+	stored.Results = append([]hvs.JobResult{}, j.Results...)
+	store.jobStore[j.Id] = stored
+	return j, nil
+}
+
+// Retrieve returns a copy of a Job
+func (store *MockJobStore) Retrieve(id uuid.UUID) (*hvs.Job, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if j, ok := store.jobStore[id]; ok {
+		j.Results = append([]hvs.JobResult{}, j.Results...)
+		return &j, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns the Jobs matching the criteria without their results, the latest Jobs first
+func (store *MockJobStore) Search(criteria *models.JobFilterCriteria) ([]hvs.Job, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	jobs := []hvs.Job{}
+	for _, j := range store.jobStore {
+		if criteria != nil {
+			if (criteria.Type != "" && j.Type != criteria.Type) || (criteria.State != "" && j.State != criteria.State) {
+				continue
+			}
+			if criteria.HostId != uuid.Nil && !jobHasHost(j, criteria.HostId) {
+				continue
+			}
+		}
+		j.Results = nil
+		jobs = append(jobs, j)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	if criteria != nil && criteria.Limit > 0 && len(jobs) > criteria.Limit {
+		jobs = jobs[:criteria.Limit]
+	}
+	return jobs, nil
+}
+
+// Update updates the state and the completion time of a Job
+func (store *MockJobStore) Update(j *hvs.Job) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	stored, ok := store.jobStore[j.Id]
+	if !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	stored.State = j.State
+	stored.CompletedAt = j.CompletedAt
+	store.jobStore[j.Id] = stored
+	return nil
+}
+
+// UpdateResult updates a result of a Job
+func (store *MockJobStore) UpdateResult(jobId uuid.UUID, position int, result *hvs.JobResult) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	stored, ok := store.jobStore[jobId]
+	if !ok || position < 0 || position >= len(stored.Results) {
+		return errors.New(commErr.RowsNotFound)
+	}
+	stored.Results[position] = *result
+	return nil
+}
+
+// DeleteCompletedBefore deletes the Jobs that ended before the given time
+func (store *MockJobStore) DeleteCompletedBefore(before time.Time) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	for id, j := range store.jobStore {
+		if j.CompletedAt != nil && j.CompletedAt.Before(before) {
+			delete(store.jobStore, id)
+		}
+	}
+	return nil
+}
+
+func jobHasHost(j hvs.Job, hostId uuid.UUID) bool {
+	for _, result := range j.Results {
+		if result.HostId != nil && *result.HostId == hostId {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMockJobStore provides a MockJobStore without jobs
+func NewMockJobStore() *MockJobStore {
+	return &MockJobStore{jobStore: make(map[uuid.UUID]hvs.Job)}
+}
@@ -10,19 +10,24 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"sync"
 	"time"
 )
 
 type qStore struct {
 	m map[uuid.UUID]models.Queue
+	// the store is used by the workers of the host trust manager
+	mtx sync.Mutex
 }
 
 func NewQueueStore() domain.QueueStore {
 
-	return &qStore{make(map[uuid.UUID]models.Queue)}
+	return &qStore{m: make(map[uuid.UUID]models.Queue)}
 }
 
 func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if criteria.Id == uuid.Nil {
 		rslt := make([]*models.Queue, 0, len(qs.m))
 		for _, v := range qs.m {
@@ -38,6 +43,8 @@ func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue,
 }
 
 func (qs *qStore) Retrieve(uuid uuid.UUID) (*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if _, ok := qs.m[uuid]; ok {
 		cp := qs.m[uuid]
 		return &cp, nil
@@ -46,6 +53,8 @@ func (qs *qStore) Retrieve(uuid uuid.UUID) (*models.Queue, error) {
 }
 
 func (qs *qStore) Update(queue *models.Queue) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if rec, ok := qs.m[queue.Id]; ok {
 
 		for k, v := range queue.Params {
@@ -66,6 +75,8 @@ func (qs *qStore) Update(queue *models.Queue) error {
 }
 
 func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	rec := *queue
 	rec.Id = uuid.New()
 	rec.Created = time.Now()
@@ -76,6 +87,8 @@ func (qs *qStore) Create(queue *models.Queue) (*models.Queue, error) {
 }
 
 func (qs *qStore) Delete(uuid uuid.UUID) error {
+	qs.mtx.Lock()
+	defer qs.mtx.Unlock()
 	if _, ok := qs.m[uuid]; ok {
 		delete(qs.m, uuid)
 		return nil
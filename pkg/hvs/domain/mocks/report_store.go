@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -132,6 +133,48 @@ func (store *MockReportStore) FindHostIdsFromExpiredReports(fromTime time.Time,
 	return hostIDs, nil
 }
 
+// SearchTrustHistory returns the reports of a host created between fromDate and toDate, and the latest report
+// created before fromDate, in the order they were created
+func (store *MockReportStore) SearchTrustHistory(hostId uuid.UUID, fromDate, toDate time.Time) ([]models.HVSReport, error) {
+	var reports []models.HVSReport
+	var previous *models.HVSReport
+	for _, r := range store.reportStore {
+		if r.HostID != hostId || !r.CreatedAt.Before(toDate) {
+			continue
+		}
+		if r.CreatedAt.Before(fromDate) {
+			if previous == nil || r.CreatedAt.After(previous.CreatedAt) {
+				report := r
+				previous = &report
+			}
+			continue
+		}
+		reports = append(reports, r)
+	}
+	if previous != nil {
+		reports = append(reports, *previous)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.Before(reports[j].CreatedAt)
+	})
+	return reports, nil
+}
+
+// SearchTrustAnalytics aggregates the trust timelines of the hosts between fromDate and toDate
+func (store *MockReportStore) SearchTrustAnalytics(hostIds []uuid.UUID, fromDate, toDate time.Time, top int) (*hvs.FlavorgroupTrustAnalytics, error) {
+	var timelines []*hvs.TrustTimeline
+	for _, hostId := range hostIds {
+		reports, _ := store.SearchTrustHistory(hostId, fromDate, toDate)
+		timeline := hvs.NewTrustTimeline(hostId, fromDate, toDate)
+		for i := range reports {
+			timeline.AddReport(reports[i].ID, reports[i].CreatedAt, &reports[i].TrustReport)
+		}
+		timelines = append(timelines, timeline)
+	}
+	analytics := hvs.NewFlavorgroupTrustAnalytics(&hvs.FlavorGroup{}, timelines, top)
+	return &analytics, nil
+}
+
 // NewMockReportStore provides two dummy data for Reports
 func NewMockReportStore() *MockReportStore {
 	//TODO add more data
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockScheduleStore provides a mocked implementation of interface domain.ScheduleStore
+type MockScheduleStore struct {
+	lock          sync.Mutex
+	scheduleStore map[uuid.UUID]hvs.Schedule
+}
+
+// Create inserts a Schedule
+func (store *MockScheduleStore) Create(sc *hvs.Schedule) (*hvs.Schedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if sc.Id == uuid.Nil {
+		sc.Id = uuid.New()
+	}
+	sc.CreatedAt = time.Now()
+	store.scheduleStore[sc.Id] = *sc
+	return sc, nil
+}
+
+// Update updates a Schedule
+func (store *MockScheduleStore) Update(sc *hvs.Schedule) (*hvs.Schedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if _, ok := store.scheduleStore[sc.Id]; !ok {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	store.scheduleStore[sc.Id] = *sc
+	return sc, nil
+}
+
+// Retrieve returns a Schedule
+func (store *MockScheduleStore) Retrieve(id uuid.UUID) (*hvs.Schedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if sc, ok := store.scheduleStore[id]; ok {
+		return &sc, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns a filtered list of schedules as per the provided ScheduleFilterCriteria
+func (store *MockScheduleStore) Search(criteria *models.ScheduleFilterCriteria) ([]hvs.Schedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	schedules := []hvs.Schedule{}
+	for _, sc := range store.scheduleStore {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != sc.Id {
+				continue
+			}
+			if criteria.HostId != uuid.Nil && (sc.HostId == nil || criteria.HostId != *sc.HostId) {
+				continue
+			}
+			if criteria.FlavorgroupId != uuid.Nil && (sc.FlavorgroupId == nil || criteria.FlavorgroupId != *sc.FlavorgroupId) {
+				continue
+			}
+		}
+		schedules = append(schedules, sc)
+	}
+	return schedules, nil
+}
+
+// Delete deletes a Schedule
+func (store *MockScheduleStore) Delete(id uuid.UUID) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if _, ok := store.scheduleStore[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.scheduleStore, id)
+	return nil
+}
+
+// NewEmptyMockScheduleStore provides a MockScheduleStore without schedules
+func NewEmptyMockScheduleStore() *MockScheduleStore {
+	return &MockScheduleStore{scheduleStore: make(map[uuid.UUID]hvs.Schedule)}
+}
+
+// NewMockScheduleStore provides one dummy schedule for a flavorgroup
+func NewMockScheduleStore() *MockScheduleStore {
+	store := NewEmptyMockScheduleStore()
+	flavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	store.Create(&hvs.Schedule{
+		Id:            uuid.MustParse("7a3ee5b0-2bd6-4c9c-9d62-6b3c43f4a1d0"),
+		FlavorgroupId: &flavorgroupId,
+		Interval:      "1h",
+		Jitter:        "5m",
+		MaintenanceWindows: []hvs.MaintenanceWindow{
+			{
+				Start:    "22:00",
+				Duration: "4h",
+				Weekdays: []string{"Saturday"},
+			},
+		},
+	})
+	return store
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v3/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockWebhookStore provides a mocked implementation of interface domain.WebhookStore
+type MockWebhookStore struct {
+	lock         sync.Mutex
+	webhookStore map[uuid.UUID]hvs.Webhook
+}
+
+// Create inserts a Webhook
+func (store *MockWebhookStore) Create(wh *hvs.Webhook) (*hvs.Webhook, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if wh.Id == uuid.Nil {
+		wh.Id = uuid.New()
+	}
+	wh.CreatedAt = time.Now()
+	store.webhookStore[wh.Id] = *wh
+	return wh, nil
+}
+
+// Update updates a Webhook
+func (store *MockWebhookStore) Update(wh *hvs.Webhook) (*hvs.Webhook, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if _, ok := store.webhookStore[wh.Id]; !ok {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	store.webhookStore[wh.Id] = *wh
+	return wh, nil
+}
+
+// Retrieve returns a Webhook
+func (store *MockWebhookStore) Retrieve(id uuid.UUID) (*hvs.Webhook, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if wh, ok := store.webhookStore[id]; ok {
+		return &wh, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns a filtered list of webhooks as per the provided WebhookFilterCriteria
+func (store *MockWebhookStore) Search(criteria *models.WebhookFilterCriteria) ([]hvs.Webhook, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	webhooks := []hvs.Webhook{}
+	for _, wh := range store.webhookStore {
+		if criteria != nil {
+			if criteria.Id != uuid.Nil && criteria.Id != wh.Id {
+				continue
+			}
+			if criteria.UrlContains != "" && !strings.Contains(wh.Url, criteria.UrlContains) {
+				continue
+			}
+		}
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+// Delete deletes a Webhook
+func (store *MockWebhookStore) Delete(id uuid.UUID) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if _, ok := store.webhookStore[id]; !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	delete(store.webhookStore, id)
+	return nil
+}
+
+// NewMockWebhookStore provides one dummy webhook
+func NewMockWebhookStore() *MockWebhookStore {
+	store := &MockWebhookStore{webhookStore: make(map[uuid.UUID]hvs.Webhook)}
+	store.Create(&hvs.Webhook{
+		Id:          uuid.MustParse("1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01"),
+		Url:         "https://ihub.com:19082/webhook",
+		Secret:      "0123456789abcdef0123456789abcdef",
+		Description: "Integration hub",
+	})
+	return store
+}
+
+// MockWebhookDeadLetterStore provides a mocked implementation of interface domain.WebhookDeadLetterStore
+type MockWebhookDeadLetterStore struct {
+	lock        sync.Mutex
+	DeadLetters []models.WebhookDeadLetter
+}
+
+// Create inserts a WebhookDeadLetter
+func (store *MockWebhookDeadLetterStore) Create(dl *models.WebhookDeadLetter) (*models.WebhookDeadLetter, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if dl.ID == uuid.Nil {
+		dl.ID = uuid.New()
+	}
+	dl.CreatedAt = time.Now()
+	store.DeadLetters = append(store.DeadLetters, *dl)
+	return dl, nil
+}
+
+// Search returns a filtered list of dead letters as per the provided WebhookDeadLetterFilterCriteria
+func (store *MockWebhookDeadLetterStore) Search(criteria *models.WebhookDeadLetterFilterCriteria) ([]models.WebhookDeadLetter, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	deadLetters := []models.WebhookDeadLetter{}
+	for _, dl := range store.DeadLetters {
+		if criteria != nil {
+			if criteria.WebhookId != uuid.Nil && criteria.WebhookId != dl.WebhookID {
+				continue
+			}
+			if criteria.EventId != uuid.Nil && criteria.EventId != dl.EventID {
+				continue
+			}
+			if criteria.Limit > 0 && len(deadLetters) == criteria.Limit {
+				break
+			}
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	return deadLetters, nil
+}
+
+func NewMockWebhookDeadLetterStore() *MockWebhookDeadLetterStore {
+	return &MockWebhookDeadLetterStore{}
+}
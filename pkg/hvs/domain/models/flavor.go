@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"github.com/google/uuid"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
+	"time"
 )
 
 type flavors []hvs.Flavor
@@ -23,6 +25,25 @@ type FlavorCreateRequest struct {
 	FlavorParts            []cf.FlavorPart            `json:"partial_flavor_types,omitempty"`
 }
 
+// FlavorCaptureRequest holds the golden host to capture candidate flavors from. The host is given by the id of a
+// registered host or by a connection string
+type FlavorCaptureRequest struct {
+	HostId           uuid.UUID       `json:"host_id,omitempty"`
+	ConnectionString string          `json:"connection_string,omitempty"`
+	FlavorgroupNames []string        `json:"flavorgroup_names,omitempty"`
+	FlavorParts      []cf.FlavorPart `json:"partial_flavor_types,omitempty"`
+}
+
+// FlavorEvaluateRequest holds the candidate flavors and the host to evaluate them against. The host is given by
+// its id, an inline host manifest or both. The flavorgroup provides the match policies and, when its id is set,
+// the flavors already in the flavorgroup
+type FlavorEvaluateRequest struct {
+	HostId           uuid.UUID            `json:"host_id,omitempty"`
+	HostManifest     *types.HostManifest  `json:"host_manifest,omitempty"`
+	FlavorCollection hvs.FlavorCollection `json:"flavor_collection,omitempty"`
+	Flavorgroup      *hvs.FlavorGroup     `json:"flavorgroup,omitempty"`
+}
+
 type FlavorFilterCriteria struct {
 	Ids           []uuid.UUID
 	Key           string
@@ -35,6 +56,9 @@ type FlavorVerificationFC struct {
 	FlavorFC              FlavorFilterCriteria
 	FlavorMeta            map[cf.FlavorPart][]FlavorMetaKv
 	FlavorPartsWithLatest map[cf.FlavorPart]bool
+	// UsableAt restricts the search to the flavors whose lifecycle allows them to be used for verification at
+	// the given time
+	UsableAt *time.Time
 }
 
 type FlavorMetaKv struct {
@@ -4,7 +4,10 @@
  */
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
 
 type HostFilterCriteria struct {
 	Id               uuid.UUID
@@ -14,4 +17,5 @@ type HostFilterCriteria struct {
 	Key              string
 	Value            string
 	IdList           []uuid.UUID
+	LabelSelector    hvs.LabelSelector
 }
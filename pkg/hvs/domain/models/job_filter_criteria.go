@@ -0,0 +1,18 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+type JobFilterCriteria struct {
+	Type   hvs.JobType
+	State  hvs.JobState
+	HostId uuid.UUID
+	Limit  int
+}
@@ -7,6 +7,7 @@ package models
 
 import (
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"time"
 )
 
@@ -21,9 +22,16 @@ type ReportFilterCriteria struct {
 	ToDate         time.Time
 	LatestPerHost  bool
 	Limit          int
+	LabelSelector  hvs.LabelSelector
 }
 
 type ReportLocator struct {
 	ID          uuid.UUID
 	HostID      uuid.UUID
-}
\ No newline at end of file
+}
+// ReportStreamFilterCriteria selects the reports delivered on the report stream
+type ReportStreamFilterCriteria struct {
+	HostID        uuid.UUID
+	FlavorgroupID uuid.UUID
+	Trusted       *bool
+}
@@ -0,0 +1,14 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/google/uuid"
+
+type ScheduleFilterCriteria struct {
+	Id            uuid.UUID
+	HostId        uuid.UUID
+	FlavorgroupId uuid.UUID
+}
@@ -21,6 +21,23 @@ const (
 	ReportCreationDone
 )
 
+var stageToString = [...]string{
+	DoNotUse:              "UNKNOWN",
+	FlavorVerifyQueued:    "FLAVOR_VERIFY_QUEUED",
+	FlavorVerifyStarted:   "FLAVOR_VERIFY_STARTED",
+	GetHostDataQueued:     "GET_HOST_DATA_QUEUED",
+	GetHostDataStarted:    "GET_HOST_DATA_STARTED",
+	ReportCreationStarted: "REPORT_CREATION_STARTED",
+	ReportCreationDone:    "REPORT_CREATION_DONE",
+}
+
+func (stg Stage) String() string {
+	if stg < DoNotUse || stg > ReportCreationDone {
+		return stageToString[DoNotUse]
+	}
+	return stageToString[stg]
+}
+
 type key int
 
 const stageKey = 0
@@ -0,0 +1,30 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeadLetter records a notification that could not be delivered to a webhook after all retries
+type WebhookDeadLetter struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	WebhookID uuid.UUID `json:"webhook_id"`
+	// swagger:strfmt uuid
+	EventID   uuid.UUID `json:"event_id"`
+	Payload   string    `json:"payload"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created"`
+}
+
+type WebhookDeadLetterCollection struct {
+	DeadLetters []WebhookDeadLetter `json:"dead_letters"`
+}
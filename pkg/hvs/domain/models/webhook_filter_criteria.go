@@ -0,0 +1,19 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/google/uuid"
+
+type WebhookFilterCriteria struct {
+	Id          uuid.UUID
+	UrlContains string
+}
+
+type WebhookDeadLetterFilterCriteria struct {
+	WebhookId uuid.UUID
+	EventId   uuid.UUID
+	Limit     int
+}
@@ -7,6 +7,7 @@ package hvs
 import (
 	"fmt"
 
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/version"
 )
 
@@ -22,9 +23,22 @@ Avaliable Commands:
 	stop                   Stop hvs
 	erase-data             Reset all tables in database and create default flavor groups
 	config-db-rotation     Configure database table rotaition for audit log table, reference db_rotation.sql in documents
+	verify-manifest        Verify a host manifest against signed flavors without a running hvs
 	uninstall [--purge]    Uninstall hvs
 		--purge            all configuration and data files will be removed if this flag is set
 
+Usage of hvs verify-manifest:
+	hvs verify-manifest --manifest <file> --flavor <file> [--flavor <file>...] [--skip-flavor-signature-verification]
+	                    [--privacy-ca <file>] [--flavor-signing-cert <file>] [--flavor-ca <file|dir>] [--tag-ca <file>]
+		--manifest <file>                       host manifest json file
+		--flavor <file>                         signed flavor json file, can be a signed flavor, a list or a collection of signed flavors
+		--skip-flavor-signature-verification    do not verify the signature of the flavors
+		--privacy-ca <file>                     privacy CA certificates, defaults to ` + constants.PrivacyCACertFile + `
+		--flavor-signing-cert <file>            flavor signing certificate and chain, defaults to ` + constants.FlavorSigningCertFile + `
+		--flavor-ca <file|dir>                  flavor root CA certificates, defaults to ` + constants.TrustedRootCACertsDir + `
+		--tag-ca <file>                         asset tag CA certificates, defaults to ` + constants.TagCACertFile + `
+	The trust report is printed to the console, the command exits with an error if the host manifest is not trusted
+
 Usage of hvs setup:
 	hvs setup <task> [--help] [--force] [-f <answer-file>]
 		--help                      show help message for setup task
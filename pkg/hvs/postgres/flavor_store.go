@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	fc "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	fm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -51,6 +52,28 @@ func (f *FlavorStore) Create(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor,
 	return signedFlavor, nil
 }
 
+// update the content and signature of a flavor
+func (f *FlavorStore) Update(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	defaultLog.Trace("postgres/flavor_store:Update() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Update() Leaving")
+	if signedFlavor == nil || signedFlavor.Signature == "" || signedFlavor.Flavor.Meta.ID == uuid.Nil {
+		return nil, errors.New("postgres/flavor_store:Update()- invalid input : must have content, signature and the id for the flavor")
+	}
+
+	dbf := flavor{
+		ID:        signedFlavor.Flavor.Meta.ID,
+		Content:   PGFlavorContent(signedFlavor.Flavor),
+		Signature: signedFlavor.Signature,
+	}
+	if db := f.Store.Db.Model(&dbf).Updates(&dbf); db.Error != nil || db.RowsAffected != 1 {
+		if db.Error != nil {
+			return nil, errors.Wrap(db.Error, "postgres/flavor_store:Update() failed to update flavor "+dbf.ID.String())
+		}
+		return nil, errors.New("postgres/flavor_store:Update() - no rows affected - Record not found = id : " + dbf.ID.String())
+	}
+	return signedFlavor, nil
+}
+
 func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.SignedFlavor, error) {
 	defaultLog.Trace("postgres/flavor_store:Search() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:Search() Leaving")
@@ -77,7 +100,7 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 			flavorFilter.FlavorPartsWithLatest = getFlavorPartsWithLatestMap(flavorFilter.FlavorFC.FlavorParts, flavorFilter.FlavorPartsWithLatest)
 		}
 		// add all flavor parts in list of flavor Parts
-		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest)
+		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest, flavorFilter.UsableAt)
 	}
 
 	if tx == nil {
@@ -103,7 +126,7 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 	return signedFlavors, nil
 }
 
-func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.UUID, flavorMetaInfo map[fc.FlavorPart][]models.FlavorMetaKv, flavorPartsWithLatest map[fc.FlavorPart]bool) *gorm.DB {
+func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.UUID, flavorMetaInfo map[fc.FlavorPart][]models.FlavorMetaKv, flavorPartsWithLatest map[fc.FlavorPart]bool, usableAt *time.Time) *gorm.DB {
 	defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Leaving")
 
@@ -112,6 +135,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 	var aTagQuery *gorm.DB
 	var softwareQuery *gorm.DB
 	var hostUniqueQuery *gorm.DB
+	var imaQuery *gorm.DB
 
 	if flavorPartsWithLatest != nil && len(flavorPartsWithLatest) >= 1 {
 		for flavorPart := range flavorPartsWithLatest {
@@ -124,6 +148,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, pfQueryAttribute := range pfQueryAttributes {
 					biosQuery = biosQuery.Where(convertToPgJsonqueryString("f.content", pfQueryAttribute.Key) + " = ?", pfQueryAttribute.Value)
 				}
+				biosQuery = buildFlavorLifecycleQueryString(biosQuery, usableAt)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartPlatform] {
 					biosQuery = biosQuery.Order("f.created_at desc").Limit(1)
@@ -137,6 +162,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, osfQueryAttribute := range osfQueryAttributes {
 					osQuery = osQuery.Where(convertToPgJsonqueryString("f.content", osfQueryAttribute.Key) + " = ?", osfQueryAttribute.Value)
 				}
+				osQuery = buildFlavorLifecycleQueryString(osQuery, usableAt)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartOs] {
 					osQuery = osQuery.Order("f.created_at desc").Limit(1)
@@ -152,6 +178,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, hufQueryAttribute := range hufQueryAttributes {
 					hostUniqueQuery = hostUniqueQuery.Where(convertToPgJsonqueryString("f.content", hufQueryAttribute.Key) + " = ?", hufQueryAttribute.Value)
 				}
+				hostUniqueQuery = buildFlavorLifecycleQueryString(hostUniqueQuery, usableAt)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartHostUnique] {
 					hostUniqueQuery = hostUniqueQuery.Order("f.created_at desc").Limit(1)
@@ -165,6 +192,7 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, sfQueryAttribute := range sfQueryAttributes {
 					softwareQuery = softwareQuery.Where("f.label IN (?)", sfQueryAttribute.Value.([]string))
 				}
+				softwareQuery = buildFlavorLifecycleQueryString(softwareQuery, usableAt)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartSoftware] {
 					softwareQuery = softwareQuery.Order("f.created_at desc").Limit(1)
@@ -179,11 +207,21 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 				for _, atfQueryAttribute := range atfQueryAttributes {
 					aTagQuery = aTagQuery.Where(convertToPgJsonqueryString("f.content", atfQueryAttribute.Key) + " = ?", atfQueryAttribute.Value)
 				}
+				aTagQuery = buildFlavorLifecycleQueryString(aTagQuery, usableAt)
 				// apply limit if latest
 				if flavorPartsWithLatest[fc.FlavorPartAssetTag] {
 					aTagQuery = aTagQuery.Order("f.created_at desc").Limit(1)
 				}
 
+			case fc.FlavorPartIma:
+				imaQuery = f.Store.Db
+				imaQuery = buildFlavorPartQueryStringWithFlavorParts(fc.FlavorPartIma.String(), fgId.String(), imaQuery)
+				imaQuery = buildFlavorLifecycleQueryString(imaQuery, usableAt)
+				// apply limit if latest
+				if flavorPartsWithLatest[fc.FlavorPartIma] {
+					imaQuery = imaQuery.Order("f.created_at desc").Limit(1)
+				}
+
 			default:
 				defaultLog.Error("postgres/flavor_store:buildMultipleFlavorPartQueryString() Invalid flavor part")
 				return nil
@@ -233,12 +271,23 @@ func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.
 			subQuery = subQuery.Where("f.id IN ?", hostUniqueSubQuery)
 		}
 	}
+	// add IMA query to sub query
+	if imaQuery != nil {
+		imaSubQuery := imaQuery.SubQuery()
+		if biosQuery != nil || osQuery != nil || softwareQuery != nil || aTagQuery != nil || hostUniqueQuery != nil {
+			subQuery = subQuery.Or("f.id IN ?", imaSubQuery)
+		} else {
+			subQuery = subQuery.Where("f.id IN ?", imaSubQuery)
+		}
+	}
 	// check if none of the flavor part queries are not formed,
-	if subQuery != nil && (biosQuery != nil || aTagQuery != nil || softwareQuery != nil || hostUniqueQuery != nil || osQuery != nil) {
+	if subQuery != nil && (biosQuery != nil || aTagQuery != nil || softwareQuery != nil || hostUniqueQuery != nil || osQuery != nil || imaQuery != nil) {
 		tx = subQuery
 	} else if fgId != uuid.Nil {
-		fgSubQuery := buildFlavorPartQueryStringWithFlavorgroup(fgId.String(), tx).SubQuery()
+		fgSubQuery := buildFlavorLifecycleQueryString(buildFlavorPartQueryStringWithFlavorgroup(fgId.String(), tx), usableAt).SubQuery()
 		tx = tx.Where("f.id IN ?", fgSubQuery)
+	} else {
+		tx = buildFlavorLifecycleQueryString(tx, usableAt)
 	}
 	return tx
 }
@@ -253,6 +302,22 @@ func convertToPgJsonqueryString(queryHead string, jsonKeyPath string) string {
 	return jsonQueryStr
 }
 
+// buildFlavorLifecycleQueryString restricts the query to the flavors that can be used for verification at the given
+// time, flavors without lifecycle are always used
+func buildFlavorLifecycleQueryString(tx *gorm.DB, usableAt *time.Time) *gorm.DB {
+	if usableAt == nil {
+		return tx
+	}
+	state := convertToPgJsonqueryString("f.content", "meta.lifecycle.state")
+	notBefore := convertToPgJsonqueryString("f.content", "meta.lifecycle.not_before")
+	notAfter := convertToPgJsonqueryString("f.content", "meta.lifecycle.not_after")
+	tx = tx.Where(fmt.Sprintf("(%s IS NULL OR %s IN (?))", state, state),
+		[]string{string(fm.FlavorStateActive), string(fm.FlavorStateDeprecated)})
+	tx = tx.Where(fmt.Sprintf("(%s IS NULL OR CAST(%s AS TIMESTAMPTZ) <= ?)", notBefore, notBefore), *usableAt)
+	tx = tx.Where(fmt.Sprintf("(%s IS NULL OR CAST(%s AS TIMESTAMPTZ) > ?)", notAfter, notAfter), *usableAt)
+	return tx
+}
+
 func buildFlavorPartQueryStringWithFlavorParts(flavorpart, flavorgroupId string, tx *gorm.DB) *gorm.DB {
 	defaultLog.Trace("postgres/flavor_store:buildFlavorPartQueryStringWithFlavorParts() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:buildFlavorPartQueryStringWithFlavorParts() Leaving")
@@ -376,15 +441,18 @@ func (f *FlavorStore) GetFlavorTypesInFlavorgroup(flvGrpId uuid.UUID, flvParts [
 	return flavorTypesInFlavorGroup, nil
 }
 
-//Check whether flavors exists for given flavorPart and hardware uuid, associated with host_unique flavorgroup
+//Check whether flavors usable for verification exists for given flavorPart and hardware uuid, associated with
+//host_unique flavorgroup
 func (f *FlavorStore) isHostHavingFlavorType(hwId, flavorType string) (bool, error) {
 	var tx *gorm.DB
 	var count int
-	tx = f.Store.Db.Model(&flavor{}).Joins("INNER JOIN flavorgroup_flavor as l ON flavor.id = l.flavor_id").
+	now := time.Now()
+	tx = f.Store.Db.Table("flavor f").Joins("INNER JOIN flavorgroup_flavor as l ON f.id = l.flavor_id").
 		Joins("INNER JOIN flavor_group as fg ON l.flavorgroup_id = fg.id").
 		Where("fg.name = 'host_unique'").
-		Where(convertToPgJsonqueryString("flavor.content", "meta.description.flavor_part") + " = ?", flavorType).
-		Where("LOWER(flavor.content -> 'meta' -> 'description' ->> 'hardware_uuid') = ?", strings.ToLower(hwId))
+		Where(convertToPgJsonqueryString("f.content", "meta.description.flavor_part") + " = ?", flavorType).
+		Where("LOWER(f.content -> 'meta' -> 'description' ->> 'hardware_uuid') = ?", strings.ToLower(hwId))
+	tx = buildFlavorLifecycleQueryString(tx, &now)
 
 	if err := tx.Count(&count).Error; err != nil {
 		return false, errors.Wrap(err, "postgres/flavor_store:isHostHavingFlavorType() failed to execute query")
@@ -396,18 +464,20 @@ func (f *FlavorStore) isHostHavingFlavorType(hwId, flavorType string) (bool, err
 	return false, nil
 }
 
-// Checks whether any flavors exists with given flavorPart and associated with flavorgroup for given flavorgroup Id fgId
-// and which has policies with given flavorPart.
+// Checks whether any flavors usable for verification exists with given flavorPart and associated with flavorgroup for
+// given flavorgroup Id fgId and which has policies with given flavorPart.
 func (f *FlavorStore) flavorgroupContainsFlavorType(fgId, flavorPart string) (bool, error) {
 	var tx *gorm.DB
 	var count int
 
-	tx = f.Store.Db.Model(&flavor{}).Joins("INNER JOIN flavorgroup_flavor as l ON flavor.id = l.flavor_id").
+	now := time.Now()
+	tx = f.Store.Db.Table("flavor f").Joins("INNER JOIN flavorgroup_flavor as l ON f.id = l.flavor_id").
 		Joins("INNER JOIN flavor_group as fg ON l.flavorgroup_id = fg.id, jsonb_array_elements(fg.flavor_type_match_policy) policies").
 		Where("fg.id = ?", fgId).
 		Where("fg.name != ?", models.FlavorGroupsHostUnique.String()).
 		Where("policies ->> 'flavor_part' = ?", flavorPart).
-		Where(convertToPgJsonqueryString("flavor.content", "meta.description.flavor_part") + " = ?", flavorPart)
+		Where(convertToPgJsonqueryString("f.content", "meta.description.flavor_part") + " = ?", flavorPart)
+	tx = buildFlavorLifecycleQueryString(tx, &now)
 	if err := tx.Count(&count).Error; err != nil {
 		return false, errors.Wrap(err, "postgres/flavor_store:flavorgroupContainsFlavorType() failed to execute query")
 	}
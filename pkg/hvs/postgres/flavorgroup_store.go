@@ -31,6 +31,8 @@ func (f *FlavorGroupStore) Create(fg *hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		ID:                    fg.ID,
 		Name:                  fg.Name,
 		FlavorTypeMatchPolicy: PGFlavorMatchPolicies(fg.MatchPolicies),
+		Policy:                (*PGFlavorGroupPolicy)(fg.Policy),
+		HostSelector:          fg.HostSelector,
 	}
 
 	if err := f.Store.Db.Create(&dbFlavorGroup).Error; err != nil {
@@ -44,10 +46,12 @@ func (f *FlavorGroupStore) Retrieve(flavorGroupId uuid.UUID) (*hvs.FlavorGroup,
 	defer defaultLog.Trace("postgres/flavorgroup_store:Retrieve() Leaving")
 
 	fg := hvs.FlavorGroup{}
+	var policy *PGFlavorGroupPolicy
 	row := f.Store.Db.Model(&flavorGroup{}).Where(&flavorGroup{ID: flavorGroupId}).Row()
-	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &policy, &fg.HostSelector); err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Retrieve() failed to scan record")
 	}
+	fg.Policy = (*hvs.FlavorGroupPolicy)(policy)
 	return &fg, nil
 }
 
@@ -83,9 +87,11 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 	flavorgroupList := []hvs.FlavorGroup{}
 	for rows.Next() {
 		fg := hvs.FlavorGroup{}
-		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies)); err != nil {
+		var policy *PGFlavorGroupPolicy
+		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &policy, &fg.HostSelector); err != nil {
 			return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to scan record")
 		}
+		fg.Policy = (*hvs.FlavorGroupPolicy)(policy)
 		flavorgroupList = append(flavorgroupList, fg)
 	}
 
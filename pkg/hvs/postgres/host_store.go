@@ -33,6 +33,7 @@ func (hs *HostStore) Create(h *hvs.Host) (*hvs.Host, error) {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           PGHostLabels(h.Labels),
 	}
 
 	if h.HardwareUuid != nil {
@@ -51,7 +52,7 @@ func (hs *HostStore) Retrieve(id uuid.UUID) (*hvs.Host, error) {
 
 	h := hvs.Host{}
 	row := hs.Store.Db.Model(&host{}).Where(&host{Id: id}).Row()
-	if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid); err != nil {
+	if err := row.Scan(&h.Id, &h.HostName, &h.Description, &h.ConnectionString, &h.HardwareUuid, (*PGHostLabels)(&h.Labels)); err != nil {
 		return nil, errors.Wrap(err, "postgres/host_store:Retrieve() failed to scan record")
 	}
 	return &h, nil
@@ -66,6 +67,7 @@ func (hs *HostStore) Update(h *hvs.Host) error {
 		Name:             h.HostName,
 		Description:      h.Description,
 		ConnectionString: h.ConnectionString,
+		Labels:           PGHostLabels(h.Labels),
 	}
 
 	if h.HardwareUuid != nil {
@@ -111,7 +113,7 @@ func (hs *HostStore) Search(criteria *models.HostFilterCriteria) ([]*hvs.Host, e
 	hosts := []*hvs.Host{}
 	for rows.Next() {
 		host := hvs.Host{}
-		if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid); err != nil {
+		if err := rows.Scan(&host.Id, &host.HostName, &host.Description, &host.ConnectionString, &host.HardwareUuid, (*PGHostLabels)(&host.Labels)); err != nil {
 			return nil, errors.Wrap(err, "postgres/host_store:Search() failed to scan record")
 		}
 		hosts = append(hosts, &host)
@@ -145,9 +147,46 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 		tx = tx.Where("id IN (?)", criteria.IdList)
 	}
 
+	if len(criteria.LabelSelector) > 0 {
+		query, args := buildLabelSelectorQuery("labels", criteria.LabelSelector)
+		tx = tx.Where(query, args...)
+	}
 	return tx
 }
 
+// helper function to build the condition matching the host labels stored in labelsColumn against a label selector.
+// A label that is not set is NULL, so that it only meets the !=, notin and ! requirements
+func buildLabelSelectorQuery(labelsColumn string, selector hvs.LabelSelector) (string, []interface{}) {
+	defaultLog.Trace("postgres/host_store:buildLabelSelectorQuery() Entering")
+	defer defaultLog.Trace("postgres/host_store:buildLabelSelectorQuery() Leaving")
+
+	var conditions []string
+	var args []interface{}
+	for _, lr := range selector {
+		switch lr.Operator {
+		case hvs.LabelOperatorEquals:
+			conditions = append(conditions, labelsColumn+" ->> ? = ?")
+			args = append(args, lr.Key, lr.Values[0])
+		case hvs.LabelOperatorNotEquals:
+			conditions = append(conditions, "("+labelsColumn+" ->> ? IS NULL OR "+labelsColumn+" ->> ? != ?)")
+			args = append(args, lr.Key, lr.Key, lr.Values[0])
+		case hvs.LabelOperatorIn:
+			conditions = append(conditions, labelsColumn+" ->> ? IN (?)")
+			args = append(args, lr.Key, lr.Values)
+		case hvs.LabelOperatorNotIn:
+			conditions = append(conditions, "("+labelsColumn+" ->> ? IS NULL OR "+labelsColumn+" ->> ? NOT IN (?))")
+			args = append(args, lr.Key, lr.Key, lr.Values)
+		case hvs.LabelOperatorExists:
+			conditions = append(conditions, labelsColumn+" ->> ? IS NOT NULL")
+			args = append(args, lr.Key)
+		case hvs.LabelOperatorDoesNotExist:
+			conditions = append(conditions, labelsColumn+" ->> ? IS NULL")
+			args = append(args, lr.Key)
+		}
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
 func (hs *HostStore) AddFlavorgroups(hId uuid.UUID, fgIds []uuid.UUID) error {
 	defaultLog.Trace("postgres/host_store:AddFlavorgroups() Entering")
 	defer defaultLog.Trace("postgres/host_store:AddFlavorgroups() Leaving")
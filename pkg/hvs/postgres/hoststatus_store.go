@@ -233,6 +233,7 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 	defer defaultLog.Trace("postgres/hoststatus_store:buildHostStatusSearchQuery() Leaving")
 
 	var tableJoinString, additionalOptionsQueryString string
+	var args []interface{}
 
 	// define joins
 	auditLogAbbrv := "au"
@@ -291,6 +292,15 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 		additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, hostStateQueryString)
 	}
 
+	//Build label selector partial query string and add it to the additional options query string, the label values are
+	//passed as query arguments
+	if len(hsFilter.LabelSelector) > 0 {
+		var labelSelectorQueryString string
+		labelSelectorQueryString, args = buildLabelSelectorQuery("labels", hsFilter.LabelSelector)
+		hostLabelsQueryString := fmt.Sprintf("%s.data -> 'Columns' -> 1 ->> 'Value' IN (SELECT CAST(id AS VARCHAR) FROM host WHERE %s)", auditLogAbbrv, labelSelectorQueryString)
+		additionalOptionsQueryString = fmt.Sprintf("%s AND %s", additionalOptionsQueryString, hostLabelsQueryString)
+	}
+
 	//Build host status ID partial query string and add it to the additional options query string
 	if hsFilter.Id != uuid.Nil {
 		hostStatusIDQueryString := fmt.Sprintf("%s.entity_id = '%s'", auditLogAbbrv, hsFilter.Id.String())
@@ -337,7 +347,7 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 	}
 
 	// finalize query
-	tx = tx.Raw(formattedQuery).Limit(hsFilter.Limit)
+	tx = tx.Raw(formattedQuery, args...).Limit(hsFilter.Limit)
 
 	return tx
 }
@@ -390,6 +400,12 @@ func buildLatestHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFi
 		tx = tx.Where(`status @> '{"host_state": "` + strings.ToUpper(hsFilter.HostStatus) + `"}'`)
 	}
 
+	// Host Labels
+	if len(hsFilter.LabelSelector) > 0 {
+		query, args := buildLabelSelectorQuery("labels", hsFilter.LabelSelector)
+		tx = tx.Where("host_status.host_id IN (SELECT id FROM host WHERE "+query+")", args...)
+	}
+
 	// Apply default row limit when called internally
 	if hsFilter.Limit == 0 {
 		hsFilter.Limit = constants.DefaultSearchResultRowLimit
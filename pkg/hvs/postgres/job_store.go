@@ -0,0 +1,234 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type JobStore struct {
+	Store *DataStore
+}
+
+func NewJobStore(store *DataStore) *JobStore {
+	return &JobStore{store}
+}
+
+// Create stores the job with all its results, the results are stored in a single transaction with the job
+func (js *JobStore) Create(j *hvs.Job) (*hvs.Job, error) {
+	defaultLog.Trace("postgres/job_store:Create() Entering")
+	defer defaultLog.Trace("postgres/job_store:Create() Leaving")
+
+	if j.Id == uuid.Nil {
+		j.Id = uuid.New()
+	}
+	j.CreatedAt = time.Now()
+
+	tx := js.Store.Db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Create() Failed to begin transaction")
+	}
+	dbJob := job{
+		Id:          j.Id,
+		Type:        string(j.Type),
+		State:       string(j.State),
+		CreatedAt:   j.CreatedAt,
+		CompletedAt: j.CompletedAt,
+	}
+	if err := tx.Create(&dbJob).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(err, "postgres/job_store:Create() Failed to create job")
+	}
+
+	if len(j.Results) > 0 {
+		var jrValues []string
+		var jrValueArgs []interface{}
+		for position, result := range j.Results {
+			jrValues = append(jrValues, "(?, ?, ?, ?, ?, ?, ?, ?)")
+			jrValueArgs = append(jrValueArgs, j.Id, position, result.HostId, result.HostName, string(result.State),
+				result.Trusted, result.Error, result.CompletedAt)
+		}
+		insertQuery := fmt.Sprintf("INSERT INTO job_result (job_id, position, host_id, host_name, state, trusted, error, completed) VALUES %s",
+			strings.Join(jrValues, ","))
+		if err := tx.Exec(insertQuery, jrValueArgs...).Error; err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(err, "postgres/job_store:Create() Failed to create job results")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Create() Failed to commit transaction")
+	}
+	return j, nil
+}
+
+// Retrieve returns the job with its results in the order of the request
+func (js *JobStore) Retrieve(id uuid.UUID) (*hvs.Job, error) {
+	defaultLog.Trace("postgres/job_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/job_store:Retrieve() Leaving")
+
+	dbJob := job{}
+	row := js.Store.Db.Model(&job{}).Where(&job{Id: id}).Row()
+	if err := row.Scan(&dbJob.Id, &dbJob.Type, &dbJob.State, &dbJob.CreatedAt, &dbJob.CompletedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Retrieve() Failed to scan record")
+	}
+
+	rows, err := js.Store.Db.Model(&jobResult{}).Where(&jobResult{JobId: id}).Order("position").Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Retrieve() Failed to retrieve job results from db")
+	}
+	defer rows.Close()
+
+	results := []hvs.JobResult{}
+	for rows.Next() {
+		dbResult := jobResult{}
+		if err := rows.Scan(&dbResult.JobId, &dbResult.Position, &dbResult.HostId, &dbResult.HostName,
+			&dbResult.State, &dbResult.Trusted, &dbResult.Error, &dbResult.CompletedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/job_store:Retrieve() Failed to scan job result record")
+		}
+		results = append(results, hvs.JobResult{
+			HostId:      dbResult.HostId,
+			HostName:    dbResult.HostName,
+			State:       hvs.JobResultState(dbResult.State),
+			Trusted:     dbResult.Trusted,
+			Error:       dbResult.Error,
+			CompletedAt: dbResult.CompletedAt,
+		})
+	}
+
+	return &hvs.Job{
+		Id:          dbJob.Id,
+		Type:        hvs.JobType(dbJob.Type),
+		State:       hvs.JobState(dbJob.State),
+		Results:     results,
+		CreatedAt:   dbJob.CreatedAt,
+		CompletedAt: dbJob.CompletedAt,
+	}, nil
+}
+
+// Search returns the jobs matching the criteria without their results, the latest jobs first
+func (js *JobStore) Search(criteria *models.JobFilterCriteria) ([]hvs.Job, error) {
+	defaultLog.Trace("postgres/job_store:Search() Entering")
+	defer defaultLog.Trace("postgres/job_store:Search() Leaving")
+
+	tx := buildJobSearchQuery(js.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/job_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/job_store:Search() Failed to retrieve records from db")
+	}
+	defer rows.Close()
+
+	jobs := []hvs.Job{}
+	for rows.Next() {
+		dbJob := job{}
+		if err := rows.Scan(&dbJob.Id, &dbJob.Type, &dbJob.State, &dbJob.CreatedAt, &dbJob.CompletedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/job_store:Search() Failed to scan record")
+		}
+		jobs = append(jobs, hvs.Job{
+			Id:          dbJob.Id,
+			Type:        hvs.JobType(dbJob.Type),
+			State:       hvs.JobState(dbJob.State),
+			CreatedAt:   dbJob.CreatedAt,
+			CompletedAt: dbJob.CompletedAt,
+		})
+	}
+	return jobs, nil
+}
+
+// Update stores the state and the completion time of the job, the results are updated by UpdateResult
+func (js *JobStore) Update(j *hvs.Job) error {
+	defaultLog.Trace("postgres/job_store:Update() Entering")
+	defer defaultLog.Trace("postgres/job_store:Update() Leaving")
+
+	if j.Id == uuid.Nil {
+		return errors.New("postgres/job_store:Update() Job ID must be specified")
+	}
+	db := js.Store.Db.Model(&job{}).Where(&job{Id: j.Id}).Updates(map[string]interface{}{
+		"state":     string(j.State),
+		"completed": j.CompletedAt,
+	})
+	if db.Error != nil {
+		return errors.Wrap(db.Error, "postgres/job_store:Update() Failed to update job")
+	}
+	if db.RowsAffected != 1 {
+		return errors.New("postgres/job_store:Update() - no rows affected - Record not found")
+	}
+	return nil
+}
+
+// UpdateResult stores the result of the host at the given position of the job
+func (js *JobStore) UpdateResult(jobId uuid.UUID, position int, result *hvs.JobResult) error {
+	defaultLog.Trace("postgres/job_store:UpdateResult() Entering")
+	defer defaultLog.Trace("postgres/job_store:UpdateResult() Leaving")
+
+	db := js.Store.Db.Model(&jobResult{}).Where("job_id = ? AND position = ?", jobId, position).Updates(map[string]interface{}{
+		"host_id":   result.HostId,
+		"host_name": result.HostName,
+		"state":     string(result.State),
+		"trusted":   result.Trusted,
+		"error":     result.Error,
+		"completed": result.CompletedAt,
+	})
+	if db.Error != nil {
+		return errors.Wrap(db.Error, "postgres/job_store:UpdateResult() Failed to update job result")
+	}
+	if db.RowsAffected != 1 {
+		return errors.New("postgres/job_store:UpdateResult() - no rows affected - Record not found")
+	}
+	return nil
+}
+
+// DeleteCompletedBefore deletes the jobs that ended before the given time with their results
+func (js *JobStore) DeleteCompletedBefore(before time.Time) error {
+	defaultLog.Trace("postgres/job_store:DeleteCompletedBefore() Entering")
+	defer defaultLog.Trace("postgres/job_store:DeleteCompletedBefore() Leaving")
+
+	if err := js.Store.Db.Where("completed IS NOT NULL AND completed < ?", before).Delete(&job{}).Error; err != nil {
+		return errors.Wrap(err, "postgres/job_store:DeleteCompletedBefore() Failed to delete jobs")
+	}
+	return nil
+}
+
+// helper function to build the query object for a job search.
+func buildJobSearchQuery(tx *gorm.DB, criteria *models.JobFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/job_store:buildJobSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/job_store:buildJobSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&job{}).Order("created desc")
+	if criteria == nil {
+		return tx
+	}
+
+	if criteria.Type != "" {
+		tx = tx.Where("type = ?", string(criteria.Type))
+	}
+	if criteria.State != "" {
+		tx = tx.Where("state = ?", string(criteria.State))
+	}
+	if criteria.HostId != uuid.Nil {
+		tx = tx.Where("id IN (SELECT job_id FROM job_result WHERE host_id = ?)", criteria.HostId)
+	}
+	if criteria.Limit > 0 {
+		tx = tx.Limit(criteria.Limit)
+	}
+	return tx
+}
@@ -20,14 +20,19 @@ import (
 type (
 	PGJsonStrMap            map[string]interface{}
 	PGFlavorMatchPolicies   hvs.FlavorMatchPolicies
+	PGFlavorGroupPolicy     hvs.FlavorGroupPolicy
 	PGHostManifest          types.HostManifest
 	PGHostStatusInformation hvs.HostStatusInformation
 	PGFlavorContent         hvs.Flavor
+	PGMaintenanceWindows    []hvs.MaintenanceWindow
+	PGHostLabels            map[string]string
 
 	flavorGroup struct {
 		ID                    uuid.UUID             `json:"id" gorm:"primary_key;type:uuid"`
 		Name                  string                `json:"name" gorm:"type:varchar(255);not null;index:idx_flavorgroup_name"`
 		FlavorTypeMatchPolicy PGFlavorMatchPolicies `json:"flavor_type_match_policy,omitempty" sql:"type:JSONB"`
+		Policy                *PGFlavorGroupPolicy  `json:"policy,omitempty" sql:"type:JSONB"`
+		HostSelector          string                `json:"host_selector,omitempty" gorm:"not null;default:''"`
 	}
 
 	flavor struct {
@@ -45,6 +50,7 @@ type (
 		Description      string
 		ConnectionString string        `gorm:"not null"`
 		HardwareUuid     models.HwUUID `gorm:"type:uuid;index:idx_host_hardware_uuid"`
+		Labels           PGHostLabels  `sql:"type:JSONB NOT NULL DEFAULT '{}'::JSONB"`
 	}
 
 	hostFlavorgroup struct {
@@ -132,6 +138,54 @@ type (
 		Data       PGAuditLogData `sql:"type:JSONB"`
 	}
 
+	webhook struct {
+		Id          uuid.UUID `gorm:"primary_key;type:uuid"`
+		Url         string    `gorm:"type:varchar(2048);not null"`
+		Secret      string    `gorm:"not null"`
+		Description string
+		CreatedAt   time.Time `gorm:"column:created;not null"`
+	}
+
+	webhookDeadLetter struct {
+		ID        uuid.UUID `gorm:"primary_key;type:uuid"`
+		WebhookID uuid.UUID `gorm:"type:uuid REFERENCES webhook(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;index:idx_webhook_dead_letter_webhook_id"`
+		EventID   uuid.UUID `gorm:"type:uuid;not null"`
+		Payload   string    `gorm:"not null" sql:"type:JSONB"`
+		Attempts  int
+		LastError string
+		CreatedAt time.Time `gorm:"column:created;not null"`
+	}
+
+	schedule struct {
+		Id                 uuid.UUID            `gorm:"primary_key;type:uuid"`
+		HostId             *uuid.UUID           `gorm:"type:uuid REFERENCES host(Id) ON UPDATE CASCADE ON DELETE CASCADE;unique_index:idx_schedule_host_id"`
+		FlavorgroupId      *uuid.UUID           `gorm:"type:uuid REFERENCES flavor_group(Id) ON UPDATE CASCADE ON DELETE CASCADE;unique_index:idx_schedule_flavorgroup_id"`
+		Interval           string               `gorm:"not null"`
+		Jitter             string
+		MaintenanceWindows PGMaintenanceWindows `sql:"type:JSONB"`
+		CreatedAt          time.Time            `gorm:"column:created;not null"`
+	}
+
+	job struct {
+		Id          uuid.UUID  `gorm:"primary_key;type:uuid"`
+		Type        string     `gorm:"not null"`
+		State       string     `gorm:"not null"`
+		CreatedAt   time.Time  `gorm:"column:created;not null"`
+		CompletedAt *time.Time `gorm:"column:completed"`
+	}
+
+	// the host ids of the job results are not references, the hosts deleted by a job are still in its results
+	jobResult struct {
+		JobId       uuid.UUID  `gorm:"type:uuid REFERENCES job(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;unique_index:idx_job_result_job_id_position"`
+		Position    int        `gorm:"not null;unique_index:idx_job_result_job_id_position"`
+		HostId      *uuid.UUID `gorm:"type:uuid"`
+		HostName    string
+		State       string `gorm:"not null"`
+		Trusted     *bool
+		Error       string
+		CompletedAt *time.Time `gorm:"column:completed"`
+	}
+
 	tagCertificate struct {
 		ID           uuid.UUID `gorm:"primary_key; type:uuid"`
 		HardwareUUID uuid.UUID `gorm:"not null; type:uuid; column:hardware_uuid"`
@@ -157,6 +211,21 @@ func (qp *PGJsonStrMap) Scan(value interface{}) error {
 	return json.Unmarshal(b, &qp)
 }
 
+func (hl PGHostLabels) Value() (driver.Value, error) {
+	if hl == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(hl)
+}
+
+func (hl *PGHostLabels) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGHostLabels_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &hl)
+}
+
 func (phm PGHostManifest) Value() (driver.Value, error) {
 	return json.Marshal(phm)
 }
@@ -196,6 +265,18 @@ func (fmp *PGFlavorMatchPolicies) Scan(value interface{}) error {
 	return json.Unmarshal(b, &fmp)
 }
 
+func (fgp PGFlavorGroupPolicy) Value() (driver.Value, error) {
+	return json.Marshal(fgp)
+}
+
+func (fgp *PGFlavorGroupPolicy) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGFlavorGroupPolicy_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &fgp)
+}
+
 func (trp PGTrustReport) Value() (driver.Value, error) {
 	return json.Marshal(trp)
 }
@@ -231,3 +312,15 @@ func (fl *PGFlavorContent) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(b, &fl)
 }
+
+func (mw PGMaintenanceWindows) Value() (driver.Value, error) {
+	return json.Marshal(mw)
+}
+
+func (mw *PGMaintenanceWindows) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGMaintenanceWindows_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &mw)
+}
@@ -139,7 +139,7 @@ func (ds *DataStore) Migrate() {
 
 	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, trustCache{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
 		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
-		queue{})
+		queue{}, webhook{}, webhookDeadLetter{}, schedule{}, job{}, jobResult{})
 }
 
 func (ds *DataStore) Close() {
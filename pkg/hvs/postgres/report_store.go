@@ -16,6 +16,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
@@ -159,7 +161,7 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 
 	var tx *gorm.DB
 	if fromDate.IsZero() && toDate.IsZero() && criteria.LatestPerHost {
-		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.Limit)
+		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.LabelSelector, criteria.Limit)
 
 		if tx == nil {
 			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
@@ -185,7 +187,7 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 
 		return reports, nil
 	} else {
-		tx = buildReportSearchQuery(r.Store.Db, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost, criteria.Limit)
+		tx = buildReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.LabelSelector, fromDate, toDate, latestPerHost, criteria.Limit)
 		if tx == nil {
 			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
 				" a gorm query object in HVSReport Search function.")
@@ -240,6 +242,128 @@ func (r *ReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime t
 	return hostIDs, nil
 }
 
+// SearchTrustHistory retrieves the reports of a host from the audit log in the order they were created. The
+// latest report created before fromDate is included since it sets the trust status of the host at fromDate.
+// The host manifests are stripped from the audit log data to limit the size of the history
+func (r *ReportStore) SearchTrustHistory(hostId uuid.UUID, fromDate, toDate time.Time) ([]models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_store:SearchTrustHistory() Entering")
+	defer defaultLog.Trace("postgres/report_store:SearchTrustHistory() Leaving")
+
+	query := "SELECT au.id, au.entity_id, au.entity_type, au.created, au.action, au.data #- '{columns,2,value,host_manifest}' " +
+		"FROM audit_log_entry au WHERE au.entity_type = 'report' AND au.data -> 'columns' -> 1 ->> 'value' = ? " +
+		"AND CAST(au.created AS TIMESTAMP) >= COALESCE((SELECT max(CAST(auj.created AS TIMESTAMP)) FROM audit_log_entry auj " +
+		"WHERE auj.entity_type = 'report' AND auj.data -> 'columns' -> 1 ->> 'value' = ? AND CAST(auj.created AS TIMESTAMP) < CAST(? AS TIMESTAMP)), CAST(? AS TIMESTAMP)) " +
+		"AND CAST(au.created AS TIMESTAMP) < CAST(? AS TIMESTAMP) ORDER BY au.created"
+	rows, err := r.Store.Db.Raw(query, hostId.String(), hostId.String(), fromDate, fromDate, toDate).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:SearchTrustHistory() failed to retrieve records from db")
+	}
+	defer rows.Close()
+
+	var reports []models.HVSReport
+	for rows.Next() {
+		result := models.AuditLogEntry{}
+		if err := rows.Scan(&result.ID, &result.EntityID, &result.EntityType, &result.CreatedAt, &result.Action, (*PGAuditLogData)(&result.Data)); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchTrustHistory() failed to scan record")
+		}
+		if reflect.DeepEqual(models.AuditTableData{}, result.Data) || len(result.Data.Columns) == 0 {
+			continue
+		}
+		hvsReport, err := auditlogEntryToReport(result)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchTrustHistory() convert auditlog entry into report")
+		}
+		reports = append(reports, *hvsReport)
+	}
+	return reports, nil
+}
+
+// trustHistoryQuery selects the reports of the hosts created before toDate and since the latest report created
+// before fromDate, with their overall trust status. A report is trusted when it has results and none of them has
+// faults, the same way as TrustReport.IsTrusted
+const trustHistoryQuery = "WITH report AS (SELECT au.id, au.data -> 'columns' -> 1 ->> 'value' AS host_id, CAST(au.created AS TIMESTAMP) AS created, " +
+	"CASE WHEN jsonb_typeof(au.data -> 'columns' -> 2 -> 'value' -> 'results') = 'array' THEN au.data -> 'columns' -> 2 -> 'value' -> 'results' ELSE '[]'::jsonb END AS results " +
+	"FROM audit_log_entry au WHERE au.entity_type = 'report' AND au.data -> 'columns' -> 1 ->> 'value' IN (?) AND CAST(au.created AS TIMESTAMP) < CAST(? AS TIMESTAMP)), " +
+	"history AS (SELECT r.id, r.host_id, r.created, r.results, r.created >= CAST(? AS TIMESTAMP) AS in_period, " +
+	"(r.results -> 0 IS NOT NULL AND NOT EXISTS (SELECT 1 FROM jsonb_array_elements(r.results) res WHERE res -> 'faults' -> 0 IS NOT NULL)) AS trusted " +
+	"FROM (SELECT report.*, max(report.created) FILTER (WHERE report.created < CAST(? AS TIMESTAMP)) OVER (PARTITION BY report.host_id) AS previous FROM report) r " +
+	"WHERE r.created >= COALESCE(r.previous, CAST(? AS TIMESTAMP))) "
+
+// SearchTrustAnalytics aggregates the trust history of the hosts in the database, so that the reports of large
+// fleets are not loaded. The intervals are computed the same way as the trust timeline of a host: consecutive reports
+// with the same trust status are compacted and the intervals are cut at the boundaries of the period
+func (r *ReportStore) SearchTrustAnalytics(hostIds []uuid.UUID, fromDate, toDate time.Time, top int) (*hvs.FlavorgroupTrustAnalytics, error) {
+	defaultLog.Trace("postgres/report_store:SearchTrustAnalytics() Entering")
+	defer defaultLog.Trace("postgres/report_store:SearchTrustAnalytics() Leaving")
+
+	analytics := hvs.FlavorgroupTrustAnalytics{Hosts: len(hostIds)}
+	if len(hostIds) == 0 {
+		return &analytics, nil
+	}
+	var ids []string
+	for _, id := range hostIds {
+		ids = append(ids, id.String())
+	}
+	historyParams := []interface{}{ids, toDate, fromDate, fromDate, fromDate}
+
+	query := trustHistoryQuery + ", trust_interval AS (SELECT t.host_id, t.trusted, t.interval_start, " +
+		"COALESCE(LEAD(t.interval_start) OVER (PARTITION BY t.host_id ORDER BY t.created), CAST(? AS TIMESTAMP)) AS interval_end " +
+		"FROM (SELECT h.host_id, h.trusted, h.created, GREATEST(h.created, CAST(? AS TIMESTAMP)) AS interval_start, " +
+		"h.trusted IS DISTINCT FROM LAG(h.trusted) OVER (PARTITION BY h.host_id ORDER BY h.created) AS changed FROM history h) t WHERE t.changed) " +
+		"SELECT (SELECT count(*) FROM history WHERE in_period), count(DISTINCT host_id), count(*), " +
+		"COALESCE(sum(EXTRACT(EPOCH FROM interval_end - interval_start)), 0) FROM trust_interval WHERE NOT trusted AND interval_end > interval_start"
+	var timeUntrusted float64
+	err := r.Store.Db.Raw(query, append(historyParams, toDate, fromDate)...).Row().
+		Scan(&analytics.Reports, &analytics.UntrustedHosts, &analytics.UntrustedIntervals, &timeUntrusted)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to aggregate the trust intervals")
+	}
+	if analytics.UntrustedIntervals > 0 {
+		analytics.MeanTimeUntrusted = timeUntrusted / float64(analytics.UntrustedIntervals)
+	}
+
+	// a rule or a PCR is only counted once per untrusted report
+	query = trustHistoryQuery + ", failure AS (SELECT DISTINCT h.id, h.host_id, COALESCE(res -> 'rule' ->> 'rule_name', '') AS rule_name " +
+		"FROM history h CROSS JOIN jsonb_array_elements(h.results) res WHERE h.in_period AND NOT h.trusted AND res -> 'faults' -> 0 IS NOT NULL) " +
+		"SELECT rule_name, count(*) AS reports, count(DISTINCT host_id) FROM failure GROUP BY rule_name ORDER BY reports DESC, rule_name COLLATE \"C\" LIMIT ?"
+	rows, err := r.Store.Db.Raw(query, append(historyParams, top)...).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to aggregate the failing rules")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		failure := hvs.RuleFailureCount{}
+		if err := rows.Scan(&failure.RuleName, &failure.Reports, &failure.Hosts); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to scan failing rule")
+		}
+		analytics.TopFailingRules = append(analytics.TopFailingRules, failure)
+	}
+
+	// the PCR index is stored as "pcr_N", it is only parsed to order the PCRs
+	query = trustHistoryQuery + ", failure AS (SELECT DISTINCT h.id, h.host_id, COALESCE(res -> 'rule' -> 'expected_pcr' ->> 'pcr_bank', '') AS pcr_bank, " +
+		"res -> 'rule' -> 'expected_pcr' ->> 'index' AS pcr_index FROM history h CROSS JOIN jsonb_array_elements(h.results) res " +
+		"WHERE h.in_period AND NOT h.trusted AND res -> 'faults' -> 0 IS NOT NULL AND res -> 'rule' -> 'expected_pcr' ->> 'index' IS NOT NULL) " +
+		"SELECT pcr_bank, pcr_index, count(*) AS reports, count(DISTINCT host_id) FROM failure GROUP BY pcr_bank, pcr_index " +
+		"ORDER BY reports DESC, pcr_bank COLLATE \"C\", CAST(substring(pcr_index from 'pcr_([0-9]+)') AS INTEGER) LIMIT ?"
+	pcrRows, err := r.Store.Db.Raw(query, append(historyParams, top)...).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to aggregate the failing PCRs")
+	}
+	defer pcrRows.Close()
+	for pcrRows.Next() {
+		failure := hvs.PcrFailureCount{}
+		var pcrIndex string
+		if err := pcrRows.Scan(&failure.PcrBank, &pcrIndex, &failure.Reports, &failure.Hosts); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to scan failing PCR")
+		}
+		if failure.PcrIndex, err = types.GetPcrIndexFromString(pcrIndex); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchTrustAnalytics() failed to parse failing PCR index")
+		}
+		analytics.TopFailingPcrs = append(analytics.TopFailingPcrs, failure)
+	}
+	return &analytics, nil
+}
+
 func auditlogEntryToReport(auRecord models.AuditLogEntry) (*models.HVSReport, error) {
 	defaultLog.Trace("postgres/report_store:auditlogEntryToReport() Entering")
 	defer defaultLog.Trace("postgres/report_store:auditlogEntryToReport() Leaving")
@@ -311,7 +435,7 @@ func (r *ReportStore) Delete(reportId uuid.UUID) error {
 }
 
 // buildReportSearchQuery is a helper function to build the query object for a report search.
-func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool, limit int) *gorm.DB {
+func buildReportSearchQuery(tx *gorm.DB, reportID, hostHardwareID, hostID uuid.UUID, hostName, hostState string, labelSelector hvs.LabelSelector, fromDate, toDate time.Time, latestPerHost bool, limit int) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Leaving")
 
@@ -321,20 +445,20 @@ func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostN
 	if latestPerHost {
 		entity := "auj"
 		txSubQuery := tx.Table("audit_log_entry auj").Select("entity_id, max(auj.created) AS max_date ")
-		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
+		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, reportID, hostHardwareID, hostID, entity, hostName, hostState, labelSelector, fromDate, toDate)
 		txSubQuery = txSubQuery.Group("entity_id")
 		subQuery := txSubQuery.SubQuery()
 		tx = tx.Table("audit_log_entry au").Select("au.*").Joins("INNER JOIN ? a ON a.entity_id = au.entity_id AND a.max_date = au.created", subQuery)
 	} else {
 		entity := "au"
 		tx = tx.Table("audit_log_entry au").Select("au.*")
-		tx = buildReportSearchQueryWithCriteria(tx, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
+		tx = buildReportSearchQueryWithCriteria(tx, reportID, hostHardwareID, hostID, entity, hostName, hostState, labelSelector, fromDate, toDate)
 	}
 	tx = tx.Limit(limit)
 	return tx
 }
 
-func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, entity, hostName string, hostState string, fromDate, toDate time.Time) *gorm.DB {
+func buildReportSearchQueryWithCriteria(tx *gorm.DB, reportID, hostHardwareID, hostID uuid.UUID, entity, hostName string, hostState string, labelSelector hvs.LabelSelector, fromDate, toDate time.Time) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Leaving")
 
@@ -349,6 +473,11 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 	//TODO rename after testing
 	tx = tx.Where(entity + ".entity_type = 'report'")
 
+	// reports that are no longer the latest for a host are only found in the audit log
+	if reportID != uuid.Nil {
+		tx = tx.Where(entity+".entity_id = ?", reportID)
+	}
+
 	if hostName != "" {
 		tx = tx.Where("h.name = ?", hostName)
 	}
@@ -365,6 +494,11 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 		tx = tx.Where("hs.status ->> 'host_state' = ?", strings.ToUpper(hostState))
 	}
 
+	if len(labelSelector) > 0 {
+		query, args := buildLabelSelectorQuery("labels", labelSelector)
+		tx = tx.Where(entity+".data -> 'columns' -> 1 ->> 'value' IN (SELECT CAST(id AS VARCHAR) FROM host WHERE "+query+")", args...)
+	}
+
 	if !fromDate.IsZero() {
 		tx = tx.Where("CAST("+entity+".created AS TIMESTAMP) >= CAST(? AS TIMESTAMP)", fromDate)
 	}
@@ -377,7 +511,7 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 }
 
 // buildLatestReportSearchQuery is a helper function to build the query object for a latest report search.
-func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, limit int) *gorm.DB {
+func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, labelSelector hvs.LabelSelector, limit int) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Leaving")
 
@@ -413,6 +547,11 @@ func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID
 		tx = tx.Where("host_id = ?", hostID.String())
 	}
 
+	if len(labelSelector) > 0 {
+		query, args := buildLabelSelectorQuery("labels", labelSelector)
+		tx = tx.Where("report.host_id IN (SELECT id FROM host WHERE "+query+")", args...)
+	}
+
 	tx = tx.Limit(limit)
 	return tx
 }
@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportStore_SearchTrustAnalytics_FailingPcrs(t *testing.T) {
+	dataStore, mock := postgres.NewSQLMockDataStore()
+	reportStore := postgres.NewReportStore(dataStore)
+
+	// the index of the expected PCR of a rule is stored the way it is marshalled into the trust report
+	expectedPcr, err := json.Marshal(types.Pcr{Index: types.PCR7, PcrBank: types.SHA256})
+	assert.NoError(t, err)
+	var storedPcr map[string]interface{}
+	assert.NoError(t, json.Unmarshal(expectedPcr, &storedPcr))
+
+	mock.ExpectQuery(`WITH report AS .* FROM trust_interval`).
+		WillReturnRows(sqlmock.NewRows([]string{"reports", "untrusted_hosts", "untrusted_intervals", "time_untrusted"}).
+			AddRow(3, 1, 1, "3600"))
+	mock.ExpectQuery(`WITH report AS .* GROUP BY rule_name`).
+		WillReturnRows(sqlmock.NewRows([]string{"rule_name", "reports", "hosts"}).
+			AddRow("com.intel.mtwilson.core.verifier.policy.rule.PcrMatchesConstant", 2, 1))
+	mock.ExpectQuery(`WITH report AS .* GROUP BY pcr_bank, pcr_index`).
+		WillReturnRows(sqlmock.NewRows([]string{"pcr_bank", "pcr_index", "reports", "hosts"}).
+			AddRow(storedPcr["pcr_bank"], storedPcr["index"], 2, 1))
+
+	to := time.Now()
+	analytics, err := reportStore.SearchTrustAnalytics([]uuid.UUID{uuid.New()}, to.AddDate(0, 0, -30), to, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, analytics.Hosts)
+	assert.Equal(t, 3, analytics.Reports)
+	assert.Equal(t, 3600.0, analytics.MeanTimeUntrusted)
+	assert.Len(t, analytics.TopFailingRules, 1)
+	assert.Len(t, analytics.TopFailingPcrs, 1)
+	assert.Equal(t, types.SHA256, analytics.TopFailingPcrs[0].PcrBank)
+	assert.Equal(t, types.PCR7, analytics.TopFailingPcrs[0].PcrIndex)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type ScheduleStore struct {
+	Store *DataStore
+}
+
+func NewScheduleStore(store *DataStore) *ScheduleStore {
+	return &ScheduleStore{store}
+}
+
+func (s *ScheduleStore) Create(sc *hvs.Schedule) (*hvs.Schedule, error) {
+	defaultLog.Trace("postgres/schedule_store:Create() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:Create() Leaving")
+
+	if sc.Id == uuid.Nil {
+		sc.Id = uuid.New()
+	}
+	sc.CreatedAt = time.Now()
+
+	dbSchedule := toDbSchedule(sc)
+	if err := s.Store.Db.Create(&dbSchedule).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/schedule_store:Create() Failed to create schedule")
+	}
+	return sc, nil
+}
+
+func (s *ScheduleStore) Update(sc *hvs.Schedule) (*hvs.Schedule, error) {
+	defaultLog.Trace("postgres/schedule_store:Update() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:Update() Leaving")
+
+	if sc.Id == uuid.Nil {
+		return nil, errors.New("postgres/schedule_store:Update() Schedule ID must be specified")
+	}
+	dbSchedule := toDbSchedule(sc)
+	if err := s.Store.Db.Save(&dbSchedule).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/schedule_store:Update() Failed to update schedule")
+	}
+	return sc, nil
+}
+
+func (s *ScheduleStore) Retrieve(id uuid.UUID) (*hvs.Schedule, error) {
+	defaultLog.Trace("postgres/schedule_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:Retrieve() Leaving")
+
+	dbSchedule := schedule{}
+	row := s.Store.Db.Model(&schedule{}).Where(&schedule{Id: id}).Row()
+	if err := row.Scan(&dbSchedule.Id, &dbSchedule.HostId, &dbSchedule.FlavorgroupId, &dbSchedule.Interval,
+		&dbSchedule.Jitter, &dbSchedule.MaintenanceWindows, &dbSchedule.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/schedule_store:Retrieve() Failed to scan record")
+	}
+	sc := fromDbSchedule(dbSchedule)
+	return &sc, nil
+}
+
+func (s *ScheduleStore) Search(criteria *models.ScheduleFilterCriteria) ([]hvs.Schedule, error) {
+	defaultLog.Trace("postgres/schedule_store:Search() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:Search() Leaving")
+
+	tx := buildScheduleSearchQuery(s.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/schedule_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/schedule_store:Search() Failed to retrieve records from db")
+	}
+	defer rows.Close()
+
+	schedules := []hvs.Schedule{}
+	for rows.Next() {
+		dbSchedule := schedule{}
+		if err := rows.Scan(&dbSchedule.Id, &dbSchedule.HostId, &dbSchedule.FlavorgroupId, &dbSchedule.Interval,
+			&dbSchedule.Jitter, &dbSchedule.MaintenanceWindows, &dbSchedule.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/schedule_store:Search() Failed to scan record")
+		}
+		schedules = append(schedules, fromDbSchedule(dbSchedule))
+	}
+	return schedules, nil
+}
+
+func (s *ScheduleStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/schedule_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:Delete() Leaving")
+
+	if err := s.Store.Db.Delete(&schedule{Id: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/schedule_store:Delete() Failed to delete schedule")
+	}
+	return nil
+}
+
+// helper function to build the query object for a schedule search.
+func buildScheduleSearchQuery(tx *gorm.DB, criteria *models.ScheduleFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/schedule_store:buildScheduleSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/schedule_store:buildScheduleSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&schedule{})
+	if criteria == nil {
+		return tx
+	}
+
+	if criteria.Id != uuid.Nil {
+		tx = tx.Where("id = ?", criteria.Id)
+	} else if criteria.HostId != uuid.Nil {
+		tx = tx.Where("host_id = ?", criteria.HostId)
+	} else if criteria.FlavorgroupId != uuid.Nil {
+		tx = tx.Where("flavorgroup_id = ?", criteria.FlavorgroupId)
+	}
+	return tx
+}
+
+func toDbSchedule(sc *hvs.Schedule) schedule {
+	return schedule{
+		Id:                 sc.Id,
+		HostId:             sc.HostId,
+		FlavorgroupId:      sc.FlavorgroupId,
+		Interval:           sc.Interval,
+		Jitter:             sc.Jitter,
+		MaintenanceWindows: PGMaintenanceWindows(sc.MaintenanceWindows),
+		CreatedAt:          sc.CreatedAt,
+	}
+}
+
+func fromDbSchedule(dbSchedule schedule) hvs.Schedule {
+	return hvs.Schedule{
+		Id:                 dbSchedule.Id,
+		HostId:             dbSchedule.HostId,
+		FlavorgroupId:      dbSchedule.FlavorgroupId,
+		Interval:           dbSchedule.Interval,
+		Jitter:             dbSchedule.Jitter,
+		MaintenanceWindows: []hvs.MaintenanceWindow(dbSchedule.MaintenanceWindows),
+		CreatedAt:          dbSchedule.CreatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/pkg/errors"
+)
+
+type WebhookDeadLetterStore struct {
+	Store *DataStore
+}
+
+func NewWebhookDeadLetterStore(store *DataStore) *WebhookDeadLetterStore {
+	return &WebhookDeadLetterStore{store}
+}
+
+// Create records a webhook notification that could not be delivered
+func (d *WebhookDeadLetterStore) Create(dl *models.WebhookDeadLetter) (*models.WebhookDeadLetter, error) {
+	defaultLog.Trace("postgres/webhook_dead_letter_store:Create() Entering")
+	defer defaultLog.Trace("postgres/webhook_dead_letter_store:Create() Leaving")
+
+	if dl.ID == uuid.Nil {
+		dl.ID = uuid.New()
+	}
+	if dl.CreatedAt.IsZero() {
+		dl.CreatedAt = time.Now()
+	}
+	dbDeadLetter := webhookDeadLetter{
+		ID:        dl.ID,
+		WebhookID: dl.WebhookID,
+		EventID:   dl.EventID,
+		Payload:   dl.Payload,
+		Attempts:  dl.Attempts,
+		LastError: dl.LastError,
+		CreatedAt: dl.CreatedAt,
+	}
+	if err := d.Store.Db.Create(&dbDeadLetter).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_dead_letter_store:Create() Failed to create webhook dead letter")
+	}
+	return dl, nil
+}
+
+// Search retrieves the dead letters matching the WebhookDeadLetterFilterCriteria, most recent first
+func (d *WebhookDeadLetterStore) Search(criteria *models.WebhookDeadLetterFilterCriteria) ([]models.WebhookDeadLetter, error) {
+	defaultLog.Trace("postgres/webhook_dead_letter_store:Search() Entering")
+	defer defaultLog.Trace("postgres/webhook_dead_letter_store:Search() Leaving")
+
+	tx := d.Store.Db.Model(&webhookDeadLetter{})
+	if criteria != nil {
+		if criteria.WebhookId != uuid.Nil {
+			tx = tx.Where("webhook_id = ?", criteria.WebhookId)
+		}
+		if criteria.EventId != uuid.Nil {
+			tx = tx.Where("event_id = ?", criteria.EventId)
+		}
+		if criteria.Limit > 0 {
+			tx = tx.Limit(criteria.Limit)
+		}
+	}
+	tx = tx.Order("created desc")
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_dead_letter_store:Search() Failed to retrieve records from db")
+	}
+	defer rows.Close()
+
+	deadLetters := []models.WebhookDeadLetter{}
+	for rows.Next() {
+		dl := models.WebhookDeadLetter{}
+		if err := rows.Scan(&dl.ID, &dl.WebhookID, &dl.EventID, &dl.Payload, &dl.Attempts, &dl.LastError, &dl.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/webhook_dead_letter_store:Search() Failed to scan record")
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	return deadLetters, nil
+}
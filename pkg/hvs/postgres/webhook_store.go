@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type WebhookStore struct {
+	Store *DataStore
+	Dek   []byte
+}
+
+func NewWebhookStore(store *DataStore, dek []byte) *WebhookStore {
+	return &WebhookStore{store, dek}
+}
+
+func (w *WebhookStore) Create(wh *hvs.Webhook) (*hvs.Webhook, error) {
+	defaultLog.Trace("postgres/webhook_store:Create() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:Create() Leaving")
+
+	if wh.Id == uuid.Nil {
+		wh.Id = uuid.New()
+	}
+	wh.CreatedAt = time.Now()
+
+	encSecret, err := utils.EncryptString(wh.Secret, w.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Create() Failed to encrypt webhook secret")
+	}
+	dbWebhook := webhook{
+		Id:          wh.Id,
+		Url:         wh.Url,
+		Secret:      encSecret,
+		Description: wh.Description,
+		CreatedAt:   wh.CreatedAt,
+	}
+
+	if err := w.Store.Db.Create(&dbWebhook).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Create() Failed to create webhook")
+	}
+	return wh, nil
+}
+
+func (w *WebhookStore) Update(wh *hvs.Webhook) (*hvs.Webhook, error) {
+	defaultLog.Trace("postgres/webhook_store:Update() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:Update() Leaving")
+
+	if wh.Id == uuid.Nil {
+		return nil, errors.New("postgres/webhook_store:Update() Webhook ID must be specified")
+	}
+	encSecret, err := utils.EncryptString(wh.Secret, w.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Update() Failed to encrypt webhook secret")
+	}
+	dbWebhook := webhook{
+		Id:          wh.Id,
+		Url:         wh.Url,
+		Secret:      encSecret,
+		Description: wh.Description,
+		CreatedAt:   wh.CreatedAt,
+	}
+	if err := w.Store.Db.Save(&dbWebhook).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Update() Failed to update webhook")
+	}
+	return wh, nil
+}
+
+func (w *WebhookStore) Retrieve(id uuid.UUID) (*hvs.Webhook, error) {
+	defaultLog.Trace("postgres/webhook_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:Retrieve() Leaving")
+
+	wh := hvs.Webhook{}
+	row := w.Store.Db.Model(&webhook{}).Where(&webhook{Id: id}).Row()
+	if err := row.Scan(&wh.Id, &wh.Url, &wh.Secret, &wh.Description, &wh.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Retrieve() Failed to scan record")
+	}
+
+	secret, err := utils.DecryptString(wh.Secret, w.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Retrieve() Failed to decrypt webhook secret")
+	}
+	wh.Secret = secret
+	return &wh, nil
+}
+
+func (w *WebhookStore) Search(criteria *models.WebhookFilterCriteria) ([]hvs.Webhook, error) {
+	defaultLog.Trace("postgres/webhook_store:Search() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:Search() Leaving")
+
+	tx := buildWebhookSearchQuery(w.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/webhook_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object.")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/webhook_store:Search() Failed to retrieve records from db")
+	}
+	defer rows.Close()
+
+	webhooks := []hvs.Webhook{}
+	for rows.Next() {
+		wh := hvs.Webhook{}
+		if err := rows.Scan(&wh.Id, &wh.Url, &wh.Secret, &wh.Description, &wh.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "postgres/webhook_store:Search() Failed to scan record")
+		}
+		secret, err := utils.DecryptString(wh.Secret, w.Dek)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/webhook_store:Search() Failed to decrypt webhook secret")
+		}
+		wh.Secret = secret
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+func (w *WebhookStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/webhook_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:Delete() Leaving")
+
+	if err := w.Store.Db.Delete(&webhook{Id: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/webhook_store:Delete() Failed to delete webhook")
+	}
+	return nil
+}
+
+// helper function to build the query object for a webhook search.
+func buildWebhookSearchQuery(tx *gorm.DB, criteria *models.WebhookFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/webhook_store:buildWebhookSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/webhook_store:buildWebhookSearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&webhook{})
+	if criteria == nil {
+		return tx
+	}
+
+	if criteria.Id != uuid.Nil {
+		tx = tx.Where("id = ?", criteria.Id)
+	} else if criteria.UrlContains != "" {
+		tx = tx.Where("url like ? ", "%"+criteria.UrlContains+"%")
+	}
+	return tx
+}
@@ -30,7 +30,7 @@ func SetDeploySoftwareManifestRoute(router *mux.Router, store *postgres.DataStor
 	dsmController := controllers.NewDeploySoftwareManifestController(flavorStore, *hc)
 
 	router.Handle("/rpc/deploy-software-manifest",
-		ErrorHandler(permissionsHandler(ResponseHandler(dsmController.DeployManifest),
+		ErrorHandler(permissionsHandler(JsonResponseHandler(dsmController.DeployManifest),
 			[]string{constants.SoftwareFlavorDeploy}))).Methods("POST")
 
 	return router
@@ -37,6 +37,26 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, certStore *m
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Search),
 			[]string{constants.FlavorSearch}))).Methods("GET")
 
+	router.Handle("/flavors/evaluate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Evaluate),
+			[]string{constants.FlavorEvaluate}))).Methods("POST")
+
+	router.Handle("/flavors/export",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Export),
+			[]string{constants.FlavorExport}))).Methods("GET")
+
+	router.Handle("/flavors/import",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Import),
+			[]string{constants.FlavorImport}))).Methods("POST")
+
+	router.Handle("/flavors/candidates",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Capture),
+			[]string{constants.FlavorCreate}))).Methods("POST")
+
+	router.Handle("/flavors/candidates",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.SearchCandidates),
+			[]string{constants.FlavorSearch}))).Methods("GET")
+
 	router.Handle(flavorIdExpr,
 		ErrorHandler(permissionsHandler(ResponseHandler(flavorController.Delete),
 			[]string{constants.FlavorDelete}))).Methods("DELETE")
@@ -45,5 +65,13 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, certStore *m
 		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Retrieve),
 			[]string{constants.FlavorRetrieve}))).Methods("GET")
 
+	router.Handle(flavorIdExpr+"/lifecycle",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.UpdateLifecycle),
+			[]string{constants.FlavorUpdate}))).Methods("PUT")
+
+	router.Handle(flavorIdExpr+"/approve",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(flavorController.Approve),
+			[]string{constants.FlavorApprove}))).Methods("POST")
+
 	return router
 }
@@ -93,6 +93,26 @@ func XMLResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{},
 	}
 }
 
+// EventStreamResponseHandler handler for Server-Sent Events endpoints. The application handler writes the
+// event stream itself, only errors returned before the stream is opened are written by this handler
+func EventStreamResponseHandler(h func(http.ResponseWriter, *http.Request) (interface{}, int, error)) endpointHandler {
+	defaultLog.Trace("router/handlers:EventStreamResponseHandler() Entering")
+	defer defaultLog.Trace("router/handlers:EventStreamResponseHandler() Leaving")
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if r.Header.Get("Accept") != constants.HTTPMediaTypeEventStream {
+			return errorFormatter(&commErr.EndpointError{
+				Message: "Invalid Accept type",
+			}, http.StatusUnsupportedMediaType)
+		}
+		_, status, err := h(w, r) // execute application handler
+		if err != nil {
+			return errorFormatter(err, status)
+		}
+		return nil
+	}
+}
+
 func errorFormatter(err error, status int) error {
 	defaultLog.Trace("router/handlers:errorFormatter() Entering")
 	defer defaultLog.Trace("router/handlers:errorFormatter() Leaving")
@@ -29,8 +29,10 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
 		flavorGroupStore, hostCredentialStore,
 		hostTrustManager, hostControllerConfig)
+	hostController.JobStore = postgres.NewJobStore(store)
 
 	hostExpr := "/hosts"
+	batchExpr := fmt.Sprintf("%s/batch", hostExpr)
 	hostIdExpr := fmt.Sprintf("%s/{hId:%s}", hostExpr, validation.UUIDReg)
 	flavorgroupExpr := fmt.Sprintf("%s/flavorgroups", hostIdExpr)
 	flavorgroupIdExpr := fmt.Sprintf("%s/{fgId:%s}", flavorgroupExpr, validation.UUIDReg)
@@ -46,6 +48,15 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	router.Handle(hostExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.Search),
 		[]string{constants.HostSearch}))).Methods("GET")
 
+	router.Handle(batchExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.CreateBatch),
+		[]string{constants.HostCreate}))).Methods("POST")
+	router.Handle(batchExpr+"/delete", ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.DeleteBatch),
+		[]string{constants.HostDelete}))).Methods("POST")
+	router.Handle(batchExpr+"/flavorgroups", ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.AddFlavorgroupBatch),
+		[]string{constants.HostCreate}))).Methods("POST")
+	router.Handle(batchExpr+"/verify", ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.VerifyBatch),
+		[]string{constants.ReportCreate}))).Methods("POST")
+
 	router.Handle(flavorgroupExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.AddFlavorgroup),
 		[]string{constants.HostCreate}))).Methods("POST")
 	router.Handle(flavorgroupIdExpr, ErrorHandler(permissionsHandler(JsonResponseHandler(hostController.RetrieveFlavorgroup),
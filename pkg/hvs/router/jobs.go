@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetJobRoutes registers routes for the jobs of the batch host operations and the flavor verifications
+func SetJobRoutes(router *mux.Router, store *postgres.DataStore, htm domain.HostTrustManager) *mux.Router {
+	defaultLog.Trace("router/jobs:SetJobRoutes() Entering")
+	defer defaultLog.Trace("router/jobs:SetJobRoutes() Leaving")
+
+	jobController := controllers.JobController{
+		Store:     postgres.NewJobStore(store),
+		HTManager: htm,
+	}
+
+	jobIdExpr := fmt.Sprintf("%s%s", "/jobs/", validation.IdReg)
+
+	router.Handle("/jobs",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(jobController.Search),
+			[]string{constants.JobSearch}))).Methods("GET")
+
+	router.Handle(jobIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(jobController.Retrieve),
+			[]string{constants.JobRetrieve}))).Methods("GET")
+
+	router.Handle(jobIdExpr+"/cancel",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(jobController.Cancel),
+			[]string{constants.JobCancel}))).Methods("POST")
+
+	return router
+}
@@ -6,6 +6,7 @@ package router
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
@@ -17,7 +18,8 @@ import (
 )
 
 // SetReportRoutes registers routes for reports
-func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager) *mux.Router {
+func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManager domain.HostTrustManager,
+	reportBroadcaster domain.ReportBroadcaster, tokenReportGenerator domain.TokenReportGenerator, writeTimeout time.Duration) *mux.Router {
 	defaultLog.Trace("router/reports:SetReportRoutes() Entering")
 	defer defaultLog.Trace("router/reports:SetReportRoutes() Leaving")
 
@@ -25,13 +27,21 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 	hostStore := postgres.NewHostStore(store)
 	hostStatusStore := postgres.NewHostStatusStore(store)
 	reportController := controllers.NewReportController(reportStore, hostStore, hostStatusStore, hostTrustManager)
+	reportController.TokenReportGenerator = tokenReportGenerator
+	reportStreamController := controllers.NewReportStreamController(reportBroadcaster, hostStore,
+		constants.ReportStreamKeepAliveInterval, reportStreamTimeout(writeTimeout))
 
 	reportIdExpr := fmt.Sprintf("%s%s", "/reports/", validation.IdReg)
+	reportDiffExpr := fmt.Sprintf("/reports/{id:%s}/diff", validation.UUIDReg)
 
 	router.Handle("/reports",
 		ErrorHandler(permissionsHandler(ResponseHandler(reportController.CreateSaml),
 			[]string{constants.ReportCreate}))).Methods("POST").Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle("/reports",
+		ErrorHandler(permissionsHandler(ResponseHandler(reportController.CreateJwt),
+			[]string{constants.ReportCreate}))).Methods("POST").Headers("Accept", consts.HTTPMediaTypeJwt)
+
 	router.Handle("/reports",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Create),
 			[]string{constants.ReportCreate}))).Methods("POST")
@@ -40,13 +50,37 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 		ErrorHandler(permissionsHandler(ResponseHandler(reportController.SearchSaml),
 			[]string{constants.ReportSearch}))).Methods("GET").Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle("/reports/stream",
+		ErrorHandler(permissionsHandler(EventStreamResponseHandler(reportStreamController.Stream),
+			[]string{constants.ReportSearch}))).Methods("GET")
+
 	router.Handle(reportIdExpr,
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Retrieve),
 			[]string{constants.ReportRetrieve}))).Methods("GET")
 
+	router.Handle(reportDiffExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Diff),
+			[]string{constants.ReportRetrieve}))).Methods("GET")
+
 	router.Handle("/reports",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(reportController.Search),
 			[]string{constants.ReportSearch}))).Methods("GET")
 
 	return router
 }
+
+// reportStreamTimeout closes the stream before the server write timeout expires so that clients reconnect cleanly,
+// the stream is never closed by the service when the server has no write timeout. The clients reconnecting with the
+// Last-Event-ID header get the reports published in the meantime
+func reportStreamTimeout(writeTimeout time.Duration) time.Duration {
+	if writeTimeout <= 0 {
+		return 0
+	}
+	timeout := writeTimeout - constants.ReportStreamCloseMargin
+	if timeout <= 0 {
+		timeout = writeTimeout / 2
+		defaultLog.Warnf("router/reports:reportStreamTimeout() The server write timeout %s is too short, report streams are closed after %s",
+			writeTimeout, timeout)
+	}
+	return timeout
+}
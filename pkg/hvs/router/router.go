@@ -33,7 +33,8 @@ type Router struct {
 }
 
 // InitRoutes registers all routes for the application.
-func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) *mux.Router {
+func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig,
+	reportBroadcaster domain.ReportBroadcaster, tokenReportGenerator domain.TokenReportGenerator) *mux.Router {
 	defaultLog.Trace("router/router:InitRoutes() Entering")
 	defer defaultLog.Trace("router/router:InitRoutes() Leaving")
 
@@ -42,13 +43,14 @@ func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, certSt
 
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
-	defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, certStore, hostTrustManager, hostControllerConfig)
-	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, certStore, hostTrustManager, hostControllerConfig)
+	defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, certStore, hostTrustManager, hostControllerConfig, reportBroadcaster, tokenReportGenerator)
+	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, certStore, hostTrustManager, hostControllerConfig, reportBroadcaster, tokenReportGenerator)
 	return router
 }
 
 func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, dataStore *postgres.DataStore,
-	certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) {
+	certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig,
+	reportBroadcaster domain.ReportBroadcaster, tokenReportGenerator domain.TokenReportGenerator) {
 	defaultLog.Trace("router/router:defineSubRoutes() Entering")
 	defer defaultLog.Trace("router/router:defineSubRoutes() Leaving")
 
@@ -71,13 +73,17 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	subRouter = SetHostStatusRoutes(subRouter, dataStore)
 	subRouter = SetCertifyHostKeysRoutes(subRouter, certStore)
 	subRouter = SetHostRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
-	subRouter = SetReportRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetReportRoutes(subRouter, dataStore, hostTrustManager, reportBroadcaster, tokenReportGenerator, cfg.Server.WriteTimeout)
 	subRouter = SetCreateCaCertificatesRoutes(subRouter, certStore)
-	subRouter = SetTagCertificateRoutes(subRouter, cfg, certStore, hostTrustManager, dataStore)
+	subRouter = SetTagCertificateRoutes(subRouter, cfg, certStore, hostTrustManager, dataStore, hostControllerConfig.HostConnectorProvider)
 	subRouter = SetESXiClusterRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetDeploySoftwareManifestRoute(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetManifestsRoute(subRouter, dataStore)
 	subRouter = SetFlavorFromAppManifestRoute(subRouter, dataStore, certStore, hostTrustManager, hostControllerConfig)
+	subRouter = SetWebhookRoutes(subRouter, dataStore, hostControllerConfig.DataEncryptionKey)
+	subRouter = SetScheduleRoutes(subRouter, dataStore)
+	subRouter = SetJobRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetTrustAnalyticsRoutes(subRouter, dataStore)
 }
 
 // Fetch JWT certificate from AAS
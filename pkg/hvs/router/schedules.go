@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetScheduleRoutes registers routes for schedules
+func SetScheduleRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/schedules:SetScheduleRoutes() Entering")
+	defer defaultLog.Trace("router/schedules:SetScheduleRoutes() Leaving")
+
+	scheduleStore := postgres.NewScheduleStore(store)
+	hostStore := postgres.NewHostStore(store)
+	flavorGroupStore := postgres.NewFlavorGroupStore(store)
+	scheduleController := controllers.ScheduleController{
+		Store:            scheduleStore,
+		HostStore:        hostStore,
+		FlavorGroupStore: flavorGroupStore,
+	}
+
+	scheduleIdExpr := fmt.Sprintf("%s%s", "/schedules/", validation.IdReg)
+
+	router.Handle("/schedules",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(scheduleController.Create),
+			[]string{constants.ScheduleCreate}))).Methods("POST")
+
+	router.Handle("/schedules",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(scheduleController.Search),
+			[]string{constants.ScheduleSearch}))).Methods("GET")
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(scheduleController.Retrieve),
+			[]string{constants.ScheduleRetrieve}))).Methods("GET")
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(scheduleController.Update),
+			[]string{constants.ScheduleStore}))).Methods("PUT")
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(permissionsHandler(ResponseHandler(scheduleController.Delete),
+			[]string{constants.ScheduleDelete}))).Methods("DELETE")
+
+	return router
+}
@@ -24,15 +24,11 @@ const (
 )
 
 // SetTagCertificateRoutes registers routes for tag-certificates API
-func SetTagCertificateRoutes(router *mux.Router, cfg *config.Configuration, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, store *postgres.DataStore) *mux.Router {
+func SetTagCertificateRoutes(router *mux.Router, cfg *config.Configuration, certStore *models.CertificatesStore, hostTrustManager domain.HostTrustManager, store *postgres.DataStore, hcp hostConnector.HostConnectorProvider) *mux.Router {
 	defaultLog.Trace("router/tag_certificates:SetTagCertificateRoutes() Entering")
 	defer defaultLog.Trace("router/tag_certificates:SetTagCertificateRoutes() Leaving")
 
-	// set up the HostConnectorProvider for the Controller
-	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()].Certificates
-	var hcp hostConnector.HostConnectorProvider
-	hcp = hostConnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs)
-
+	// the HostConnectorProvider of the Controller is shared with the host controllers
 	if hcp == nil {
 		defaultLog.Errorf("router/tag_certificates:SetTagCertificateRoutes() %s : Error initializing the Host Connector Factory", commLogMsg.AppRuntimeErr)
 		return nil
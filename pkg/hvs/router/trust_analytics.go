@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetTrustAnalyticsRoutes registers routes for the host trust timelines and the flavorgroup trust analytics
+func SetTrustAnalyticsRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/trust_analytics:SetTrustAnalyticsRoutes() Entering")
+	defer defaultLog.Trace("router/trust_analytics:SetTrustAnalyticsRoutes() Leaving")
+
+	trustAnalyticsController := controllers.TrustAnalyticsController{
+		ReportStore:      postgres.NewReportStore(store),
+		HostStore:        postgres.NewHostStore(store),
+		FlavorGroupStore: postgres.NewFlavorGroupStore(store),
+	}
+
+	trustTimelineExpr := fmt.Sprintf("/hosts/{hId:%s}/trust-timeline", validation.UUIDReg)
+
+	router.Handle(trustTimelineExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(trustAnalyticsController.Timeline),
+			[]string{constants.ReportSearch}))).Methods("GET")
+
+	router.Handle("/trust-analytics",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(trustAnalyticsController.Analytics),
+			[]string{constants.TrustAnalyticsRetrieve}))).Methods("GET")
+
+	return router
+}
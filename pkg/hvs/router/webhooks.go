@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/validation"
+)
+
+// SetWebhookRoutes registers routes for webhooks
+func SetWebhookRoutes(router *mux.Router, store *postgres.DataStore, dek []byte) *mux.Router {
+	defaultLog.Trace("router/webhooks:SetWebhookRoutes() Entering")
+	defer defaultLog.Trace("router/webhooks:SetWebhookRoutes() Leaving")
+
+	webhookStore := postgres.NewWebhookStore(store, dek)
+	deadLetterStore := postgres.NewWebhookDeadLetterStore(store)
+	webhookController := controllers.WebhookController{Store: webhookStore, DLStore: deadLetterStore}
+
+	webhookIdExpr := fmt.Sprintf("%s%s", "/webhooks/", validation.IdReg)
+	deadLettersExpr := fmt.Sprintf("/webhooks/{id:%s}/dead-letters", validation.UUIDReg)
+
+	router.Handle("/webhooks",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(webhookController.Create),
+			[]string{constants.WebhookCreate}))).Methods("POST")
+
+	router.Handle("/webhooks",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(webhookController.Search),
+			[]string{constants.WebhookSearch}))).Methods("GET")
+
+	router.Handle(webhookIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(webhookController.Retrieve),
+			[]string{constants.WebhookRetrieve}))).Methods("GET")
+
+	router.Handle(webhookIdExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(webhookController.Update),
+			[]string{constants.WebhookStore}))).Methods("PUT")
+
+	router.Handle(webhookIdExpr,
+		ErrorHandler(permissionsHandler(ResponseHandler(webhookController.Delete),
+			[]string{constants.WebhookDelete}))).Methods("DELETE")
+
+	router.Handle(deadLettersExpr,
+		ErrorHandler(permissionsHandler(JsonResponseHandler(webhookController.SearchDeadLetters),
+			[]string{constants.WebhookRetrieve}))).Methods("GET")
+
+	return router
+}
@@ -6,29 +6,37 @@ package hvs
 
 import (
 	"context"
+	"crypto"
 	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/config"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/postgres"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/auditlog"
 	hostfetcher "github.com/intel-secl/intel-secl/v3/pkg/hvs/services/host-fetcher"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/webhook"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	hostconnector "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 
 	"github.com/gorilla/handlers"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
@@ -71,25 +79,58 @@ func (a *App) startServer() error {
 	// Load Certificates
 	certStore := utils.LoadCertificates(a.loadCertPathStore())
 
-	// Initialize Host trust manager
-	hostTrustManager := initHostTrustManager(c, dataStore, certStore, alw)
+	// Initialize webhook notifications for host trust changes
+	trustChangeNotifier, err := initTrustChangeNotifier(c, dataStore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing webhook notifications")
+	}
+
+	// Initialize the host connector factory shared by the host fetcher and the controllers
+	hcFactory, err := initHostConnectorFactory(c, certStore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing the host connector factory")
+	}
+
+	// Initialize the host data fetcher shared by the host trust manager and the batch host operations
+	hostDataFetcher := initHostDataFetcher(c, dataStore, alw, hcFactory)
+
+	// Initialize Host trust manager, reports created by it are published to the report stream
+	reportBroadcaster := hosttrust.NewReportBroadcaster(constants.DefaultReportStreamBufferSize, constants.ReportStreamHistorySize)
+	hostTrustManager := initHostTrustManager(c, dataStore, certStore, alw, trustChangeNotifier, reportBroadcaster, hostDataFetcher)
 	go hostTrustManager.ProcessQueue()
 
 	// create an instance of the HRRS and start it...
 	reportStore := postgres.NewReportStore(dataStore)
 	reportStore.AuditLogWriter = alw
-	reportRefresher, err := hrrs.NewHostReportRefresher(c.HRRS, reportStore, hostTrustManager)
+	scheduleStore := postgres.NewScheduleStore(dataStore)
+	flavorGroupStore := postgres.NewFlavorGroupStore(dataStore)
+	reportRefresher, err := hrrs.NewHostReportRefresher(c.HRRS, reportStore, scheduleStore, flavorGroupStore, hostTrustManager)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while initializing HRRS")
 	}
 
 	reportRefresher.Run()
 
-	// Initialize Host controller config
-	hostControllerConfig := initHostControllerConfig(c, certStore)
+	// The batch host jobs that did not finish before the service stopped are cancelled before new jobs are accepted
+	if err := controllers.CancelUnfinishedHostBatchJobs(postgres.NewJobStore(dataStore)); err != nil {
+		defaultLog.WithError(err).Error("An error occurred while cancelling the unfinished batch host jobs")
+	}
+
+	// Initialize Host controller config, the batch host jobs stop starting hosts once batchJobsQuit is closed
+	batchJobsQuit := make(chan struct{})
+	var batchJobsWg sync.WaitGroup
+	hostControllerConfig := initHostControllerConfig(c, hcFactory, hostDataFetcher)
+	hostControllerConfig.BatchJobsQuit = batchJobsQuit
+	hostControllerConfig.BatchJobsWg = &batchJobsWg
+
+	// Initialize the attestation tokens, the reports are still served as SAML and JSON when it fails
+	tokenReportGenerator, err := initTokenReportGenerator(c, certStore)
+	if err != nil {
+		defaultLog.WithError(err).Warn("Attestation tokens are disabled, the token signing key could not be loaded")
+	}
 
 	// Initialize routes
-	routes := router.InitRoutes(c, dataStore, certStore, hostTrustManager, hostControllerConfig)
+	routes := router.InitRoutes(c, dataStore, certStore, hostTrustManager, hostControllerConfig, reportBroadcaster, tokenReportGenerator)
 
 	defaultLog.Info("Starting server")
 	tlsConfig := &tls.Config{
@@ -133,8 +174,12 @@ func (a *App) startServer() error {
 	defer cancel()
 
 	reportRefresher.Stop()
+	trustChangeNotifier.Stop()
 
-	if err := h.Shutdown(ctx); err != nil {
+	err = h.Shutdown(ctx)
+	close(batchJobsQuit)
+	batchJobsWg.Wait()
+	if err != nil {
 		defaultLog.WithError(err).Info("Failed to gracefully shutdown webserver")
 		return err
 	}
@@ -142,18 +187,104 @@ func (a *App) startServer() error {
 	return nil
 }
 
-func initHostControllerConfig(cfg *config.Configuration, certStore *models.CertificatesStore) domain.HostControllerConfig {
-	defaultLog.Trace("server:initHostControllerConfig() Entering")
-	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
+// initHostConnectorFactory creates the host connector factory. When the host simulator is enabled, the AIK
+// certificates of the simulated hosts are issued by the privacy CA so that they can be verified like real hosts. The
+// attestation keys of the Keylime agents are certified by the privacy CA the same way, provided that their EK
+// certificate is issued by one of the endorsement CAs
+func initHostConnectorFactory(cfg *config.Configuration, certStore *models.CertificatesStore) (*hostconnector.HostConnectorFactory, error) {
+	defaultLog.Trace("server:initHostConnectorFactory() Entering")
+	defer defaultLog.Trace("server:initHostConnectorFactory() Leaving")
 
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
+	hcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
+	if !cfg.HostSimulator.Enabled && cfg.Keylime.RegistrarURL == "" {
+		return hcFactory, nil
+	}
+
+	privacyCACert, privacyCAKey, err := crypt.LoadX509CertAndPrivateKey(constants.PrivacyCACertFile, constants.PrivacyCAKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load the privacy CA certificate and key")
+	}
+	privacyCARsaKey, ok := privacyCAKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Privacy CA key is not a RSA key")
+	}
+
+	if cfg.HostSimulator.Enabled {
+		sim, err := simulator.NewSimulator(simulator.Config{
+			TemplateDir:      cfg.HostSimulator.TemplateDir,
+			Latency:          cfg.HostSimulator.Latency,
+			AikCACertificate: privacyCACert,
+			AikCAKey:         privacyCARsaKey,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to initialize the host simulator")
+		}
+		hcFactory.SetSimulator(sim)
+		utils.RegisterHostStateMapper(func(err error) (hvs.HostState, bool) {
+			hostState, ok := simulator.HostState(err)
+			return hvs.GetHostState(hostState), ok
+		})
+		defaultLog.Warn("Host simulator is enabled, the hosts with a simulated connection string are not real hosts")
+	}
+
+	if cfg.Keylime.RegistrarURL != "" {
+		tlsConfig, err := loadKeylimeTLSConfig(cfg.Keylime)
+		if err != nil {
+			return nil, err
+		}
+		var endorsementCACerts []x509.Certificate
+		if endorsementCAs := (*certStore)[models.CaCertTypesEndorsementCa.String()]; endorsementCAs != nil {
+			endorsementCACerts = endorsementCAs.Certificates
+		}
+		keylimeFactory, err := hostconnector.NewKeylimeConnectorFactory(hostconnector.KeylimeConfig{
+			RegistrarURL:              cfg.Keylime.RegistrarURL,
+			ApiVersion:                cfg.Keylime.ApiVersion,
+			TLSConfig:                 tlsConfig,
+			AikCACertificate:          privacyCACert,
+			AikCAKey:                  privacyCARsaKey,
+			EndorsementCACertificates: endorsementCACerts,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to initialize the Keylime host connector")
+		}
+		hcFactory.SetKeylimeConnectorFactory(keylimeFactory)
+	}
+	return hcFactory, nil
+}
+
+// loadKeylimeTLSConfig returns the mTLS configuration of the connections to the Keylime agents and registrar
+func loadKeylimeTLSConfig(keylimeConfig config.KeylimeConfig) (*tls.Config, error) {
+	caCertPem, err := ioutil.ReadFile(keylimeConfig.CaCertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the Keylime CA certificate")
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCertPem) {
+		return nil, errors.New("Failed to parse the Keylime CA certificate")
+	}
+	clientCert, err := tls.LoadX509KeyPair(keylimeConfig.ClientCertFile, keylimeConfig.ClientKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load the Keylime client certificate and key")
+	}
+	return &tls.Config{
+		RootCAs:      caCertPool,
+		Certificates: []tls.Certificate{clientCert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func initHostControllerConfig(cfg *config.Configuration, hcFactory *hostconnector.HostConnectorFactory, hf domain.HostDataFetcher) domain.HostControllerConfig {
+	defaultLog.Trace("server:initHostControllerConfig() Entering")
+	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
 
 	hcc := domain.HostControllerConfig{
-		HostConnectorProvider: hcProvider,
+		HostConnectorProvider: hcFactory,
 		DataEncryptionKey:     getDecodedDek(cfg),
 		Username:              cfg.HVS.Username,
 		Password:              cfg.HVS.Password,
+		HostDataFetcher:       hf,
+		NumberOfDataFetchers:  cfg.FVS.NumberOfDataFetchers,
 	}
 	return hcc
 }
@@ -170,13 +301,87 @@ func getDecodedDek(cfg *config.Configuration) []byte {
 	return dek
 }
 
-func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, certStore *models.CertificatesStore, alw domain.AuditLogWriter) domain.HostTrustManager {
+func initTrustChangeNotifier(cfg *config.Configuration, dataStore *postgres.DataStore) (*webhook.Notifier, error) {
+	defaultLog.Trace("server:initTrustChangeNotifier() Entering")
+	defer defaultLog.Trace("server:initTrustChangeNotifier() Leaving")
+
+	ws := postgres.NewWebhookStore(dataStore, getDecodedDek(cfg))
+	dls := postgres.NewWebhookDeadLetterStore(dataStore)
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+	return webhook.NewNotifier(cfg.Webhook, ws, dls, client)
+}
+
+func initTokenReportGenerator(cfg *config.Configuration, certStore *models.CertificatesStore) (domain.TokenReportGenerator, error) {
+	defaultLog.Trace("server:initTokenReportGenerator() Entering")
+	defer defaultLog.Trace("server:initTokenReportGenerator() Leaving")
+
+	var signingKey crypto.PrivateKey
+	var signingCert *x509.Certificate
+	if samlCert := (*certStore)[models.CertTypesSaml.String()]; samlCert != nil && len(samlCert.Certificates) > 0 {
+		signingKey, signingCert = samlCert.Key, &samlCert.Certificates[0]
+	}
+	if cfg.AttestationToken.SigningKeyFile != "" || cfg.AttestationToken.SigningCertFile != "" {
+		key, err := crypt.GetPrivateKeyFromPKCS8File(cfg.AttestationToken.SigningKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to load the attestation token signing key")
+		}
+		cert, err := crypt.GetCertFromPemFile(cfg.AttestationToken.SigningCertFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to load the attestation token signing certificate")
+		}
+		signingKey, signingCert = key, cert
+	}
+	if signingKey == nil || signingCert == nil {
+		return nil, errors.New("The attestation token signing key and certificate are not available")
+	}
+	// the generator is returned as an untyped nil on error so that the routes see the attestation tokens as disabled
+	tokenReportGenerator, err := hosttrust.NewTokenReportGenerator(signingKey, signingCert, cfg.SAML.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return tokenReportGenerator, nil
+}
+
+func initHostDataFetcher(cfg *config.Configuration, dataStore *postgres.DataStore, alw domain.AuditLogWriter, htcFactory *hostconnector.HostConnectorFactory) domain.HostDataFetcher {
+	defaultLog.Trace("server:initHostDataFetcher() Entering")
+	defer defaultLog.Trace("server:initHostDataFetcher() Leaving")
+
+	hs := postgres.NewHostStore(dataStore)
+	hc := postgres.NewHostCredentialStore(dataStore, getDecodedDek(cfg))
+	hss := postgres.NewHostStatusStore(dataStore)
+	hss.AuditLogWriter = alw
+
+	// Initialize Host Fetcher service
+	c := domain.HostDataFetcherConfig{
+		HostConnectorFactory: *htcFactory,
+		HostConnectionConfig: domain.HostConnectionConfig{
+			HCStore:         hc,
+			ServiceUsername: cfg.HVS.Username,
+			ServicePassword: cfg.HVS.Password,
+		},
+		RetryTimeMinutes: 5,
+		HostStatusStore:  hss,
+		HostStore:        hs,
+	}
+	_, hf, err := hostfetcher.NewService(c, cfg.FVS.NumberOfDataFetchers)
+	if err != nil {
+		defaultLog.WithError(err).Error("Error initializing host fetcher")
+	}
+	return hf
+}
+
+func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, certStore *models.CertificatesStore, alw domain.AuditLogWriter, tcn domain.TrustChangeNotifier, rb domain.ReportBroadcaster, hf domain.HostDataFetcher) domain.HostTrustManager {
 	defaultLog.Trace("server:InitHostTrustManager() Entering")
 	defer defaultLog.Trace("server:InitHostTrustManager() Leaving")
 
 	//Load store
 	hs := postgres.NewHostStore(dataStore)
-	hc := postgres.NewHostCredentialStore(dataStore, getDecodedDek(cfg))
 	fs := postgres.NewFlavorStore(dataStore)
 	fgs := postgres.NewFlavorGroupStore(dataStore)
 	qs := postgres.NewDBQueueStore(dataStore)
@@ -221,26 +426,9 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		CertsStore:                      *certStore,
 		SamlIssuerConfig:                samlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.FVS.SkipFlavorSignatureVerification,
+		TrustChangeNotifier:             tcn,
 	}
 
-	// Initialize Host Fetcher service
-	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates)
-
-	c := domain.HostDataFetcherConfig{
-		HostConnectorFactory: *htcFactory,
-		HostConnectionConfig: domain.HostConnectionConfig{
-			HCStore:         hc,
-			ServiceUsername: cfg.HVS.Username,
-			ServicePassword: cfg.HVS.Password,
-		},
-		RetryTimeMinutes: 5,
-		HostStatusStore:  hss,
-		HostStore:        hs,
-	}
-	_, hf, err := hostfetcher.NewService(c, cfg.FVS.NumberOfDataFetchers)
-	if err != nil {
-		defaultLog.WithError(err).Error("Error initializing host fetcher")
-	}
 	// Initialize Host Trust service
 	_, htm, _ := hosttrust.NewService(domain.HostTrustMgrConfig{
 		PersistStore:      qs,
@@ -249,6 +437,8 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		HostFetcher:       hf,
 		Verifiers:         cfg.FVS.NumberOfVerifiers,
 		HostTrustVerifier: hosttrust.NewVerifier(htv),
+		ReportBroadcaster: rb,
+		JobStore:          postgres.NewJobStore(dataStore),
 	})
 
 	return htm
@@ -243,11 +243,13 @@ func (svc *Service) Retrieve(ctx context.Context, host hvs.Host) (*types.HostMan
 	defaultLog.Trace("hostfetcher/Service:Retrieve() Entering")
 	defer defaultLog.Trace("hostfetcher/Service:Retrieve() Leaving")
 
+	lastQuoteInfo := svc.getLastQuoteInfo(host.Id)
 	hostData, err := svc.GetHostData(host.ConnectionString)
 	hostStatus := &hvs.HostStatus{
 		HostID: host.Id,
 		HostStatusInformation: hvs.HostStatusInformation{
 			LastTimeConnected: time.Now(),
+			LastQuoteInfo:     lastQuoteInfo,
 		},
 	}
 	if err != nil {
@@ -261,6 +263,7 @@ func (svc *Service) Retrieve(ctx context.Context, host hvs.Host) (*types.HostMan
 	}
 
 	hostStatus.HostStatusInformation.HostState = hvs.HostStateConnected
+	hostStatus.HostStatusInformation.LastQuoteInfo = recordQuoteInfo(hostData, lastQuoteInfo)
 	hostStatus.HostManifest = *hostData
 	svc.updateMissingHostDetails(host.Id, hostData)
 	if err := svc.hss.Persist(hostStatus); err != nil {
@@ -287,6 +290,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string) {
 	defaultLog.Trace("hostfetcher/Service:FetchDataAndRespond() Entering")
 	defer defaultLog.Trace("hostfetcher/Service:FetchDataAndRespond() Leaving")
 
+	lastQuoteInfo := svc.getLastQuoteInfo(hId)
 	hostData, err := svc.GetHostData(connUrl)
 	if err != nil {
 		defaultLog.WithError(err).Errorf("hostfetcher/Service:FetchDataAndRespond() Failed to get data	")
@@ -322,6 +326,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string) {
 			HostStatusInformation: hvs.HostStatusInformation{
 				HostState:         hostState,
 				LastTimeConnected: time.Now(),
+				LastQuoteInfo:     lastQuoteInfo,
 			},
 		})
 		return
@@ -339,6 +344,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string) {
 		HostStatusInformation: hvs.HostStatusInformation{
 			HostState:         hvs.HostStateConnected,
 			LastTimeConnected: time.Now(),
+			LastQuoteInfo:     recordQuoteInfo(hostData, lastQuoteInfo),
 		},
 		HostManifest: *hostData,
 	})
@@ -378,6 +384,44 @@ func (svc *Service) GetHostData(connUrl string) (*types.HostManifest, error) {
 	return &data, err
 }
 
+// getLastQuoteInfo returns the metadata of the last quote of the host from its latest host status
+func (svc *Service) getLastQuoteInfo(hostId uuid.UUID) *types.QuoteInfo {
+	defaultLog.Trace("hostfetcher/Service:getLastQuoteInfo() Entering")
+	defer defaultLog.Trace("hostfetcher/Service:getLastQuoteInfo() Leaving")
+
+	hostStatusCollection, err := svc.hss.Search(&models.HostStatusFilterCriteria{
+		HostId:        hostId,
+		LatestPerHost: true,
+	})
+	if err != nil || len(hostStatusCollection) == 0 {
+		return nil
+	}
+	if hostStatusCollection[0].HostStatusInformation.LastQuoteInfo != nil {
+		return hostStatusCollection[0].HostStatusInformation.LastQuoteInfo
+	}
+	// the host status was stored before the quote metadata was recorded in it
+	return hostStatusCollection[0].HostManifest.QuoteInfo
+}
+
+// recordQuoteInfo sets the nonce and the clock info of the last quote of the host in the quote metadata of the new
+// host manifest, so that the verifier can detect a replayed quote or a TPM clock that went backwards. It returns the
+// quote metadata to record in the host status.
+func recordQuoteInfo(manifest *types.HostManifest, lastQuoteInfo *types.QuoteInfo) *types.QuoteInfo {
+	if manifest == nil || manifest.QuoteInfo == nil {
+		return lastQuoteInfo
+	}
+
+	if lastQuoteInfo != nil {
+		lastClockInfo := lastQuoteInfo.ClockInfo
+		manifest.QuoteInfo.PreviousNonce = lastQuoteInfo.Nonce
+		manifest.QuoteInfo.PreviousClockInfo = &lastClockInfo
+	}
+	return &types.QuoteInfo{
+		Nonce:     manifest.QuoteInfo.Nonce,
+		ClockInfo: manifest.QuoteInfo.ClockInfo,
+	}
+}
+
 func (svc *Service) updateMissingHostDetails(hostId uuid.UUID, manifest *types.HostManifest) {
 	defaultLog.Trace("hostfetcher/Service:updateMissingHostDetails() Entering")
 	defer defaultLog.Trace("hostfetcher/Service:updateMissingHostDetails() Leaving")
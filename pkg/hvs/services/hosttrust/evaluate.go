@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var errReadOnlyEvaluation = errors.New("store is read only during flavor evaluation")
+
+// Evaluate verifies the host data against the flavorgroup requirements and returns the resulting trust report.
+// The candidate flavors are evaluated along with the flavors of the flavorgroup in the store, if the flavorgroup
+// exists. Unlike Verify, the trust cache is neither used nor updated and the report is not stored
+func (v *Verifier) Evaluate(hostId uuid.UUID, hostData *types.HostManifest, fg hvs.FlavorGroup, candidates []hvs.SignedFlavor) (*hvs.TrustReport, error) {
+	defaultLog.Trace("hosttrust/evaluate:Evaluate() Entering")
+	defer defaultLog.Trace("hosttrust/evaluate:Evaluate() Leaving")
+
+	if hostData == nil {
+		return nil, ErrInvalidHostManiFest
+	}
+	hwUuid, err := uuid.Parse(hostData.HostInfo.HardwareUUID)
+	if err != nil || hwUuid == uuid.Nil {
+		return nil, ErrManifestMissingHwUUID
+	}
+
+	dryRun := *v
+	dryRun.FlavorStore = &evaluationFlavorStore{
+		FlavorStore:   v.FlavorStore,
+		flavorgroupId: fg.ID,
+		candidates:    candidates,
+	}
+	dryRun.HostStore = &evaluationHostStore{HostStore: v.HostStore}
+	dryRun.ReportStore = nil
+	dryRun.TrustChangeNotifier = nil
+
+	fgTrustReqs, err := NewFlvGrpHostTrustReqs(hostId, hwUuid, fg, dryRun.FlavorStore, hostData, v.SkipFlavorSignatureVerification)
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/evaluate:Evaluate() Error while retrieving NewFlvGrpHostTrustReqs")
+	}
+	fgTrustReport, err := dryRun.createTrustReport(hostId, hostData, *fgTrustReqs, hostTrustCache{}, fgTrustReqs.GetLatestFlavorTypeMap())
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/evaluate:Evaluate() Error while creating flavorgroup report")
+	}
+
+	trustReport := hvs.TrustReport{HostManifest: *hostData}
+	trustReport.AddResults(fgTrustReport.Results)
+	trustReport.Trusted = trustReport.IsTrusted()
+	return &trustReport, nil
+}
+
+// evaluationFlavorStore serves the candidate flavors of an evaluation in addition to the flavors in the store.
+// Flavorgroup flavors are only read from the store when the evaluated flavorgroup exists, host unique flavors
+// are always read since they apply to the host regardless of the flavorgroup
+type evaluationFlavorStore struct {
+	domain.FlavorStore
+	flavorgroupId uuid.UUID
+	candidates    []hvs.SignedFlavor
+}
+
+func (s *evaluationFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]hvs.SignedFlavor, error) {
+	defaultLog.Trace("hosttrust/evaluate:Search() Entering")
+	defer defaultLog.Trace("hosttrust/evaluate:Search() Leaving")
+
+	flavorParts := map[cf.FlavorPart]bool{}
+	for _, flavorPart := range criteria.FlavorFC.FlavorParts {
+		flavorParts[flavorPart] = false
+	}
+	for flavorPart, latest := range criteria.FlavorPartsWithLatest {
+		flavorParts[flavorPart] = latest
+	}
+	if len(flavorParts) == 0 {
+		for _, flavorPart := range cf.GetFlavorTypes() {
+			flavorParts[flavorPart] = false
+		}
+	}
+
+	// a candidate is newer than any flavor in the store, so for LATEST flavor parts only the last candidate is kept
+	candidates := map[cf.FlavorPart][]hvs.SignedFlavor{}
+	for _, candidate := range s.candidates {
+		var flavorPart cf.FlavorPart
+		if err := (&flavorPart).Parse(candidate.Flavor.Meta.Description.FlavorPart); err != nil {
+			continue
+		}
+		if latest, ok := flavorParts[flavorPart]; ok {
+			if latest {
+				candidates[flavorPart] = []hvs.SignedFlavor{candidate}
+			} else {
+				candidates[flavorPart] = append(candidates[flavorPart], candidate)
+			}
+		}
+	}
+
+	storeCriteria := *criteria
+	storeCriteria.FlavorFC.FlavorParts = nil
+	storeCriteria.FlavorPartsWithLatest = map[cf.FlavorPart]bool{}
+	for flavorPart, latest := range flavorParts {
+		if latest && len(candidates[flavorPart]) > 0 {
+			continue
+		}
+		if s.flavorgroupId != uuid.Nil || flavorPart == cf.FlavorPartHostUnique || flavorPart == cf.FlavorPartAssetTag {
+			storeCriteria.FlavorPartsWithLatest[flavorPart] = latest
+		}
+	}
+
+	var signedFlavors []hvs.SignedFlavor
+	if len(storeCriteria.FlavorPartsWithLatest) > 0 {
+		var err error
+		signedFlavors, err = s.FlavorStore.Search(&storeCriteria)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, flavorPartCandidates := range candidates {
+		signedFlavors = append(signedFlavors, flavorPartCandidates...)
+	}
+	return signedFlavors, nil
+}
+
+func (s *evaluationFlavorStore) GetUniqueFlavorTypesThatExistForHost(hwId uuid.UUID) (map[cf.FlavorPart]bool, error) {
+	defaultLog.Trace("hosttrust/evaluate:GetUniqueFlavorTypesThatExistForHost() Entering")
+	defer defaultLog.Trace("hosttrust/evaluate:GetUniqueFlavorTypesThatExistForHost() Leaving")
+
+	flavorTypes, err := s.FlavorStore.GetUniqueFlavorTypesThatExistForHost(hwId)
+	if err != nil {
+		return nil, err
+	}
+	if flavorTypes == nil {
+		flavorTypes = make(map[cf.FlavorPart]bool)
+	}
+	for _, candidate := range s.candidates {
+		flavorPart := candidate.Flavor.Meta.Description.FlavorPart
+		if flavorPart == cf.FlavorPartHostUnique.String() || flavorPart == cf.FlavorPartAssetTag.String() {
+			flavorTypes[cf.FlavorPart(flavorPart)] = true
+		}
+	}
+	return flavorTypes, nil
+}
+
+func (s *evaluationFlavorStore) GetFlavorTypesInFlavorgroup(flvGrpId uuid.UUID, flvParts []cf.FlavorPart) (map[cf.FlavorPart]bool, error) {
+	defaultLog.Trace("hosttrust/evaluate:GetFlavorTypesInFlavorgroup() Entering")
+	defer defaultLog.Trace("hosttrust/evaluate:GetFlavorTypesInFlavorgroup() Leaving")
+
+	flavorTypes := make(map[cf.FlavorPart]bool)
+	if flvGrpId != uuid.Nil {
+		storeFlavorTypes, err := s.FlavorStore.GetFlavorTypesInFlavorgroup(flvGrpId, flvParts)
+		if err != nil {
+			return nil, err
+		}
+		for flavorPart := range storeFlavorTypes {
+			flavorTypes[flavorPart] = true
+		}
+	}
+	if len(flvParts) == 0 {
+		flvParts = cf.GetFlavorTypes()
+	}
+	for _, candidate := range s.candidates {
+		for _, flavorPart := range flvParts {
+			if candidate.Flavor.Meta.Description.FlavorPart == flavorPart.String() {
+				flavorTypes[flavorPart] = true
+			}
+		}
+	}
+	return flavorTypes, nil
+}
+
+func (s *evaluationFlavorStore) Create(*hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	return nil, errReadOnlyEvaluation
+}
+
+func (s *evaluationFlavorStore) Update(*hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	return nil, errReadOnlyEvaluation
+}
+
+func (s *evaluationFlavorStore) Delete(uuid.UUID) error {
+	return errReadOnlyEvaluation
+}
+
+// evaluationHostStore keeps the trust cache of the host untouched during an evaluation
+type evaluationHostStore struct {
+	domain.HostStore
+}
+
+func (s *evaluationHostStore) AddTrustCacheFlavors(uuid.UUID, []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (s *evaluationHostStore) RemoveTrustCacheFlavors(uuid.UUID, []uuid.UUID) error {
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	libVerifier "github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvaluationVerifier(t *testing.T, flavorStore domain.FlavorStore, reportStore domain.ReportStore) domain.HostTrustVerifier {
+	verifierCertificates := createVerifierCertificates(
+		"../../../lib/verifier/test_data/vmware20/PrivacyCA.pem",
+		"../../../lib/verifier/test_data/vmware20/flavor-signer.crt.pem",
+		"../../../lib/verifier/test_data/vmware20/cms-ca-cert.pem",
+		"../../../lib/verifier/test_data/vmware20/tag-cacerts.pem")
+	flvrVerifier, err := libVerifier.NewVerifier(*verifierCertificates)
+	assert.NoError(t, err)
+
+	return hosttrust.NewVerifier(domain.HostTrustVerifierConfig{
+		FlavorStore:                     flavorStore,
+		FlavorGroupStore:                mocks.NewFakeFlavorgroupStore(),
+		HostStore:                       mocks.NewMockHostStore(),
+		ReportStore:                     reportStore,
+		FlavorVerifier:                  flvrVerifier,
+		SamlIssuerConfig:                *getIssuer(),
+		SkipFlavorSignatureVerification: true,
+	})
+}
+
+// loadEvaluationTestData returns the host manifest and the flavors of the given flavor parts from the verifier test data
+func loadEvaluationTestData(t *testing.T, flavorParts ...cf.FlavorPart) (*hcTypes.HostManifest, []hvs.SignedFlavor) {
+	var hostManifest hcTypes.HostManifest
+	manifestJSON, err := ioutil.ReadFile("../../../lib/verifier/test_data/vmware20/host_manifest.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(manifestJSON, &hostManifest))
+
+	var signedFlavors []hvs.SignedFlavor
+	flavorsJSON, err := ioutil.ReadFile("../../../lib/verifier/test_data/vmware20/signed_flavors.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(flavorsJSON, &signedFlavors))
+
+	var flavors []hvs.SignedFlavor
+	for _, signedFlavor := range signedFlavors {
+		for _, flavorPart := range flavorParts {
+			if signedFlavor.Flavor.Meta.Description.FlavorPart == flavorPart.String() {
+				flavors = append(flavors, signedFlavor)
+			}
+		}
+	}
+	return &hostManifest, flavors
+}
+
+func TestVerifier_EvaluateDoesNotStore(t *testing.T) {
+	hostManifest, signedFlavors := loadEvaluationTestData(t, cf.FlavorPartPlatform, cf.FlavorPartOs, cf.FlavorPartHostUnique)
+	flavorStore := &mocks.MockFlavorStore{}
+	reportStore := mocks.NewEmptyMockReportStore()
+	v := newEvaluationVerifier(t, flavorStore, reportStore)
+
+	fg := hvs.FlavorGroup{MatchPolicies: utils.GetAutomaticFlavorMatchPolicy()}
+	report, err := v.Evaluate(uuid.New(), hostManifest, fg, signedFlavors)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, report.Results)
+	assert.True(t, report.Trusted)
+	assert.NotEmpty(t, report.GetResultsForMarker(cf.FlavorPartPlatform.String()))
+
+	storedFlavors, err := flavorStore.Search(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, storedFlavors)
+	reports, err := reportStore.Search(&models.ReportFilterCriteria{})
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func TestVerifier_EvaluateMissingRequiredFlavor(t *testing.T) {
+	hostManifest, osFlavors := loadEvaluationTestData(t, cf.FlavorPartOs)
+	v := newEvaluationVerifier(t, &mocks.MockFlavorStore{}, mocks.NewEmptyMockReportStore())
+
+	// the automatic flavorgroup requires a PLATFORM flavor
+	fg := hvs.FlavorGroup{MatchPolicies: utils.GetAutomaticFlavorMatchPolicy()}
+	report, err := v.Evaluate(uuid.New(), hostManifest, fg, osFlavors)
+	assert.NoError(t, err)
+	assert.False(t, report.Trusted)
+	assert.False(t, report.IsTrustedForMarker(cf.FlavorPartPlatform.String()))
+}
+
+func TestVerifier_EvaluateInvalidHostData(t *testing.T) {
+	v := newEvaluationVerifier(t, &mocks.MockFlavorStore{}, mocks.NewEmptyMockReportStore())
+
+	_, err := v.Evaluate(uuid.New(), nil, hvs.FlavorGroup{}, nil)
+	assert.Equal(t, hosttrust.ErrInvalidHostManiFest, err)
+
+	_, err = v.Evaluate(uuid.New(), &hcTypes.HostManifest{}, hvs.FlavorGroup{}, nil)
+	assert.Equal(t, hosttrust.ErrManifestMissingHwUUID, err)
+}
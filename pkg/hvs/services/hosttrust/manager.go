@@ -7,9 +7,12 @@ package hosttrust
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
@@ -31,6 +34,18 @@ type verifyTrustJob struct {
 	storPersistId   uuid.UUID
 	getNewHostData  bool
 	preferHashMatch bool
+	// results of the FLAVOR_VERIFY jobs waiting for the verification of the host
+	jobResults []jobResultRef
+}
+
+// jobResultRef points to the result of a host in a FLAVOR_VERIFY job. The references are kept in the
+// "job_results" parameter of the queue records so that the jobs are completed after a restart. Created is set when
+// the job queued the verification of the host, it is not set when the job waits for a verification queued by another
+// request
+type jobResultRef struct {
+	JobId    uuid.UUID `json:"job_id"`
+	Position int       `json:"position"`
+	Created  bool      `json:"created,omitempty"`
 }
 
 type newHostFetch struct {
@@ -60,6 +75,13 @@ type Service struct {
 	hostStore       domain.HostStore
 	verifier        domain.HostTrustVerifier
 	hostStatusStore domain.HostStatusStore
+	// reports created by the verification are published to live subscribers when set
+	reportBroadcaster domain.ReportBroadcaster
+	// the hosts queued by each VerifyHostsAsync call are tracked as a FLAVOR_VERIFY job when set
+	jobStore domain.JobStore
+	// number of results of each job still waiting for a verification, guarded by the map mutex
+	pendingJobResults map[uuid.UUID]int
+	lastJobCleanup    time.Time
 	// waitgroup used to wait for workers to finish up when signal for shutdown comes in
 	wg          sync.WaitGroup
 	quit        chan struct{}
@@ -71,12 +93,15 @@ func NewService(cfg domain.HostTrustMgrConfig) (*Service, domain.HostTrustManage
 	defer defaultLog.Trace("hosttrust/manager:NewService() Leaving")
 
 	svc := &Service{prstStor: cfg.PersistStore,
-		hdFetcher:       cfg.HostFetcher,
-		hostStore:       cfg.HostStore,
-		verifier:        cfg.HostTrustVerifier,
-		hostStatusStore: cfg.HostStatusStore,
-		quit:            make(chan struct{}),
-		hosts:           make(map[uuid.UUID]*verifyTrustJob),
+		hdFetcher:         cfg.HostFetcher,
+		hostStore:         cfg.HostStore,
+		verifier:          cfg.HostTrustVerifier,
+		hostStatusStore:   cfg.HostStatusStore,
+		reportBroadcaster: cfg.ReportBroadcaster,
+		jobStore:          cfg.JobStore,
+		quit:              make(chan struct{}),
+		hosts:             make(map[uuid.UUID]*verifyTrustJob),
+		pendingJobResults: make(map[uuid.UUID]int),
 	}
 	var err error
 	nw := cfg.Verifiers
@@ -130,19 +155,47 @@ func (svc *Service) VerifyHost(hostId uuid.UUID, fetchHostData, preferHashMatch
 		hostData, err = svc.hdFetcher.Retrieve(context.Background(), hvs.Host{
 			Id:               host.Id,
 			ConnectionString: host.ConnectionString})
-
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve host data for host id "+hostId.String())
+		}
 	} else {
-		hostStatusCollection, err := svc.hostStatusStore.Search(&models.HostStatusFilterCriteria{
-			HostId:        hostId,
-			LatestPerHost: true,
-		})
-		if err != nil || len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
-			return nil, errors.New("could not retrieve host manifest for host id " + hostId.String())
+		var err error
+		hostData, err = svc.retrieveHostManifest(hostId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	report, err := svc.verifier.Verify(hostId, hostData, fetchHostData)
+	if err == nil {
+		svc.publishReport(report)
+	}
+	return report, err
+}
+
+func (svc *Service) EvaluateHost(hostId uuid.UUID, hostData *types.HostManifest, fg hvs.FlavorGroup, flavors []hvs.SignedFlavor) (*hvs.TrustReport, error) {
+	defaultLog.Trace("hosttrust/manager:EvaluateHost() Entering")
+	defer defaultLog.Trace("hosttrust/manager:EvaluateHost() Leaving")
+
+	if hostData == nil {
+		var err error
+		hostData, err = svc.retrieveHostManifest(hostId)
+		if err != nil {
+			return nil, err
 		}
+	}
+	return svc.verifier.Evaluate(hostId, hostData, fg, flavors)
+}
 
-		hostData = &hostStatusCollection[0].HostManifest
+// retrieveHostManifest returns the latest manifest of a connected host from the host status store
+func (svc *Service) retrieveHostManifest(hostId uuid.UUID) (*types.HostManifest, error) {
+	hostStatusCollection, err := svc.hostStatusStore.Search(&models.HostStatusFilterCriteria{
+		HostId:        hostId,
+		LatestPerHost: true,
+	})
+	if err != nil || len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
+		return nil, errors.New("could not retrieve host manifest for host id " + hostId.String())
 	}
-	return svc.verifier.Verify(hostId, hostData, fetchHostData)
+	return &hostStatusCollection[0].HostManifest, nil
 }
 
 func (svc *Service) ProcessQueue() error {
@@ -163,6 +216,7 @@ func (svc *Service) ProcessQueue() error {
 				hostId := uuid.UUID{}
 				fetchHostData := false
 				preferHashMatch := false
+				var jobResults []jobResultRef
 				for key, value := range queue.Params {
 					if key == "host_id" {
 						hostId = uuid.MustParse(value.(string))
@@ -173,17 +227,23 @@ func (svc *Service) ProcessQueue() error {
 					if key == "prefer_hash_match" {
 						preferHashMatch = value.(bool)
 					}
+					if key == "job_results" {
+						jobResults = parseJobResults(value)
+					}
 				}
 				if fetchHostData {
 					verifyWithFetchDataHostIds = append(verifyWithFetchDataHostIds, hostId)
 				} else {
 					verifyHostIds = append(verifyHostIds, hostId)
 				}
-				ctx, cancel := context.WithCancel(context.Background())
+				ctx, cancel := newJobContext(fetchHostData)
 
 				// the host field is not filled at this stage since it requires a trip to the host store
 				svc.hosts[hostId] = &verifyTrustJob{ctx, cancel, nil, queue.Id,
-					fetchHostData, preferHashMatch}
+					fetchHostData, preferHashMatch, jobResults}
+				for _, jr := range jobResults {
+					svc.pendingJobResults[jr.JobId]++
+				}
 			}
 		}
 		svc.mapmtx.Unlock()
@@ -199,43 +259,228 @@ func (svc *Service) ProcessQueue() error {
 	return nil
 }
 
-func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+// VerifyHostsAsync queues the hosts for trust verification and returns the id of the FLAVOR_VERIFY job tracking
+// them, the id is nil when the hosts are not tracked by a job
+func (svc *Service) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) (uuid.UUID, error) {
 	defaultLog.Trace("hosttrust/manager:VerifyHostsAsync() Entering")
 	defer defaultLog.Trace("hosttrust/manager:VerifyHostsAsync() Leaving")
 
 	adds := make([]uuid.UUID, 0, len(hostIds))
 	updates := []uuid.UUID{}
+	jobId, jobResults := svc.createJob(hostIds)
+	if jobId != uuid.Nil {
+		// the job results are counted up front so that the job cannot complete before all the hosts are queued
+		svc.mapmtx.Lock()
+		svc.pendingJobResults[jobId] += len(jobResults)
+		svc.mapmtx.Unlock()
+	}
 
-	svc.mapmtx.RLock()
-	// iterate through the hosts and check if there is an existing entry
-	for _, hid := range hostIds {
-		vtj, found := svc.hosts[hid]
-		if found {
-			prevJobStage, _ := taskstage.FromContext(vtj.ctx)
-			if shouldCancelPrevJob(fetchHostData, vtj.getNewHostData, prevJobStage) {
-				// cancel the curr Job and make a new entry
-				vtj.cancelFn()
-				updates = append(updates, hid)
+	// the queue records are persisted without holding the map lock, the hosts whose map entry changed in the meantime
+	// are planned again against the new entry
+	var err error
+	for pending := hostIds; len(pending) > 0; {
+		svc.mapmtx.Lock()
+		records := svc.planQueueRecords(pending, fetchHostData, preferHashMatch, jobResults)
+		svc.mapmtx.Unlock()
+
+		err = svc.persistToStore(records)
+
+		svc.mapmtx.Lock()
+		var stale []uuid.UUID
+		pending, stale, err = svc.recordQueueRecords(records, err, &adds, &updates)
+		svc.mapmtx.Unlock()
+		for _, strRecId := range stale {
+			if deleteErr := svc.prstStor.Delete(strRecId); deleteErr != nil {
+				log.Error("could not delete from persistent queue store err - ", deleteErr)
 			}
-			continue
-		} else {
-			adds = append(adds, hid)
+		}
+		if err != nil {
+			break
 		}
 	}
-	svc.mapmtx.RUnlock()
-	if err := svc.persistToStore(adds, updates, fetchHostData, preferHashMatch); err != nil {
-		return errors.Wrap(err, "hosttrust/manager:VerifyHostsAsync() persistRequest - error in Persisting to Store")
+	if err != nil {
+		// the job does not wait for the hosts that could not be queued
+		if jobId != uuid.Nil {
+			if cancelErr := svc.CancelJob(jobId); cancelErr != nil {
+				defaultLog.WithError(cancelErr).Error("hosttrust/manager:VerifyHostsAsync() Could not cancel job")
+			}
+		}
+		return uuid.Nil, errors.Wrap(err, "hosttrust/manager:VerifyHostsAsync() persistRequest - error in Persisting to Store")
 	}
 	// at this point, it is safe to return the async call as the records have been persisted.
 	if fetchHostData {
 		svc.wg.Add(1)
-		go svc.submitHostDataFetch(adds)
+		go svc.submitHostDataFetch(adds, updates)
 	} else {
 		go svc.queueFlavorVerify(adds, updates)
 	}
+	return jobId, nil
+}
+
+// queueRecord is the queue record of a host planned from the map entry of the host. The record creates a new entry
+// when the host is not queued, replaces the entry when the queued verification is cancelled, or adds the job result
+// to the queued verification
+type queueRecord struct {
+	hostId uuid.UUID
+	// entry is the map entry the record was planned from, nil for a new entry
+	entry           *verifyTrustJob
+	entryJobResults []jobResultRef
+	storPersistId   uuid.UUID
+	fetchHostData   bool
+	preferHashMatch bool
+	jobResults      []jobResultRef
+	// addJobResult is set when the job waits for the verification queued by another request
+	addJobResult bool
+	persisted    bool
+	failed       bool
+}
+
+// planQueueRecords plans the queue records of the hosts, the verifications that should not be kept are cancelled. The
+// caller holds the map lock
+func (svc *Service) planQueueRecords(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool, jobResults map[uuid.UUID]jobResultRef) []queueRecord {
+	defaultLog.Trace("hosttrust/manager:planQueueRecords() Entering")
+	defer defaultLog.Trace("hosttrust/manager:planQueueRecords() Leaving")
+
+	records := make([]queueRecord, 0, len(hostIds))
+	planned := make(map[uuid.UUID]bool, len(hostIds))
+	// iterate through the hosts and check if there is an existing entry
+	for _, hid := range hostIds {
+		if planned[hid] {
+			continue
+		}
+		planned[hid] = true
+		jr, hasJobResult := jobResults[hid]
+		vtj, found := svc.hosts[hid]
+		if !found {
+			record := queueRecord{hostId: hid, fetchHostData: fetchHostData, preferHashMatch: preferHashMatch}
+			if hasJobResult {
+				record.jobResults = []jobResultRef{jr}
+			}
+			records = append(records, record)
+			continue
+		}
+
+		prevJobStage, _ := taskstage.FromContext(vtj.ctx)
+		if shouldCancelPrevJob(fetchHostData, vtj.getNewHostData, prevJobStage) {
+			// cancel the curr Job and make a new entry, the jobs waiting for the cancelled verification wait for
+			// the new one
+			vtj.cancelFn()
+			record := queueRecord{hostId: hid, entry: vtj, storPersistId: vtj.storPersistId,
+				fetchHostData: fetchHostData, preferHashMatch: preferHashMatch, entryJobResults: vtj.jobResults,
+				jobResults: append([]jobResultRef{}, vtj.jobResults...)}
+			if hasJobResult {
+				record.jobResults = append(record.jobResults, jr)
+			}
+			records = append(records, record)
+		} else if hasJobResult {
+			// the host already queued for the verification is not queued again, the job waits for its verification
+			jr.Created = false
+			records = append(records, queueRecord{hostId: hid, entry: vtj, storPersistId: vtj.storPersistId,
+				fetchHostData: vtj.getNewHostData, preferHashMatch: vtj.preferHashMatch, entryJobResults: vtj.jobResults,
+				jobResults: append(append([]jobResultRef{}, vtj.jobResults...), jr), addJobResult: true})
+		}
+	}
+	return records
+}
+
+// persistToStore creates and updates the queue records of the hosts, it stops at the first record that could not be
+// persisted. The map lock is not held so that the workers are not blocked by the queue store
+func (svc *Service) persistToStore(records []queueRecord) error {
+	defaultLog.Trace("hosttrust/manager:persistToStore() Entering")
+	defer defaultLog.Trace("hosttrust/manager:persistToStore() Leaving")
+
+	for i := range records {
+		record := &records[i]
+		strRec := &models.Queue{Id: record.storPersistId,
+			Params: queueParams(record.hostId, record.fetchHostData, record.preferHashMatch, record.jobResults),
+		}
+		if record.entry == nil {
+			strRec.Action = "flavor-verify"
+			strRec.State = models.QueueStatePending
+			defaultLog.Debugf("hosttrust/manager:persistToStore() DEBUG - Creating FVQueue entry for host %s | %v", record.hostId.String(), strRec)
+			created, err := svc.prstStor.Create(strRec)
+			if err != nil {
+				record.failed = true
+				return errors.Wrapf(err, "hosttrust/manager:persistToStore() - Could not create queue record for host %s", record.hostId.String())
+			}
+			record.storPersistId = created.Id
+		} else {
+			if !record.addJobResult {
+				strRec.Action = "flavor-verify"
+				strRec.State = models.QueueStatePending
+			}
+			defaultLog.Debugf("hosttrust/manager:persistToStore() DEBUG - Updating FVQueue entry for host %s | %v", record.hostId.String(), strRec)
+			if err := svc.prstStor.Update(strRec); err != nil {
+				record.failed = true
+				return errors.Wrapf(err, "hosttrust/manager:persistToStore() - Could not update queue record for host %s", record.hostId.String())
+			}
+		}
+		record.persisted = true
+	}
 	return nil
 }
 
+// recordQueueRecords records the persisted queue records in the map. The hosts whose map entry changed while the records
+// were persisted, and the hosts that were not persisted, are returned to be planned again with the records created for
+// them that are no longer used. The error of a record is ignored when its map entry changed. The caller holds the map
+// lock
+func (svc *Service) recordQueueRecords(records []queueRecord, persistErr error, adds, updates *[]uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	defaultLog.Trace("hosttrust/manager:recordQueueRecords() Entering")
+	defer defaultLog.Trace("hosttrust/manager:recordQueueRecords() Leaving")
+
+	var replan, stale []uuid.UUID
+	for i := range records {
+		record := &records[i]
+		if !record.persisted && !record.failed {
+			replan = append(replan, record.hostId)
+			continue
+		}
+		vtj, found := svc.hosts[record.hostId]
+		if record.entry == nil && found ||
+			record.entry != nil && (vtj != record.entry || !sameJobResults(vtj.jobResults, record.entryJobResults)) {
+			// another request queued the host or changed its jobs, or the queued verification completed, in the
+			// meantime
+			replan = append(replan, record.hostId)
+			if record.entry == nil && record.persisted {
+				stale = append(stale, record.storPersistId)
+			}
+			if record.failed {
+				persistErr = nil
+			}
+			continue
+		}
+		if record.failed {
+			break
+		}
+		if record.addJobResult {
+			vtj.jobResults = record.jobResults
+			continue
+		}
+		ctx, cancel := newJobContext(record.fetchHostData)
+		// the host field is not filled at this stage since it requires a trip to the host store
+		svc.hosts[record.hostId] = &verifyTrustJob{ctx, cancel, nil, record.storPersistId,
+			record.fetchHostData, record.preferHashMatch, record.jobResults}
+		if record.entry == nil {
+			*adds = append(*adds, record.hostId)
+		} else {
+			*updates = append(*updates, record.hostId)
+		}
+	}
+	return replan, stale, persistErr
+}
+
+func sameJobResults(a, b []jobResultRef) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (svc *Service) submitHostDataFetch(hostLists ...[]uuid.UUID) {
 	defaultLog.Trace("hosttrust/manager:submitHostDataFetch() Entering")
 	defer defaultLog.Trace("hosttrust/manager:submitHostDataFetch() Leaving")
@@ -248,6 +493,12 @@ func (svc *Service) submitHostDataFetch(hostLists ...[]uuid.UUID) {
 		for _, hId := range hosts {
 			if host, err := svc.hostStore.Retrieve(hId); err != nil {
 				defaultLog.Info("hosttrust/manager:submitHostDataFetch() - error retrieving host data for id", hId)
+				svc.mapmtx.RLock()
+				vtj, ok := svc.hosts[hId]
+				svc.mapmtx.RUnlock()
+				if ok {
+					svc.deleteEntry(hId, vtj.ctx, nil, errors.Wrap(err, "could not retrieve host id "+hId.String()))
+				}
 				continue
 			} else {
 				svc.mapmtx.Lock() //  need to update the record - so take a write lock
@@ -287,89 +538,6 @@ func (svc *Service) queueFlavorVerify(hostsLists ...[]uuid.UUID) {
 	}
 }
 
-func (svc *Service) persistToStore(additions, updates []uuid.UUID, fetchHostData, preferHashMatch bool) error {
-	defaultLog.Trace("hosttrust/manager:persistToStore() Entering")
-	defer defaultLog.Trace("hosttrust/manager:persistToStore() Leaving")
-
-	persistRecords := func(lst []uuid.UUID, create bool) error {
-		strRec := &models.Queue{Action: "flavor-verify",
-			Params: map[string]interface{}{"host_id": uuid.Nil, "fetch_host_data": fetchHostData, "prefer_hash_match": preferHashMatch},
-			State:  models.QueueStatePending,
-		}
-
-		for _, hid := range lst {
-			var err error
-
-			mapNeedsUpdate := false
-
-			// if record does not exist in map
-			if create {
-				existingHTVJob, htvJobExists := svc.hosts[hid]
-				// check if the record is the same as the one we trying to put in
-				if htvJobExists && existingHTVJob.getNewHostData == fetchHostData && existingHTVJob.preferHashMatch == preferHashMatch {
-					defaultLog.Infof("hosttrust/manager:persistToStore() DEBUG - Skipping adding to queue as it already exists in table %s | %v", hid.String(), strRec)
-					// if yes, then skip
-					continue
-				}
-				strRec.Params["host_id"] = hid
-				defaultLog.Debugf("hosttrust/manager:persistToStore() DEBUG - Creating FVQueue entry for host %s | %v", hid.String(), strRec)
-				if strRec, err = svc.prstStor.Create(strRec); err != nil {
-					return errors.Wrapf(err, "hosttrust/manager:persistToStore() - Could not create queue record for host %s", hid.String())
-				}
-				// update record in map
-				mapNeedsUpdate = true
-			} else {
-				// check if the map entry still exists
-				if _, ok := svc.hosts[hid]; !ok {
-					return errors.Errorf("hosttrust/manager:persistToStore() - Update record failed - Host %s map entry does not exist!", hid.String())
-				}
-				// check if the map entry points to a valid queue record
-				if hidMapEntry, ok := svc.hosts[hid]; ok && hidMapEntry.storPersistId == uuid.Nil {
-					return errors.Errorf("hosttrust/manager:persistToStore() - Update record failed - Host %s map entry does not point to a valid queue record!", hid.String())
-				}
-
-				strRec.Id = svc.hosts[hid].storPersistId
-				if strRec.Id == uuid.Nil {
-					return errors.Errorf("hosttrust/manager:persistToStore() - Update record failed as the ")
-				}
-				strRec.Params["host_id"] = hid
-				defaultLog.Debugf("hosttrust/manager:persistToStore() DEBUG - Updating FVQueue entry for host %s | %v", hid.String(), strRec)
-				if err = svc.prstStor.Update(strRec); err != nil {
-					return errors.Wrap(err, "hosttrust/manager:persistToStore() - Could not update record")
-				}
-				// update record in map
-				mapNeedsUpdate = true
-			}
-			// update map ONLY if CRUD operation on queue store
-			if mapNeedsUpdate {
-				ctx, cancel := context.WithCancel(context.Background())
-
-				// check if existing map has fetchHostData == true - then force update to true
-				if !create && svc.hosts[hid].getNewHostData && !fetchHostData {
-					// the host field is not filled at this stage since it requires a trip to the host store
-					svc.hosts[hid] = &verifyTrustJob{ctx, cancel, nil, strRec.Id,
-						true, preferHashMatch}
-				} else {
-					// the host field is not filled at this stage since it requires a trip to the host store
-					svc.hosts[hid] = &verifyTrustJob{ctx, cancel, nil, strRec.Id,
-						fetchHostData, preferHashMatch}
-				}
-			}
-		}
-		return nil
-	}
-	svc.mapmtx.Lock()
-	defer svc.mapmtx.Unlock()
-	if err := persistRecords(additions, true); err != nil {
-		return errors.Wrap(err, "hosttrust/manager:persistToStore() - persistRecords additions error")
-	}
-	if err := persistRecords(updates, false); err != nil {
-		return errors.Wrap(err, "hosttrust/manager:persistToStore() - persistRecords updates error")
-	}
-
-	return nil
-}
-
 // function that does the actual work. There are two seperate channels that contains work.
 // First one is the flavor verification work submitted that does not require new host data
 // Second one is work that first requires new data from host.
@@ -406,7 +574,13 @@ func (svc *Service) doWork() {
 				})
 				if err != nil || len(hostStatusCollection) == 0 || hostStatusCollection[0].HostStatusInformation.HostState != hvs.HostStateConnected {
 					defaultLog.Error("hosttrust/manager:doWork() - could not retrieve host data from store - error :", err)
-					return
+					svc.mapmtx.RLock()
+					vtj, ok := svc.hosts[hId]
+					svc.mapmtx.RUnlock()
+					if ok {
+						svc.deleteEntry(hId, vtj.ctx, nil, errors.New("could not retrieve host manifest for host id "+hId.String()))
+					}
+					continue
 				}
 				hostId = hId
 				hostData = &hostStatusCollection[0].HostManifest
@@ -451,12 +625,14 @@ func (svc *Service) verifyHostData(hostId uuid.UUID, data *types.HostManifest, n
 	}
 	svc.mapmtx.Unlock()
 
-	_, err := svc.verifier.Verify(hostId, data, newData)
+	report, err := svc.verifier.Verify(hostId, data, newData)
 	if err != nil {
 		defaultLog.WithError(err).Errorf("hosttrust/manager:verifyHostData() Error while verification")
+	} else {
+		svc.publishReport(report)
 	}
 	// verify is completed - delete the entry
-	svc.deleteEntry(hostId)
+	svc.deleteEntry(hostId, vtj.ctx, report, err)
 }
 
 // This function is the implementation of the HostDataReceiver interface method. Just create a new request
@@ -472,7 +648,8 @@ func (svc *Service) ProcessHostData(ctx context.Context, host hvs.Host, data *ty
 	}
 	// if there is an error - delete the entry
 	if err != nil {
-		svc.deleteEntry(host.Id)
+		svc.deleteEntry(host.Id, ctx, nil, errors.Wrap(err, "could not retrieve host data for host id "+host.Id.String()))
+		return nil
 	}
 
 	// queue the new data to be processed by one of the worker threads by adding this to the queue
@@ -485,6 +662,13 @@ func (svc *Service) ProcessHostData(ctx context.Context, host hvs.Host, data *ty
 	return nil
 }
 
+// publishReport makes the report created by a finished verification available to the report stream subscribers
+func (svc *Service) publishReport(report *models.HVSReport) {
+	if svc.reportBroadcaster != nil && report != nil {
+		svc.reportBroadcaster.Publish(report)
+	}
+}
+
 func shouldCancelPrevJob(newJobNeedFreshHostData, prevJobNeededFreshData bool, prevJobStage taskstage.Stage) bool {
 	defaultLog.Trace("hosttrust/manager:shouldCancelPrevJob() Entering")
 	defer defaultLog.Trace("hosttrust/manager:shouldCancelPrevJob() Leaving")
@@ -498,14 +682,18 @@ func shouldCancelPrevJob(newJobNeedFreshHostData, prevJobNeededFreshData bool, p
 	return true
 }
 
-func (svc *Service) deleteEntry(hostId uuid.UUID) {
+// deleteEntry removes the entry of a finished verification and records its outcome in the jobs waiting for it. The
+// entry is identified by its context so that an entry replaced by a newer request for the host is not removed
+func (svc *Service) deleteEntry(hostId uuid.UUID, ctx context.Context, report *models.HVSReport, verifyErr error) {
 	defaultLog.Trace("hosttrust/manager:deleteEntry() Entering")
 	defer defaultLog.Trace("hosttrust/manager:deleteEntry() Leaving")
 
 	var strRecId uuid.UUID
+	var jobResults []jobResultRef
 	svc.mapmtx.Lock()
-	if strRec, exists := svc.hosts[hostId]; exists {
+	if strRec, exists := svc.hosts[hostId]; exists && strRec.ctx == ctx {
 		strRecId = strRec.storPersistId
+		jobResults = strRec.jobResults
 		strRec.ctx.Done()
 		delete(svc.hosts, hostId)
 	}
@@ -516,4 +704,226 @@ func (svc *Service) deleteEntry(hostId uuid.UUID) {
 			log.Error("could not delete from persistent queue store err - ", err)
 		}
 	}
+	svc.completeJobResults(hostId, jobResults, report, verifyErr)
+}
+
+// JobStages returns the stage of the hosts of a job that are still queued for trust verification
+func (svc *Service) JobStages(jobId uuid.UUID) map[uuid.UUID]taskstage.Stage {
+	defaultLog.Trace("hosttrust/manager:JobStages() Entering")
+	defer defaultLog.Trace("hosttrust/manager:JobStages() Leaving")
+
+	stages := make(map[uuid.UUID]taskstage.Stage)
+	svc.mapmtx.RLock()
+	defer svc.mapmtx.RUnlock()
+	for hostId, vtj := range svc.hosts {
+		for _, jr := range vtj.jobResults {
+			if jr.JobId == jobId {
+				stages[hostId], _ = taskstage.FromContext(vtj.ctx)
+			}
+		}
+	}
+	return stages
+}
+
+// CancelJob removes the job from the queued verifications of its hosts. The verification of a host is only cancelled
+// when the job queued it and no other job waits for it, a verification that already started still completes. The
+// verifications queued by other requests that the job waits for are left queued. The pending results of the job are
+// cancelled
+func (svc *Service) CancelJob(jobId uuid.UUID) error {
+	defaultLog.Trace("hosttrust/manager:CancelJob() Entering")
+	defer defaultLog.Trace("hosttrust/manager:CancelJob() Leaving")
+
+	if svc.jobStore == nil {
+		return errors.New("hosttrust/manager:CancelJob() Jobs are not tracked")
+	}
+	job, err := svc.jobStore.Retrieve(jobId)
+	if err != nil {
+		return errors.Wrap(err, "hosttrust/manager:CancelJob() Could not retrieve job "+jobId.String())
+	}
+	if job.Type != hvs.JobTypeFlavorVerify || job.State != hvs.JobStateRunning {
+		return errors.Errorf("hosttrust/manager:CancelJob() Job %s is not a running %s job", jobId, hvs.JobTypeFlavorVerify)
+	}
+
+	var strRecIds []uuid.UUID
+	var strRecUpdates []*models.Queue
+	svc.mapmtx.Lock()
+	delete(svc.pendingJobResults, jobId)
+	for hostId, vtj := range svc.hosts {
+		var jobResults []jobResultRef
+		created := false
+		for _, jr := range vtj.jobResults {
+			if jr.JobId != jobId {
+				jobResults = append(jobResults, jr)
+			} else if jr.Created {
+				created = true
+			}
+		}
+		if len(jobResults) == len(vtj.jobResults) {
+			continue
+		}
+		if len(jobResults) == 0 && created {
+			vtj.cancelFn()
+			delete(svc.hosts, hostId)
+			strRecIds = append(strRecIds, vtj.storPersistId)
+			continue
+		}
+		vtj.jobResults = jobResults
+		strRecUpdates = append(strRecUpdates, &models.Queue{Id: vtj.storPersistId,
+			Params: queueParams(hostId, vtj.getNewHostData, vtj.preferHashMatch, jobResults)})
+	}
+	svc.mapmtx.Unlock()
+	for _, strRec := range strRecUpdates {
+		if err := svc.prstStor.Update(strRec); err != nil {
+			defaultLog.WithError(err).Errorf("hosttrust/manager:CancelJob() Could not update queue record %s", strRec.Id)
+		}
+	}
+	for _, strRecId := range strRecIds {
+		if err := svc.prstStor.Delete(strRecId); err != nil {
+			log.Error("could not delete from persistent queue store err - ", err)
+		}
+	}
+
+	cancelledAt := time.Now()
+	for position, result := range job.Results {
+		if result.State != hvs.JobResultStatePending {
+			continue
+		}
+		result.State = hvs.JobResultStateCancelled
+		result.CompletedAt = &cancelledAt
+		if err := svc.jobStore.UpdateResult(jobId, position, &result); err != nil {
+			return errors.Wrap(err, "hosttrust/manager:CancelJob() Could not cancel job result")
+		}
+	}
+	job.State = hvs.JobStateCancelled
+	job.CompletedAt = &cancelledAt
+	if err := svc.jobStore.Update(job); err != nil {
+		return errors.Wrap(err, "hosttrust/manager:CancelJob() Could not cancel job")
+	}
+	return nil
+}
+
+// createJob stores a running FLAVOR_VERIFY job with a pending result for every host and returns the id of the job
+// with the result of each host. No job is tracked when the job store is not set or the job cannot be stored
+func (svc *Service) createJob(hostIds []uuid.UUID) (uuid.UUID, map[uuid.UUID]jobResultRef) {
+	defaultLog.Trace("hosttrust/manager:createJob() Entering")
+	defer defaultLog.Trace("hosttrust/manager:createJob() Leaving")
+
+	if svc.jobStore == nil || len(hostIds) == 0 {
+		return uuid.Nil, nil
+	}
+	svc.cleanupJobs()
+
+	jobResults := make(map[uuid.UUID]jobResultRef, len(hostIds))
+	results := make([]hvs.JobResult, 0, len(hostIds))
+	for _, hid := range hostIds {
+		if _, ok := jobResults[hid]; ok {
+			continue
+		}
+		hostId := hid
+		jobResults[hid] = jobResultRef{Position: len(results), Created: true}
+		results = append(results, hvs.JobResult{HostId: &hostId, State: hvs.JobResultStatePending})
+	}
+	job, err := svc.jobStore.Create(&hvs.Job{
+		Type:    hvs.JobTypeFlavorVerify,
+		State:   hvs.JobStateRunning,
+		Results: results,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("hosttrust/manager:createJob() Could not create flavor verification job")
+		return uuid.Nil, nil
+	}
+	for hid, jr := range jobResults {
+		jr.JobId = job.Id
+		jobResults[hid] = jr
+	}
+	return job.Id, jobResults
+}
+
+// completeJobResults records the outcome of the verification of a host in the results of the jobs that waited for it,
+// the jobs without any pending result left are completed
+func (svc *Service) completeJobResults(hostId uuid.UUID, jobResults []jobResultRef, report *models.HVSReport, verifyErr error) {
+	if svc.jobStore == nil || len(jobResults) == 0 {
+		return
+	}
+
+	completedAt := time.Now()
+	result := hvs.JobResult{HostId: &hostId, State: hvs.JobResultStateSucceeded, CompletedAt: &completedAt}
+	if verifyErr != nil {
+		result.State = hvs.JobResultStateFailed
+		result.Error = verifyErr.Error()
+	} else if report != nil {
+		trusted := report.TrustReport.IsTrusted()
+		result.Trusted = &trusted
+	}
+	for _, jr := range jobResults {
+		jobResult := result
+		if err := svc.jobStore.UpdateResult(jr.JobId, jr.Position, &jobResult); err != nil {
+			defaultLog.WithError(err).Errorf("hosttrust/manager:completeJobResults() Could not update result of host %s in job %s", hostId, jr.JobId)
+		}
+
+		// the pending results are counted down once the result is stored, a job is only completed with all its
+		// results stored
+		svc.mapmtx.Lock()
+		pending, ok := svc.pendingJobResults[jr.JobId]
+		if ok && pending > 1 {
+			svc.pendingJobResults[jr.JobId] = pending - 1
+		} else {
+			delete(svc.pendingJobResults, jr.JobId)
+		}
+		svc.mapmtx.Unlock()
+		if !ok || pending > 1 {
+			continue
+		}
+		if err := svc.jobStore.Update(&hvs.Job{Id: jr.JobId, State: hvs.JobStateCompleted, CompletedAt: &completedAt}); err != nil {
+			defaultLog.WithError(err).Errorf("hosttrust/manager:completeJobResults() Could not complete job %s", jr.JobId)
+		}
+	}
+}
+
+// cleanupJobs deletes the jobs that ended more than the job retention ago, at most once every job cleanup interval
+func (svc *Service) cleanupJobs() {
+	svc.mapmtx.Lock()
+	if time.Since(svc.lastJobCleanup) < constants.JobCleanupInterval {
+		svc.mapmtx.Unlock()
+		return
+	}
+	svc.lastJobCleanup = time.Now()
+	svc.mapmtx.Unlock()
+
+	if err := svc.jobStore.DeleteCompletedBefore(time.Now().Add(-constants.JobRetention)); err != nil {
+		defaultLog.WithError(err).Error("hosttrust/manager:cleanupJobs() Could not delete completed jobs")
+	}
+}
+
+// newJobContext returns the cancellable context of a queued verification, the context holds the stage of the
+// verification
+func newJobContext(fetchHostData bool) (context.Context, context.CancelFunc) {
+	stage := taskstage.FlavorVerifyQueued
+	if fetchHostData {
+		stage = taskstage.GetHostDataQueued
+	}
+	return context.WithCancel(taskstage.NewContext(context.Background(), stage))
+}
+
+// queueParams returns the parameters of the queue record of the verification of a host
+func queueParams(hostId uuid.UUID, fetchHostData, preferHashMatch bool, jobResults []jobResultRef) map[string]interface{} {
+	params := map[string]interface{}{"host_id": hostId, "fetch_host_data": fetchHostData, "prefer_hash_match": preferHashMatch}
+	if len(jobResults) > 0 {
+		params["job_results"] = jobResults
+	}
+	return params
+}
+
+// parseJobResults reads the job results from the parameter of a queue record
+func parseJobResults(value interface{}) []jobResultRef {
+	var jobResults []jobResultRef
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, &jobResults)
+	}
+	if err != nil {
+		defaultLog.WithError(err).Error("hosttrust/manager:parseJobResults() Could not parse job results of queue record")
+		return nil
+	}
+	return jobResults
 }
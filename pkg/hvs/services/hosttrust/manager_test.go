@@ -6,6 +6,7 @@
 package hosttrust_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
@@ -18,8 +19,10 @@ import (
 	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	libVerifier "github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"sync"
 	"time"
 
 	"testing"
@@ -62,7 +65,7 @@ func TestHostTrustManagerNewService(t *testing.T) {
 		HostTrustVerifier: fv,
 	})
 
-	err = ht.VerifyHostsAsync([]uuid.UUID{newHost.Id}, true, false)
+	_, err = ht.VerifyHostsAsync([]uuid.UUID{newHost.Id}, true, false)
 	assert.NoError(t, err)
 	time.Sleep(time.Duration(5 * time.Second))
 
@@ -146,3 +149,252 @@ func TestVerifier_Verify(t *testing.T) {
 	fmt.Println(report.Saml)
 	assert.NoError(t, err)
 }
+
+// queuedHostDataFetcher holds the host data requests till the test answers them
+type queuedHostDataFetcher struct {
+	lock     sync.Mutex
+	requests map[uuid.UUID]queuedHostDataRequest
+}
+
+type queuedHostDataRequest struct {
+	ctx   context.Context
+	host  hvs.Host
+	rcvrs []domain.HostDataReceiver
+}
+
+func (fetcher *queuedHostDataFetcher) Retrieve(ctx context.Context, host hvs.Host) (*hcTypes.HostManifest, error) {
+	return nil, errors.New("Retrieve is not implemented")
+}
+
+func (fetcher *queuedHostDataFetcher) RetrieveAsync(ctx context.Context, host hvs.Host, rcvrs ...domain.HostDataReceiver) error {
+	fetcher.lock.Lock()
+	defer fetcher.lock.Unlock()
+	fetcher.requests[host.Id] = queuedHostDataRequest{ctx, host, rcvrs}
+	return nil
+}
+
+// respond answers the latest host data request of the host, with an error when err is set
+func (fetcher *queuedHostDataFetcher) respond(hostId uuid.UUID, err error) bool {
+	fetcher.lock.Lock()
+	request, ok := fetcher.requests[hostId]
+	delete(fetcher.requests, hostId)
+	fetcher.lock.Unlock()
+	if !ok {
+		return false
+	}
+	var hostData *hcTypes.HostManifest
+	if err == nil {
+		hostData = &hcTypes.HostManifest{}
+	}
+	for _, rcv := range request.rcvrs {
+		_ = rcv.ProcessHostData(request.ctx, request.host, hostData, err)
+	}
+	return true
+}
+
+// trustedHostVerifier reports every host as trusted
+type trustedHostVerifier struct{}
+
+func (trustedHostVerifier) Verify(hostId uuid.UUID, hostData *hcTypes.HostManifest, newData bool) (*models.HVSReport, error) {
+	return &models.HVSReport{HostID: hostId, TrustReport: hvs.TrustReport{
+		Results: []hvs.RuleResult{{Trusted: true}},
+	}}, nil
+}
+
+func (trustedHostVerifier) Evaluate(hostId uuid.UUID, hostData *hcTypes.HostManifest, fg hvs.FlavorGroup, flavors []hvs.SignedFlavor) (*hvs.TrustReport, error) {
+	return nil, errors.New("Evaluate is not implemented")
+}
+
+func newJobTestService(t *testing.T, hostNames ...string) (domain.HostTrustManager, *mocks.MockJobStore, *queuedHostDataFetcher, domain.QueueStore, []uuid.UUID) {
+	return newJobTestServiceWithQueue(t, mocks.NewQueueStore(), hostNames...)
+}
+
+func newJobTestServiceWithQueue(t *testing.T, qs domain.QueueStore, hostNames ...string) (domain.HostTrustManager, *mocks.MockJobStore, *queuedHostDataFetcher, domain.QueueStore, []uuid.UUID) {
+	hs := mocks.NewMockHostStore()
+	var hostIds []uuid.UUID
+	for _, hostName := range hostNames {
+		host, err := hs.Create(&hvs.Host{HostName: hostName, ConnectionString: "intel:https://" + hostName + ":1443"})
+		assert.NoError(t, err)
+		hostIds = append(hostIds, host.Id)
+	}
+	js := mocks.NewMockJobStore()
+	fetcher := &queuedHostDataFetcher{requests: make(map[uuid.UUID]queuedHostDataRequest)}
+	_, ht, err := hosttrust.NewService(domain.HostTrustMgrConfig{
+		PersistStore:      qs,
+		HostStore:         hs,
+		HostStatusStore:   mocks.NewMockHostStatusStore(),
+		HostFetcher:       fetcher,
+		Verifiers:         2,
+		HostTrustVerifier: trustedHostVerifier{},
+		JobStore:          js,
+	})
+	assert.NoError(t, err)
+	return ht, js, fetcher, qs, hostIds
+}
+
+// waitForJob returns the job once it is in the given state
+func waitForJob(t *testing.T, js *mocks.MockJobStore, jobId uuid.UUID, state hvs.JobState) *hvs.Job {
+	for i := 0; i < 100; i++ {
+		job, err := js.Retrieve(jobId)
+		assert.NoError(t, err)
+		if job.State == state {
+			return job
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach state %s", jobId, state)
+	return nil
+}
+
+// waitForHostDataRequest answers the host data request of the host once it is submitted
+func waitForHostDataRequest(t *testing.T, fetcher *queuedHostDataFetcher, hostId uuid.UUID, err error) {
+	for i := 0; i < 100; i++ {
+		if fetcher.respond(hostId, err) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("host data of host %s was not requested", hostId)
+}
+
+func TestHostTrustManagerJobCompletes(t *testing.T) {
+	ht, js, fetcher, qs, hostIds := newJobTestService(t, "host1", "host2")
+
+	jobId, err := ht.VerifyHostsAsync(hostIds, true, false)
+	assert.NoError(t, err)
+	jobs, err := js.Search(&models.JobFilterCriteria{Type: hvs.JobTypeFlavorVerify})
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, jobId, jobs[0].Id)
+	assert.Equal(t, hvs.JobStateRunning, jobs[0].State)
+
+	waitForHostDataRequest(t, fetcher, hostIds[0], nil)
+	waitForHostDataRequest(t, fetcher, hostIds[1], errors.New("connection refused"))
+
+	job := waitForJob(t, js, jobId, hvs.JobStateCompleted)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Equal(t, hvs.JobResultStateSucceeded, job.Results[0].State)
+	assert.True(t, *job.Results[0].Trusted)
+	assert.NotNil(t, job.Results[0].CompletedAt)
+	assert.Equal(t, hvs.JobResultStateFailed, job.Results[1].State)
+	assert.Contains(t, job.Results[1].Error, "connection refused")
+	assert.Empty(t, ht.JobStages(job.Id))
+
+	qrecs, err := qs.Search(&models.QueueFilterCriteria{})
+	assert.NoError(t, err)
+	assert.Empty(t, qrecs)
+}
+
+func TestHostTrustManagerCancelJob(t *testing.T) {
+	ht, js, fetcher, qs, hostIds := newJobTestService(t, "host1", "host2")
+
+	firstJobId, err := ht.VerifyHostsAsync(hostIds, true, false)
+	assert.NoError(t, err)
+	// the second job waits for the verification of host1 queued by the first job
+	secondJobId, err := ht.VerifyHostsAsync(hostIds[:1], false, false)
+	assert.NoError(t, err)
+	jobs, err := js.Search(&models.JobFilterCriteria{Type: hvs.JobTypeFlavorVerify, State: hvs.JobStateRunning})
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	stages := ht.JobStages(firstJobId)
+	assert.Len(t, stages, 2)
+
+	err = ht.CancelJob(firstJobId)
+	assert.NoError(t, err)
+	job := waitForJob(t, js, firstJobId, hvs.JobStateCancelled)
+	assert.Equal(t, hvs.JobResultStateCancelled, job.Results[0].State)
+	assert.Equal(t, hvs.JobResultStateCancelled, job.Results[1].State)
+	assert.Error(t, ht.CancelJob(firstJobId))
+
+	// the verification of host2 is removed from the queue, host1 is still verified for the second job
+	qrecs, err := qs.Search(&models.QueueFilterCriteria{})
+	assert.NoError(t, err)
+	assert.Len(t, qrecs, 1)
+	assert.Len(t, ht.JobStages(secondJobId), 1)
+
+	waitForHostDataRequest(t, fetcher, hostIds[0], nil)
+	job = waitForJob(t, js, secondJobId, hvs.JobStateCompleted)
+	assert.Equal(t, hvs.JobResultStateSucceeded, job.Results[0].State)
+	job, err = js.Retrieve(firstJobId)
+	assert.NoError(t, err)
+	assert.Equal(t, hvs.JobStateCancelled, job.State)
+}
+
+func TestHostTrustManagerCancelJobWaitingForOtherRequest(t *testing.T) {
+	ht, js, fetcher, qs, hostIds := newJobTestService(t, "host1")
+
+	// the first request queues the verification of host1, the job of the second request only waits for it
+	firstJobId, err := ht.VerifyHostsAsync(hostIds, true, false)
+	assert.NoError(t, err)
+	secondJobId, err := ht.VerifyHostsAsync(hostIds, false, false)
+	assert.NoError(t, err)
+	assert.NoError(t, ht.CancelJob(firstJobId))
+	assert.NoError(t, ht.CancelJob(secondJobId))
+	job := waitForJob(t, js, secondJobId, hvs.JobStateCancelled)
+	assert.Equal(t, hvs.JobResultStateCancelled, job.Results[0].State)
+
+	// the second job did not queue the verification of host1, it is not cancelled with the job
+	qrecs, err := qs.Search(&models.QueueFilterCriteria{})
+	assert.NoError(t, err)
+	assert.Len(t, qrecs, 1)
+	assert.Empty(t, ht.JobStages(secondJobId))
+
+	waitForHostDataRequest(t, fetcher, hostIds[0], nil)
+	for i := 0; i < 100 && len(qrecs) > 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+		qrecs, err = qs.Search(&models.QueueFilterCriteria{})
+		assert.NoError(t, err)
+	}
+	assert.Empty(t, qrecs)
+}
+
+// blockingQueueStore blocks the creation of the queue records once blocked is set, until release is closed
+type blockingQueueStore struct {
+	domain.QueueStore
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (qs *blockingQueueStore) Create(queue *models.Queue) (*models.Queue, error) {
+	if qs.release != nil {
+		qs.creating <- struct{}{}
+		<-qs.release
+	}
+	return qs.QueueStore.Create(queue)
+}
+
+func TestHostTrustManagerVerifyHostsAsyncDoesNotLockQueue(t *testing.T) {
+	qs := &blockingQueueStore{QueueStore: mocks.NewQueueStore()}
+	ht, js, fetcher, _, hostIds := newJobTestServiceWithQueue(t, qs, "host1", "host2")
+
+	firstJobId, err := ht.VerifyHostsAsync(hostIds[:1], true, false)
+	assert.NoError(t, err)
+
+	// the stages of the first job are available while the queue record of the second request is created
+	qs.creating = make(chan struct{}, 1)
+	qs.release = make(chan struct{})
+	secondJob := make(chan uuid.UUID)
+	go func() {
+		jobId, err := ht.VerifyHostsAsync(hostIds[1:], true, false)
+		assert.NoError(t, err)
+		secondJob <- jobId
+	}()
+	<-qs.creating
+	stages := make(chan int)
+	go func() {
+		stages <- len(ht.JobStages(firstJobId))
+	}()
+	select {
+	case n := <-stages:
+		assert.Equal(t, 1, n)
+	case <-time.After(time.Second):
+		t.Error("the job stages are blocked by the creation of a queue record")
+	}
+	close(qs.release)
+	secondJobId := <-secondJob
+
+	waitForHostDataRequest(t, fetcher, hostIds[0], nil)
+	waitForHostDataRequest(t, fetcher, hostIds[1], nil)
+	waitForJob(t, js, firstJobId, hvs.JobStateCompleted)
+	waitForJob(t, js, secondJobId, hvs.JobStateCompleted)
+}
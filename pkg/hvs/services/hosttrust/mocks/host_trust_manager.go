@@ -8,6 +8,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
 )
 
 type MockHostTrustManager struct{}
@@ -15,13 +19,32 @@ type MockHostTrustManager struct{}
 func (mock *MockHostTrustManager) VerifyHost(hostId uuid.UUID, fetchHostData, preferHashMatch bool) (*models.HVSReport, error) {
 	store := mocks.NewMockReportStore()
 	report, _ := store.Search(&models.ReportFilterCriteria{HostID: hostId})
+	if len(report) == 0 {
+		// the hosts without a report are not trusted
+		return &models.HVSReport{HostID: hostId}, nil
+	}
 	return &report[0], nil
 }
 
-func (mock *MockHostTrustManager) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) error {
-	return nil
+func (mock *MockHostTrustManager) VerifyHostsAsync(hostIds []uuid.UUID, fetchHostData, preferHashMatch bool) (uuid.UUID, error) {
+	return uuid.New(), nil
 }
 
 func (mock *MockHostTrustManager) ProcessQueue() error {
 	return nil
+}
+
+func (mock *MockHostTrustManager) JobStages(jobId uuid.UUID) map[uuid.UUID]taskstage.Stage {
+	return map[uuid.UUID]taskstage.Stage{}
+}
+
+func (mock *MockHostTrustManager) CancelJob(jobId uuid.UUID) error {
+	return nil
+}
+
+func (mock *MockHostTrustManager) EvaluateHost(hostId uuid.UUID, hostData *types.HostManifest, fg hvs.FlavorGroup, flavors []hvs.SignedFlavor) (*hvs.TrustReport, error) {
+	if hostData == nil {
+		return nil, errors.New("could not retrieve host manifest for host id " + hostId.String())
+	}
+	return &hvs.TrustReport{HostManifest: *hostData, Trusted: len(flavors) > 0}, nil
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+)
+
+// MockTokenReportGenerator returns unsigned tokens carrying the host id of the report
+type MockTokenReportGenerator struct{}
+
+func (mock *MockTokenReportGenerator) GenerateTokenReport(report *models.HVSReport) (string, error) {
+	payload, err := json.Marshal(map[string]string{"sub": report.HostID.String()})
+	if err != nil {
+		return "", err
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".", nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+)
+
+// ReportBroadcaster delivers the reports created by the host trust manager to all current subscribers.
+// Publishing never blocks the verification workers - reports are dropped for subscribers whose buffer is full.
+// The latest published reports are kept so that the reports published while a subscriber reconnects are replayed
+type ReportBroadcaster struct {
+	bufferSize  int
+	subscribers map[chan *models.HVSReport]struct{}
+	historySize int
+	history     []*models.HVSReport
+	mtx         sync.RWMutex
+}
+
+func NewReportBroadcaster(bufferSize, historySize int) *ReportBroadcaster {
+	defaultLog.Trace("hosttrust/report_broadcaster:NewReportBroadcaster() Entering")
+	defer defaultLog.Trace("hosttrust/report_broadcaster:NewReportBroadcaster() Leaving")
+
+	return &ReportBroadcaster{
+		bufferSize:  bufferSize,
+		subscribers: make(map[chan *models.HVSReport]struct{}),
+		historySize: historySize,
+	}
+}
+
+func (rb *ReportBroadcaster) Publish(report *models.HVSReport) {
+	defaultLog.Trace("hosttrust/report_broadcaster:Publish() Entering")
+	defer defaultLog.Trace("hosttrust/report_broadcaster:Publish() Leaving")
+
+	if report == nil {
+		return
+	}
+	rb.mtx.Lock()
+	defer rb.mtx.Unlock()
+	if rb.historySize > 0 {
+		rb.history = append(rb.history, report)
+		if len(rb.history) > rb.historySize {
+			rb.history = rb.history[len(rb.history)-rb.historySize:]
+		}
+	}
+	for subscriber := range rb.subscribers {
+		select {
+		case subscriber <- report:
+		default:
+			defaultLog.Warnf("hosttrust/report_broadcaster:Publish() Subscriber is not keeping up, dropping report %s for host %s", report.ID, report.HostID)
+		}
+	}
+}
+
+func (rb *ReportBroadcaster) Subscribe() (<-chan *models.HVSReport, func()) {
+	defaultLog.Trace("hosttrust/report_broadcaster:Subscribe() Entering")
+	defer defaultLog.Trace("hosttrust/report_broadcaster:Subscribe() Leaving")
+
+	return rb.SubscribeAfter(uuid.Nil)
+}
+
+// SubscribeAfter subscribes to the published reports, the kept reports published after the report with the given ID
+// are delivered first. Nothing is replayed when the report is no longer kept
+func (rb *ReportBroadcaster) SubscribeAfter(lastReportId uuid.UUID) (<-chan *models.HVSReport, func()) {
+	defaultLog.Trace("hosttrust/report_broadcaster:SubscribeAfter() Entering")
+	defer defaultLog.Trace("hosttrust/report_broadcaster:SubscribeAfter() Leaving")
+
+	rb.mtx.Lock()
+	var missed []*models.HVSReport
+	if lastReportId != uuid.Nil {
+		found := false
+		for i := len(rb.history) - 1; i >= 0 && !found; i-- {
+			if rb.history[i].ID == lastReportId {
+				missed = rb.history[i+1:]
+				found = true
+			}
+		}
+		if !found {
+			defaultLog.Warnf("hosttrust/report_broadcaster:SubscribeAfter() Report %s is no longer kept, the reports published since then are not replayed", lastReportId)
+		}
+	}
+	subscriber := make(chan *models.HVSReport, rb.bufferSize+len(missed))
+	for _, report := range missed {
+		subscriber <- report
+	}
+	rb.subscribers[subscriber] = struct{}{}
+	rb.mtx.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			rb.mtx.Lock()
+			delete(rb.subscribers, subscriber)
+			close(subscriber)
+			rb.mtx.Unlock()
+		})
+	}
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportBroadcasterPublish(t *testing.T) {
+	rb := hosttrust.NewReportBroadcaster(1, 0)
+
+	first, unsubscribeFirst := rb.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := rb.Subscribe()
+	defer unsubscribeSecond()
+
+	report := &models.HVSReport{ID: uuid.New(), HostID: uuid.New()}
+	rb.Publish(report)
+
+	assert.Equal(t, report, <-first)
+	assert.Equal(t, report, <-second)
+}
+
+func TestReportBroadcasterDropsReportsForSlowSubscriber(t *testing.T) {
+	rb := hosttrust.NewReportBroadcaster(1, 0)
+
+	reports, unsubscribe := rb.Subscribe()
+	defer unsubscribe()
+
+	first := &models.HVSReport{ID: uuid.New()}
+	rb.Publish(first)
+	// the buffer is full, publish must not block
+	rb.Publish(&models.HVSReport{ID: uuid.New()})
+
+	assert.Equal(t, first, <-reports)
+	assert.Len(t, reports, 0)
+}
+
+func TestReportBroadcasterUnsubscribe(t *testing.T) {
+	rb := hosttrust.NewReportBroadcaster(1, 0)
+
+	reports, unsubscribe := rb.Subscribe()
+	unsubscribe()
+	// calling unsubscribe again is a no-op
+	unsubscribe()
+
+	rb.Publish(&models.HVSReport{ID: uuid.New()})
+	_, ok := <-reports
+	assert.False(t, ok)
+}
+
+func TestReportBroadcasterSubscribeAfter(t *testing.T) {
+	rb := hosttrust.NewReportBroadcaster(1, 2)
+
+	first := &models.HVSReport{ID: uuid.New()}
+	second := &models.HVSReport{ID: uuid.New()}
+	third := &models.HVSReport{ID: uuid.New()}
+	rb.Publish(first)
+	rb.Publish(second)
+	rb.Publish(third)
+
+	// the reports published after the last received report are replayed
+	reports, unsubscribe := rb.SubscribeAfter(second.ID)
+	defer unsubscribe()
+	assert.Equal(t, third, <-reports)
+	assert.Len(t, reports, 0)
+
+	// only the latest reports are kept
+	reports, unsubscribeFirst := rb.SubscribeAfter(first.ID)
+	defer unsubscribeFirst()
+	assert.Len(t, reports, 0)
+}
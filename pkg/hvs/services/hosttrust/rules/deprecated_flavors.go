@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// DeprecatedFlavors warns about the flavor parts for which the host is only trusted by deprecated flavors. The
+// warning does not change the trust status of the host
+type DeprecatedFlavors struct {
+	TrustedFlavors []hvs.Flavor
+}
+
+func NewDeprecatedFlavors(trustedFlavors []hvs.Flavor) *DeprecatedFlavors {
+	return &DeprecatedFlavors{
+		TrustedFlavors: trustedFlavors,
+	}
+}
+
+func (r *DeprecatedFlavors) Apply(trustReport hvs.TrustReport) *hvs.TrustReport {
+	deprecatedFlavors := make(map[cf.FlavorPart][]hvs.Flavor)
+	activeFlavorParts := make(map[cf.FlavorPart]bool)
+	var flavorParts []cf.FlavorPart
+	for _, flavor := range r.TrustedFlavors {
+		var flavorPart cf.FlavorPart
+		if err := (&flavorPart).Parse(flavor.Meta.Description.FlavorPart); err != nil {
+			continue
+		}
+		if !flavor.Meta.Lifecycle.IsDeprecated() {
+			activeFlavorParts[flavorPart] = true
+			continue
+		}
+		if _, ok := deprecatedFlavors[flavorPart]; !ok {
+			flavorParts = append(flavorParts, flavorPart)
+		}
+		deprecatedFlavors[flavorPart] = append(deprecatedFlavors[flavorPart], flavor)
+	}
+
+	for _, flavorPart := range flavorParts {
+		if activeFlavorParts[flavorPart] {
+			continue
+		}
+		ruleResult := hvs.RuleResult{
+			Rule: hvs.RuleInfo{
+				Name:    constants.RuleFlavorNotDeprecated,
+				Markers: []cf.FlavorPart{flavorPart},
+			},
+			Trusted: true,
+		}
+		for _, flavor := range deprecatedFlavors[flavorPart] {
+			flavorId := flavor.Meta.ID
+			warning := hvs.Fault{
+				Name:        constants.FaultFlavorDeprecated,
+				Description: fmt.Sprintf("Host is only trusted by deprecated %s flavor %s", flavorPart.String(), flavorId),
+				FlavorId:    &flavorId,
+			}
+			if flavor.Meta.Lifecycle.NotAfter != nil {
+				warning.Description += fmt.Sprintf(" which cannot be used after %s", flavor.Meta.Lifecycle.NotAfter.String())
+			}
+			ruleResult.Warnings = append(ruleResult.Warnings, warning)
+		}
+		defaultLog.Debugf("Host is only trusted by deprecated flavors for flavor part [%s]", flavorPart.String())
+		trustReport.AddResult(ruleResult)
+	}
+	return &trustReport
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rules
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// FlavorGroupPolicy evaluates the expression of a flavorgroup policy against the number of trusted flavors
+// of each flavor part
+type FlavorGroupPolicy struct {
+	Expression *hvs.PolicyExpression
+}
+
+func NewFlavorGroupPolicy(expression *hvs.PolicyExpression) *FlavorGroupPolicy {
+	return &FlavorGroupPolicy{
+		Expression: expression,
+	}
+}
+
+// Apply adds the result of the expression to the trust report. When the expression is satisfied, the faults of
+// the flavor parts referenced by the expression are removed from the report since the policy allows them
+func (r *FlavorGroupPolicy) Apply(trustReport hvs.TrustReport, trustedFlavorCount map[cf.FlavorPart]int) *hvs.TrustReport {
+	markers := r.Expression.GetFlavorParts()
+	ruleResult := hvs.RuleResult{
+		Rule: hvs.RuleInfo{
+			Name:    constants.RuleFlavorGroupPolicy,
+			Markers: markers,
+		},
+	}
+
+	if r.Expression.Evaluate(trustedFlavorCount) {
+		ruleResult.Trusted = true
+		var results []hvs.RuleResult
+		for _, result := range trustReport.Results {
+			if result.IsTrusted() || !isMarkedOnlyWith(result, markers) {
+				results = append(results, result)
+			}
+		}
+		trustReport.Results = results
+	} else {
+		defaultLog.Debugf("Flavorgroup policy expression is not satisfied by the trusted flavors %v", trustedFlavorCount)
+		ruleResult.Faults = append(ruleResult.Faults, hvs.Fault{
+			Name:        constants.FaultFlavorGroupPolicyNotSatisfied,
+			Description: "Flavorgroup policy expression is not satisfied by the trusted flavors",
+		})
+	}
+	trustReport.AddResult(ruleResult)
+	return &trustReport
+}
+
+func isMarkedOnlyWith(result hvs.RuleResult, flavorParts []cf.FlavorPart) bool {
+	if len(result.Rule.Markers) == 0 {
+		return false
+	}
+	for _, marker := range result.Rule.Markers {
+		found := false
+		for _, flavorPart := range flavorParts {
+			if marker == flavorPart {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
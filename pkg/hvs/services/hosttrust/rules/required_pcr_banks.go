@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// RequiredPcrBanks checks that the PCR manifest of the host has the PCR banks required by the flavorgroup policy
+type RequiredPcrBanks struct {
+	Policy *hvs.FlavorGroupPolicy
+}
+
+func NewRequiredPcrBanks(policy *hvs.FlavorGroupPolicy) *RequiredPcrBanks {
+	return &RequiredPcrBanks{
+		Policy: policy,
+	}
+}
+
+// Apply adds a PcrBankMissing fault to the trust report for each required PCR bank that is not in the host manifest
+func (r *RequiredPcrBanks) Apply(trustReport hvs.TrustReport, hostManifest *types.HostManifest) *hvs.TrustReport {
+	ruleResult := hvs.RuleResult{
+		Rule: hvs.RuleInfo{
+			Name: constants.RuleRequiredPcrBanks,
+		},
+		Trusted: true,
+	}
+
+	for _, pcrBank := range r.Policy.GetMissingPcrBanks(hostManifest.PcrManifest.GetPcrBanks()) {
+		defaultLog.Debugf("Required PCR bank [%s] is missing from the host manifest", pcrBank)
+		ruleResult.Faults = append(ruleResult.Faults, hvs.Fault{
+			Name:        constants.FaultPcrBankMissing,
+			Description: fmt.Sprintf("Required PCR bank %s is missing from the host manifest", pcrBank),
+		})
+	}
+	ruleResult.Trusted = len(ruleResult.Faults) == 0
+	trustReport.AddResult(ruleResult)
+	return &trustReport
+}
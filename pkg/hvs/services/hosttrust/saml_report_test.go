@@ -22,20 +22,26 @@ import (
 )
 
 var _ = Describe("SamlReport", func() {
-	testIc := getIssuer()
-	reportGen := hosttrust.NewSamlReportGenerator(testIc)
-
-	verifierCertificates := createVerifierCertificates(
-		"../../../lib/verifier/test_data/intel20/PrivacyCA.pem",
-		"../../../lib/verifier/test_data/intel20/flavor-signer.crt.pem",
-		"../../../lib/verifier/test_data/intel20/cms-ca-cert.pem",
-		"../../../lib/verifier/test_data/intel20/tag-cacerts.pem")
-
-	javaTrustReport := getTrustReport(
-		"../../../lib/verifier/test_data/intel20/host_manifest.json",
-		"../../../lib/verifier/test_data/intel20/signed_flavors.json",
-		"../../../lib/verifier/test_data/intel20/trust_report.json",
-		verifierCertificates)
+	var reportGen *hosttrust.SamlReportGenerator
+	var javaTrustReport *hvs.TrustReport
+
+	BeforeEach(func() {
+		reportGen = hosttrust.NewSamlReportGenerator(getIssuer())
+
+		verifierCertificates := createVerifierCertificates(
+			"../../../lib/verifier/test_data/intel20/PrivacyCA.pem",
+			"../../../lib/verifier/test_data/intel20/flavor-signer.crt.pem",
+			"../../../lib/verifier/test_data/intel20/cms-ca-cert.pem",
+			"../../../lib/verifier/test_data/intel20/tag-cacerts.pem")
+
+		var err error
+		javaTrustReport, err = getTrustReport(
+			"../../../lib/verifier/test_data/intel20/host_manifest.json",
+			"../../../lib/verifier/test_data/intel20/signed_flavors.json",
+			"../../../lib/verifier/test_data/intel20/trust_report.json",
+			verifierCertificates)
+		Expect(err).NotTo(HaveOccurred())
+	})
 
 	Describe("Generate SAML report", func() {
 		Context("Given trust report and issuer details to SAML report generator", func() {
@@ -67,7 +73,7 @@ func getTrustReport(
 	hostManifestFile string,
 	signedFlavorsFile string,
 	trustReportFile string,
-	verifierCertificates *verifier.VerifierCertificates) *hvs.TrustReport {
+	verifierCertificates *verifier.VerifierCertificates) (*hvs.TrustReport, error) {
 
 	var hostManifest types.HostManifest
 	var signedFlavors []hvs.SignedFlavor
@@ -77,18 +83,24 @@ func getTrustReport(
 	json.Unmarshal(manifestJSON, &hostManifest)
 	flavorsJSON, _ := ioutil.ReadFile(signedFlavorsFile)
 	json.Unmarshal(flavorsJSON, &signedFlavors)
-	v, _ := verifier.NewVerifier(*verifierCertificates)
+	v, err := verifier.NewVerifier(*verifierCertificates)
+	if err != nil {
+		return nil, err
+	}
 	javaTrustReportsJSON, _ := ioutil.ReadFile(trustReportFile)
 	json.Unmarshal(javaTrustReportsJSON, &javaTrustReports)
-	
+
 	var collectiveReport hvs.TrustReport
 	for _, signedFlavor := range signedFlavors {
-		trustReport, _ := v.Verify(&hostManifest, &signedFlavor, true)
+		trustReport, err := v.Verify(&hostManifest, &signedFlavor, true)
+		if err != nil {
+			return nil, err
+		}
 		collectiveReport.Results = append(collectiveReport.Results, trustReport.Results...)
 	}
 	collectiveReport.HostManifest = hostManifest
 	collectiveReport.Trusted = collectiveReport.IsTrusted()
-	return &collectiveReport
+	return &collectiveReport, nil
 }
 
 func createVerifierCertificates(
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hosttrust
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	jwtauth "github.com/intel-secl/intel-secl/v3/pkg/lib/common/jwt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// ueidTypeRand is the type byte of a 128 bit universal entity ID, as defined for the EAT ueid claim
+const ueidTypeRand = 0x01
+
+// TokenReportGenerator signs the trust reports of the hosts as JWT attestation tokens
+type TokenReportGenerator struct {
+	tokenFactory *jwtauth.JwtFactory
+}
+
+// NewTokenReportGenerator returns a TokenReportGenerator signing the tokens with the given key, the key ID of the
+// tokens is derived from the signing certificate which must hold the public key of the signing key. Only 3072 or 4096
+// bits RSA and P-256 or P-384 ECDSA keys are supported
+func NewTokenReportGenerator(signingKey crypto.PrivateKey, signingCert *x509.Certificate, issuer string) (*TokenReportGenerator, error) {
+	defaultLog.Trace("hosttrust/token_report:NewTokenReportGenerator() Entering")
+	defer defaultLog.Trace("hosttrust/token_report:NewTokenReportGenerator() Leaving")
+
+	signer, ok := signingKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("The token signing key is not supported")
+	}
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the public key of the token signing key")
+	}
+	if !bytes.Equal(publicKeyDer, signingCert.RawSubjectPublicKeyInfo) {
+		return nil, errors.New("The token signing certificate does not match the token signing key")
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the token signing key")
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signingCert.Raw})
+	tokenFactory, err := jwtauth.NewTokenFactory(keyDer, true, certPem, issuer, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize the token factory")
+	}
+	return &TokenReportGenerator{tokenFactory}, nil
+}
+
+// GenerateTokenReport returns the signed attestation token of the report, the token expires with the report
+func (trg *TokenReportGenerator) GenerateTokenReport(report *models.HVSReport) (string, error) {
+	defaultLog.Trace("hosttrust/token_report:GenerateTokenReport() Entering")
+	defer defaultLog.Trace("hosttrust/token_report:GenerateTokenReport() Leaving")
+
+	validity := time.Until(report.Expiration)
+	if validity <= 0 {
+		return "", errors.Errorf("The report of host %s has expired", report.HostID)
+	}
+	claims := getTokenClaims(report.HostID, &report.TrustReport)
+	token, err := trg.tokenFactory.Create(claims, report.HostID.String(), validity)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign the attestation token")
+	}
+	return token, nil
+}
+
+// load the attestation token claims from the trust report, the SAML report helpers are reused so that both
+// formats carry the same attributes
+func getTokenClaims(hostId uuid.UUID, t *hvs.TrustReport) *hvs.AttestationTokenClaims {
+	defaultLog.Trace("hosttrust/token_report:getTokenClaims() Entering")
+	defer defaultLog.Trace("hosttrust/token_report:getTokenClaims() Leaving")
+
+	hostInfo := t.HostManifest.HostInfo
+	claims := hvs.AttestationTokenClaims{
+		Profile:               hvs.AttestationTokenProfile,
+		HostId:                hostId,
+		HostName:              hostInfo.HostName,
+		Trusted:               t.IsTrusted(),
+		TrustMarkers:          make(map[string]bool),
+		HardwareFeatures:      trimKeyPrefix(getHardwareFeaturesMap(hostInfo.HardwareFeatures), "FEATURE_"),
+		AssetTags:             trimKeyPrefix(getTags(t), "TAG_"),
+		HostInfo:              getHostInfoMap(hostInfo),
+		TPMVersion:            hostInfo.HardwareFeatures.TPM.Meta.TPMVersion,
+		AIKCertificate:        t.HostManifest.AIKCertificate,
+		BindingKeyCertificate: t.HostManifest.BindingKeyCertificate,
+		NewBootSession:        t.NewBootSession,
+	}
+	if hardwareUUID, err := uuid.Parse(hostInfo.HardwareUUID); err == nil {
+		claims.UEID = base64.RawURLEncoding.EncodeToString(append([]byte{ueidTypeRand}, hardwareUUID[:]...))
+	}
+	// the markers the host was not verified against are left out, the SAML report has them as NA
+	for _, flavorType := range common.GetFlavorTypes() {
+		marker := flavorType.String()
+		if len(t.GetResultsForMarker(marker)) > 0 {
+			claims.TrustMarkers[strings.ToUpper(marker)] = t.IsTrustedForMarker(marker)
+		}
+	}
+	return &claims
+}
+
+func trimKeyPrefix(attributes map[string]string, prefix string) map[string]string {
+	trimmed := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		trimmed[strings.TrimPrefix(key, prefix)] = value
+	}
+	return trimmed
+}
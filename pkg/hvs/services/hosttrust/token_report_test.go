@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hosttrust_test
+
+import (
+	"crypto/x509"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/services/hosttrust"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/saml"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenReport", func() {
+	var testIc *saml.IssuerConfiguration
+	var javaTrustReport *hvs.TrustReport
+
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		testIc = getIssuer()
+
+		verifierCertificates := createVerifierCertificates(
+			"../../../lib/verifier/test_data/intel20/PrivacyCA.pem",
+			"../../../lib/verifier/test_data/intel20/flavor-signer.crt.pem",
+			"../../../lib/verifier/test_data/intel20/cms-ca-cert.pem",
+			"../../../lib/verifier/test_data/intel20/tag-cacerts.pem")
+
+		var err error
+		javaTrustReport, err = getTrustReport(
+			"../../../lib/verifier/test_data/intel20/host_manifest.json",
+			"../../../lib/verifier/test_data/intel20/signed_flavors.json",
+			"../../../lib/verifier/test_data/intel20/trust_report.json",
+			verifierCertificates)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Generate attestation token", func() {
+		Context("Given a report and the SAML issuer key", func() {
+			It("Should generate a signed attestation token", func() {
+				reportGen, err := hosttrust.NewTokenReportGenerator(testIc.PrivateKey, testIc.Certificate, testIc.IssuerName)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := reportGen.GenerateTokenReport(&models.HVSReport{
+					HostID:      hostId,
+					TrustReport: *javaTrustReport,
+					Expiration:  time.Now().Add(time.Hour),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				claims := jwt.MapClaims{}
+				parsedToken, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+					return testIc.Certificate.PublicKey, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedToken.Header["alg"]).To(Equal("RS384"))
+				Expect(parsedToken.Header["kid"]).NotTo(BeEmpty())
+				Expect(claims["sub"]).To(Equal(hostId.String()))
+				Expect(claims["iss"]).To(Equal(testIc.IssuerName))
+				Expect(claims["host_id"]).To(Equal(hostId.String()))
+				Expect(claims["trusted"]).To(Equal(javaTrustReport.IsTrusted()))
+				Expect(claims["eat_profile"]).NotTo(BeEmpty())
+				trustMarkers, ok := claims["trust_markers"].(map[string]interface{})
+				Expect(ok).To(BeTrue())
+				Expect(trustMarkers["PLATFORM"]).To(Equal(javaTrustReport.IsTrustedForMarker("PLATFORM")))
+				Expect(claims["hardware_features"]).To(HaveKey("TPM"))
+			})
+		})
+
+		Context("Given a P-521 ECDSA key", func() {
+			It("Should fail to create the token report generator", func() {
+				certDer, keyDer, err := crypt.CreateKeyPairAndCertificate("root-test", "", "ecdsa", 521)
+				Expect(err).NotTo(HaveOccurred())
+				cert, err := x509.ParseCertificate(certDer)
+				Expect(err).NotTo(HaveOccurred())
+				key, err := x509.ParsePKCS8PrivateKey(keyDer)
+				Expect(err).NotTo(HaveOccurred())
+
+				reportGen, err := hosttrust.NewTokenReportGenerator(key, cert, testIc.IssuerName)
+				Expect(err).To(HaveOccurred())
+				Expect(reportGen).To(BeNil())
+			})
+		})
+
+		Context("Given a certificate that does not match the signing key", func() {
+			It("Should fail to create the token report generator", func() {
+				otherIc := getIssuer()
+				_, err := hosttrust.NewTokenReportGenerator(testIc.PrivateKey, otherIc.Certificate, testIc.IssuerName)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("Given an expired report", func() {
+			It("Should fail to generate the attestation token", func() {
+				reportGen, err := hosttrust.NewTokenReportGenerator(testIc.PrivateKey, testIc.Certificate, testIc.IssuerName)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = reportGen.GenerateTokenReport(&models.HVSReport{
+					HostID:      hostId,
+					TrustReport: *javaTrustReport,
+					Expiration:  time.Now().Add(-time.Hour),
+				})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -13,12 +13,14 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/utils"
 	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // FlavorVerify.java: 529
@@ -50,7 +52,11 @@ func (v *Verifier) CreateFlavorGroupReport(hostId uuid.UUID, reqs flvGrpHostTrus
 		return v.createTrustReport(hostId, hostData, reqs, trustCache, latestReqAndDefFlavorTypes)
 	}
 
-	return trustCache.trustReport, nil
+	trustReport := trustCache.trustReport
+	if err := v.applyFlavorGroupPolicy(hostData, reqs, trustCache.trustedFlavors, &trustReport); err != nil {
+		return hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:CreateFlavorGroupReport() Error applying flavorgroup policy")
+	}
+	return trustReport, nil
 }
 
 func areAllOfFlavorsMissingInCachedTrustReport(cachedTrustReport hvs.TrustReport, ruleAllOfFlavors rules.AllOfFlavors) bool {
@@ -98,12 +104,14 @@ func (v *Verifier) createTrustReport(hostId uuid.UUID, hostData *types.HostManif
 	if err != nil {
 		return hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:createTrustReport() Error while finding flavors")
 	}
-	trustReport, err := v.verifyFlavors(hostId, flavorsToVerify, hostData, reqs)
+	flavorsToVerify = reqs.filterValidFlavors(flavorsToVerify)
+	trustReport, trustedFlavors, err := v.verifyFlavors(hostId, flavorsToVerify, hostData, reqs)
 	if err != nil {
 		return hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:createTrustReport() Error while verifying flavors")
 	}
 	if !trustCache.isTrustCacheEmpty() {
 		trustReport.AddResults(trustCache.trustReport.Results)
+		trustedFlavors = append(trustedFlavors, trustCache.trustedFlavors...)
 	}
 
 	for flavorPart, _ := range reqs.DefinedAndRequiredFlavorTypes {
@@ -117,9 +125,54 @@ func (v *Verifier) createTrustReport(hostId uuid.UUID, hostData *types.HostManif
 	if err != nil {
 		return hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:createTrustReport() Error applying ruleAllOfFlavors")
 	}
+
+	if err = v.applyFlavorGroupPolicy(hostData, reqs, trustedFlavors, trustReport); err != nil {
+		return hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:createTrustReport() Error applying flavorgroup policy")
+	}
 	return *trustReport, nil
 }
 
+// applyFlavorGroupPolicy evaluates the policy expression of the flavorgroup against the trusted flavors, checks
+// the PCR banks required by the policy, warns about the flavor parts that are only trusted by deprecated flavors
+// and applies the rules referenced by the flavor match policies
+func (v *Verifier) applyFlavorGroupPolicy(hostData *types.HostManifest, reqs flvGrpHostTrustReqs, trustedFlavors []hvs.Flavor, trustReport *hvs.TrustReport) error {
+	defaultLog.Trace("hosttrust/trust_report:applyFlavorGroupPolicy() Entering")
+	defer defaultLog.Trace("hosttrust/trust_report:applyFlavorGroupPolicy() Leaving")
+
+	if reqs.Policy != nil && reqs.Policy.Expression != nil {
+		rule := rules.NewFlavorGroupPolicy(reqs.Policy.Expression)
+		*trustReport = *rule.Apply(*trustReport, countTrustedFlavors(trustedFlavors))
+	}
+	if reqs.Policy != nil && len(reqs.Policy.RequiredPcrBanks) > 0 {
+		*trustReport = *rules.NewRequiredPcrBanks(reqs.Policy).Apply(*trustReport, hostData)
+	}
+	*trustReport = *rules.NewDeprecatedFlavors(trustedFlavors).Apply(*trustReport)
+	return v.applyPolicyRules(hostData, reqs, trustReport)
+}
+
+// applyPolicyRules applies the rules referenced by the flavor match policies of the flavorgroup, these
+// are registered in the verifier library and apply to all the hosts in the flavorgroup
+func (v *Verifier) applyPolicyRules(hostData *types.HostManifest, reqs flvGrpHostTrustReqs, trustReport *hvs.TrustReport) error {
+	defaultLog.Trace("hosttrust/trust_report:applyPolicyRules() Entering")
+	defer defaultLog.Trace("hosttrust/trust_report:applyPolicyRules() Leaving")
+
+	for _, flvMatchPolicy := range reqs.FlavorMatchPolicies {
+		if len(flvMatchPolicy.Rules) == 0 {
+			continue
+		}
+		results, err := verifier.ApplyRegisteredRules(flvMatchPolicy.Rules, verifier.RuleContext{
+			VerifierCertificates: v.FlavorVerifier.GetVerifierCerts(),
+			HostManifest:         hostData,
+			FlavorPart:           flvMatchPolicy.FlavorPart,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Error applying rules of %s flavor match policy", flvMatchPolicy.FlavorPart)
+		}
+		trustReport.AddResults(results)
+	}
+	return nil
+}
+
 func getMatchPolicy(flvMatchPolicies hvs.FlavorMatchPolicies, part cf.FlavorPart) *hvs.MatchPolicy {
 	defaultLog.Trace("hosttrust/trust_report:getMatchPolicy() Entering")
 	defer defaultLog.Trace("hosttrust/trust_report:getMatchPolicy() Leaving")
@@ -141,7 +194,7 @@ type flavorReport struct {
 }
 
 // FlavorVerify.java: 405
-func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, hostData *types.HostManifest, hostTrustReqs flvGrpHostTrustReqs) (*hvs.TrustReport, error) {
+func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, hostData *types.HostManifest, hostTrustReqs flvGrpHostTrustReqs) (*hvs.TrustReport, []hvs.Flavor, error) {
 	defaultLog.Trace("hosttrust/trust_report:verifyFlavors() Entering")
 	defer defaultLog.Trace("hosttrust/trust_report:verifyFlavors() Leaving")
 
@@ -157,6 +210,7 @@ func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, h
 	}
 
 	newTrustCaches := make([]uuid.UUID, 0, len(flavors))
+	var trustedFlavors []hvs.Flavor
 	for _, signedFlavor := range flavors {
 		for _, flvMatchPolicy := range hostTrustReqs.FlavorMatchPolicies {
 			// TODO
@@ -165,9 +219,9 @@ func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, h
 			flvPart := signedFlavor.Flavor.Meta.Description.FlavorPart
 			if flvPart == flvMatchPolicy.FlavorPart.String() {
 
-				individualTrustReport, err := v.FlavorVerifier.Verify(hostData, &signedFlavor, v.SkipFlavorSignatureVerification)
+				individualTrustReport, err := v.verifyFlavor(hostData, &signedFlavor, &hostTrustReqs)
 				if err != nil {
-					return &hvs.TrustReport{}, errors.Wrap(err, "hosttrust/trust_report:verifyFlavors() Error verifying flavor")
+					return &hvs.TrustReport{}, nil, errors.Wrap(err, "hosttrust/trust_report:verifyFlavors() Error verifying flavor")
 				}
 				if individualTrustReport.Trusted {
 					if reflect.DeepEqual(collectiveTrustReport, hvs.TrustReport{}){
@@ -176,6 +230,7 @@ func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, h
 						collectiveTrustReport.AddResults(individualTrustReport.Results)
 					}
 					newTrustCaches = append(newTrustCaches, signedFlavor.Flavor.Meta.ID)
+					trustedFlavors = append(trustedFlavors, signedFlavor.Flavor)
 				} else {
 					// will need the fault count later on... just iterate through the results and determine the fault count
 					faults := 0
@@ -235,14 +290,27 @@ func (v *Verifier) verifyFlavors(hostID uuid.UUID, flavors []hvs.SignedFlavor, h
 		//TODO - check if we return an error here
 		return &hvs.TrustReport{
 			HostManifest: *hostData,
-		}, nil
+		}, nil, nil
 	}
 	// save the trust cache // ignore error since it is just a cache.
 	if _, err := v.HostStore.AddTrustCacheFlavors(hostID, newTrustCaches); err != nil {
 		log.Error("hosttrust/trust_report:verifyFlavors() error while adding flavor trust cache to store for host id ", hostID, "error - ", err)
 	}
 
-	return &collectiveTrustReport, nil
+	return &collectiveTrustReport, trustedFlavors, nil
+}
+
+// verifyFlavor verifies the host data against the flavor and applies the pcr exceptions of the flavorgroup policy
+func (v *Verifier) verifyFlavor(hostData *types.HostManifest, signedFlavor *hvs.SignedFlavor, hostTrustReqs *flvGrpHostTrustReqs) (*hvs.TrustReport, error) {
+	report, err := v.FlavorVerifier.Verify(hostData, signedFlavor, v.SkipFlavorSignatureVerification)
+	if err != nil {
+		return nil, err
+	}
+	var flavorPart cf.FlavorPart
+	if err = (&flavorPart).Parse(signedFlavor.Flavor.Meta.Description.FlavorPart); err == nil {
+		hostTrustReqs.applyPcrExceptions(flavorPart, report)
+	}
+	return report, nil
 }
 
 // FlavorVerify.java: 684
@@ -251,12 +319,15 @@ func (v *Verifier) findFlavors(flavorGroupID uuid.UUID, latestReqAndDefFlavorTyp
 	defaultLog.Trace("hosttrust/trust_report:findFlavors() Entering")
 	defer defaultLog.Trace("hosttrust/trust_report:findFlavors() Leaving")
 
+	// only the flavors whose lifecycle allows them to be used for verification are retrieved
+	now := time.Now()
 	flvrFilterCriteria := models.FlavorVerificationFC{
 		FlavorFC: models.FlavorFilterCriteria{
 			FlavorgroupID: flavorGroupID,
 		},
 		FlavorPartsWithLatest: latestReqAndDefFlavorTypes,
 		FlavorMeta:            hostManifestMap,
+		UsableAt:              &now,
 	}
 
 	signedFlavors, err := v.FlavorStore.Search(&flvrFilterCriteria)
@@ -398,6 +469,9 @@ func getHostManifestMap(hostManifest *types.HostManifest, flavorParts []cf.Flavo
 					})
 				}
 				hostInfoValues[cf.FlavorPartSoftware] = sfQueryAttrs
+			} else if fp == cf.FlavorPartIma {
+				// the IMA flavors of the flavorgroup apply to all the hosts
+				hostInfoValues[cf.FlavorPartIma] = []models.FlavorMetaKv{}
 			} else {
 				return nil, errors.New("Invalid flavor part - " + fp.String())
 			}
@@ -16,6 +16,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"reflect"
+	"time"
 )
 
 type flvGrpHostTrustReqs struct {
@@ -27,6 +28,7 @@ type flvGrpHostTrustReqs struct {
 	DefinedAndRequiredFlavorTypes map[cf.FlavorPart]bool
 	FlavorPartMatchPolicy         map[cf.FlavorPart]hvs.MatchPolicy
 	SkipFlavorSignatureVerification bool
+	Policy                        *hvs.FlavorGroupPolicy
 }
 
 func NewFlvGrpHostTrustReqs(hostId uuid.UUID, hwUUID uuid.UUID, fg hvs.FlavorGroup, fs domain.FlavorStore, hostData *types.HostManifest, SkipFlavorSignatureVerification bool) (*flvGrpHostTrustReqs, error) {
@@ -40,6 +42,7 @@ func NewFlvGrpHostTrustReqs(hostId uuid.UUID, hwUUID uuid.UUID, fg hvs.FlavorGro
 		//Initialize empty map.
 		DefinedAndRequiredFlavorTypes:   make(map[cf.FlavorPart]bool),
 		SkipFlavorSignatureVerification: SkipFlavorSignatureVerification,
+		Policy:                          fg.Policy,
 	}
 
 	var fgRequirePolicyMap map[hvs.FlavorRequiredPolicy][]cf.FlavorPart
@@ -55,6 +58,7 @@ func NewFlvGrpHostTrustReqs(hostId uuid.UUID, hwUUID uuid.UUID, fg hvs.FlavorGro
 		if err != nil {
 			return nil, errors.Wrap(err, "error while creating host manifest map")
 		}
+		now := time.Now()
 		reqs.AllOfFlavors, _ = fs.Search(&models.FlavorVerificationFC{
 			FlavorFC: models.FlavorFilterCriteria{
 				// Flavor Parts of the Search Criteria takes a []cf.FlavorPart - but we have a map.
@@ -64,11 +68,13 @@ func NewFlvGrpHostTrustReqs(hostId uuid.UUID, hwUUID uuid.UUID, fg hvs.FlavorGro
 			},
 			FlavorMeta:            hostManifestMap,
 			FlavorPartsWithLatest: nil,
+			UsableAt:              &now,
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "error searching flavor for "+hwUUID.String())
 		}
 		defaultLog.Debugf("%v from Flavorgroup %v Flavors retrieved with ALL_OF policy", fg.ID, len(reqs.AllOfFlavors))
+		reqs.AllOfFlavors = reqs.filterValidFlavors(reqs.AllOfFlavors)
 	}
 
 	reqPartsMap := fgRequirePolicyMap[hvs.FlavorRequired]
@@ -146,10 +152,83 @@ func (r *flvGrpHostTrustReqs) MeetsFlavorGroupReqs(trustCache hostTrustCache, ve
 		defaultLog.Debugf("Some all of flavors do not match what is in the trust cache for host: %s", r.HostId.String())
 		return false
 	}
+
+	if r.Policy != nil {
+		for _, flavor := range trustCache.trustedFlavors {
+			if !r.isFlavorValid(flavor.Meta.ID) {
+				defaultLog.Debugf("Flavor %s in the trust cache of host %s is outside of its validity", flavor.Meta.ID, r.HostId.String())
+				return false
+			}
+		}
+		if r.Policy.Expression != nil && !r.Policy.Expression.Evaluate(countTrustedFlavors(trustCache.trustedFlavors)) {
+			defaultLog.Debugf("Flavorgroup policy expression is not satisfied by the trust cache for host: %s", r.HostId.String())
+			return false
+		}
+	}
 	defaultLog.Debugf("Trust cache valid for host: %s", r.HostId.String())
 	return true
 }
 
+// isFlavorValid returns false if the flavorgroup policy restricts the use of the flavor at the current time
+func (r *flvGrpHostTrustReqs) isFlavorValid(flavorId uuid.UUID) bool {
+	return r.Policy == nil || r.Policy.IsFlavorValid(flavorId, time.Now())
+}
+
+// filterValidFlavors removes the flavors that cannot be used for verification according to the flavorgroup policy
+func (r *flvGrpHostTrustReqs) filterValidFlavors(signedFlavors []hvs.SignedFlavor) []hvs.SignedFlavor {
+	if r.Policy == nil || len(r.Policy.FlavorValidity) == 0 {
+		return signedFlavors
+	}
+	validFlavors := make([]hvs.SignedFlavor, 0, len(signedFlavors))
+	for _, signedFlavor := range signedFlavors {
+		if r.isFlavorValid(signedFlavor.Flavor.Meta.ID) {
+			validFlavors = append(validFlavors, signedFlavor)
+		} else {
+			defaultLog.Debugf("Flavor %s is outside of its validity and is not used for verification", signedFlavor.Flavor.Meta.ID)
+		}
+	}
+	return validFlavors
+}
+
+// applyPcrExceptions removes the faults of the rules that verify PCRs excluded by the flavorgroup policy
+// for the flavor part and updates the trust status of the flavor report accordingly
+func (r *flvGrpHostTrustReqs) applyPcrExceptions(flavorPart cf.FlavorPart, report *hvs.TrustReport) {
+	if r.Policy == nil || len(r.Policy.PcrExceptions) == 0 {
+		return
+	}
+	for i, result := range report.Results {
+		var excluded bool
+		if result.Rule.ExpectedPcr != nil {
+			excluded = r.Policy.IsPcrException(flavorPart, result.Rule.ExpectedPcr.Index, result.Rule.ExpectedPcr.PcrBank)
+		} else if result.Rule.ExpectedEventLogEntry != nil {
+			excluded = r.Policy.IsPcrException(flavorPart, result.Rule.ExpectedEventLogEntry.PcrIndex, result.Rule.ExpectedEventLogEntry.PcrBank)
+		}
+		if excluded && len(result.Faults) > 0 {
+			defaultLog.Debugf("Ignoring faults of rule %s for %s flavor part due to flavorgroup policy pcr exception", result.Rule.Name, flavorPart)
+			report.Results[i].Faults = nil
+			report.Results[i].Trusted = true
+		}
+	}
+	report.Trusted = report.IsTrusted()
+}
+
+// countTrustedFlavors returns the number of distinct trusted flavors of each flavor part
+func countTrustedFlavors(flavors []hvs.Flavor) map[cf.FlavorPart]int {
+	trustedFlavorCount := make(map[cf.FlavorPart]int)
+	counted := make(map[uuid.UUID]bool)
+	for _, flavor := range flavors {
+		if counted[flavor.Meta.ID] {
+			continue
+		}
+		counted[flavor.Meta.ID] = true
+		var flavorPart cf.FlavorPart
+		if err := (&flavorPart).Parse(flavor.Meta.Description.FlavorPart); err == nil {
+			trustedFlavorCount[flavorPart]++
+		}
+	}
+	return trustedFlavorCount
+}
+
 // According to verification-service: RuleAllOfFlavors.java
 // the protected 'marker' variable inherited from lib-verifier: BaseRule.java
 // does not at all effect the behavior of methods implemented in RuleAllOfFlavors
@@ -15,6 +15,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"time"
 )
 
 var ErrInvalidHostManiFest = errors.New("invalid host data")
@@ -29,6 +30,7 @@ type Verifier struct {
 	CertsStore                      models.CertificatesStore
 	SamlIssuer                      saml.IssuerConfiguration
 	SkipFlavorSignatureVerification bool
+	TrustChangeNotifier             domain.TrustChangeNotifier
 }
 
 func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
@@ -41,6 +43,7 @@ func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
 		CertsStore:                      cfg.CertsStore,
 		SamlIssuer:                      cfg.SamlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.SkipFlavorSignatureVerification,
+		TrustChangeNotifier:             cfg.TrustChangeNotifier,
 	}
 }
 
@@ -68,20 +71,36 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 	// create an empty trust report with the host manifest
 	finalTrustReport := hvs.TrustReport{HostManifest: *hostData}
 
+	// the flavors in the trust cache were verified against the measurements of a previous boot of the host, they are
+	// not reused once the host is rebooted
+	newBootSession := newData && hostData.QuoteInfo.IsNewBootSession()
+	if newBootSession {
+		log.Infof("hosttrust/verifier:Verify() Host %s was rebooted since its previous attestation, dropping its trust cache", hostId)
+		finalTrustReport.NewBootSession = true
+	}
+
 	for _, fg := range flvGroups {
 		//TODO - handle errors in case of DB transaction
 		fgTrustReqs, err := NewFlvGrpHostTrustReqs(hostId, hwUuid, fg, v.FlavorStore, hostData, v.SkipFlavorSignatureVerification)
 		if err != nil {
 			return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while retrieving NewFlvGrpHostTrustReqs")
 		}
-		fgCachedFlavors, err := v.getCachedFlavors(hostId, (fg).ID)
-		if err != nil {
-			return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while retrieving getCachedFlavors")
+		var fgCachedFlavors []hvs.SignedFlavor
+		if newBootSession {
+			err = v.clearTrustCache(hostId, fg.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while clearing trust cache")
+			}
+		} else {
+			fgCachedFlavors, err = v.getCachedFlavors(hostId, (fg).ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while retrieving getCachedFlavors")
+			}
 		}
 
 		var fgTrustCache hostTrustCache
 		if len(fgCachedFlavors) > 0 {
-			fgTrustCache, err = v.validateCachedFlavors(hostId, hostData, fgCachedFlavors)
+			fgTrustCache, err = v.validateCachedFlavors(hostId, hostData, fgCachedFlavors, fgTrustReqs)
 			if err != nil {
 				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while validating cache")
 			}
@@ -95,6 +114,8 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *types.HostManifest, newDat
 			if err != nil {
 				return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while creating flavorgroup report")
 			}
+		} else if err = v.applyFlavorGroupPolicy(hostData, *fgTrustReqs, fgTrustCache.trustedFlavors, &fgTrustReport); err != nil {
+			return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while applying flavorgroup policy")
 		}
 		log.Debug("hosttrust/verifier:Verify() Trust status for host id ", hostId, " for flavorgroup ", fg.ID, " is ", fgTrustReport.IsTrusted())
 		// append the results
@@ -132,9 +153,25 @@ func (v *Verifier) getCachedFlavors(hostId uuid.UUID, flavGrpId uuid.UUID) ([]hv
 	}
 }
 
+// clearTrustCache removes all the flavors of the flavorgroup from the trust cache of the host
+func (v *Verifier) clearTrustCache(hostId uuid.UUID, flavGrpId uuid.UUID) error {
+	defaultLog.Trace("hosttrust/verifier:clearTrustCache() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:clearTrustCache() Leaving")
+
+	flIds, err := v.HostStore.RetrieveTrustCacheFlavors(hostId, flavGrpId)
+	if err != nil {
+		return errors.Wrap(err, "hosttrust/verifier:clearTrustCache() Error while retrieving TrustCacheFlavors")
+	}
+	if len(flIds) == 0 {
+		return nil
+	}
+	return v.HostStore.RemoveTrustCacheFlavors(hostId, flIds)
+}
+
 func (v *Verifier) validateCachedFlavors(hostId uuid.UUID,
 	hostData *types.HostManifest,
-	cachedFlavors []hvs.SignedFlavor) (hostTrustCache, error) {
+	cachedFlavors []hvs.SignedFlavor,
+	hostTrustReqs *flvGrpHostTrustReqs) (hostTrustCache, error) {
 	defaultLog.Trace("hosttrust/verifier:validateCachedFlavors() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:validateCachedFlavors() Leaving")
 
@@ -144,8 +181,14 @@ func (v *Verifier) validateCachedFlavors(hostId uuid.UUID,
 	var collectiveReport hvs.TrustReport
 	var trustCachesToDelete []uuid.UUID
 	for _, cachedFlavor := range cachedFlavors {
+		// flavors that are no longer valid according to their lifecycle or the flavorgroup policy are removed
+		// from the cache
+		if !cachedFlavor.Flavor.Meta.Lifecycle.IsUsable(time.Now()) || !hostTrustReqs.isFlavorValid(cachedFlavor.Flavor.Meta.ID) {
+			trustCachesToDelete = append(trustCachesToDelete, cachedFlavor.Flavor.Meta.ID)
+			continue
+		}
 		//TODO: change the signature verification depending on decision on signed flavors
-		report, err := v.FlavorVerifier.Verify(hostData, &cachedFlavor, v.SkipFlavorSignatureVerification)
+		report, err := v.verifyFlavor(hostData, &cachedFlavor, hostTrustReqs)
 		if err != nil {
 			return hostTrustCache{}, errors.Wrap(err, "hosttrust/verifier:validateCachedFlavors() Error from flavor verifier")
 		}
@@ -174,9 +217,30 @@ func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustRepo
 		Expiration:  samlReport.ExpiryTime,
 		Saml:        samlReport.Assertion,
 	}
+	// the previous report is replaced on update, so retrieve it first to find out if the trust status changed
+	var previousTrustReport *hvs.TrustReport
+	if v.TrustChangeNotifier != nil {
+		previousReports, err := v.ReportStore.Search(&models.ReportFilterCriteria{
+			HostID:        hostID,
+			LatestPerHost: true,
+		})
+		if err != nil {
+			log.WithError(err).Errorf("hosttrust/verifier:storeTrustReport() Failed to retrieve previous Report")
+		} else if len(previousReports) > 0 {
+			previousTrustReport = &previousReports[0].TrustReport
+		}
+	}
 	report, err := v.ReportStore.Update(&hvsReport)
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/verifier:storeTrustReport() Failed to store Report")
+		return report
+	}
+	if v.TrustChangeNotifier != nil {
+		if event := hvs.NewTrustChangeEvent(hostID, previousTrustReport, trustReport); event != nil {
+			event.ReportId = report.ID
+			log.Debugf("hosttrust/verifier:storeTrustReport() Trust status of host %s changed, trusted: %t", hostID, event.Trusted)
+			v.TrustChangeNotifier.Notify(event)
+		}
 	}
 	return report
 }
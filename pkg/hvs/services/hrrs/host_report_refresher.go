@@ -7,17 +7,24 @@ package hrrs
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 
 	"github.com/pkg/errors"
 )
 
 // HostReportRefresher runs in the background and periodically queries HVS'
 // reports to see if they have been expired.  If so, they are passed to
-// the HostTrustManager queue to be updated.
+// the HostTrustManager queue to be updated.  Hosts that have an attestation
+// schedule, directly or through one of their flavorgroups, are also queued
+// every interval of the schedule, and are not queued during the maintenance
+// windows of their schedules.
 type HostReportRefresher interface {
 	Run() error
 	Stop() error
@@ -27,13 +34,24 @@ var (
 	firstFromTime, _ = time.Parse(time.RFC3339, "1970-01-01T00:00:00Z") // i.e. epoch
 )
 
-func NewHostReportRefresher(cfg HRRSConfig, reportStore domain.ReportStore, hostTrustManager domain.HostTrustManager) (HostReportRefresher, error) {
+// minimumWaitPeriod keeps the HRRS from polling the database continuously when hosts are due
+const minimumWaitPeriod = 10 * time.Second
+
+// maxOverlappingMaintenanceWindows limits how many overlapping maintenance windows are followed to find the end of
+// the maintenance of a host
+const maxOverlappingMaintenanceWindows = 10
+
+func NewHostReportRefresher(cfg HRRSConfig, reportStore domain.ReportStore, scheduleStore domain.ScheduleStore,
+	flavorGroupStore domain.FlavorGroupStore, hostTrustManager domain.HostTrustManager) (HostReportRefresher, error) {
 
 	return &hostReportRefresherImpl{
 		reportStore:      reportStore,
+		scheduleStore:    scheduleStore,
+		flavorGroupStore: flavorGroupStore,
 		hostTrustManager: hostTrustManager,
 		cfg:              cfg,
 		fromTime:         firstFromTime,
+		nextAttestations: make(map[uuid.UUID]time.Time),
 	}, nil
 }
 
@@ -44,10 +62,63 @@ var (
 
 type hostReportRefresherImpl struct {
 	reportStore      domain.ReportStore
+	scheduleStore    domain.ScheduleStore
+	flavorGroupStore domain.FlavorGroupStore
 	hostTrustManager domain.HostTrustManager
 	cfg              HRRSConfig
 	ctx              context.Context
 	fromTime         time.Time
+	// nextAttestations is the time at which each scheduled host is to be attested next
+	nextAttestations map[uuid.UUID]time.Time
+	// lastRefreshTime is when the reports were last refreshed, the hosts that were in a maintenance window then
+	// are queued once it ends if their latest report has expired
+	lastRefreshTime time.Time
+	// deferredUntil is the earliest end of the maintenance windows that deferred hosts with expired reports
+	deferredUntil time.Time
+}
+
+// hostSchedule is the attestation schedule that applies to a host.  The interval and jitter of a schedule of the
+// host override those of the schedules of its flavorgroups, otherwise the shortest interval of its flavorgroups
+// applies.  The maintenance windows of all the schedules of the host apply.
+type hostSchedule struct {
+	interval      time.Duration
+	jitter        time.Duration
+	hostScheduled bool
+	schedules     []hvs.Schedule
+}
+
+func (hs *hostSchedule) inMaintenance(t time.Time) bool {
+	for _, schedule := range hs.schedules {
+		if schedule.InMaintenance(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// maintenanceEnd returns the end of the maintenance windows of the host that are active at the time, overlapping
+// windows are followed until the host is out of maintenance
+func (hs *hostSchedule) maintenanceEnd(t time.Time) time.Time {
+	end := t
+	for i := 0; i < maxOverlappingMaintenanceWindows && hs.inMaintenance(end); i++ {
+		next := end
+		for _, schedule := range hs.schedules {
+			if scheduleEnd := schedule.MaintenanceEnd(end); scheduleEnd.After(next) {
+				next = scheduleEnd
+			}
+		}
+		end = next
+	}
+	return end
+}
+
+func (hs *hostSchedule) nextAttestation(from time.Time) time.Time {
+	next := from.Add(hs.interval)
+	if hs.jitter > 0 {
+		// spread the attestations of the hosts so that they are not all queued at once
+		next = next.Add(time.Duration(rand.Int63n(int64(hs.jitter))))
+	}
+	return next
 }
 
 func (refresher *hostReportRefresherImpl) Run() error {
@@ -70,7 +141,7 @@ func (refresher *hostReportRefresherImpl) Run() error {
 			}
 
 			select {
-			case <-time.After(refresher.cfg.RefreshPeriod):
+			case <-time.After(refresher.getWaitPeriod()):
 				// continue with the loop and refresh reports again
 			case <-refresher.ctx.Done():
 				defaultLog.Info("The HRRS has been stopped and will now exit")
@@ -100,28 +171,219 @@ func (refresher *hostReportRefresherImpl) Stop() error {
 //
 // The intent of this logic is to avoid adding duplicate hosts to the
 // HostTrustManage queue.
+//
+// Hosts that have an attestation schedule are also queued when their next
+// attestation is due.  Hosts in a maintenance window are not queued, those
+// with expired reports are queued once the maintenance window ends, based on
+// the expiration of their latest report so that they are not lost when the
+// HRRS is restarted.
 func (refresher *hostReportRefresherImpl) refreshReports() error {
 
-	toTime := time.Now().UTC().Add(refresher.cfg.RefreshPeriod)
+	now := time.Now().UTC()
+	toTime := now.Add(refresher.cfg.RefreshPeriod)
 	defaultLog.Debugf("HRRS is refreshing hosts that have expired reports between %s and %s", refresher.fromTime, toTime)
 
-	hostIDs, err := refresher.reportStore.FindHostIdsFromExpiredReports(refresher.fromTime, toTime)
+	expiredHostIDs, err := refresher.reportStore.FindHostIdsFromExpiredReports(refresher.fromTime, toTime)
 
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while HRRS searched for host ids")
 	}
 
-	defaultLog.Debugf("HRRS found %d hosts to refresh", len(hostIDs))
+	defaultLog.Debugf("HRRS found %d hosts to refresh", len(expiredHostIDs))
+
+	hostSchedules, err := refresher.getHostSchedules()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while HRRS retrieved the attestation schedules")
+	}
+
+	// the hosts whose maintenance window ended since the last refresh may have reports that expired during the
+	// window
+	if !refresher.lastRefreshTime.IsZero() {
+		for hostID, hs := range hostSchedules {
+			if !hs.inMaintenance(refresher.lastRefreshTime) || hs.inMaintenance(now) {
+				continue
+			}
+			report, err := refresher.getLatestReport(hostID)
+			if err != nil {
+				defaultLog.WithError(err).Errorf("HRRS failed to retrieve the latest report of host %s", hostID)
+				continue
+			}
+			if report != nil && report.Expiration.Before(toTime) {
+				expiredHostIDs = append(expiredHostIDs, hostID)
+			}
+		}
+	}
+
+	queued := make(map[uuid.UUID]bool)
+	deferred := make(map[uuid.UUID]bool)
+	var hostIDs []uuid.UUID
+	var deferredUntil time.Time
+	for _, hostID := range expiredHostIDs {
+		if hs, ok := hostSchedules[hostID]; ok && hs.inMaintenance(now) {
+			deferred[hostID] = true
+			if end := hs.maintenanceEnd(now); deferredUntil.IsZero() || end.Before(deferredUntil) {
+				deferredUntil = end
+			}
+			continue
+		}
+		if !queued[hostID] {
+			queued[hostID] = true
+			hostIDs = append(hostIDs, hostID)
+		}
+	}
+
+	scheduledHosts := 0
+	for hostID, hs := range hostSchedules {
+		nextAttestation, ok := refresher.nextAttestations[hostID]
+		if !ok {
+			nextAttestation = refresher.getFirstAttestation(hostID, hs, now)
+		}
+		if !now.Before(nextAttestation) {
+			if hs.inMaintenance(now) {
+				// the host is attested as soon as the maintenance window ends
+				nextAttestation = hs.maintenanceEnd(now)
+			} else {
+				if !queued[hostID] {
+					queued[hostID] = true
+					hostIDs = append(hostIDs, hostID)
+					scheduledHosts++
+				}
+				nextAttestation = hs.nextAttestation(now)
+			}
+		}
+		refresher.nextAttestations[hostID] = nextAttestation
+	}
+	// forget the hosts that are no longer scheduled
+	for hostID := range refresher.nextAttestations {
+		if _, ok := hostSchedules[hostID]; !ok {
+			delete(refresher.nextAttestations, hostID)
+		}
+	}
 
 	if len(hostIDs) > 0 {
-		err = refresher.hostTrustManager.VerifyHostsAsync(hostIDs, true, false)
+		_, err = refresher.hostTrustManager.VerifyHostsAsync(hostIDs, true, false)
 		if err != nil {
 			return errors.Wrap(err, "HRRS encountered an error calling the host trust manager")
 		}
 	}
 
-	defaultLog.Infof("HRRS queued %d hosts from reports that were expiring between %s and %s", len(hostIDs), refresher.fromTime, toTime)
+	defaultLog.Infof("HRRS queued %d hosts from reports that were expiring between %s and %s and %d scheduled hosts, %d hosts are deferred by maintenance windows",
+		len(hostIDs)-scheduledHosts, refresher.fromTime, toTime, scheduledHosts, len(deferred))
 	refresher.fromTime = toTime
+	refresher.lastRefreshTime = now
+	refresher.deferredUntil = deferredUntil
 
 	return nil
 }
+
+// getHostSchedules returns the attestation schedules of the hosts, the hosts of a flavorgroup schedule are the
+// hosts linked to the flavorgroup
+func (refresher *hostReportRefresherImpl) getHostSchedules() (map[uuid.UUID]*hostSchedule, error) {
+
+	hostSchedules := make(map[uuid.UUID]*hostSchedule)
+	if refresher.scheduleStore == nil {
+		return hostSchedules, nil
+	}
+
+	schedules, err := refresher.scheduleStore.Search(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while searching schedules")
+	}
+
+	for _, schedule := range schedules {
+		interval, err := schedule.GetInterval()
+		if err != nil {
+			defaultLog.WithError(err).Errorf("HRRS is skipping schedule %s", schedule.Id)
+			continue
+		}
+		jitter, err := schedule.GetJitter()
+		if err != nil {
+			defaultLog.WithError(err).Errorf("HRRS is skipping schedule %s", schedule.Id)
+			continue
+		}
+
+		var hostIDs []uuid.UUID
+		if schedule.HostId != nil {
+			hostIDs = []uuid.UUID{*schedule.HostId}
+		} else if schedule.FlavorgroupId != nil {
+			hostIDs, err = refresher.flavorGroupStore.SearchHostsByFlavorGroup(*schedule.FlavorgroupId)
+			if err != nil {
+				return nil, errors.Wrapf(err, "An error occurred while searching the hosts of flavorgroup %s", *schedule.FlavorgroupId)
+			}
+		}
+
+		for _, hostID := range hostIDs {
+			hs, ok := hostSchedules[hostID]
+			if !ok {
+				hs = &hostSchedule{}
+				hostSchedules[hostID] = hs
+			}
+			hs.schedules = append(hs.schedules, schedule)
+			if schedule.HostId != nil {
+				hs.interval = interval
+				hs.jitter = jitter
+				hs.hostScheduled = true
+			} else if !hs.hostScheduled && (hs.interval == 0 || interval < hs.interval) {
+				hs.interval = interval
+				hs.jitter = jitter
+			}
+		}
+	}
+	return hostSchedules, nil
+}
+
+// getFirstAttestation returns when a host that was not scheduled before is due, based on its latest report
+func (refresher *hostReportRefresherImpl) getFirstAttestation(hostID uuid.UUID, hs *hostSchedule, now time.Time) time.Time {
+
+	report, err := refresher.getLatestReport(hostID)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("HRRS failed to retrieve the latest report of host %s", hostID)
+		return now
+	}
+	if report == nil {
+		return now
+	}
+	return hs.nextAttestation(report.CreatedAt)
+}
+
+// getLatestReport returns the latest report of the host, or nil if the host has no report
+func (refresher *hostReportRefresherImpl) getLatestReport(hostID uuid.UUID) (*models.HVSReport, error) {
+
+	reports, err := refresher.reportStore.Search(&models.ReportFilterCriteria{
+		HostID:        hostID,
+		LatestPerHost: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, nil
+	}
+	return &reports[0], nil
+}
+
+// getWaitPeriod returns the time until the next refresh, which is the refresh period or
+// the time until the next scheduled attestation or the end of the maintenance window of
+// hosts with expired reports if it is sooner
+func (refresher *hostReportRefresherImpl) getWaitPeriod() time.Duration {
+
+	waitPeriod := refresher.cfg.RefreshPeriod
+	now := time.Now().UTC()
+	wakeUps := make([]time.Time, 0, len(refresher.nextAttestations)+1)
+	for _, nextAttestation := range refresher.nextAttestations {
+		wakeUps = append(wakeUps, nextAttestation)
+	}
+	if !refresher.deferredUntil.IsZero() {
+		wakeUps = append(wakeUps, refresher.deferredUntil)
+	}
+	for _, wakeUp := range wakeUps {
+		untilNext := wakeUp.Sub(now)
+		if untilNext < minimumWaitPeriod {
+			untilNext = minimumWaitPeriod
+		}
+		if untilNext < waitPeriod {
+			waitPeriod = untilNext
+		}
+	}
+	return waitPeriod
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models/taskstage"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -66,7 +68,8 @@ func TestHostReportRefresher(t *testing.T) {
 	// create a new HostReportRefresher, 'run' the backgound thread and then
 	// sleep for ten seconds.  We expect the expired report to be updated
 	// in the report store.
-	refresher, err := NewHostReportRefresher(cfg, reportStore, hostTrustManager)
+	refresher, err := NewHostReportRefresher(cfg, reportStore, mocks.NewEmptyMockScheduleStore(),
+		mocks.NewFakeFlavorgroupStore(), hostTrustManager)
 	assert.NoError(t, err)
 	refresher.Run()
 
@@ -91,6 +94,144 @@ func TestHostReportRefresher(t *testing.T) {
 	}
 }
 
+func TestHostReportRefresherSchedules(t *testing.T) {
+
+	cfg := HRRSConfig{
+		RefreshPeriod: twentyFourHours,
+	}
+
+	reportStore := mocks.NewEmptyMockReportStore()
+	scheduleStore := mocks.NewEmptyMockScheduleStore()
+	flavorGroupStore := mocks.NewFakeFlavorgroupStore()
+	hostTrustManager := MockHostTrustManager{
+		reportStore: reportStore,
+	}
+
+	// all hosts have a valid report that was created two hours ago
+	createdAt := time.Now().Add(-2 * time.Hour)
+	hostIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	for _, hostID := range hostIDs {
+		_, _ = reportStore.Create(&models.HVSReport{
+			ID:         uuid.New(),
+			HostID:     hostID,
+			CreatedAt:  createdAt,
+			Expiration: time.Now().Add(2 * twentyFourHours),
+			TrustReport: hvs.TrustReport{
+				Trusted: true,
+			},
+		})
+	}
+
+	// host 0 is attested every hour, host 1 is in a maintenance window, host 2 is linked to a flavorgroup that is
+	// attested every hour but has its own schedule of every four hours, host 3 is not scheduled
+	_, _ = scheduleStore.Create(&hvs.Schedule{HostId: &hostIDs[0], Interval: "1h", Jitter: "1m"})
+	now := time.Now().UTC()
+	_, _ = scheduleStore.Create(&hvs.Schedule{HostId: &hostIDs[1], Interval: "1h", MaintenanceWindows: []hvs.MaintenanceWindow{
+		{Start: now.Add(-time.Hour).Format("15:04"), Duration: "2h"},
+	}})
+	_, _ = scheduleStore.Create(&hvs.Schedule{HostId: &hostIDs[2], Interval: "4h"})
+	flavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	flavorGroupStore.HostFlavorgroupStore = append(flavorGroupStore.HostFlavorgroupStore, &hvs.HostFlavorgroup{
+		HostId:        hostIDs[2],
+		FlavorgroupId: flavorgroupId,
+	})
+	_, _ = scheduleStore.Create(&hvs.Schedule{FlavorgroupId: &flavorgroupId, Interval: "1h"})
+
+	refresher, err := NewHostReportRefresher(cfg, reportStore, scheduleStore, flavorGroupStore, hostTrustManager)
+	assert.NoError(t, err)
+	err = refresher.(*hostReportRefresherImpl).refreshReports()
+	assert.NoError(t, err)
+
+	// only the report of host 0 was refreshed
+	for i, hostID := range hostIDs {
+		reports, err := reportStore.Search(&models.ReportFilterCriteria{
+			HostID: hostID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(reports))
+		assert.Equal(t, i == 0, reports[0].CreatedAt.After(createdAt), "host %d", i)
+	}
+
+	// host 0 is scheduled for the next hour, host 1 is due at the end of the maintenance window
+	nextAttestations := refresher.(*hostReportRefresherImpl).nextAttestations
+	assert.Equal(t, 3, len(nextAttestations))
+	assert.True(t, nextAttestations[hostIDs[0]].After(now.Add(time.Hour)))
+	assert.True(t, nextAttestations[hostIDs[0]].Before(now.Add(time.Hour+2*time.Minute)))
+	assert.True(t, nextAttestations[hostIDs[1]].After(now.Add(59*time.Minute)))
+	assert.False(t, nextAttestations[hostIDs[1]].After(now.Add(time.Hour)))
+	assert.True(t, refresher.(*hostReportRefresherImpl).getWaitPeriod() > time.Hour-2*time.Minute)
+}
+
+func TestHostReportRefresherMaintenanceWindows(t *testing.T) {
+
+	cfg := HRRSConfig{
+		RefreshPeriod: twentyFourHours,
+	}
+
+	reportStore := mocks.NewEmptyMockReportStore()
+	scheduleStore := mocks.NewEmptyMockScheduleStore()
+	hostTrustManager := MockHostTrustManager{
+		reportStore: reportStore,
+	}
+
+	// both hosts have a report that expired during a maintenance window, the window of host 0 ended an hour ago and
+	// host 1 is still in its window
+	now := time.Now().UTC()
+	createdAt := now.Add(-30 * time.Minute)
+	hostIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, hostID := range hostIDs {
+		_, _ = reportStore.Create(&models.HVSReport{
+			ID:         uuid.New(),
+			HostID:     hostID,
+			CreatedAt:  createdAt,
+			Expiration: now.Add(-10 * time.Minute),
+			TrustReport: hvs.TrustReport{
+				Trusted: true,
+			},
+		})
+	}
+	_, _ = scheduleStore.Create(&hvs.Schedule{HostId: &hostIDs[0], Interval: "1h", MaintenanceWindows: []hvs.MaintenanceWindow{
+		{Start: now.Add(-3 * time.Hour).Format("15:04"), Duration: "2h"},
+	}})
+	_, _ = scheduleStore.Create(&hvs.Schedule{HostId: &hostIDs[1], Interval: "1h", MaintenanceWindows: []hvs.MaintenanceWindow{
+		{Start: now.Add(-time.Hour).Format("15:04"), Duration: "2h"},
+	}})
+
+	// the HRRS last refreshed the reports during the maintenance window of host 0, the expired reports were
+	// already found then
+	refresher, err := NewHostReportRefresher(cfg, reportStore, scheduleStore, mocks.NewFakeFlavorgroupStore(), hostTrustManager)
+	assert.NoError(t, err)
+	refresherImpl := refresher.(*hostReportRefresherImpl)
+	refresherImpl.fromTime = now
+	refresherImpl.lastRefreshTime = now.Add(-2 * time.Hour)
+	err = refresherImpl.refreshReports()
+	assert.NoError(t, err)
+
+	// only the report of host 0 was refreshed
+	for i, hostID := range hostIDs {
+		reports, err := reportStore.Search(&models.ReportFilterCriteria{
+			HostID: hostID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(reports))
+		assert.Equal(t, i == 0, reports[0].CreatedAt.After(createdAt), "host %d", i)
+	}
+
+	// a restarted HRRS defers host 1 until the end of its maintenance window
+	refresher, err = NewHostReportRefresher(cfg, reportStore, scheduleStore, mocks.NewFakeFlavorgroupStore(), hostTrustManager)
+	assert.NoError(t, err)
+	refresherImpl = refresher.(*hostReportRefresherImpl)
+	err = refresherImpl.refreshReports()
+	assert.NoError(t, err)
+	reports, err := reportStore.Search(&models.ReportFilterCriteria{
+		HostID: hostIDs[1],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, reports[0].CreatedAt)
+	assert.True(t, refresherImpl.deferredUntil.After(now.Add(59*time.Minute)))
+	assert.False(t, refresherImpl.deferredUntil.After(now.Add(time.Hour)))
+}
+
 //-------------------------------------------------------------------------------------------------
 // M O C K   H O S T   T R U S T   M A N A G E R
 //-------------------------------------------------------------------------------------------------
@@ -106,7 +247,19 @@ func (htm MockHostTrustManager) ProcessQueue() error {
 	return errors.New("ProcessQueue is not implemented")
 }
 
-func (htm MockHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+func (htm MockHostTrustManager) EvaluateHost(hostId uuid.UUID, hostData *types.HostManifest, fg hvs.FlavorGroup, flavors []hvs.SignedFlavor) (*hvs.TrustReport, error) {
+	return nil, errors.New("EvaluateHost is not implemented")
+}
+
+func (htm MockHostTrustManager) JobStages(jobId uuid.UUID) map[uuid.UUID]taskstage.Stage {
+	return nil
+}
+
+func (htm MockHostTrustManager) CancelJob(jobId uuid.UUID) error {
+	return errors.New("CancelJob is not implemented")
+}
+
+func (htm MockHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostData, preferHashMatch bool) (uuid.UUID, error) {
 
 	for _, hostID := range hostIDs {
 
@@ -118,7 +271,7 @@ func (htm MockHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostD
 
 		reportsToDelete, err := htm.reportStore.Search(&criteria)
 		if err != nil {
-			return errors.Wrap(err, "There was an error searching for the report by host id")
+			return uuid.Nil, errors.Wrap(err, "There was an error searching for the report by host id")
 		}
 
 		for _, reportToDelete := range reportsToDelete {
@@ -137,9 +290,9 @@ func (htm MockHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostD
 
 		_, err = htm.reportStore.Create(&trustReport)
 		if err != nil {
-			return nil
+			return uuid.Nil, nil
 		}
 	}
 
-	return nil
+	return uuid.New(), nil
 }
@@ -0,0 +1,234 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/models"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+const (
+	HeaderEvent     = "X-Hvs-Event"
+	HeaderDelivery  = "X-Hvs-Delivery"
+	HeaderTimestamp = "X-Hvs-Timestamp"
+	HeaderSignature = "X-Hvs-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Notifier delivers host trust change events to all registered webhooks. Events are queued and
+// delivered in the background by a fixed number of workers, each delivery being retried with
+// exponential backoff. Deliveries that still fail after all retries are recorded in the dead-letter
+// store.
+type Notifier struct {
+	store       domain.WebhookStore
+	deadLetters domain.WebhookDeadLetterStore
+	client      *http.Client
+	cfg         WebhookConfig
+
+	events     chan *hvs.TrustChangeEvent
+	deliveries chan delivery
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+}
+
+// delivery is the notification of an event to a webhook
+type delivery struct {
+	webhook hvs.Webhook
+	event   *hvs.TrustChangeEvent
+	payload []byte
+}
+
+func NewNotifier(cfg WebhookConfig, store domain.WebhookStore, deadLetters domain.WebhookDeadLetterStore, client *http.Client) (*Notifier, error) {
+	if store == nil || deadLetters == nil {
+		return nil, errors.New("webhook/notifier:NewNotifier() webhook and dead-letter stores must be provided")
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	// the notifier works on a copy so that the request timeout does not leak into the client of the caller
+	notifierClient := &http.Client{}
+	if client != nil {
+		*notifierClient = *client
+	}
+	notifierClient.Timeout = cfg.RequestTimeout
+
+	n := &Notifier{
+		store:       store,
+		deadLetters: deadLetters,
+		client:      notifierClient,
+		cfg:         cfg,
+		events:      make(chan *hvs.TrustChangeEvent, cfg.BufferSize),
+		deliveries:  make(chan delivery),
+		stopChan:    make(chan struct{}),
+	}
+	n.wg.Add(cfg.Workers + 1)
+	for i := 0; i < cfg.Workers; i++ {
+		go n.deliverAll()
+	}
+	go n.dispatch()
+	return n, nil
+}
+
+// Notify queues the event for delivery. It never blocks the caller; if the queue is full the event is dropped
+func (n *Notifier) Notify(event *hvs.TrustChangeEvent) {
+	if event == nil {
+		return
+	}
+	select {
+	case n.events <- event:
+	default:
+		defaultLog.Errorf("webhook/notifier:Notify() Event queue is full, dropping trust change event %s for host %s", event.Id, event.HostId)
+	}
+}
+
+// Stop waits for the queued events to be dispatched and all pending deliveries to complete
+func (n *Notifier) Stop() {
+	close(n.stopChan)
+	n.wg.Wait()
+}
+
+// dispatch hands the deliveries of the queued events to the workers. It waits for a worker to be free,
+// so that the events queue up, and are eventually dropped, when the webhooks are slow
+func (n *Notifier) dispatch() {
+	defer n.wg.Done()
+	// the workers return once the remaining deliveries are done
+	defer close(n.deliveries)
+	for {
+		select {
+		case event := <-n.events:
+			n.fanOut(event)
+		case <-n.stopChan:
+			// drain existing queue and return
+			for len(n.events) > 0 {
+				n.fanOut(<-n.events)
+			}
+			return
+		}
+	}
+}
+
+func (n *Notifier) fanOut(event *hvs.TrustChangeEvent) {
+	defaultLog.Trace("webhook/notifier:fanOut() Entering")
+	defer defaultLog.Trace("webhook/notifier:fanOut() Leaving")
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("webhook/notifier:fanOut() Failed to marshal trust change event %s", event.Id)
+		return
+	}
+	webhooks, err := n.store.Search(nil)
+	if err != nil {
+		defaultLog.WithError(err).Error("webhook/notifier:fanOut() Failed to retrieve webhooks")
+		return
+	}
+	for _, wh := range webhooks {
+		n.deliveries <- delivery{webhook: wh, event: event, payload: payload}
+	}
+}
+
+func (n *Notifier) deliverAll() {
+	defer n.wg.Done()
+	for d := range n.deliveries {
+		n.deliverWithRetry(d.webhook, d.event, d.payload)
+	}
+}
+
+func (n *Notifier) deliverWithRetry(wh hvs.Webhook, event *hvs.TrustChangeEvent, payload []byte) {
+	backoff := n.cfg.RetryBackoff
+	var err error
+	attempts := 0
+	for attempts <= n.cfg.MaxRetries {
+		attempts++
+		if err = n.deliver(wh, event, payload); err == nil {
+			defaultLog.Debugf("webhook/notifier:deliverWithRetry() Delivered event %s to webhook %s", event.Id, wh.Id)
+			return
+		}
+		defaultLog.WithError(err).Warnf("webhook/notifier:deliverWithRetry() Attempt %d to deliver event %s to webhook %s failed", attempts, event.Id, wh.Id)
+		if attempts > n.cfg.MaxRetries {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-n.stopChan:
+			// service is shutting down, do not wait for the next attempt
+			attempts = n.cfg.MaxRetries + 1
+		}
+		backoff *= 2
+	}
+
+	_, dlErr := n.deadLetters.Create(&models.WebhookDeadLetter{
+		WebhookID: wh.Id,
+		EventID:   event.Id,
+		Payload:   string(payload),
+		Attempts:  attempts,
+		LastError: err.Error(),
+	})
+	if dlErr != nil {
+		defaultLog.WithError(dlErr).Errorf("webhook/notifier:deliverWithRetry() Failed to record dead letter for event %s and webhook %s", event.Id, wh.Id)
+	}
+}
+
+func (n *Notifier) deliver(wh hvs.Webhook, event *hvs.TrustChangeEvent, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, wh.Url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event.EventType)
+	req.Header.Set(HeaderDelivery, event.Id.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(wh.Secret, timestamp, payload))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign computes the value of the X-Hvs-Signature header: the hex encoded HMAC-SHA256 of the timestamp
+// and the payload, separated by a '.', keyed with the webhook secret. Receivers should recompute the
+// signature and compare it using a constant time comparison.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package webhook
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent() *hvs.TrustChangeEvent {
+	return &hvs.TrustChangeEvent{
+		Id:        uuid.New(),
+		EventType: hvs.TrustChangeEventType,
+		HostId:    uuid.New(),
+		Trusted:   false,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestNotifierDeliversSignedPayload(t *testing.T) {
+	secret := "0123456789abcdef0123456789abcdef"
+	received := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		expected := Sign(secret, r.Header.Get(HeaderTimestamp), body)
+		received <- expected == r.Header.Get(HeaderSignature) && r.Header.Get(HeaderEvent) == hvs.TrustChangeEventType
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := mocks.NewMockWebhookStore()
+	store.Delete(uuid.MustParse("1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01"))
+	store.Create(&hvs.Webhook{Url: server.URL, Secret: secret})
+	dls := mocks.NewMockWebhookDeadLetterStore()
+
+	n, err := NewNotifier(WebhookConfig{MaxRetries: 1, RetryBackoff: time.Millisecond}, store, dls, server.Client())
+	assert.NoError(t, err)
+	n.Notify(newTestEvent())
+
+	select {
+	case valid := <-received:
+		assert.True(t, valid)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+	n.Stop()
+	assert.Empty(t, dls.DeadLetters)
+}
+
+func TestNotifierRetriesAndDeadLetters(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := mocks.NewMockWebhookStore()
+	store.Delete(uuid.MustParse("1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01"))
+	wh, _ := store.Create(&hvs.Webhook{Url: server.URL, Secret: "0123456789abcdef0123456789abcdef"})
+	dls := mocks.NewMockWebhookDeadLetterStore()
+
+	n, err := NewNotifier(WebhookConfig{MaxRetries: 2, RetryBackoff: time.Millisecond}, store, dls, server.Client())
+	assert.NoError(t, err)
+	event := newTestEvent()
+	n.Notify(event)
+
+	for i := 0; i < 500; i++ {
+		if deadLetters, _ := dls.Search(nil); len(deadLetters) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	n.Stop()
+
+	assert.Len(t, dls.DeadLetters, 1)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, wh.Id, dls.DeadLetters[0].WebhookID)
+	assert.Equal(t, event.Id, dls.DeadLetters[0].EventID)
+	assert.Equal(t, 3, dls.DeadLetters[0].Attempts)
+}
+
+func TestNotifierDoesNotModifyClient(t *testing.T) {
+	client := &http.Client{Timeout: time.Minute}
+	n, err := NewNotifier(WebhookConfig{RequestTimeout: time.Second}, mocks.NewMockWebhookStore(), mocks.NewMockWebhookDeadLetterStore(), client)
+	assert.NoError(t, err)
+	n.Stop()
+
+	assert.Equal(t, time.Minute, client.Timeout)
+	assert.Equal(t, time.Second, n.client.Timeout)
+}
+
+func TestNotifierBoundsConcurrentDeliveries(t *testing.T) {
+	var active, maxActive, calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&active, 1)
+		for {
+			max := atomic.LoadInt32(&maxActive)
+			if current <= max || atomic.CompareAndSwapInt32(&maxActive, max, current) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&active, -1)
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := mocks.NewMockWebhookStore()
+	store.Delete(uuid.MustParse("1c3bbb3d-6a14-4ba5-9d6f-0ef7d5ac5c01"))
+	for i := 0; i < 5; i++ {
+		store.Create(&hvs.Webhook{Url: server.URL, Secret: "0123456789abcdef0123456789abcdef"})
+	}
+	dls := mocks.NewMockWebhookDeadLetterStore()
+
+	n, err := NewNotifier(WebhookConfig{MaxRetries: 0, Workers: 2}, store, dls, server.Client())
+	assert.NoError(t, err)
+	n.Notify(newTestEvent())
+	n.Notify(newTestEvent())
+
+	// the deliveries beyond the workers wait for one of them to be free
+	for i := 0; i < 500 && atomic.LoadInt32(&active) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&active))
+
+	close(release)
+	n.Stop()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxActive))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&calls))
+	assert.Empty(t, dls.DeadLetters)
+}
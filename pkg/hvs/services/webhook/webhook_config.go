@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package webhook
+
+import "time"
+
+var (
+	// DefaultRetryBackoff is the delay before the first redelivery attempt, doubled on each subsequent attempt
+	DefaultRetryBackoff, _ = time.ParseDuration("2s")
+	// DefaultRequestTimeout bounds a single delivery attempt
+	DefaultRequestTimeout, _ = time.ParseDuration("10s")
+)
+
+const (
+	DefaultMaxRetries = 5
+	DefaultBufferSize = 1000
+	DefaultWorkers    = 10
+)
+
+type WebhookConfig struct {
+	// MaxRetries is the number of redelivery attempts after the first failed delivery before the
+	// notification is moved to the dead-letter table (defaults to DefaultMaxRetries)
+	MaxRetries int `yaml:"max-retries" mapstructure:"max-retries"`
+	// RetryBackoff is the initial delay between delivery attempts (defaults to DefaultRetryBackoff)
+	RetryBackoff time.Duration `yaml:"retry-backoff" mapstructure:"retry-backoff"`
+	// RequestTimeout is the timeout of each delivery attempt (defaults to DefaultRequestTimeout)
+	RequestTimeout time.Duration `yaml:"request-timeout" mapstructure:"request-timeout"`
+	// BufferSize is the number of trust change events that can be queued for delivery
+	BufferSize int `yaml:"buffer-size" mapstructure:"buffer-size"`
+	// Workers is the number of deliveries that are attempted concurrently (defaults to DefaultWorkers)
+	Workers int `yaml:"workers" mapstructure:"workers"`
+}
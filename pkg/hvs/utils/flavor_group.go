@@ -32,6 +32,7 @@ func GetAutomaticFlavorMatchPolicy() []hvs.FlavorMatchPolicy {
 	policies = append(policies, hvs.NewFlavorMatchPolicy(cf.FlavorPartSoftware, hvs.NewMatchPolicy(hvs.MatchTypeAllOf, hvs.FlavorRequiredIfDefined)))
 	policies = append(policies, hvs.NewFlavorMatchPolicy(cf.FlavorPartAssetTag, hvs.NewMatchPolicy(hvs.MatchTypeLatest, hvs.FlavorRequiredIfDefined)))
 	policies = append(policies, hvs.NewFlavorMatchPolicy(cf.FlavorPartHostUnique, hvs.NewMatchPolicy(hvs.MatchTypeLatest, hvs.FlavorRequiredIfDefined)))
+	policies = append(policies, hvs.NewFlavorMatchPolicy(cf.FlavorPartIma, hvs.NewMatchPolicy(hvs.MatchTypeAnyOf, hvs.FlavorRequiredIfDefined)))
 
 	return policies
 }
@@ -41,10 +41,28 @@ func GetDefaultSoftwareFlavorGroups(components []string) []string {
 	return fgNames
 }
 
+// hostStateMappers determine the host state from the errors of the host connectors that do not return the errors of
+// the Trust Agent client
+var hostStateMappers []func(err error) (hvs.HostState, bool)
+
+// RegisterHostStateMapper adds a mapper that DetermineHostState tries before matching the errors of the Trust Agent
+// client, the mapper returns false when it does not know the error
+func RegisterHostStateMapper(mapper func(err error) (hvs.HostState, bool)) {
+	hostStateMappers = append(hostStateMappers, mapper)
+}
+
 func DetermineHostState(err error) hvs.HostState {
 	defaultLog.Trace("utils/host:DetermineHostState() Entering")
 	defer defaultLog.Trace("utils/host:DetermineHostState() Leaving")
 
+	for _, mapper := range hostStateMappers {
+		if hostState, ok := mapper(err); ok {
+			defaultLog.Warnf("Failed to get response from host, host has %s state with error message: %s",
+				hostState.String(), err.Error())
+			return hostState
+		}
+	}
+
 	if strings.Contains(err.Error(), "connect") {
 		if strings.Contains(err.Error(), "connection timed out") {
 			defaultLog.Warnf("Failed connection to host, host has CONNECTION_TIMEOUT state with error message: %s", err.Error())
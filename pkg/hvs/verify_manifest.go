@@ -0,0 +1,220 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var errManifestNotTrusted = errors.New("Host manifest is not trusted")
+
+// verifyManifestArgs holds the arguments of the verify-manifest command
+type verifyManifestArgs struct {
+	manifestFile                    string
+	flavorFiles                     []string
+	privacyCACertFile               string
+	flavorSigningCertFile           string
+	flavorCACerts                   string
+	tagCACertFile                   string
+	skipFlavorSignatureVerification bool
+}
+
+// verifyManifest verifies a host manifest against signed flavors read from files, without requiring a running
+// HVS or its database. The trust report is written to the console, an error is returned if the host manifest
+// is not trusted so that the command exits with a non-zero status
+func (a *App) verifyManifest(args []string) error {
+	vmArgs, err := parseVerifyManifestArgs(args)
+	if err != nil {
+		return err
+	}
+
+	hostManifest, err := loadHostManifest(vmArgs.manifestFile)
+	if err != nil {
+		return err
+	}
+	var signedFlavors []hvs.SignedFlavor
+	for _, flavorFile := range vmArgs.flavorFiles {
+		flavors, err := loadSignedFlavors(flavorFile)
+		if err != nil {
+			return err
+		}
+		signedFlavors = append(signedFlavors, flavors...)
+	}
+	verifierCerts, err := loadVerifierCertificates(vmArgs)
+	if err != nil {
+		return err
+	}
+
+	trustReport, err := verifyHostManifest(verifierCerts, hostManifest, signedFlavors, vmArgs.skipFlavorSignatureVerification)
+	if err != nil {
+		return err
+	}
+	reportJSON, err := json.MarshalIndent(trustReport, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal trust report")
+	}
+	fmt.Fprintln(a.consoleWriter(), string(reportJSON))
+
+	if !trustReport.Trusted {
+		return errManifestNotTrusted
+	}
+	return nil
+}
+
+func parseVerifyManifestArgs(args []string) (*verifyManifestArgs, error) {
+	vmArgs := verifyManifestArgs{
+		privacyCACertFile:     constants.PrivacyCACertFile,
+		flavorSigningCertFile: constants.FlavorSigningCertFile,
+		flavorCACerts:         constants.TrustedRootCACertsDir,
+		tagCACertFile:         constants.TagCACertFile,
+	}
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--skip-flavor-signature-verification" {
+			vmArgs.skipFlavorSignatureVerification = true
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, errors.New("Missing value for flag: " + args[i])
+		}
+		switch args[i] {
+		case "--manifest":
+			vmArgs.manifestFile = args[i+1]
+		case "--flavor":
+			vmArgs.flavorFiles = append(vmArgs.flavorFiles, args[i+1])
+		case "--privacy-ca":
+			vmArgs.privacyCACertFile = args[i+1]
+		case "--flavor-signing-cert":
+			vmArgs.flavorSigningCertFile = args[i+1]
+		case "--flavor-ca":
+			vmArgs.flavorCACerts = args[i+1]
+		case "--tag-ca":
+			vmArgs.tagCACertFile = args[i+1]
+		default:
+			return nil, errors.New("Invalid flag: " + args[i])
+		}
+		i++
+	}
+	if vmArgs.manifestFile == "" {
+		return nil, errors.New("Host manifest file must be provided with --manifest")
+	}
+	if len(vmArgs.flavorFiles) == 0 {
+		return nil, errors.New("At least one signed flavor file must be provided with --flavor")
+	}
+	return &vmArgs, nil
+}
+
+func loadHostManifest(manifestFile string) (*types.HostManifest, error) {
+	manifestJSON, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read host manifest file "+manifestFile)
+	}
+	var hostManifest types.HostManifest
+	if err = json.Unmarshal(manifestJSON, &hostManifest); err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal host manifest file "+manifestFile)
+	}
+	return &hostManifest, nil
+}
+
+// loadSignedFlavors reads the signed flavors from a file that contains either a single signed flavor, a list
+// of signed flavors or a signed flavor collection as returned by the flavors API
+func loadSignedFlavors(flavorFile string) ([]hvs.SignedFlavor, error) {
+	flavorsJSON, err := ioutil.ReadFile(flavorFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read signed flavor file "+flavorFile)
+	}
+
+	var signedFlavors []hvs.SignedFlavor
+	flavorsJSON = bytes.TrimSpace(flavorsJSON)
+	if bytes.HasPrefix(flavorsJSON, []byte("[")) {
+		err = json.Unmarshal(flavorsJSON, &signedFlavors)
+	} else {
+		var signedFlavorCollection hvs.SignedFlavorCollection
+		err = json.Unmarshal(flavorsJSON, &signedFlavorCollection)
+		signedFlavors = signedFlavorCollection.SignedFlavors
+		if err == nil && signedFlavors == nil {
+			var signedFlavor hvs.SignedFlavor
+			err = json.Unmarshal(flavorsJSON, &signedFlavor)
+			signedFlavors = []hvs.SignedFlavor{signedFlavor}
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal signed flavor file "+flavorFile)
+	}
+	if len(signedFlavors) == 0 {
+		return nil, errors.New("No signed flavors found in file " + flavorFile)
+	}
+	return signedFlavors, nil
+}
+
+// loadVerifierCertificates loads the certificates needed by the verifier, the flavor signing certificate file
+// may contain the intermediate CA certificates after the signing certificate, as installed by HVS setup
+func loadVerifierCertificates(vmArgs *verifyManifestArgs) (*verifier.VerifierCertificates, error) {
+	privacyCAs, err := crypt.GetSubjectCertsMapFromPemFile(vmArgs.privacyCACertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load privacy CA certificates from "+vmArgs.privacyCACertFile)
+	}
+	tagCAs, err := crypt.GetSubjectCertsMapFromPemFile(vmArgs.tagCACertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load tag CA certificates from "+vmArgs.tagCACertFile)
+	}
+	signingCerts, err := crypt.GetSubjectCertsMapFromPemFile(vmArgs.flavorSigningCertFile)
+	if err != nil || len(signingCerts) == 0 {
+		return nil, errors.Errorf("Failed to load flavor signing certificate from %s: %v", vmArgs.flavorSigningCertFile, err)
+	}
+
+	var flavorCAs []x509.Certificate
+	if fi, err := os.Stat(vmArgs.flavorCACerts); err == nil && fi.IsDir() {
+		flavorCAs, err = crypt.GetCertsFromDir(vmArgs.flavorCACerts)
+	} else {
+		flavorCAs, err = crypt.GetSubjectCertsMapFromPemFile(vmArgs.flavorCACerts)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load flavor CA certificates from "+vmArgs.flavorCACerts)
+	}
+	flavorCAPool := crypt.GetCertPool(flavorCAs)
+	for i := range signingCerts[1:] {
+		flavorCAPool.AddCert(&signingCerts[i+1]) //Add intermediate CA
+	}
+
+	return &verifier.VerifierCertificates{
+		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs),
+		AssetTagCACertificates:   crypt.GetCertPool(tagCAs),
+		FlavorSigningCertificate: &signingCerts[0],
+		FlavorCACertificates:     flavorCAPool,
+	}, nil
+}
+
+// verifyHostManifest verifies the host manifest against each of the signed flavors and combines the results
+// in a single trust report
+func verifyHostManifest(verifierCerts *verifier.VerifierCertificates, hostManifest *types.HostManifest, signedFlavors []hvs.SignedFlavor, skipFlavorSignatureVerification bool) (*hvs.TrustReport, error) {
+	v, err := verifier.NewVerifier(*verifierCerts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create verifier")
+	}
+
+	trustReport := hvs.TrustReport{HostManifest: *hostManifest}
+	for i := range signedFlavors {
+		flavorReport, err := v.Verify(hostManifest, &signedFlavors[i], skipFlavorSignatureVerification)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to verify host manifest against flavor %s", signedFlavors[i].Flavor.Meta.ID)
+		}
+		trustReport.AddResults(flavorReport.Results)
+	}
+	trustReport.Trusted = trustReport.IsTrusted()
+	return &trustReport, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+const verifyManifestTestData = "../lib/verifier/test_data/vmware20/"
+
+// as in the verifier integration tests, the signature of the test flavors is not verified
+func verifyManifestTestArgs(manifestFile, flavorFile string) []string {
+	return []string{
+		"--skip-flavor-signature-verification",
+		"--manifest", manifestFile,
+		"--flavor", flavorFile,
+		"--privacy-ca", verifyManifestTestData + "PrivacyCA.pem",
+		"--flavor-signing-cert", verifyManifestTestData + "flavor-signer.crt.pem",
+		"--flavor-ca", verifyManifestTestData + "cms-ca-cert.pem",
+		"--tag-ca", verifyManifestTestData + "tag-cacerts.pem",
+	}
+}
+
+func TestVerifyManifest(t *testing.T) {
+	var console bytes.Buffer
+	app := App{ConsoleWriter: &console}
+
+	err := app.verifyManifest(verifyManifestTestArgs(verifyManifestTestData+"host_manifest.json", verifyManifestTestData+"signed_flavors.json"))
+	assert.NoError(t, err)
+
+	var trustReport hvs.TrustReport
+	assert.NoError(t, json.Unmarshal(console.Bytes(), &trustReport))
+	assert.True(t, trustReport.Trusted)
+	assert.NotEmpty(t, trustReport.Results)
+}
+
+func TestVerifyManifestNotTrusted(t *testing.T) {
+	signedFlavors, err := loadSignedFlavors(verifyManifestTestData + "signed_flavors.json")
+	assert.NoError(t, err)
+	hostManifest, err := loadHostManifest(verifyManifestTestData + "host_manifest.json")
+	assert.NoError(t, err)
+	hostManifest.PcrManifest.Sha256Pcrs[0].Value = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	tmpDir, err := ioutil.TempDir("", "verify-manifest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	manifestFile := filepath.Join(tmpDir, "host_manifest.json")
+	manifestJSON, err := json.Marshal(hostManifest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(manifestFile, manifestJSON, 0600))
+	// a single signed flavor
+	flavorFile := filepath.Join(tmpDir, "signed_flavor.json")
+	flavorJSON, err := json.Marshal(signedFlavors[0])
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(flavorFile, flavorJSON, 0600))
+
+	var console bytes.Buffer
+	app := App{ConsoleWriter: &console}
+	err = app.verifyManifest(verifyManifestTestArgs(manifestFile, flavorFile))
+	assert.Equal(t, errManifestNotTrusted, err)
+
+	var trustReport hvs.TrustReport
+	assert.NoError(t, json.Unmarshal(console.Bytes(), &trustReport))
+	assert.False(t, trustReport.Trusted)
+}
+
+func TestVerifyManifestInvalidArgs(t *testing.T) {
+	app := App{}
+	assert.Error(t, app.verifyManifest([]string{"--flavor", verifyManifestTestData + "signed_flavors.json"}))
+	assert.Error(t, app.verifyManifest([]string{"--manifest", verifyManifestTestData + "host_manifest.json"}))
+	assert.Error(t, app.verifyManifest([]string{"--manifest"}))
+	assert.Error(t, app.verifyManifest([]string{"--unknown", "value"}))
+}
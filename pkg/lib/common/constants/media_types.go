@@ -12,4 +12,7 @@ const (
 	HTTPMediaTypeSaml        = "application/samlassertion+xml"
 	HTTPMediaTypePemFile     = "application/x-pem-file"
 	HTTPMediaTypeOctetStream = "application/octet-stream"
+	HTTPMediaTypeEventStream = "text/event-stream"
+	HTTPMediaTypeJwt         = "application/jwt"
+	HTTPMediaTypeCsv         = "text/csv"
 )
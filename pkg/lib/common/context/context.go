@@ -29,6 +29,12 @@ func SetUserPermissions(r *http.Request, val []types.PermissionInfo) *http.Reque
 	return r.WithContext(ctx)
 }
 
+func SetUserName(r *http.Request, val string) *http.Request {
+
+	ctx := context.WithValue(r.Context(), "username", val)
+	return r.WithContext(ctx)
+}
+
 /*
  func SetUserRoles(r *http.Request, val types.Roles) *http.Request {
 
@@ -45,6 +51,15 @@ func GetUserRoles(r *http.Request) ([]types.RoleInfo, error) {
 	return nil, fmt.Errorf("could not retrieve user roles from context")
 }
 
+func GetUserName(r *http.Request) (string, error) {
+	if rv := r.Context().Value("username"); rv != nil {
+		if un, ok := rv.(string); ok && un != "" {
+			return un, nil
+		}
+	}
+	return "", fmt.Errorf("could not retrieve user name from context")
+}
+
 func GetUserPermissions(r *http.Request) ([]types.PermissionInfo, error) {
 	if rv := r.Context().Value("userpermissions"); rv != nil {
 		if ur, ok := rv.([]types.PermissionInfo); ok {
@@ -98,6 +98,14 @@ func (t *Token) GetStandardClaims() interface{} {
 	return t.standardClaims
 }
 
+// GetSubject returns the subject of the token, which is the user name for tokens issued by AAS
+func (t *Token) GetSubject() string {
+	if t == nil || t.standardClaims == nil {
+		return ""
+	}
+	return t.standardClaims.Subject
+}
+
 func (t *Token) GetHeader() *map[string]interface{} {
 	if t.jwtToken == nil {
 		return nil
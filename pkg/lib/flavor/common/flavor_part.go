@@ -26,6 +26,8 @@ const (
 	FlavorPartHostUnique FlavorPart = "HOST_UNIQUE"
 	FlavorPartSoftware   FlavorPart = "SOFTWARE"
 	FlavorPartAssetTag   FlavorPart = "ASSET_TAG"
+	// FlavorPartIma flavors hold the allowlist of the files measured by the kernel IMA
+	FlavorPartIma FlavorPart = "IMA"
 )
 
 // GetFlavorTypes returns a list of flavor types
@@ -33,7 +35,7 @@ func GetFlavorTypes() []FlavorPart {
 	log.Trace("flavor/common/flavor_part:GetFlavorTypes() Entering")
 	defer log.Trace("flavor/common/flavor_part:GetFlavorTypes() Leaving")
 
-	return []FlavorPart{FlavorPartPlatform, FlavorPartOs, FlavorPartHostUnique, FlavorPartSoftware, FlavorPartAssetTag}
+	return []FlavorPart{FlavorPartPlatform, FlavorPartOs, FlavorPartHostUnique, FlavorPartSoftware, FlavorPartAssetTag, FlavorPartIma}
 }
 
 // GetFlavorTypesString returns a list of flavor types as strings for given flavor types
@@ -71,6 +73,8 @@ func (flavorPart *FlavorPart) Parse(flavorPartString string) error {
 		result = FlavorPartSoftware
 	case string(FlavorPartAssetTag):
 		result = FlavorPartAssetTag
+	case string(FlavorPartIma):
+		result = FlavorPartIma
 	default:
 		err = errors.Errorf("Invalid flavor part string '%s'", flavorPartString)
 	}
@@ -35,6 +35,25 @@ type CBNT struct {
 // SUEFI
 type SUEFI struct {
 	Enabled bool `json:"enabled,omitempty"`
+	// SecureBoot is verified against the Secure Boot variables measured to PCR 7 in place of the PCR 7 value
+	SecureBoot *SecureBootPolicy `json:"secure_boot,omitempty"`
+}
+
+// SecureBootPolicy is the expected UEFI Secure Boot configuration of the host.  Unlike the PCR 7 value, it
+// does not change when the db and dbx are updated, so the flavor does not need to be recreated.
+type SecureBootPolicy struct {
+	// Enabled requires the SecureBoot variable to be set
+	Enabled bool `json:"enabled"`
+	// PkIssuers, KekIssuers and DbIssuers are the issuer common names or organizations that the
+	// certificates of the PK, KEK and db must be issued by
+	PkIssuers  []string `json:"pk_issuers,omitempty"`
+	KekIssuers []string `json:"kek_issuers,omitempty"`
+	DbIssuers  []string `json:"db_issuers,omitempty"`
+	// MinDbxEntries is the minimum number of revocations in the dbx, the size of the revocation list
+	// grows with each version
+	MinDbxEntries int `json:"min_dbx_entries,omitempty"`
+	// RequiredDbxEntries are hex encoded SHA256 digests that must be revoked in the dbx
+	RequiredDbxEntries []string `json:"required_dbx_entries,omitempty"`
 }
 
 // Feature encapsulates the presence of various Platform security features on the Host hardware
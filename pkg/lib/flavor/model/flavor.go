@@ -6,6 +6,7 @@ package model
 
 import (
 	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
@@ -30,6 +31,8 @@ type Flavor struct {
 	// External section is unique to AssetTag Flavor type
 	External *External `json:"external,omitempty"`
 	Software *Software `json:"software,omitempty"`
+	// Ima section is unique to IMA Flavor type
+	Ima *Ima `json:"ima,omitempty"`
 }
 
 // NewFlavor returns a new instance of Flavor
@@ -88,3 +91,17 @@ func (flavor *Flavor) getFlavorDigest() ([]byte, error) {
 
 	return hashEntity.Sum(nil), nil
 }
+
+// GetContentDigest returns the hex encoded SHA384 digest of the flavor content. The id, label and lifecycle of the
+// flavor are not part of the content, so the same measurements staged under another label have the same digest
+func (flavor *Flavor) GetContentDigest() (string, error) {
+	tempFlavor := *flavor
+	tempFlavor.Meta.Description.Label = ""
+	tempFlavor.Meta.Lifecycle = nil
+
+	flavorDigest, err := tempFlavor.getFlavorDigest()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(flavorDigest), nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Ima is a component of flavor that holds the allowlist of the files measured by the kernel IMA
+type Ima struct {
+	// PcrBank is the PCR bank the IMA log is replayed in, e.g. SHA256
+	PcrBank string `json:"pcr_bank"`
+	// Files are the allowed digests of the measured files by path
+	Files map[string]ImaFile `json:"files"`
+	// Excludes are regular expressions of the paths that are not verified
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// ImaFile holds the allowed digests of a file measured by the kernel IMA
+type ImaFile struct {
+	// Digests are the lower case hex encoded digests the file is allowed to have
+	Digests []string `json:"digests"`
+	// Required files must be measured by the kernel IMA
+	Required bool `json:"required,omitempty"`
+}
+
+// Validate returns an error if the excludes are not valid regular expressions
+func (ima *Ima) Validate() error {
+	for _, exclude := range ima.Excludes {
+		if _, err := regexp.Compile(exclude); err != nil {
+			return errors.Wrapf(err, "Invalid IMA exclude '%s'", exclude)
+		}
+	}
+	return nil
+}
+
+// GetExcludes returns the compiled excludes, the invalid regular expressions are ignored
+func (ima *Ima) GetExcludes() []*regexp.Regexp {
+	var excludes []*regexp.Regexp
+	for _, exclude := range ima.Excludes {
+		if excludeRegexp, err := regexp.Compile(exclude); err == nil {
+			excludes = append(excludes, excludeRegexp)
+		}
+	}
+	return excludes
+}
+
+// Allows returns true if the file is in the allowlist with the given digest
+func (ima *Ima) Allows(path string, digest string) bool {
+	if file, ok := ima.Files[path]; ok {
+		for _, allowedDigest := range file.Digests {
+			if strings.EqualFold(allowedDigest, digest) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FlavorState is the lifecycle state of a flavor
+type FlavorState string
+
+const (
+	// FlavorStateDraft flavors are staged and not used for verification
+	FlavorStateDraft FlavorState = "DRAFT"
+	// FlavorStateActive flavors are used for verification
+	FlavorStateActive FlavorState = "ACTIVE"
+	// FlavorStateDeprecated flavors are still used for verification, hosts that only match deprecated flavors
+	// are reported with a warning
+	FlavorStateDeprecated FlavorState = "DEPRECATED"
+	// FlavorStateRevoked flavors are not used for verification
+	FlavorStateRevoked FlavorState = "REVOKED"
+)
+
+// Lifecycle holds the lifecycle state of a flavor and the time during which it is used for verification.
+// Flavors without lifecycle are active
+type Lifecycle struct {
+	State     FlavorState `json:"state,omitempty"`
+	NotBefore *time.Time  `json:"not_before,omitempty"`
+	NotAfter  *time.Time  `json:"not_after,omitempty"`
+	// Approval is set on the flavors captured from a golden host, which stay in draft until they are approved
+	Approval *Approval `json:"approval,omitempty"`
+}
+
+// Approval records who captured a candidate flavor and who approved it. The approver must be another user
+type Approval struct {
+	CapturedBy   string     `json:"captured_by"`
+	CapturedFrom string     `json:"captured_from,omitempty"`
+	ApprovedBy   string     `json:"approved_by,omitempty"`
+	ApprovedAt   *time.Time `json:"approved_at,omitempty"`
+}
+
+// Validate returns an error if the lifecycle state is unknown or the time bounds are inconsistent
+func (l *Lifecycle) Validate() error {
+	switch l.State {
+	case "", FlavorStateDraft, FlavorStateActive, FlavorStateDeprecated, FlavorStateRevoked:
+	default:
+		return errors.Errorf("Flavor state must be %s, %s, %s or %s", FlavorStateDraft, FlavorStateActive,
+			FlavorStateDeprecated, FlavorStateRevoked)
+	}
+	if l.NotBefore != nil && l.NotAfter != nil && !l.NotAfter.After(*l.NotBefore) {
+		return errors.New("Flavor not_after must be later than not_before")
+	}
+	return nil
+}
+
+// GetState returns the lifecycle state, which defaults to active
+func (l *Lifecycle) GetState() FlavorState {
+	if l == nil || l.State == "" {
+		return FlavorStateActive
+	}
+	return l.State
+}
+
+// IsUsable returns true if a flavor with this lifecycle can be used for verification at the given time
+func (l *Lifecycle) IsUsable(t time.Time) bool {
+	state := l.GetState()
+	if state != FlavorStateActive && state != FlavorStateDeprecated {
+		return false
+	}
+	if l == nil {
+		return true
+	}
+	if l.NotBefore != nil && t.Before(*l.NotBefore) {
+		return false
+	}
+	if l.NotAfter != nil && !t.Before(*l.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// IsPendingApproval returns true if the flavor was captured from a golden host and is not approved yet
+func (l *Lifecycle) IsPendingApproval() bool {
+	return l != nil && l.Approval != nil && l.Approval.ApprovedBy == ""
+}
+
+// IsDeprecated returns true if the flavor is deprecated
+func (l *Lifecycle) IsDeprecated() bool {
+	return l.GetState() == FlavorStateDeprecated
+}
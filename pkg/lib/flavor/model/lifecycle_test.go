@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleIsUsable(t *testing.T) {
+	notBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var lifecycle *Lifecycle
+	assert.True(t, lifecycle.IsUsable(notBefore))
+	assert.Equal(t, FlavorStateActive, lifecycle.GetState())
+
+	lifecycle = &Lifecycle{State: FlavorStateDeprecated, NotBefore: &notBefore, NotAfter: &notAfter}
+	assert.True(t, lifecycle.IsDeprecated())
+	assert.False(t, lifecycle.IsUsable(notBefore.Add(-time.Second)))
+	assert.True(t, lifecycle.IsUsable(notBefore))
+	assert.False(t, lifecycle.IsUsable(notAfter))
+
+	assert.False(t, (&Lifecycle{State: FlavorStateDraft}).IsUsable(notBefore))
+	assert.False(t, (&Lifecycle{State: FlavorStateRevoked}).IsUsable(notBefore))
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	var lifecycle Lifecycle
+	err := json.Unmarshal([]byte(`{"state":"DEPRECATED","not_before":"2020-01-01T00:00:00Z","not_after":"2021-01-01T00:00:00Z"}`), &lifecycle)
+	assert.NoError(t, err)
+	assert.NoError(t, lifecycle.Validate())
+
+	lifecycle.NotBefore, lifecycle.NotAfter = lifecycle.NotAfter, lifecycle.NotBefore
+	assert.Error(t, lifecycle.Validate())
+
+	assert.Error(t, (&Lifecycle{State: "RETIRED"}).Validate())
+}
+
+func TestLifecycleIsPendingApproval(t *testing.T) {
+	var lifecycle *Lifecycle
+	assert.False(t, lifecycle.IsPendingApproval())
+	assert.False(t, (&Lifecycle{State: FlavorStateDraft}).IsPendingApproval())
+
+	lifecycle = &Lifecycle{State: FlavorStateDraft, Approval: &Approval{CapturedBy: "admin"}}
+	assert.True(t, lifecycle.IsPendingApproval())
+	lifecycle.Approval.ApprovedBy = "security_officer"
+	assert.False(t, lifecycle.IsPendingApproval())
+}
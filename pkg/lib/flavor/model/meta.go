@@ -22,6 +22,12 @@ type Meta struct {
 	Realm       string             `json:"realm,omitempty"`
 	Description Description        `json:"description,omitempty"`
 	Vendor      hcConstants.Vendor `json:"vendor,omitempty"`
+	// RuleBuilder is the name of a rule builder registered in the verifier, used instead of the builder of the vendor
+	RuleBuilder string `json:"rule_builder,omitempty"`
+	// Rules are additional rules registered in the verifier that are applied along with the rules of the rule builder
+	Rules []RuleReference `json:"rules,omitempty"`
+	// Lifecycle controls whether and until when the flavor is used for verification
+	Lifecycle *Lifecycle `json:"lifecycle,omitempty"`
 }
 
 // Schema defines the Uri of the schema
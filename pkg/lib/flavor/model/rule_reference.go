@@ -0,0 +1,12 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+// RuleReference references a verification rule registered in the verifier by its name, along with the
+// parameters the rule is created with
+type RuleReference struct {
+	Name       string            `json:"name"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
@@ -8,7 +8,6 @@ import (
 	"crypto/x509"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
-	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/constants"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
 	hcConstants "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
@@ -62,8 +61,8 @@ func NewPlatformFlavorProvider(hostManifest *hcTypes.HostManifest, tagCertificat
 	return pfp, nil
 }
 
-// GetPlatformFlavor parses the connection string of the target host and determines the type of the host
-// and instantiates the appropriate PlatformFlavor implementation.
+// GetPlatformFlavor determines the vendor of the target host from its OS name and instantiates the PlatformFlavor
+// implementation registered for the vendor.
 func (pff PlatformFlavorProvider) GetPlatformFlavor() (*types.PlatformFlavor, error) {
 	log.Trace("flavor/platform_flavor_factory:GetPlatformFlavor() Entering")
 	defer log.Trace("flavor/platform_flavor_factory:GetPlatformFlavor() Leaving")
@@ -72,13 +71,8 @@ func (pff PlatformFlavorProvider) GetPlatformFlavor() (*types.PlatformFlavor, er
 	var rp types.PlatformFlavor
 
 	if pff.hostManifest != nil {
-		switch strings.ToUpper(strings.TrimSpace(pff.hostManifest.HostInfo.OSName)) {
-		case constants.OsVMware:
-			rp = types.NewESXPlatformFlavor(pff.hostManifest, pff.attributeCertificate)
-		// Fallback to Linux
-		default:
-			rp = types.NewLinuxPlatformFlavor(pff.hostManifest, pff.attributeCertificate)
-		}
+		newPlatformFlavor := getPlatformFlavorConstructor(strings.TrimSpace(pff.hostManifest.HostInfo.OSName))
+		rp = newPlatformFlavor(pff.hostManifest, pff.attributeCertificate)
 	} else {
 		err = errors.New("Error while retrieving PlaformFlavor - missing HostManifest")
 		return nil, errors.Wrapf(err, common.INVALID_INPUT().Message)
@@ -16,6 +16,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/util"
 	hcConstants "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
 	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"math/big"
@@ -35,6 +36,18 @@ const (
 
 var pfutil util.PlatformFlavorUtil
 
+// getHostFlavorTypes returns the flavor types that can be created from a host manifest, the IMA flavors are
+// created from allowlists
+func getHostFlavorTypes() []cf.FlavorPart {
+	var flavorParts []cf.FlavorPart
+	for _, flavorPart := range cf.GetFlavorTypes() {
+		if flavorPart != cf.FlavorPartIma {
+			flavorParts = append(flavorParts, flavorPart)
+		}
+	}
+	return flavorParts
+}
+
 // checkIfRequiredFlavorsArePresent is a helper function that ensures expected flavorparts are present in Flavor
 func checkIfRequiredFlavorsArePresent(t *testing.T, expFlavorParts []cf.FlavorPart, actualFlavorParts []cf.FlavorPart) {
 	// check if expected flavorparts are present
@@ -73,7 +86,7 @@ func TestLinuxPlatformFlavorGetFlavorParts(t *testing.T) {
 	var err error
 
 	// expected FlavorParts
-	expFlavorParts := cf.GetFlavorTypes()
+	expFlavorParts := getHostFlavorTypes()
 
 	// load hostManifest and tagCertificate
 	hm, tagCert := loadManifestAndTagCert(RHELManifestPath, TagCertPath)
@@ -96,7 +109,7 @@ func TestLinuxPlatformFlavorGetSignedPlatformFlavorWithoutAssetTag(t *testing.T)
 	var err error
 
 	// expected FlavorParts
-	expFlavorParts := cf.GetFlavorTypes()
+	expFlavorParts := getHostFlavorTypes()
 
 	// load hostManifest and tagCertificate
 	hm, tagCert := loadManifestAndTagCert(RHELManifestPath, "")
@@ -130,7 +143,7 @@ func TestLinuxPlatformFlavorGetSignedPlatformFlavor(t *testing.T) {
 	var err error
 
 	// expected FlavorParts
-	expFlavorParts := cf.GetFlavorTypes()
+	expFlavorParts := getHostFlavorTypes()
 
 	// load hostManifest and tagCertificate
 	hm, tagCert := loadManifestAndTagCert(RHELManifestPath, TagCertPath)
@@ -505,6 +518,31 @@ func TestSoftwareFlavor_Failure(t *testing.T) {
 	t.Log(sfg)
 }
 
+// TestRegisteredVendorPlatformFlavor validates that the platform flavor is resolved from the vendor of the host
+func TestRegisteredVendorPlatformFlavor(t *testing.T) {
+	vendor, err := hcConstants.RegisterVendor(hcConstants.VendorInfo{Name: "redfish", OSNames: []string{"RedfishOS"}})
+	assert.NoError(t, err)
+	assert.NoError(t, RegisterPlatformFlavor(vendor, func(hostManifest *hcTypes.HostManifest, tagCertificate *model.X509AttributeCertificate) types.PlatformFlavor {
+		return types.GenericPlatformFlavor{Vendor: vendor, TagCertificate: tagCertificate}
+	}))
+	assert.Error(t, RegisterPlatformFlavor(vendor, types.NewLinuxPlatformFlavor))
+	assert.Error(t, RegisterPlatformFlavor(hcConstants.VendorUnknown, types.NewLinuxPlatformFlavor))
+
+	hostManifests := map[string]interface{}{
+		"RedfishOS":        types.GenericPlatformFlavor{},
+		"VMware ESXi":      types.ESXPlatformFlavor{},
+		"RedHatEnterprise": types.LinuxPlatformFlavor{},
+		"UnknownOS":        types.LinuxPlatformFlavor{},
+	}
+	for osName, expectedFlavor := range hostManifests {
+		pffactory, err := NewPlatformFlavorProvider(&hcTypes.HostManifest{HostInfo: taModel.HostInfo{OSName: osName}}, nil)
+		assert.NoError(t, err)
+		pflavor, err := pffactory.GetPlatformFlavor()
+		assert.NoError(t, err)
+		assert.IsType(t, expectedFlavor, *pflavor, osName)
+	}
+}
+
 // loadManifestAndTagCert is a helper function that loads a HostManifest and TagCertificate from files
 func loadManifestAndTagCert(hmFilePath string, tcFilePath string) (*hcTypes.HostManifest, *x509.Certificate) {
 	var hm hcTypes.HostManifest
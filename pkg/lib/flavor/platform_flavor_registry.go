@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package flavor
+
+//
+// Registry of the platform flavors of the vendors of hosts, the platform flavor of a vendor added with
+// host_connector.RegisterVendor is added with RegisterPlatformFlavor.
+//
+
+import (
+	"sync"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/types"
+	hcConstants "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// PlatformFlavorConstructor creates the PlatformFlavor of a host manifest and the tag certificate of the host
+type PlatformFlavorConstructor func(hostManifest *hcTypes.HostManifest, tagCertificate *model.X509AttributeCertificate) types.PlatformFlavor
+
+var platformFlavors = struct {
+	sync.RWMutex
+	constructors map[hcConstants.Vendor]PlatformFlavorConstructor
+}{
+	constructors: map[hcConstants.Vendor]PlatformFlavorConstructor{
+		hcConstants.VendorVMware: types.NewESXPlatformFlavor,
+	},
+}
+
+// RegisterPlatformFlavor registers the platform flavor of the hosts of a vendor. The hosts of the vendors without a
+// platform flavor use the Linux platform flavor. An error is returned if a platform flavor is already registered for
+// the vendor
+func RegisterPlatformFlavor(vendor hcConstants.Vendor, constructor PlatformFlavorConstructor) error {
+	if vendor == hcConstants.VendorUnknown {
+		return errors.New("The platform flavor of an unknown vendor cannot be registered")
+	}
+	if constructor == nil {
+		return errors.Errorf("The constructor of the platform flavor of vendor '%s' cannot be nil", vendor.String())
+	}
+
+	platformFlavors.Lock()
+	defer platformFlavors.Unlock()
+	if _, ok := platformFlavors.constructors[vendor]; ok {
+		return errors.Errorf("A platform flavor is already registered for vendor '%s'", vendor.String())
+	}
+	platformFlavors.constructors[vendor] = constructor
+	return nil
+}
+
+// getPlatformFlavorConstructor returns the constructor of the platform flavor of the vendor that reports the OS name
+func getPlatformFlavorConstructor(osName string) PlatformFlavorConstructor {
+	var vendor hcConstants.Vendor
+	if err := (&vendor).GetVendorFromOSName(osName); err != nil {
+		log.Debugf("flavor/platform_flavor_registry:getPlatformFlavorConstructor() %s, falling back to the Linux platform flavor", err.Error())
+		return types.NewLinuxPlatformFlavor
+	}
+
+	platformFlavors.RLock()
+	defer platformFlavors.RUnlock()
+	if constructor, ok := platformFlavors.constructors[vendor]; ok {
+		return constructor
+	}
+	return types.NewLinuxPlatformFlavor
+}
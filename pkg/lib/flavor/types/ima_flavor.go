@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package types
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	cm "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	hcConstants "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// ImaFlavor represents a flavor consisting of the allowlist of the files measured by the kernel IMA of the target host
+type ImaFlavor struct {
+	Label string `json:"label"`
+	// PcrBank is the PCR bank the IMA log is replayed in, SHA256 is used when it is not set
+	PcrBank string `json:"pcr_bank,omitempty"`
+	// Allowlist holds the allowed file digests, either as the output of sha256sum or as a Keylime allowlist in JSON
+	Allowlist string `json:"allowlist"`
+	// RequiredFiles are the paths of the files that must be measured by the kernel IMA
+	RequiredFiles []string `json:"required_files,omitempty"`
+	// Excludes are regular expressions of the paths that are not verified
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// keylimeAllowlist is the JSON allowlist of Keylime, older versions name the digests 'hashes'
+type keylimeAllowlist struct {
+	Digests map[string][]string `json:"digests"`
+	Hashes  map[string][]string `json:"hashes"`
+}
+
+// NewImaFlavor returns an instance of ImaFlavor
+func NewImaFlavor(label, pcrBank, allowlist string, requiredFiles, excludes []string) ImaFlavor {
+	return ImaFlavor{
+		Label:         label,
+		PcrBank:       pcrBank,
+		Allowlist:     allowlist,
+		RequiredFiles: requiredFiles,
+		Excludes:      excludes,
+	}
+}
+
+// GetImaFlavor creates an ImaFlavor that would include all the file digests of the allowlist
+func (imaf *ImaFlavor) GetImaFlavor() (*cm.Flavor, error) {
+	log.Trace("flavor/types/ima_flavor:GetImaFlavor() Entering")
+	defer log.Trace("flavor/types/ima_flavor:GetImaFlavor() Leaving")
+
+	var errorMessage = "Error during creation of IMA flavor"
+	if strings.TrimSpace(imaf.Label) == "" {
+		return nil, errors.New(errorMessage + " The label must be provided")
+	}
+
+	pcrBank := hcTypes.SHA256
+	if imaf.PcrBank != "" {
+		var err error
+		pcrBank, err = hcTypes.GetSHAAlgorithm(strings.ToUpper(imaf.PcrBank))
+		if err != nil {
+			return nil, errors.Errorf("%s Invalid PCR bank '%s'", errorMessage, imaf.PcrBank)
+		}
+	}
+
+	files, err := parseImaAllowlist(imaf.Allowlist)
+	if err != nil {
+		return nil, errors.Wrap(err, errorMessage+" Failure in parsing the allowlist")
+	}
+	for _, requiredFile := range imaf.RequiredFiles {
+		file, ok := files[requiredFile]
+		if !ok {
+			return nil, errors.Errorf("%s The required file '%s' is not in the allowlist", errorMessage, requiredFile)
+		}
+		file.Required = true
+		files[requiredFile] = file
+	}
+
+	ima := cm.Ima{
+		PcrBank:  string(pcrBank),
+		Files:    files,
+		Excludes: imaf.Excludes,
+	}
+	if err := ima.Validate(); err != nil {
+		return nil, errors.Wrap(err, errorMessage)
+	}
+
+	newMeta := cm.Meta{
+		ID:     uuid.New(),
+		Vendor: hcConstants.VendorIntel,
+		Description: cm.Description{
+			FlavorPart:      cf.FlavorPartIma.String(),
+			Label:           imaf.Label,
+			DigestAlgorithm: string(pcrBank),
+		},
+	}
+	log.Debugf("flavor/types/ima_flavor:GetImaFlavor() New Meta Section: %v", newMeta)
+
+	flavor := cm.NewFlavor(&newMeta, nil, nil, nil, nil, nil)
+	flavor.Ima = &ima
+	return flavor, nil
+}
+
+// parseImaAllowlist returns the allowed digests by path of an allowlist in the sha256sum output format or in the
+// Keylime JSON format
+func parseImaAllowlist(allowlist string) (map[string]cm.ImaFile, error) {
+	files := map[string]cm.ImaFile{}
+	addDigest := func(path, digest string) error {
+		digest = strings.ToLower(digest)
+		if _, err := hex.DecodeString(digest); err != nil || digest == "" {
+			return errors.Errorf("Invalid digest '%s' of file '%s'", digest, path)
+		}
+		file := files[path]
+		for _, existingDigest := range file.Digests {
+			if existingDigest == digest {
+				return nil
+			}
+		}
+		file.Digests = append(file.Digests, digest)
+		files[path] = file
+		return nil
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(allowlist), "{") {
+		var jsonAllowlist keylimeAllowlist
+		if err := json.Unmarshal([]byte(allowlist), &jsonAllowlist); err != nil {
+			return nil, errors.Wrap(err, "Invalid JSON allowlist")
+		}
+		digests := jsonAllowlist.Digests
+		if digests == nil {
+			digests = jsonAllowlist.Hashes
+		}
+		for path, pathDigests := range digests {
+			for _, digest := range pathDigests {
+				if err := addDigest(path, digest); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else {
+		scanner := bufio.NewScanner(strings.NewReader(allowlist))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// sha256sum separates the digest from the path with a space and a mode character
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) != 2 {
+				return nil, errors.Errorf("Invalid allowlist line '%s'", line)
+			}
+			path := strings.TrimPrefix(strings.TrimPrefix(fields[1], " "), "*")
+			if err := addDigest(path, fields[0]); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrap(err, "Error reading the allowlist")
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("The allowlist is empty")
+	}
+	return files, nil
+}
@@ -7,7 +7,6 @@ package util
 import (
 	"crypto/rsa"
 	"encoding/xml"
-	"fmt"
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
@@ -285,9 +284,13 @@ func (pfutil PlatformFlavorUtil) GetPcrDetails(pcrManifest hcTypes.PcrManifest,
 			digestAlgorithm = crypt.SHA1()
 		case hcTypes.SHA256:
 			digestAlgorithm = crypt.SHA256()
+		case hcTypes.SHA384:
+			digestAlgorithm = crypt.SHA384()
+		case hcTypes.SHA512:
+			digestAlgorithm = crypt.SHA512()
 		}
 
-		// pull out the logs for the required PCRs from both banks
+		// pull out the logs for the required PCRs from all the banks
 		for _, pcrIndex := range pcrList {
 			pI := hcTypes.PcrIndex(pcrIndex)
 			var pcrInfo *hcTypes.Pcr
@@ -310,12 +313,7 @@ func (pfutil PlatformFlavorUtil) GetPcrDetails(pcrManifest hcTypes.PcrManifest,
 						for _, manifestEventLog := range *manifestPcrEventLogs {
 							var currPcrEvent hcTypes.EventLog
 							currPcrEvent = manifestEventLog
-							switch digestBank {
-							case hcTypes.SHA1:
-								currPcrEvent.DigestType = fmt.Sprintf(constants.MeasurementTypeClassNamePrefix+"%d", 1)
-							case hcTypes.SHA256:
-								currPcrEvent.DigestType = fmt.Sprintf(constants.MeasurementTypeClassNamePrefix+"%d", 256)
-							}
+							currPcrEvent.DigestType = constants.MeasurementTypeClassNamePrefix + digestBank.GetDigestTypeSuffix()
 							currPcrEx.Event = append(currPcrEx.Event, currPcrEvent)
 						}
 					}
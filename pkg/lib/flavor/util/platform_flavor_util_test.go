@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/constants"
+	hcTypes "github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformFlavorUtil_GetPcrDetailsSha384(t *testing.T) {
+	var pcrManifest hcTypes.PcrManifest
+	for _, pcrBank := range []hcTypes.SHAAlgorithm{hcTypes.SHA384, hcTypes.SHA512} {
+		for _, pcrIndex := range []hcTypes.PcrIndex{hcTypes.PCR0, hcTypes.PCR7} {
+			assert.NoError(t, pcrManifest.AddPcr(hcTypes.Pcr{
+				DigestType: constants.PcrClassNamePrefix + pcrBank.GetDigestTypeSuffix(),
+				Index:      pcrIndex,
+				Value:      strings.Repeat("ab", 48),
+				PcrBank:    pcrBank,
+			}))
+		}
+	}
+	assert.NoError(t, pcrManifest.PcrEventLogMap.AddEventLog(hcTypes.SHA384, hcTypes.PCR0, hcTypes.EventLog{
+		Value: strings.Repeat("cd", 48),
+		Label: "EV_S_CRTM_VERSION",
+	}))
+
+	pcrDetails := PlatformFlavorUtil{}.GetPcrDetails(pcrManifest, []int{0, 7, 17}, true)
+	assert.Len(t, pcrDetails, 2)
+	assert.Len(t, pcrDetails[crypt.SHA384()], 2)
+	assert.Len(t, pcrDetails[crypt.SHA512()], 2)
+
+	pcr0 := pcrDetails[crypt.SHA384()][hcTypes.PCR0]
+	assert.Equal(t, strings.Repeat("ab", 48), pcr0.Value)
+	assert.Len(t, pcr0.Event, 1)
+	assert.Equal(t, constants.MeasurementTypeClassNamePrefix+"384", pcr0.Event[0].DigestType)
+	assert.Empty(t, pcrDetails[crypt.SHA384()][hcTypes.PCR7].Event)
+}
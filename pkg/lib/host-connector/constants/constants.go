@@ -7,7 +7,9 @@ package constants
 import (
 	"encoding/json"
 	"github.com/pkg/errors"
+	"regexp"
 	"strings"
+	"sync"
 )
 
 type Vendor int
@@ -17,29 +19,150 @@ const (
 	VendorIntel
 	VendorVMware
 	VendorMicrosoft
+	VendorSimulated
+	VendorKeylime
 )
 
+// VendorInfo describes a vendor of hosts. The lower case name of the vendor is the prefix of the connection strings of
+// its hosts
+type VendorInfo struct {
+	Name string
+	// OSNames are the OS names reported by the hosts of the vendor, the vendor of a flavor that does not have one is
+	// determined from them
+	OSNames []string
+	// RuleBuilders are the names of the verifier rule builders of the flavors of the vendor by TPM version. The rule
+	// builder of the empty TPM version is used for the TPM versions that are not listed
+	RuleBuilders map[string]string
+}
+
+var vendorNameReg = regexp.MustCompile("^[A-Za-z][A-Za-z0-9-]*$")
+
+// vendors are indexed by Vendor, the vendors added with RegisterVendor follow the built-in vendors
+var vendors = struct {
+	sync.RWMutex
+	info []VendorInfo
+}{
+	info: []VendorInfo{
+		{Name: "UNKNOWN"},
+		{
+			Name:         "INTEL",
+			OSNames:      []string{"INTEL", "REDHATENTERPRISE", "REDHATENTERPRISESERVER"},
+			RuleBuilders: map[string]string{"": "intel-tpm20"},
+		},
+		{
+			Name:         "VMWARE",
+			OSNames:      []string{"VMWARE", "VMWARE ESXI"},
+			RuleBuilders: map[string]string{"1.2": "vmware-tpm12", "2.0": "vmware-tpm20"},
+		},
+		{
+			Name: "MICROSOFT",
+			OSNames: []string{"WINDOWS", "MICROSOFT WINDOWS SERVER 2016 DATACENTER", "MICROSOFT WINDOWS SERVER 2016 STANDARD",
+				"MICROSOFT"},
+		},
+		{Name: "SIMULATED"},
+		{
+			Name:         "KEYLIME",
+			RuleBuilders: map[string]string{"": "intel-tpm20"},
+		},
+	},
+}
+
+// RegisterVendor adds a vendor of hosts and returns its Vendor value. An error is returned if the name or one of the
+// OS names of the vendor is already registered
+func RegisterVendor(info VendorInfo) (Vendor, error) {
+	if !vendorNameReg.MatchString(info.Name) {
+		return VendorUnknown, errors.Errorf("Invalid vendor name '%s'", info.Name)
+	}
+	if strings.EqualFold(info.Name, "https") {
+		return VendorUnknown, errors.New("The vendor name cannot be https")
+	}
+
+	registered := VendorInfo{
+		Name:         strings.ToUpper(info.Name),
+		RuleBuilders: map[string]string{},
+	}
+	for _, osName := range info.OSNames {
+		registered.OSNames = append(registered.OSNames, strings.ToUpper(osName))
+	}
+	for tpmVersion, ruleBuilder := range info.RuleBuilders {
+		registered.RuleBuilders[tpmVersion] = ruleBuilder
+	}
+
+	vendors.Lock()
+	defer vendors.Unlock()
+	for _, vendorInfo := range vendors.info {
+		if vendorInfo.Name == registered.Name {
+			return VendorUnknown, errors.Errorf("A vendor is already registered with name '%s'", registered.Name)
+		}
+		for _, osName := range vendorInfo.OSNames {
+			for _, registeredOSName := range registered.OSNames {
+				if osName == registeredOSName {
+					return VendorUnknown, errors.Errorf("OS name '%s' is already registered for vendor '%s'",
+						osName, vendorInfo.Name)
+				}
+			}
+		}
+	}
+	vendors.info = append(vendors.info, registered)
+	return Vendor(len(vendors.info) - 1), nil
+}
+
+// GetVendorByName returns the vendor with the given case insensitive name, or VendorUnknown if no vendor is
+// registered with the name
+func GetVendorByName(name string) Vendor {
+	vendors.RLock()
+	defer vendors.RUnlock()
+	for index, vendorInfo := range vendors.info {
+		if strings.EqualFold(vendorInfo.Name, name) {
+			return Vendor(index)
+		}
+	}
+	return VendorUnknown
+}
+
+func (vendor Vendor) info() VendorInfo {
+	vendors.RLock()
+	defer vendors.RUnlock()
+	if vendor < 0 || int(vendor) >= len(vendors.info) {
+		return vendors.info[VendorUnknown]
+	}
+	return vendors.info[vendor]
+}
+
 func (vendor Vendor) String() string {
-	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT"}[vendor]
+	return vendor.info().Name
+}
+
+// GetRuleBuilder returns the name of the verifier rule builder of the flavors of the vendor for a TPM version
+func (vendor Vendor) GetRuleBuilder(tpmVersion string) (string, error) {
+	vendorInfo := vendor.info()
+	if len(vendorInfo.RuleBuilders) == 0 {
+		return "", errors.Errorf("Vendor '%s' is not currently supported", vendorInfo.Name)
+	}
+	if ruleBuilder, ok := vendorInfo.RuleBuilders[tpmVersion]; ok {
+		return ruleBuilder, nil
+	}
+	if ruleBuilder, ok := vendorInfo.RuleBuilders[""]; ok {
+		return ruleBuilder, nil
+	}
+	return "", errors.Errorf("Unknown TPM version '%s'", tpmVersion)
 }
 
 func (vendor *Vendor) GetVendorFromOSName(osName string) error {
 
-	var err error
-
-	switch strings.ToUpper(osName) {
-	case "WINDOWS", "MICROSOFT WINDOWS SERVER 2016 DATACENTER", "MICROSOFT WINDOWS SERVER 2016 STANDARD", "MICROSOFT":
-		*vendor = VendorMicrosoft
-	case "VMWARE", "VMWARE ESXI":
-		*vendor = VendorVMware
-	case "INTEL", "REDHATENTERPRISE", "REDHATENTERPRISESERVER":
-		*vendor = VendorIntel
-	default:
-		*vendor = VendorUnknown
-		err = errors.Errorf("Could not determine vendor name from OS name '%s'", osName)
+	vendors.RLock()
+	defer vendors.RUnlock()
+	for index, vendorInfo := range vendors.info {
+		for _, vendorOSName := range vendorInfo.OSNames {
+			if strings.ToUpper(osName) == vendorOSName {
+				*vendor = Vendor(index)
+				return nil
+			}
+		}
 	}
 
-	return err
+	*vendor = VendorUnknown
+	return errors.Errorf("Could not determine vendor name from OS name '%s'", osName)
 }
 
 func (vendor *Vendor) UnmarshalJSON(b []byte) error {
@@ -47,19 +170,11 @@ func (vendor *Vendor) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &jsonValue); err != nil {
 		return errors.Wrap(err, "Could not unmarshal Vendor from JSON")
 	}
-	var err error
-	switch strings.ToUpper(jsonValue) {
-	case "MICROSOFT":
-		*vendor = VendorMicrosoft
-	case "VMWARE":
-		*vendor = VendorVMware
-	case "INTEL":
-		*vendor = VendorIntel
-	default:
-		*vendor = VendorUnknown
-		err = errors.Errorf("Provided vendor is not supported. Vendor : '%s'", jsonValue)
+	*vendor = GetVendorByName(jsonValue)
+	if *vendor == VendorUnknown {
+		return errors.Errorf("Provided vendor is not supported. Vendor : '%s'", jsonValue)
 	}
-	return err
+	return nil
 }
 
 func (vendor Vendor) MarshalJSON() ([]byte, error) {
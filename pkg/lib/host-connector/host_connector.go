@@ -7,7 +7,6 @@ package host_connector
 import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
-	"github.com/vmware/govmomi/vim25/mo"
 )
 
 type HostConnector interface {
@@ -16,5 +15,5 @@ type HostConnector interface {
 	DeployAssetTag(string, string) error
 	DeploySoftwareManifest(taModel.Manifest) error
 	GetMeasurementFromManifest(taModel.Manifest) (taModel.Measurement, error)
-	GetClusterReference(string) ([]mo.HostSystem, error)
+	GetClusterReference(string) ([]types.ClusterHost, error)
 }
@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
 	"github.com/pkg/errors"
 )
@@ -23,31 +24,48 @@ type HostConnectorProvider interface {
 type HostConnectorFactory struct {
 	aasApiUrl      string
 	trustedCaCerts []x509.Certificate
+	// connectorFactories override the registered connector factories of the vendors
+	connectorFactories map[constants.Vendor]VendorHostConnectorFactory
 }
 
 func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate) *HostConnectorFactory {
-	return &HostConnectorFactory{aasApiUrl, trustedCaCerts}
+	return &HostConnectorFactory{aasApiUrl: aasApiUrl, trustedCaCerts: trustedCaCerts}
+}
+
+// SetSimulator enables the connection strings of the simulated vendor, the simulated hosts are served by the given
+// simulator
+func (htcFactory *HostConnectorFactory) SetSimulator(sim *simulator.Simulator) {
+	if htcFactory.connectorFactories == nil {
+		htcFactory.connectorFactories = map[constants.Vendor]VendorHostConnectorFactory{}
+	}
+	htcFactory.connectorFactories[constants.VendorSimulated] = &SimulatedConnectorFactory{simulator: sim}
+}
+
+// SetKeylimeConnectorFactory enables the connection strings of the keylime vendor, the hosts running the Keylime agent
+// are attested by the given connector factory
+func (htcFactory *HostConnectorFactory) SetKeylimeConnectorFactory(keylimeFactory *KeylimeConnectorFactory) {
+	if htcFactory.connectorFactories == nil {
+		htcFactory.connectorFactories = map[constants.Vendor]VendorHostConnectorFactory{}
+	}
+	htcFactory.connectorFactories[constants.VendorKeylime] = keylimeFactory
 }
 
 func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string) (HostConnector, error) {
 
 	log.Trace("host_connector/host_connector_factory:NewHostConnector() Entering")
 	defer log.Trace("host_connector/host_connector_factory:NewHostConnector() Leaving")
-	var connectorFactory VendorHostConnectorFactory
 	vendorConnector, err := util.GetConnectorDetails(connectionString)
 	if err != nil {
 		return nil, errors.Wrap(err, "host_connector/host_connector_factory:NewHostConnector() Error getting connector details")
 	}
 
-	switch vendorConnector.Vendor {
-	case constants.VendorIntel, constants.VendorMicrosoft:
-		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is INTEL")
-		connectorFactory = &IntelConnectorFactory{}
-	case constants.VendorVMware:
-		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is VMWARE")
-		connectorFactory = &VmwareConnectorFactory{}
-	default:
+	connectorFactory, ok := htcFactory.connectorFactories[vendorConnector.Vendor]
+	if !ok {
+		connectorFactory, ok = getConnectorFactory(vendorConnector.Vendor)
+	}
+	if !ok {
 		return nil, errors.New("host_connector_factory:NewHostConnector() Vendor not supported yet: " + vendorConnector.Vendor.String())
 	}
+	log.Debugf("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is %s", vendorConnector.Vendor.String())
 	return connectorFactory.GetHostConnector(vendorConnector, htcFactory.aasApiUrl, htcFactory.trustedCaCerts)
 }
@@ -14,7 +14,6 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/pkg/errors"
-	"github.com/vmware/govmomi/vim25/mo"
 	"strings"
 )
 
@@ -142,6 +141,12 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string) (types.HostMa
 	}
 	log.Info("intel_host_connector:GetHostManifestAcceptNonce() Successfully retrieved PCR manifest from quote")
 
+	clockInfo, err := util.GetQuoteClockInfo(tpmQuoteInBytes)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "intel_host_connector:GetHostManifestAcceptNonce() Error "+
+			"getting the clock info of the TPM Quote")
+	}
+
 	bindingKeyBytes, err := ic.client.GetBindingKeyCertificate()
 	if err != nil {
 		log.WithError(err).Debugf("intel_host_connector:GetHostManifestAcceptNonce() Error getting " +
@@ -165,6 +170,10 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string) (types.HostMa
 	hostManifest.AssetTagDigest = tpmQuoteResponse.AssetTag
 	hostManifest.BindingKeyCertificate = bindingKeyCertificateBase64
 	hostManifest.MeasurementXmls = tpmQuoteResponse.TcbMeasurements.TcbMeasurements
+	hostManifest.QuoteInfo = &types.QuoteInfo{
+		Nonce:     nonce,
+		ClockInfo: *clockInfo,
+	}
 
 	hostManifestJson, err := json.Marshal(hostManifest)
 	if err != nil {
@@ -200,6 +209,6 @@ func (ic *IntelConnector) GetMeasurementFromManifest(manifest taModel.Manifest)
 	return measurement, err
 }
 
-func (ic *IntelConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+func (ic *IntelConnector) GetClusterReference(clusterName string) ([]types.ClusterHost, error) {
 	return nil, errors.New("intel_host_connector :GetClusterReference() Operation not supported")
 }
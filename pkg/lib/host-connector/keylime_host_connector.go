@@ -0,0 +1,383 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/keylime"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const (
+	// keylimePcrMask selects the PCRs 0-23 in the quotes of the Keylime agents
+	keylimePcrMask = 0xffffff
+	// keylimeNonceSize is the number of random bytes of the nonces, the nonces are sent hex encoded since the
+	// Keylime agents only accept alphanumeric nonces
+	keylimeNonceSize  = 20
+	keylimeTpmVersion = "2.0"
+
+	tpmAlgRsa  = 0x0001
+	tpmAlgNull = 0x0010
+	// tpmDefaultRsaExponent is used when the exponent of a TPMS_RSA_PARMS is zero
+	tpmDefaultRsaExponent = 65537
+
+	// tpmlPcrSelectionSize, tpmlDigestCount and tpm2bDigestBufferSize are the sizes of the TPM structures of the PCR
+	// values of the Keylime quotes, which are written the way tpm2-tools keeps them in memory
+	tpmlPcrSelectionSize  = 4 + 16*8
+	tpmlDigestCount       = 8
+	tpm2bDigestBufferSize = 64
+)
+
+// KeylimeConnector attests the hosts running the Keylime agent. The quotes of the agents are verified with the
+// attestation key they registered with the Keylime registrar and translated to host manifests, so that the hosts are
+// verified by the flavors and rules of the Linux hosts running the Trust Agent
+type KeylimeConnector struct {
+	client   keylime.KeylimeClient
+	hostName string
+	factory  *KeylimeConnectorFactory
+}
+
+func (kc *KeylimeConnector) GetHostDetails() (taModel.HostInfo, error) {
+
+	log.Trace("keylime_host_connector:GetHostDetails() Entering")
+	defer log.Trace("keylime_host_connector:GetHostDetails() Leaving")
+	agentInfo, err := kc.client.GetAgentInfo()
+	if err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "keylime_host_connector:GetHostDetails() Error getting agent "+
+			"info from Keylime agent")
+	}
+	return kc.getHostInfo(agentInfo), nil
+}
+
+// getHostInfo returns the host info of an agent. The agents only report their TPM algorithms, the hardware UUID of
+// the host is the UUID of the agent
+func (kc *KeylimeConnector) getHostInfo(agentInfo keylime.AgentInfo) taModel.HostInfo {
+	var hostInfo taModel.HostInfo
+	hostInfo.HostName = kc.hostName
+	if hostInfo.HostName == "" {
+		hostInfo.HostName = kc.client.GetBaseURL().Hostname()
+	}
+
+	// the agent UUID can be any string, the hardware UUID is derived from it when it is not a UUID
+	if agentUuid, err := uuid.Parse(agentInfo.AgentUuid); err == nil {
+		hostInfo.HardwareUUID = agentUuid.String()
+	} else {
+		hostInfo.HardwareUUID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(agentInfo.AgentUuid)).String()
+	}
+
+	hostInfo.HardwareFeatures.TPM.Enabled = true
+	hostInfo.HardwareFeatures.TPM.Meta.TPMVersion = keylimeTpmVersion
+	hostInfo.HardwareFeatures.TPM.Meta.PCRBanks = strings.ToUpper(agentInfo.TpmHashAlg)
+	return hostInfo
+}
+
+func (kc *KeylimeConnector) GetHostManifest() (types.HostManifest, error) {
+	log.Trace("keylime_host_connector:GetHostManifest() Entering")
+	defer log.Trace("keylime_host_connector:GetHostManifest() Leaving")
+
+	nonceBytes := make([]byte, keylimeNonceSize)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifest() Error generating "+
+			"nonce for TPM quote request")
+	}
+	hostManifest, err := kc.GetHostManifestAcceptNonce(hex.EncodeToString(nonceBytes))
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifest() Error creating "+
+			"host manifest")
+	}
+	return hostManifest, nil
+}
+
+// GetHostManifestAcceptNonce creates the host manifest from the integrity quote of the given nonce, the nonce is the
+// qualifying data of the quote
+func (kc *KeylimeConnector) GetHostManifestAcceptNonce(nonce string) (types.HostManifest, error) {
+
+	log.Trace("keylime_host_connector:GetHostManifestAcceptNonce() Entering")
+	defer log.Trace("keylime_host_connector:GetHostManifestAcceptNonce() Leaving")
+	var hostManifest types.HostManifest
+
+	agentInfo, err := kc.client.GetAgentInfo()
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"getting agent info from Keylime agent")
+	}
+	hostManifest.HostInfo = kc.getHostInfo(agentInfo)
+
+	registrarAgent, err := kc.client.GetRegistrarAgent(agentInfo.AgentUuid)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"getting the attestation key of the agent from Keylime registrar")
+	}
+	aikTpm, err := base64.StdEncoding.DecodeString(registrarAgent.AikTpm)
+	if err != nil || len(aikTpm) == 0 {
+		return types.HostManifest{}, errors.New("keylime_host_connector:GetHostManifestAcceptNonce() Invalid " +
+			"attestation key returned by Keylime registrar")
+	}
+	aikPublicKey, err := parseTpm2bPublic(aikTpm)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"parsing the attestation key of the agent")
+	}
+	aikCertificate, err := kc.factory.getAikCertificate(agentInfo.AgentUuid, registrarAgent, aikTpm, aikPublicKey)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"certifying the attestation key of the agent")
+	}
+	secLog.Debug("keylime_host_connector:GetHostManifestAcceptNonce() Successfully certified the attestation key of the agent")
+
+	integrityQuote, err := kc.client.GetIntegrityQuote(nonce, keylimePcrMask)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"getting integrity quote from Keylime agent")
+	}
+
+	tpmQuoteInBytes, err := getKeylimeQuoteBytes(integrityQuote.Quote)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"converting the quote of the agent")
+	}
+
+	// the measure log of the boot events is empty when the agent does not report the event log of the measured boot
+	var measureLog types.MeasureLog
+	var tcgEventLog *types.TcgEventLog
+	if integrityQuote.MbMeasurementList != "" {
+		eventLogBytes, err := base64.StdEncoding.DecodeString(integrityQuote.MbMeasurementList)
+		if err != nil {
+			return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() "+
+				"Error converting event log to bytes")
+		}
+		tcgEventLog, err = util.ParseTcgEventLog(eventLogBytes)
+		if err != nil {
+			return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() "+
+				"Error parsing the measured boot event log")
+		}
+		measureLog = util.GetTcgMeasureLog(tcgEventLog)
+	}
+	measureLogXml, err := xml.Marshal(measureLog)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"marshalling the measure log")
+	}
+	log.Info("keylime_host_connector:GetHostManifestAcceptNonce() Retrieved event log from integrity quote")
+
+	log.Info("keylime_host_connector:GetHostManifestAcceptNonce() Verifying quote and retrieving PCR manifest from " +
+		"integrity quote ...")
+	pcrManifest, err := util.VerifyQuoteAndGetPCRManifest(string(measureLogXml), []byte(nonce), tpmQuoteInBytes,
+		aikCertificate)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"verifying TPM Quote")
+	}
+	log.Info("keylime_host_connector:GetHostManifestAcceptNonce() Successfully retrieved PCR manifest from quote")
+
+	clockInfo, err := util.GetQuoteClockInfo(tpmQuoteInBytes)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"getting the clock info of the TPM Quote")
+	}
+
+	// the IMA log is only reported when the IMA is enabled in the kernel of the host
+	if integrityQuote.ImaMeasurementList != "" {
+		imaLog, err := util.ParseImaAsciiLog(integrityQuote.ImaMeasurementList)
+		if err != nil {
+			return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() "+
+				"Error parsing the IMA log")
+		}
+		hostManifest.ImaLog = imaLog
+	}
+
+	hostManifest.PcrManifest = pcrManifest
+	hostManifest.TcgEventLog = tcgEventLog
+	hostManifest.AIKCertificate = base64.StdEncoding.EncodeToString(aikCertificate.Raw)
+	hostManifest.QuoteInfo = &types.QuoteInfo{
+		Nonce:     nonce,
+		ClockInfo: *clockInfo,
+	}
+
+	hostManifestJson, err := json.Marshal(hostManifest)
+	if err != nil {
+		return types.HostManifest{}, errors.Wrap(err, "keylime_host_connector:GetHostManifestAcceptNonce() Error "+
+			"marshalling host manifest to JSON")
+	}
+	log.Debugf("keylime_host_connector:GetHostManifestAcceptNonce() Host Manifest : %s", string(hostManifestJson))
+	log.Info("keylime_host_connector:GetHostManifestAcceptNonce() Host manifest created successfully")
+	return hostManifest, nil
+}
+
+func (kc *KeylimeConnector) DeployAssetTag(hardwareUUID, tag string) error {
+	return errors.New("keylime_host_connector:DeployAssetTag() Operation not supported")
+}
+
+func (kc *KeylimeConnector) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	return errors.New("keylime_host_connector:DeploySoftwareManifest() Operation not supported")
+}
+
+func (kc *KeylimeConnector) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("keylime_host_connector:GetMeasurementFromManifest() Operation not supported")
+}
+
+func (kc *KeylimeConnector) GetClusterReference(clusterName string) ([]types.ClusterHost, error) {
+	return nil, errors.New("keylime_host_connector:GetClusterReference() Operation not supported")
+}
+
+// getKeylimeQuoteBytes converts a Keylime quote, 'r' followed by the base64 encoded TPM2B_ATTEST, TPMT_SIGNATURE and
+// PCR values separated by ':', to the layout of the quotes of the Trust Agent parsed by
+// util.VerifyQuoteAndGetPCRManifest: the TPM2B_ATTEST, the TPMT_SIGNATURE, then the values of the selected PCRs
+func getKeylimeQuoteBytes(quote string) ([]byte, error) {
+	if !strings.HasPrefix(quote, "r") {
+		return nil, errors.New("The quote is not a TPM 2.0 quote")
+	}
+	parts := strings.Split(quote[1:], ":")
+	if len(parts) != 3 {
+		return nil, errors.New("The quote does not have the TPM2B_ATTEST, TPMT_SIGNATURE and PCR values")
+	}
+
+	var decoded [3][]byte
+	for i, part := range parts {
+		var err error
+		decoded[i], err = base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error decoding the quote")
+		}
+	}
+	pcrValues, err := getKeylimePcrValues(decoded[2])
+	if err != nil {
+		return nil, err
+	}
+
+	tpmQuote := bytes.NewBuffer(decoded[0])
+	tpmQuote.Write(decoded[1])
+	tpmQuote.Write(pcrValues)
+	return tpmQuote.Bytes(), nil
+}
+
+// getKeylimePcrValues returns the concatenated PCR values of a Keylime quote. They are written as a TPML_PCR_SELECTION,
+// the number of TPML_DIGEST that follow, then the TPML_DIGEST structures, all with their in-memory little endian
+// layout. The values are in the order of the PCR selection of the quote
+func getKeylimePcrValues(pcrBlob []byte) ([]byte, error) {
+	if len(pcrBlob) < tpmlPcrSelectionSize+4 {
+		return nil, errors.New("The PCR values of the quote are truncated")
+	}
+	reader := bytes.NewReader(pcrBlob[tpmlPcrSelectionSize:])
+	var digestListCount uint32
+	_ = binary.Read(reader, binary.LittleEndian, &digestListCount)
+
+	var pcrValues []byte
+	for i := uint32(0); i < digestListCount; i++ {
+		var digestList struct {
+			Count   uint32
+			Digests [tpmlDigestCount]struct {
+				Size   uint16
+				Buffer [tpm2bDigestBufferSize]byte
+			}
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &digestList); err != nil {
+			return nil, errors.Wrap(err, "Error reading the PCR values of the quote")
+		}
+		if digestList.Count > tpmlDigestCount {
+			return nil, errors.Errorf("Invalid number of PCR values %d in the quote", digestList.Count)
+		}
+		for j := uint32(0); j < digestList.Count; j++ {
+			digest := digestList.Digests[j]
+			if digest.Size > tpm2bDigestBufferSize {
+				return nil, errors.Errorf("Invalid PCR value size %d in the quote", digest.Size)
+			}
+			pcrValues = append(pcrValues, digest.Buffer[:digest.Size]...)
+		}
+	}
+	return pcrValues, nil
+}
+
+// parseTpm2bPublic returns the RSA public key of a TPM2B_PUBLIC. The size of the TPM2B_PUBLIC is optional, some
+// versions of the Keylime registrar only keep the TPMT_PUBLIC
+func parseTpm2bPublic(tpm2bPublic []byte) (*rsa.PublicKey, error) {
+	tpmtPublic := tpm2bPublic
+	if len(tpm2bPublic) > 2 && int(binary.BigEndian.Uint16(tpm2bPublic)) == len(tpm2bPublic)-2 {
+		tpmtPublic = tpm2bPublic[2:]
+	}
+	reader := bytes.NewReader(tpmtPublic)
+
+	var header struct {
+		Type             uint16
+		NameAlg          uint16
+		ObjectAttributes uint32
+	}
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "Error reading the public area")
+	}
+	if header.Type != tpmAlgRsa {
+		return nil, errors.Errorf("Unsupported attestation key type %d", header.Type)
+	}
+	if _, err := readTpm2b(reader); err != nil {
+		return nil, errors.Wrap(err, "Error reading the authorization policy")
+	}
+
+	// TPMS_RSA_PARMS: the symmetric algorithm and the scheme, with their details unless they are TPM_ALG_NULL, the
+	// key size and the exponent
+	var symmetricAlg uint16
+	if err := binary.Read(reader, binary.BigEndian, &symmetricAlg); err != nil {
+		return nil, errors.Wrap(err, "Error reading the symmetric algorithm")
+	}
+	if symmetricAlg != tpmAlgNull {
+		var symmetricDetails [2]uint16
+		if err := binary.Read(reader, binary.BigEndian, &symmetricDetails); err != nil {
+			return nil, errors.Wrap(err, "Error reading the symmetric algorithm")
+		}
+	}
+	var scheme uint16
+	if err := binary.Read(reader, binary.BigEndian, &scheme); err != nil {
+		return nil, errors.Wrap(err, "Error reading the scheme")
+	}
+	if scheme != tpmAlgNull {
+		var schemeHashAlg uint16
+		if err := binary.Read(reader, binary.BigEndian, &schemeHashAlg); err != nil {
+			return nil, errors.Wrap(err, "Error reading the scheme")
+		}
+	}
+	var keyParameters struct {
+		KeyBits  uint16
+		Exponent uint32
+	}
+	if err := binary.Read(reader, binary.BigEndian, &keyParameters); err != nil {
+		return nil, errors.Wrap(err, "Error reading the key parameters")
+	}
+	modulus, err := readTpm2b(reader)
+	if err != nil || len(modulus) == 0 {
+		return nil, errors.New("Error reading the modulus")
+	}
+
+	exponent := int(keyParameters.Exponent)
+	if exponent == 0 {
+		exponent = tpmDefaultRsaExponent
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: exponent}, nil
+}
+
+func readTpm2b(reader *bytes.Reader) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if int(size) > reader.Len() {
+		return nil, errors.New("The size of the buffer exceeds the structure")
+	}
+	buffer := make([]byte, size)
+	_, err := reader.Read(buffer)
+	return buffer, err
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/keylime"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+const keylimeAikCertCommonName = "Keylime AIK"
+
+// KeylimeConfig holds the configuration of the connectors of the hosts running the Keylime agent
+type KeylimeConfig struct {
+	// RegistrarURL is the URL of the Keylime registrar the agents registered their attestation keys with
+	RegistrarURL string
+	// ApiVersion is the version of the Keylime REST API, keylime.DefaultApiVersion is used when it is not set
+	ApiVersion string
+	// TLSConfig is used to connect to the agents and the registrar, it holds the mTLS client certificate of the
+	// Keylime deployment. The trusted CA certificates of the HVS are used when it is not set
+	TLSConfig *tls.Config
+	// AikCACertificate and AikCAKey issue the AIK certificates of the attestation keys of the agents, they should be
+	// the privacy CA of the HVS for the AIK certificates to be trusted
+	AikCACertificate *x509.Certificate
+	AikCAKey         *rsa.PrivateKey
+	// EndorsementCACertificates are the endorsement CAs of the HVS, the attestation keys are only certified for the
+	// agents whose EK certificate is issued by one of them
+	EndorsementCACertificates []x509.Certificate
+}
+
+// KeylimeConnectorFactory creates the connectors of the hosts running the Keylime agent. The attestation keys of the
+// agents are certified by the AIK CA once, the AIK certificates are cached by agent. Like the privacy CA, the factory
+// only certifies the attestation keys that the registrar activated for a TPM with a trusted EK certificate
+type KeylimeConnectorFactory struct {
+	config       *KeylimeConfig
+	registrarURL *url.URL
+
+	aikCertificates map[string]*x509.Certificate
+	lock            sync.Mutex
+}
+
+func NewKeylimeConnectorFactory(config KeylimeConfig) (*KeylimeConnectorFactory, error) {
+	registrarURL, err := url.Parse(config.RegistrarURL)
+	if err != nil || registrarURL.Host == "" {
+		return nil, errors.Errorf("keylime_host_connector_factory:NewKeylimeConnectorFactory() Invalid Keylime "+
+			"registrar URL '%s'", config.RegistrarURL)
+	}
+	if config.AikCACertificate == nil || config.AikCAKey == nil {
+		return nil, errors.New("keylime_host_connector_factory:NewKeylimeConnectorFactory() The AIK CA certificate " +
+			"and key must be provided")
+	}
+	if len(config.EndorsementCACertificates) == 0 {
+		return nil, errors.New("keylime_host_connector_factory:NewKeylimeConnectorFactory() The endorsement CA " +
+			"certificates must be provided")
+	}
+	return &KeylimeConnectorFactory{
+		config:          &config,
+		registrarURL:    registrarURL,
+		aikCertificates: map[string]*x509.Certificate{},
+	}, nil
+}
+
+func (kcf *KeylimeConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+
+	log.Trace("keylime_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("keylime_host_connector_factory:GetHostConnector() Leaving")
+	if kcf.config == nil {
+		return nil, errors.New("keylime_host_connector_factory:GetHostConnector() Keylime is not configured")
+	}
+
+	agentURL, err := url.Parse(vendorConnector.Url)
+	if err != nil {
+		return nil, errors.New("keylime_host_connector_factory:GetHostConnector() error retrieving Keylime agent URL")
+	}
+
+	tlsConfig := kcf.config.TLSConfig
+	if tlsConfig == nil {
+		rootCAs := x509.NewCertPool()
+		for i := range trustedCaCerts {
+			rootCAs.AddCert(&trustedCaCerts[i])
+		}
+		tlsConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	keylimeClient, err := keylime.NewKeylimeClient(agentURL, kcf.registrarURL, kcf.config.ApiVersion, tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "keylime_host_connector_factory:GetHostConnector() Could not create Keylime client")
+	}
+
+	log.Debug("keylime_host_connector_factory:GetHostConnector() Keylime client created")
+	return &KeylimeConnector{
+		client:   keylimeClient,
+		hostName: vendorConnector.Configuration.Hostname,
+		factory:  kcf,
+	}, nil
+}
+
+// getAikCertificate returns the AIK certificate of the attestation key registered by an agent, the certificate is
+// issued when the agent registers a new attestation key
+func (kcf *KeylimeConnectorFactory) getAikCertificate(agentId string, registrarAgent keylime.RegistrarAgent, aikTpm []byte,
+	aikPublicKey *rsa.PublicKey) (*x509.Certificate, error) {
+
+	// the registrar may deactivate the agent or be given a new EK, it is verified every time
+	if err := kcf.verifyRegistrarAgent(registrarAgent); err != nil {
+		return nil, errors.Wrap(err, "The attestation key of the agent cannot be certified")
+	}
+
+	aikDigest := sha256.Sum256(aikTpm)
+	cacheKey := agentId + ":" + hex.EncodeToString(aikDigest[:])
+
+	kcf.lock.Lock()
+	defer kcf.lock.Unlock()
+	if aikCertificate, ok := kcf.aikCertificates[cacheKey]; ok && time.Now().Before(aikCertificate.NotAfter) {
+		return aikCertificate, nil
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate the serial number of the AIK certificate")
+	}
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: keylimeAikCertCommonName + " " + agentId},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     kcf.config.AikCACertificate.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, &template, kcf.config.AikCACertificate, aikPublicKey,
+		kcf.config.AikCAKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the AIK certificate")
+	}
+	aikCertificate, err := x509.ParseCertificate(certificateBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse the AIK certificate")
+	}
+	kcf.aikCertificates[cacheKey] = aikCertificate
+	return aikCertificate, nil
+}
+
+// verifyRegistrarAgent checks that the registrar activated the attestation key of the agent, which proves that the
+// key is resident in the TPM of the endorsement key, and that the EK certificate is issued by an endorsement CA
+func (kcf *KeylimeConnectorFactory) verifyRegistrarAgent(registrarAgent keylime.RegistrarAgent) error {
+	if !registrarAgent.Active {
+		return errors.New("The agent is not active in the Keylime registrar")
+	}
+
+	ekCertBytes, err := base64.StdEncoding.DecodeString(registrarAgent.EkCert)
+	if err != nil || len(ekCertBytes) == 0 {
+		return errors.New("Invalid EK certificate returned by Keylime registrar")
+	}
+	ekCert, err := x509.ParseCertificate(ekCertBytes)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing the EK certificate")
+	}
+	trusted := false
+	for i := range kcf.config.EndorsementCACertificates {
+		if ekCert.CheckSignatureFrom(&kcf.config.EndorsementCACertificates[i]) == nil {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return errors.Errorf("The EK certificate issued by '%s' is not trusted", ekCert.Issuer.CommonName)
+	}
+
+	// the credential was activated with the endorsement key registered by the agent, it must be the certified key
+	ekTpm, err := base64.StdEncoding.DecodeString(registrarAgent.EkTpm)
+	if err != nil || len(ekTpm) == 0 {
+		return errors.New("Invalid endorsement key returned by Keylime registrar")
+	}
+	ekPublicKey, err := parseTpm2bPublic(ekTpm)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing the endorsement key")
+	}
+	ekCertPublicKey, ok := ekCert.PublicKey.(*rsa.PublicKey)
+	if !ok || ekCertPublicKey.N.Cmp(ekPublicKey.N) != 0 || ekCertPublicKey.E != ekPublicKey.E {
+		return errors.New("The endorsement key does not match the EK certificate")
+	}
+	return nil
+}
@@ -0,0 +1,503 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/clients/keylime"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKeylimeAgentUuid = "d432fbb3-d2f1-4a97-9ef7-75bd81c00000"
+
+// keylimeEvent is a boot event of the stub Keylime agent, extended to its PCR bank
+type keylimeEvent struct {
+	pcrIndex  uint32
+	eventType uint32
+	data      string
+}
+
+var testKeylimeEvents = []keylimeEvent{
+	{0, types.EV_S_CRTM_VERSION, "firmware-version"},
+	{0, types.EV_EFI_PLATFORM_FIRMWARE_BLOB, "firmware-blob"},
+	{7, types.EV_EFI_VARIABLE_DRIVER_CONFIG, "SecureBoot"},
+	{0, types.EV_SEPARATOR, "separator"},
+	{7, types.EV_SEPARATOR, "separator"},
+}
+
+// keylimePcrBanks are the hash algorithms and TPM algorithm ids of the PCR banks of the stub Keylime agents
+var keylimePcrBanks = map[types.SHAAlgorithm]struct {
+	hash        crypto.Hash
+	algorithmId uint16
+}{
+	types.SHA256: {crypto.SHA256, util.TPM_API_ALG_ID_SHA256},
+	types.SHA384: {crypto.SHA384, util.TPM_API_ALG_ID_SHA384},
+}
+
+// keylimeStub serves the agent and registrar APIs of a Keylime agent with software attestation and endorsement keys
+type keylimeStub struct {
+	aikKey    *rsa.PrivateKey
+	ekKey     *rsa.PrivateKey
+	ekCert    []byte
+	inactive  bool
+	pcrBank   types.SHAAlgorithm
+	pcrValues [24][]byte
+	eventLog  []byte
+}
+
+func newKeylimeStub(t *testing.T, pcrBank types.SHAAlgorithm) *keylimeStub {
+	aikKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ekKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	stub := &keylimeStub{aikKey: aikKey, ekKey: ekKey, pcrBank: pcrBank}
+	bankHash := keylimePcrBanks[pcrBank].hash
+	for pcr := range stub.pcrValues {
+		stub.pcrValues[pcr] = make([]byte, bankHash.Size())
+	}
+
+	// crypto agile event log: the spec id event lists the algorithm of the PCR bank, then the events
+	eventLog := new(bytes.Buffer)
+	specIdEvent := new(bytes.Buffer)
+	specIdEvent.WriteString("Spec ID Event03\x00")
+	_ = binary.Write(specIdEvent, binary.LittleEndian, struct {
+		PlatformClass      uint32
+		SpecVersion        [3]uint8
+		UintnSize          uint8
+		NumberOfAlgorithms uint32
+		AlgorithmId        uint16
+		DigestSize         uint16
+		VendorInfoSize     uint8
+	}{0, [3]uint8{0, 2, 0}, 2, 1, keylimePcrBanks[pcrBank].algorithmId, uint16(bankHash.Size()), 0})
+	_ = binary.Write(eventLog, binary.LittleEndian, []uint32{0, types.EV_NO_ACTION})
+	eventLog.Write(make([]byte, util.SHA1_SIZE))
+	_ = binary.Write(eventLog, binary.LittleEndian, uint32(specIdEvent.Len()))
+	eventLog.Write(specIdEvent.Bytes())
+
+	for _, event := range testKeylimeEvents {
+		hash := bankHash.New()
+		hash.Write([]byte(event.data))
+		digest := hash.Sum(nil)
+		hash.Reset()
+		hash.Write(stub.pcrValues[event.pcrIndex])
+		hash.Write(digest)
+		stub.pcrValues[event.pcrIndex] = hash.Sum(nil)
+
+		_ = binary.Write(eventLog, binary.LittleEndian, []uint32{event.pcrIndex, event.eventType, 1})
+		_ = binary.Write(eventLog, binary.LittleEndian, keylimePcrBanks[pcrBank].algorithmId)
+		eventLog.Write(digest)
+		_ = binary.Write(eventLog, binary.LittleEndian, uint32(len(event.data)))
+		eventLog.WriteString(event.data)
+	}
+	stub.eventLog = eventLog.Bytes()
+	return stub
+}
+
+// aikTpm returns the TPM2B_PUBLIC of the attestation key
+func (stub *keylimeStub) aikTpm() []byte {
+	tpmtPublic := new(bytes.Buffer)
+	modulus := stub.aikKey.N.Bytes()
+	_ = binary.Write(tpmtPublic, binary.BigEndian, []uint16{tpmAlgRsa, util.TPM_API_ALG_ID_SHA256})
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(0x00050072))
+	// empty auth policy, no symmetric algorithm, RSASSA SHA256 scheme, 2048 bits key and the default exponent
+	_ = binary.Write(tpmtPublic, binary.BigEndian, []uint16{0, tpmAlgNull, 0x0014, util.TPM_API_ALG_ID_SHA256, 2048})
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(0))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(len(modulus)))
+	tpmtPublic.Write(modulus)
+
+	tpm2bPublic := new(bytes.Buffer)
+	_ = binary.Write(tpm2bPublic, binary.BigEndian, uint16(tpmtPublic.Len()))
+	tpm2bPublic.Write(tpmtPublic.Bytes())
+	return tpm2bPublic.Bytes()
+}
+
+// ekTpm returns the TPM2B_PUBLIC of the endorsement key, a restricted decryption key with an AES-128-CFB symmetric
+// algorithm
+func (stub *keylimeStub) ekTpm() []byte {
+	tpmtPublic := new(bytes.Buffer)
+	modulus := stub.ekKey.N.Bytes()
+	_ = binary.Write(tpmtPublic, binary.BigEndian, []uint16{tpmAlgRsa, util.TPM_API_ALG_ID_SHA256})
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(0x000300b2))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(0))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, []uint16{0x0006, 128, 0x0043, tpmAlgNull, 2048})
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint32(0))
+	_ = binary.Write(tpmtPublic, binary.BigEndian, uint16(len(modulus)))
+	tpmtPublic.Write(modulus)
+
+	tpm2bPublic := new(bytes.Buffer)
+	_ = binary.Write(tpm2bPublic, binary.BigEndian, uint16(tpmtPublic.Len()))
+	tpm2bPublic.Write(tpmtPublic.Bytes())
+	return tpm2bPublic.Bytes()
+}
+
+// issueEkCertificate has the endorsement CA issue the EK certificate of the stub
+func (stub *keylimeStub) issueEkCertificate(t *testing.T, caCertificate *x509.Certificate, caKey *rsa.PrivateKey) {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	ekCert, err := x509.CreateCertificate(rand.Reader, &template, caCertificate, &stub.ekKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	stub.ekCert = ekCert
+}
+
+// registrarAgent returns the agent as registered with the Keylime registrar
+func (stub *keylimeStub) registrarAgent() keylime.RegistrarAgent {
+	return keylime.RegistrarAgent{
+		AikTpm: base64.StdEncoding.EncodeToString(stub.aikTpm()),
+		EkTpm:  base64.StdEncoding.EncodeToString(stub.ekTpm()),
+		EkCert: base64.StdEncoding.EncodeToString(stub.ekCert),
+		Active: !stub.inactive,
+	}
+}
+
+// quote returns a Keylime quote of the PCRs 0-23 of the bank of the stub with the nonce as qualifying data
+func (stub *keylimeStub) quote(nonce string) (string, error) {
+	var pcrConcat []byte
+	for _, pcrValue := range stub.pcrValues {
+		pcrConcat = append(pcrConcat, pcrValue...)
+	}
+	pcrDigest := sha256.Sum256(pcrConcat)
+
+	attest := new(bytes.Buffer)
+	_ = binary.Write(attest, binary.BigEndian, uint32(0xff544347))
+	_ = binary.Write(attest, binary.BigEndian, []uint16{0x8018, 0, uint16(len(nonce))})
+	attest.WriteString(nonce)
+	// TPMS_CLOCK_INFO and firmware version
+	_ = binary.Write(attest, binary.BigEndian, uint64(123456))
+	_ = binary.Write(attest, binary.BigEndian, []uint32{2, 1})
+	attest.WriteByte(1)
+	attest.Write(make([]byte, 8))
+	_ = binary.Write(attest, binary.BigEndian, uint32(1))
+	_ = binary.Write(attest, binary.BigEndian, keylimePcrBanks[stub.pcrBank].algorithmId)
+	attest.Write([]byte{3, 0xff, 0xff, 0xff})
+	_ = binary.Write(attest, binary.BigEndian, uint16(len(pcrDigest)))
+	attest.Write(pcrDigest[:])
+
+	attestDigest := sha256.Sum256(attest.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, stub.aikKey, crypto.SHA256, attestDigest[:])
+	if err != nil {
+		return "", err
+	}
+
+	tpm2bAttest := new(bytes.Buffer)
+	_ = binary.Write(tpm2bAttest, binary.BigEndian, uint16(attest.Len()))
+	tpm2bAttest.Write(attest.Bytes())
+	tpmtSignature := new(bytes.Buffer)
+	_ = binary.Write(tpmtSignature, binary.BigEndian, []uint16{0x0014, util.TPM_API_ALG_ID_SHA256,
+		uint16(len(signature))})
+	tpmtSignature.Write(signature)
+
+	// TPML_PCR_SELECTION and TPML_DIGEST structures, 8 PCRs per digest list
+	pcrBlob := new(bytes.Buffer)
+	pcrSelection := make([]byte, tpmlPcrSelectionSize)
+	binary.LittleEndian.PutUint32(pcrSelection, 1)
+	binary.LittleEndian.PutUint16(pcrSelection[4:], keylimePcrBanks[stub.pcrBank].algorithmId)
+	copy(pcrSelection[6:], []byte{3, 0xff, 0xff, 0xff})
+	pcrBlob.Write(pcrSelection)
+	_ = binary.Write(pcrBlob, binary.LittleEndian, uint32(3))
+	for list := 0; list < 3; list++ {
+		_ = binary.Write(pcrBlob, binary.LittleEndian, uint32(tpmlDigestCount))
+		for _, pcrValue := range stub.pcrValues[list*tpmlDigestCount : (list+1)*tpmlDigestCount] {
+			_ = binary.Write(pcrBlob, binary.LittleEndian, uint16(len(pcrValue)))
+			pcrBlob.Write(pcrValue)
+			pcrBlob.Write(make([]byte, tpm2bDigestBufferSize-len(pcrValue)))
+		}
+	}
+
+	return "r" + base64.StdEncoding.EncodeToString(tpm2bAttest.Bytes()) + ":" +
+		base64.StdEncoding.EncodeToString(tpmtSignature.Bytes()) + ":" +
+		base64.StdEncoding.EncodeToString(pcrBlob.Bytes()), nil
+}
+
+func (stub *keylimeStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var results interface{}
+	switch r.URL.Path {
+	case "/" + keylime.DefaultApiVersion + "/agent/info":
+		results = keylime.AgentInfo{
+			AgentUuid:  testKeylimeAgentUuid,
+			TpmHashAlg: strings.ToLower(string(stub.pcrBank)),
+			TpmEncAlg:  "rsa",
+			TpmSignAlg: "rsassa",
+		}
+	case "/" + keylime.DefaultApiVersion + "/agents/" + testKeylimeAgentUuid:
+		results = stub.registrarAgent()
+	case "/" + keylime.DefaultApiVersion + "/quotes/integrity":
+		quote, err := stub.quote(r.URL.Query().Get("nonce"))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		results = keylime.IntegrityQuote{
+			Quote:             quote,
+			HashAlg:           strings.ToLower(string(stub.pcrBank)),
+			EncAlg:            "rsa",
+			SignAlg:           "rsassa",
+			MbMeasurementList: base64.StdEncoding.EncodeToString(stub.eventLog),
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	resultsJson, _ := json.Marshal(results)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    http.StatusOK,
+		"status":  "Success",
+		"results": json.RawMessage(resultsJson),
+	})
+}
+
+func newTestCA(t *testing.T, commonName string) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(certificateBytes)
+	assert.NoError(t, err)
+	return certificate, key
+}
+
+// getTestKeylimeConnectorFactory returns a Keylime connector factory for the registrar of the test server, the EK
+// certificate of the stub is issued by the endorsement CA of the factory
+func getTestKeylimeConnectorFactory(t *testing.T, server *httptest.Server, stub *keylimeStub) (*KeylimeConnectorFactory, *x509.Certificate) {
+	aikCACertificate, aikCAKey := newTestCA(t, "Test Privacy CA")
+	endorsementCACertificate, endorsementCAKey := newTestCA(t, "Test Endorsement CA")
+	stub.issueEkCertificate(t, endorsementCACertificate, endorsementCAKey)
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	keylimeFactory, err := NewKeylimeConnectorFactory(KeylimeConfig{
+		RegistrarURL:              server.URL,
+		TLSConfig:                 &tls.Config{RootCAs: rootCAs},
+		AikCACertificate:          aikCACertificate,
+		AikCAKey:                  aikCAKey,
+		EndorsementCACertificates: []x509.Certificate{*endorsementCACertificate},
+	})
+	assert.NoError(t, err)
+	return keylimeFactory, aikCACertificate
+}
+
+func TestKeylimeHostManifest(t *testing.T) {
+	stub := newKeylimeStub(t, types.SHA256)
+	server := httptest.NewTLSServer(stub)
+	defer server.Close()
+
+	keylimeFactory, aikCACertificate := getTestKeylimeConnectorFactory(t, server, stub)
+
+	// the keylime connection strings are not supported until Keylime is configured
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	_, err := htcFactory.NewHostConnector("keylime:" + server.URL)
+	assert.Error(t, err)
+
+	htcFactory.SetKeylimeConnectorFactory(keylimeFactory)
+	hostConnector, err := htcFactory.NewHostConnector("keylime:" + server.URL + ";h=keylime-host")
+	assert.NoError(t, err)
+
+	hostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, "keylime-host", hostManifest.HostInfo.HostName)
+	assert.Equal(t, testKeylimeAgentUuid, hostManifest.HostInfo.HardwareUUID)
+	assert.Equal(t, "SHA256", hostManifest.HostInfo.HardwareFeatures.TPM.Meta.PCRBanks)
+	assert.Len(t, hostManifest.PcrManifest.Sha256Pcrs, 24)
+	assert.Empty(t, hostManifest.PcrManifest.Sha1Pcrs)
+
+	// the metadata of the quote is recorded for the verification of its freshness
+	assert.NotNil(t, hostManifest.QuoteInfo)
+	assert.NotEmpty(t, hostManifest.QuoteInfo.Nonce)
+	assert.Equal(t, types.TpmClockInfo{Clock: 123456, ResetCount: 2, RestartCount: 1, Safe: true},
+		hostManifest.QuoteInfo.ClockInfo)
+
+	// the AIK certificate is issued by the AIK CA for the attestation key registered by the agent
+	aikCertificateBytes, err := base64.StdEncoding.DecodeString(hostManifest.AIKCertificate)
+	assert.NoError(t, err)
+	aikCertificate, err := x509.ParseCertificate(aikCertificateBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, aikCertificate.CheckSignatureFrom(aikCACertificate))
+	assert.Equal(t, stub.aikKey.N, aikCertificate.PublicKey.(*rsa.PublicKey).N)
+
+	// the PCRs with boot events match the replay of the event log
+	assert.Len(t, hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs, 2)
+	for _, eventLogEntry := range hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs {
+		replay, err := eventLogEntry.Replay()
+		assert.NoError(t, err)
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(types.SHA256, eventLogEntry.PcrIndex)
+		assert.NoError(t, err)
+		assert.Equal(t, pcr.Value, replay)
+	}
+
+	// the AIK certificate is reused for the same attestation key
+	otherHostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, hostManifest.AIKCertificate, otherHostManifest.AIKCertificate)
+}
+
+func TestKeylimeQuoteTampered(t *testing.T) {
+	stub := newKeylimeStub(t, types.SHA256)
+	quote, err := stub.quote("abcdef")
+	assert.NoError(t, err)
+	quoteBytes, err := getKeylimeQuoteBytes(quote)
+	assert.NoError(t, err)
+
+	aikCACertificate, aikCAKey := newTestCA(t, "Test Privacy CA")
+	endorsementCACertificate, endorsementCAKey := newTestCA(t, "Test Endorsement CA")
+	stub.issueEkCertificate(t, endorsementCACertificate, endorsementCAKey)
+	keylimeFactory, err := NewKeylimeConnectorFactory(KeylimeConfig{
+		RegistrarURL:              "https://registrar.server.com:8891",
+		AikCACertificate:          aikCACertificate,
+		AikCAKey:                  aikCAKey,
+		EndorsementCACertificates: []x509.Certificate{*endorsementCACertificate},
+	})
+	assert.NoError(t, err)
+	aikPublicKey, err := parseTpm2bPublic(stub.aikTpm())
+	assert.NoError(t, err)
+	aikCertificate, err := keylimeFactory.getAikCertificate(testKeylimeAgentUuid, stub.registrarAgent(), stub.aikTpm(),
+		aikPublicKey)
+	assert.NoError(t, err)
+
+	_, err = util.VerifyQuoteAndGetPCRManifest("<measureLog/>", []byte("abcdef"), quoteBytes, aikCertificate)
+	assert.NoError(t, err)
+	_, err = util.VerifyQuoteAndGetPCRManifest("<measureLog/>", []byte("123456"), quoteBytes, aikCertificate)
+	assert.Error(t, err)
+
+	// a PCR value that does not match the digest of the quote
+	quoteBytes[len(quoteBytes)-1] ^= 0xff
+	_, err = util.VerifyQuoteAndGetPCRManifest("<measureLog/>", []byte("abcdef"), quoteBytes, aikCertificate)
+	assert.Error(t, err)
+
+	_, err = getKeylimeQuoteBytes("rAAAA:BBBB")
+	assert.Error(t, err)
+}
+
+func TestKeylimeHostManifestSha384(t *testing.T) {
+	stub := newKeylimeStub(t, types.SHA384)
+	server := httptest.NewTLSServer(stub)
+	defer server.Close()
+
+	keylimeFactory, _ := getTestKeylimeConnectorFactory(t, server, stub)
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	htcFactory.SetKeylimeConnectorFactory(keylimeFactory)
+	hostConnector, err := htcFactory.NewHostConnector("keylime:" + server.URL + ";h=keylime-host")
+	assert.NoError(t, err)
+
+	// the host only has a SHA384 bank
+	hostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA384", hostManifest.HostInfo.HardwareFeatures.TPM.Meta.PCRBanks)
+	assert.Equal(t, []types.SHAAlgorithm{types.SHA384}, hostManifest.PcrManifest.GetPcrBanks())
+	assert.Len(t, hostManifest.PcrManifest.Sha384Pcrs, 24)
+
+	// both the module event log and the TCG event log replay to the SHA384 PCRs
+	assert.Len(t, hostManifest.PcrManifest.PcrEventLogMap.Sha384EventLogs, 2)
+	for _, eventLogEntry := range hostManifest.PcrManifest.PcrEventLogMap.Sha384EventLogs {
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(types.SHA384, eventLogEntry.PcrIndex)
+		assert.NoError(t, err)
+		replay, err := eventLogEntry.Replay()
+		assert.NoError(t, err)
+		assert.Equal(t, pcr.Value, replay)
+		replay, err = hostManifest.TcgEventLog.Replay(types.SHA384, eventLogEntry.PcrIndex)
+		assert.NoError(t, err)
+		assert.Equal(t, pcr.Value, replay)
+	}
+}
+
+func TestKeylimeAgentNotActive(t *testing.T) {
+	stub := newKeylimeStub(t, types.SHA256)
+	stub.inactive = true
+	server := httptest.NewTLSServer(stub)
+	defer server.Close()
+
+	keylimeFactory, _ := getTestKeylimeConnectorFactory(t, server, stub)
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	htcFactory.SetKeylimeConnectorFactory(keylimeFactory)
+	hostConnector, err := htcFactory.NewHostConnector("keylime:" + server.URL + ";h=keylime-host")
+	assert.NoError(t, err)
+
+	// the attestation key is not certified until the agent activated it with the registrar
+	_, err = hostConnector.GetHostManifest()
+	assert.Error(t, err)
+	assert.Empty(t, keylimeFactory.aikCertificates)
+
+	stub.inactive = false
+	_, err = hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Len(t, keylimeFactory.aikCertificates, 1)
+}
+
+func TestKeylimeUntrustedEk(t *testing.T) {
+	stub := newKeylimeStub(t, types.SHA256)
+	aikPublicKey, err := parseTpm2bPublic(stub.aikTpm())
+	assert.NoError(t, err)
+
+	aikCACertificate, aikCAKey := newTestCA(t, "Test Privacy CA")
+	endorsementCACertificate, endorsementCAKey := newTestCA(t, "Test Endorsement CA")
+	keylimeFactory, err := NewKeylimeConnectorFactory(KeylimeConfig{
+		RegistrarURL:              "https://registrar.server.com:8891",
+		AikCACertificate:          aikCACertificate,
+		AikCAKey:                  aikCAKey,
+		EndorsementCACertificates: []x509.Certificate{*endorsementCACertificate},
+	})
+	assert.NoError(t, err)
+
+	// the EK certificate is issued by another CA
+	otherCACertificate, otherCAKey := newTestCA(t, "Test Endorsement CA")
+	stub.issueEkCertificate(t, otherCACertificate, otherCAKey)
+	_, err = keylimeFactory.getAikCertificate(testKeylimeAgentUuid, stub.registrarAgent(), stub.aikTpm(), aikPublicKey)
+	assert.Error(t, err)
+
+	// the agent has no EK certificate
+	registrarAgent := stub.registrarAgent()
+	registrarAgent.EkCert = ""
+	_, err = keylimeFactory.getAikCertificate(testKeylimeAgentUuid, registrarAgent, stub.aikTpm(), aikPublicKey)
+	assert.Error(t, err)
+
+	// the EK certificate is trusted but was issued for another endorsement key
+	stub.issueEkCertificate(t, endorsementCACertificate, endorsementCAKey)
+	registrarAgent = stub.registrarAgent()
+	registrarAgent.EkTpm = base64.StdEncoding.EncodeToString(stub.aikTpm())
+	_, err = keylimeFactory.getAikCertificate(testKeylimeAgentUuid, registrarAgent, stub.aikTpm(), aikPublicKey)
+	assert.Error(t, err)
+	assert.Empty(t, keylimeFactory.aikCertificates)
+
+	_, err = keylimeFactory.getAikCertificate(testKeylimeAgentUuid, stub.registrarAgent(), stub.aikTpm(), aikPublicKey)
+	assert.NoError(t, err)
+
+	// the endorsement CAs are required
+	_, err = NewKeylimeConnectorFactory(KeylimeConfig{
+		RegistrarURL:     "https://registrar.server.com:8891",
+		AikCACertificate: aikCACertificate,
+		AikCAKey:         aikCAKey,
+	})
+	assert.Error(t, err)
+}
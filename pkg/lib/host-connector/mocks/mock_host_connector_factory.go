@@ -15,8 +15,6 @@ import (
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
-	"github.com/vmware/govmomi/vim25/mo"
-	vim25Types "github.com/vmware/govmomi/vim25/types"
 	"io/ioutil"
 	"os"
 )
@@ -86,11 +84,9 @@ type MockVmwareConnectorFactory struct{}
 func (micf MockVmwareConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string, trustedCaCerts []x509.Certificate) (host_connector.HostConnector, error) {
 	vmc := MockVmwareConnector{}
 
-	var hostInfoList []mo.HostSystem
-	hostInfoList = append(hostInfoList, mo.HostSystem{ManagedEntity: mo.ManagedEntity{Name: "1.1.1.1"}, Summary:
-	vim25Types.HostListSummary{Hardware: &vim25Types.HostHardwareSummary{Uuid: "7a569dad-2d82-49e4-9156-069b0065b261"}}})
-	hostInfoList = append(hostInfoList, mo.HostSystem{ManagedEntity: mo.ManagedEntity{Name: "2.2.2.2"}, Summary:
-	vim25Types.HostListSummary{Hardware: &vim25Types.HostHardwareSummary{Uuid: "7a569dad-2d82-49e4-9156-069b0065b262"}}})
+	var hostInfoList []types.ClusterHost
+	hostInfoList = append(hostInfoList, types.ClusterHost{Name: "1.1.1.1", HardwareUUID: "7a569dad-2d82-49e4-9156-069b0065b261"})
+	hostInfoList = append(hostInfoList, types.ClusterHost{Name: "2.2.2.2", HardwareUUID: "7a569dad-2d82-49e4-9156-069b0065b262"})
 	vmc.On("GetClusterReference", mock.AnythingOfType("string")).Return(hostInfoList, nil)
 
 	var hostInfo taModel.HostInfo
@@ -11,7 +11,6 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/stretchr/testify/mock"
-	"github.com/vmware/govmomi/vim25/mo"
 )
 
 type MockIntelConnector struct {
@@ -44,7 +43,7 @@ func (ihc *MockIntelConnector) GetMeasurementFromManifest(manifest taModel.Manif
 	return args.Get(0).(taModel.Measurement), args.Error(1)
 }
 
-func (ihc *MockIntelConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+func (ihc *MockIntelConnector) GetClusterReference(clusterName string) ([]types.ClusterHost, error) {
 	args := ihc.Called(clusterName)
-	return args.Get(0).([]mo.HostSystem), args.Error(1)
+	return args.Get(0).([]types.ClusterHost), args.Error(1)
 }
\ No newline at end of file
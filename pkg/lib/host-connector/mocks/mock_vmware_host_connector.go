@@ -10,7 +10,6 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/stretchr/testify/mock"
-	"github.com/vmware/govmomi/vim25/mo"
 )
 
 type MockVmwareConnector struct {
@@ -43,7 +42,7 @@ func (vhc *MockVmwareConnector) GetMeasurementFromManifest(manifest taModel.Mani
 	return args.Get(0).(taModel.Measurement), args.Error(1)
 }
 
-func (vhc *MockVmwareConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+func (vhc *MockVmwareConnector) GetClusterReference(clusterName string) ([]types.ClusterHost, error) {
 	args := vhc.Called(clusterName)
-	return args.Get(0).([]mo.HostSystem), args.Error(1)
+	return args.Get(0).([]types.ClusterHost), args.Error(1)
 }
@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// SimulatedConnectorFactory creates the connectors of the simulated hosts. The simulated hosts are verified by the
+// IntelConnector the same way as the hosts running the Trust Agent
+type SimulatedConnectorFactory struct {
+	simulator *simulator.Simulator
+}
+
+func (scf *SimulatedConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+
+	log.Trace("simulated_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("simulated_host_connector_factory:GetHostConnector() Leaving")
+	if scf.simulator == nil {
+		return nil, errors.New("simulated_host_connector_factory:GetHostConnector() Host simulator is not enabled")
+	}
+
+	taClient, err := scf.simulator.NewTAClient(vendorConnector)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulated_host_connector_factory:GetHostConnector() Could not create simulated Trust Agent client")
+	}
+	return &IntelConnector{taClient}, nil
+}
@@ -0,0 +1,133 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/simulator"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newSimulatedHostConnectorFactory(t *testing.T) (*HostConnectorFactory, *simulator.Simulator) {
+	sim, err := simulator.NewSimulator(simulator.Config{})
+	assert.NoError(t, err)
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	htcFactory.SetSimulator(sim)
+	return htcFactory, sim
+}
+
+func TestSimulatedHostManifest(t *testing.T) {
+	htcFactory, sim := newSimulatedHostConnectorFactory(t)
+
+	hostConnector, err := htcFactory.NewHostConnector("simulated:https://sim-0001:1443;u=admin;p=password")
+	assert.NoError(t, err)
+
+	hostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, "sim-0001", hostManifest.HostInfo.HostName)
+	assert.Equal(t, "RedHatEnterprise", hostManifest.HostInfo.OSName)
+	assert.Len(t, hostManifest.PcrManifest.Sha1Pcrs, 24)
+	assert.Len(t, hostManifest.PcrManifest.Sha256Pcrs, 24)
+
+	// the AIK certificate is issued by the simulator CA
+	aikCertificateBytes, err := base64.StdEncoding.DecodeString(hostManifest.AIKCertificate)
+	assert.NoError(t, err)
+	aikCertificate, err := x509.ParseCertificate(aikCertificateBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, aikCertificate.CheckSignatureFrom(sim.AikCACertificate()))
+
+	// the PCRs with events match the replay of the event log
+	for _, eventLogEntry := range hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs {
+		replay, err := eventLogEntry.Replay()
+		assert.NoError(t, err)
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(types.SHA256, eventLogEntry.PcrIndex)
+		assert.NoError(t, err)
+		assert.Equal(t, pcr.Value, replay)
+	}
+
+	// the hosts of the same template report the same PCRs with a different hardware UUID
+	hostConnector, err = htcFactory.NewHostConnector("simulated:https://sim-0002:1443;u=admin;p=password")
+	assert.NoError(t, err)
+	otherHostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Equal(t, hostManifest.PcrManifest.Sha256Pcrs, otherHostManifest.PcrManifest.Sha256Pcrs)
+	assert.NotEqual(t, hostManifest.HostInfo.HardwareUUID, otherHostManifest.HostInfo.HardwareUUID)
+}
+
+func TestSimulatedHostFaults(t *testing.T) {
+	htcFactory, sim := newSimulatedHostConnectorFactory(t)
+
+	hostConnector, err := htcFactory.NewHostConnector("simulated:https://sim-0001:1443?fault=PCR_DRIFT;u=admin;p=password")
+	assert.NoError(t, err)
+	driftedManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+
+	assert.NoError(t, sim.SetFault("sim-0001:1443", simulator.FaultNone))
+	hostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hostManifest.PcrManifest.Sha256Pcrs[0].Value, driftedManifest.PcrManifest.Sha256Pcrs[0].Value)
+	assert.Equal(t, hostManifest.PcrManifest.Sha256Pcrs[17].Value, driftedManifest.PcrManifest.Sha256Pcrs[17].Value)
+
+	assert.NoError(t, sim.SetFault("sim-0001:1443", simulator.FaultUnauthorized))
+	_, err = hostConnector.GetHostManifest()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	_, ok := simulator.HostState(err)
+	assert.False(t, ok)
+
+	// the TPM faults do not prevent getting the host details
+	hostConnector, err = htcFactory.NewHostConnector("simulated:https://sim-0001:1443?fault=AIK_NOT_PROVISIONED;u=admin;p=password")
+	assert.NoError(t, err)
+	_, err = hostConnector.GetHostDetails()
+	assert.NoError(t, err)
+	_, err = hostConnector.GetHostManifest()
+	assert.Error(t, err)
+	hostState, ok := simulator.HostState(err)
+	assert.True(t, ok)
+	assert.Equal(t, string(simulator.FaultAikNotProvisioned), hostState)
+
+	_, err = htcFactory.NewHostConnector("simulated:https://sim-0001:1443?fault=MELTDOWN;u=admin;p=password")
+	assert.Error(t, err)
+	_, err = htcFactory.NewHostConnector("simulated:https://sim-0001:1443?template=windows;u=admin;p=password")
+	assert.Error(t, err)
+}
+
+func TestSimulatedHostSoftwareManifest(t *testing.T) {
+	htcFactory, _ := newSimulatedHostConnectorFactory(t)
+
+	hostConnector, err := htcFactory.NewHostConnector("simulated:https://sim-0001:1443;u=admin;p=password")
+	assert.NoError(t, err)
+	manifest := taModel.Manifest{
+		Label:     "ISecL_Default_Application_Flavor_v3.0_TPM2.0",
+		Uuid:      "7a9ac586-40f9-43b2-976b-26667431efca",
+		DigestAlg: "SHA384",
+		File:      []taModel.FileManifestType{{Path: "/opt/trustagent/bin/tagent"}},
+		Dir:       []taModel.DirManifestType{{Path: "/opt/trustagent/bin", Include: ".*"}},
+	}
+	measurement, err := hostConnector.GetMeasurementFromManifest(manifest)
+	assert.NoError(t, err)
+	assert.Len(t, measurement.File, 1)
+	assert.NotEmpty(t, measurement.CumulativeHash)
+
+	assert.NoError(t, hostConnector.DeploySoftwareManifest(manifest))
+	hostManifest, err := hostConnector.GetHostManifest()
+	assert.NoError(t, err)
+	assert.Len(t, hostManifest.MeasurementXmls, 1)
+	eventLogs, err := hostManifest.PcrManifest.GetPcrEventLog(types.SHA256, types.PCR15)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Label+"-"+manifest.Uuid, (*eventLogs)[0].Label)
+}
+
+func TestSimulatedHostNotEnabled(t *testing.T) {
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	hostConnector, err := htcFactory.NewHostConnector("simulated:https://sim-0001:1443;u=admin;p=password")
+	assert.Error(t, err)
+	assert.Nil(t, hostConnector)
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// simulatedClient implements the Trust Agent client interface for a simulated host
+type simulatedClient struct {
+	host      *host
+	simulator *Simulator
+	baseURL   *url.URL
+}
+
+// send simulates the latency of a request to the host and returns the error of the fault injected in the host
+func (sc *simulatedClient) send(usesTpm bool) error {
+	sc.host.lock.Lock()
+	latency, fault := sc.host.latency, sc.host.fault
+	sc.host.lock.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if err := fault.requestError(sc.host.name); err != nil {
+		return err
+	}
+	if usesTpm {
+		if err := fault.tpmError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sc *simulatedClient) GetHostInfo() (taModel.HostInfo, error) {
+	log.Trace("simulator/client:GetHostInfo() Entering")
+	defer log.Trace("simulator/client:GetHostInfo() Leaving")
+
+	if err := sc.send(false); err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "simulator/client:GetHostInfo() Error while getting response"+
+			" from Get host info from simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+
+	hostInfo := sc.host.template.HostInfo
+	hostInfo.HostName = strings.Split(sc.host.name, ":")[0]
+	hostInfo.HardwareUUID = sc.host.hardwareUUID.String()
+	hostInfo.InstalledComponents = append([]string(nil), hostInfo.InstalledComponents...)
+	return hostInfo, nil
+}
+
+func (sc *simulatedClient) GetTPMQuote(nonce string, pcrList []int, pcrBankList []string) (taModel.TpmQuoteResponse, error) {
+	log.Trace("simulator/client:GetTPMQuote() Entering")
+	defer log.Trace("simulator/client:GetTPMQuote() Leaving")
+
+	nonceBytes, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil {
+		return taModel.TpmQuoteResponse{}, errors.New("simulator/client:GetTPMQuote() Error decoding nonce from base64 to bytes")
+	}
+	if err := sc.send(true); err != nil {
+		return taModel.TpmQuoteResponse{}, errors.Wrap(err, "simulator/client:GetTPMQuote() Error while getting response"+
+			" from Get host manifest from simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+
+	quoteResponse, err := sc.simulator.quote(sc.host, nonceBytes, pcrList, pcrBankList)
+	if err != nil {
+		return taModel.TpmQuoteResponse{}, errors.Wrap(err, "simulator/client:GetTPMQuote() Error creating TPM quote")
+	}
+	return quoteResponse, nil
+}
+
+func (sc *simulatedClient) GetAIK() ([]byte, error) {
+	log.Trace("simulator/client:GetAIK() Entering")
+	defer log.Trace("simulator/client:GetAIK() Leaving")
+
+	if err := sc.send(true); err != nil {
+		return []byte{}, errors.Wrap(err, "simulator/client:GetAIK() Error while getting response from Get AIK"+
+			" from simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+	return sc.host.aikCertificate.Raw, nil
+}
+
+// GetBindingKeyCertificate returns no certificate, the workload agent is not simulated
+func (sc *simulatedClient) GetBindingKeyCertificate() ([]byte, error) {
+	log.Trace("simulator/client:GetBindingKeyCertificate() Entering")
+	defer log.Trace("simulator/client:GetBindingKeyCertificate() Leaving")
+
+	if err := sc.send(false); err != nil {
+		return nil, errors.Wrap(err, "simulator/client:GetBindingKeyCertificate() Error while getting response"+
+			" from Get binding key certificate from simulated host")
+	}
+	return nil, nil
+}
+
+func (sc *simulatedClient) DeployAssetTag(hardwareUUID, tag string) error {
+	log.Trace("simulator/client:DeployAssetTag() Entering")
+	defer log.Trace("simulator/client:DeployAssetTag() Leaving")
+
+	if _, err := base64.StdEncoding.DecodeString(tag); err != nil {
+		return errors.Wrap(err, "simulator/client:DeployAssetTag() Invalid asset tag")
+	}
+	if err := sc.send(true); err != nil {
+		return errors.Wrap(err, "simulator/client:DeployAssetTag() Error while getting response from Deploy asset"+
+			" tag to simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+
+	if !strings.EqualFold(hardwareUUID, sc.host.hardwareUUID.String()) {
+		return errors.New("simulator/client:DeployAssetTag() Error while getting response from Deploy asset tag" +
+			" to simulated host: HTTP Status :400")
+	}
+	sc.host.assetTag = tag
+	return nil
+}
+
+func (sc *simulatedClient) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	log.Trace("simulator/client:DeploySoftwareManifest() Entering")
+	defer log.Trace("simulator/client:DeploySoftwareManifest() Leaving")
+
+	if err := sc.send(false); err != nil {
+		return errors.Wrap(err, "simulator/client:DeploySoftwareManifest() Error while getting response from"+
+			" Deploy software manifest to simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+
+	deployed, _, err := measureManifest(sc.host.template, manifest)
+	if err != nil {
+		return errors.Wrap(err, "simulator/client:DeploySoftwareManifest() Error measuring software manifest")
+	}
+	for i, m := range sc.host.manifests {
+		if m.uuid == deployed.uuid {
+			sc.host.manifests[i] = deployed
+			return nil
+		}
+	}
+	sc.host.manifests = append(sc.host.manifests, deployed)
+	return nil
+}
+
+func (sc *simulatedClient) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	log.Trace("simulator/client:GetMeasurementFromManifest() Entering")
+	defer log.Trace("simulator/client:GetMeasurementFromManifest() Leaving")
+
+	if err := sc.send(false); err != nil {
+		return taModel.Measurement{}, errors.Wrap(err, "simulator/client:GetMeasurementFromManifest() Error while"+
+			" getting response from Host application measurement from simulated host")
+	}
+	sc.host.lock.Lock()
+	defer sc.host.lock.Unlock()
+
+	_, measurement, err := measureManifest(sc.host.template, manifest)
+	if err != nil {
+		return taModel.Measurement{}, errors.Wrap(err, "simulator/client:GetMeasurementFromManifest() Error "+
+			"measuring software manifest")
+	}
+	return measurement, nil
+}
+
+func (sc *simulatedClient) GetBaseURL() *url.URL {
+	return sc.baseURL
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Fault is a failure injected in a simulated host. Except for FaultPcrDrift, the faults are named after the host state
+// the HVS reports for the host
+type Fault string
+
+const (
+	FaultNone Fault = ""
+	// FaultPcrDrift changes the value of PCR 0, the host is reachable but does not match its flavors anymore
+	FaultPcrDrift Fault = "PCR_DRIFT"
+
+	FaultConnectionFailure     Fault = "CONNECTION_FAILURE"
+	FaultConnectionTimeout     Fault = "CONNECTION_TIMEOUT"
+	FaultUnauthorized          Fault = "UNAUTHORIZED"
+	FaultAikNotProvisioned     Fault = "AIK_NOT_PROVISIONED"
+	FaultEcNotPresent          Fault = "EC_NOT_PRESENT"
+	FaultMeasuredLaunchFailure Fault = "MEASURED_LAUNCH_FAILURE"
+	FaultTpmOwnershipFailure   Fault = "TPM_OWNERSHIP_FAILURE"
+	FaultTpmNotPresent         Fault = "TPM_NOT_PRESENT"
+	FaultTpmNotSupported       Fault = "UNSUPPORTED_TPM"
+	FaultUnknown               Fault = "UNKNOWN"
+)
+
+// Validate returns an error if the fault is not supported
+func (f Fault) Validate() error {
+	switch f {
+	case FaultNone, FaultPcrDrift, FaultConnectionFailure, FaultConnectionTimeout, FaultUnauthorized,
+		FaultAikNotProvisioned, FaultEcNotPresent, FaultMeasuredLaunchFailure, FaultTpmOwnershipFailure,
+		FaultTpmNotPresent, FaultTpmNotSupported, FaultUnknown:
+		return nil
+	}
+	return errors.Errorf("Unsupported simulated host fault %s", string(f))
+}
+
+// FaultError is the error of a request that uses the TPM of a host with a TPM fault, the Trust Agent client does not
+// return a specific error for these faults
+type FaultError struct {
+	Fault   Fault
+	message string
+}
+
+func (e *FaultError) Error() string {
+	return e.message
+}
+
+// HostState returns the name of the host state for the errors caused by a TPM fault, it returns false for other errors
+func HostState(err error) (string, bool) {
+	var faultErr *FaultError
+	if !errors.As(err, &faultErr) {
+		return "", false
+	}
+	return string(faultErr.Fault), true
+}
+
+// requestError returns the error of a request sent to a host with this fault. The errors have the same messages as
+// the errors returned by the Trust Agent client, so that the HVS determines the host state from them
+func (f Fault) requestError(hostName string) error {
+	switch f {
+	case FaultConnectionFailure:
+		return errors.Errorf("dial tcp %s: connect: connection refused", hostName)
+	case FaultConnectionTimeout:
+		return errors.Errorf("dial tcp %s: connect: connection timed out", hostName)
+	case FaultUnauthorized:
+		return errors.New("HTTP Status :401")
+	case FaultUnknown:
+		return errors.New("HTTP Status :500")
+	}
+	return nil
+}
+
+// tpmError returns the error of a request that uses the TPM of a host with this fault
+func (f Fault) tpmError() error {
+	switch f {
+	case FaultAikNotProvisioned:
+		return &FaultError{Fault: f, message: "AIK certificate is not provisioned"}
+	case FaultEcNotPresent:
+		return &FaultError{Fault: f, message: "Endorsement certificate is not present"}
+	case FaultMeasuredLaunchFailure:
+		return &FaultError{Fault: f, message: "Measured launch failed"}
+	case FaultTpmOwnershipFailure:
+		return &FaultError{Fault: f, message: "TPM ownership failed"}
+	case FaultTpmNotPresent:
+		return &FaultError{Fault: f, message: "TPM is not present"}
+	case FaultTpmNotSupported:
+		return &FaultError{Fault: f, message: "TPM version is not supported"}
+	}
+	return nil
+}
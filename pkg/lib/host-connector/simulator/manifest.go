@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/xml"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+const (
+	manifestDigestAlg = "SHA384"
+	manifestPcrIndex  = 15
+)
+
+// manifestMeasurement is a software manifest deployed to a simulated host, it is measured at every quote the same way
+// tbootxm measures the manifests at boot
+type manifestMeasurement struct {
+	uuid string
+	xml  string
+	// event is extended to PCR 15 of the SHA256 bank with the cumulative hash of the measurement
+	event Event
+}
+
+// measureManifest measures the files, directories and symlinks of a manifest. The measurements are derived from the
+// template name and the paths, so that the hosts of the same template report the same measurements
+func measureManifest(template *Template, manifest taModel.Manifest) (*manifestMeasurement, taModel.Measurement, error) {
+	if manifest.DigestAlg != manifestDigestAlg {
+		return nil, taModel.Measurement{}, errors.Errorf("Unsupported manifest digest algorithm %s", manifest.DigestAlg)
+	}
+
+	measurement := taModel.Measurement{
+		Label:     manifest.Label,
+		Uuid:      manifest.Uuid,
+		DigestAlg: manifest.DigestAlg,
+	}
+	// the cumulative hash is computed in the order of the measurement XML, files then directories then symlinks
+	cumulativeHash := make([]byte, sha512.Size384)
+	measure := func(path string) string {
+		value := sha512.Sum384([]byte(template.Name + ":" + path))
+		hash := sha512.New384()
+		hash.Write(cumulativeHash)
+		hash.Write(value[:])
+		cumulativeHash = hash.Sum(nil)
+		return hex.EncodeToString(value[:])
+	}
+	for _, file := range manifest.File {
+		measurement.File = append(measurement.File, taModel.FileMeasurementType{
+			Value:      measure(file.Path),
+			Path:       file.Path,
+			SearchType: file.SearchType,
+		})
+	}
+	for _, dir := range manifest.Dir {
+		measurement.Dir = append(measurement.Dir, taModel.DirectoryMeasurementType{
+			Value:      measure(dir.Path),
+			Include:    dir.Include,
+			Exclude:    dir.Exclude,
+			FilterType: dir.FilterType,
+			Path:       dir.Path,
+			SearchType: dir.SearchType,
+		})
+	}
+	for _, symlink := range manifest.Symlink {
+		measurement.Symlink = append(measurement.Symlink, taModel.SymlinkMeasurementType{
+			Value:      measure(symlink.Path),
+			Path:       symlink.Path,
+			SearchType: symlink.SearchType,
+		})
+	}
+	measurement.CumulativeHash = hex.EncodeToString(cumulativeHash)
+
+	measurementXml, err := xml.Marshal(measurement)
+	if err != nil {
+		return nil, taModel.Measurement{}, errors.Wrap(err, "Failed to marshal the measurement")
+	}
+	eventValue := sha256.Sum256(cumulativeHash)
+	return &manifestMeasurement{
+		uuid: manifest.Uuid,
+		xml:  string(measurementXml),
+		event: Event{
+			PcrBank:  string(types.SHA256),
+			PcrIndex: manifestPcrIndex,
+			Name:     manifest.Label + "-" + manifest.Uuid,
+			Value:    hex.EncodeToString(eventValue[:]),
+		},
+	}, measurement, nil
+}
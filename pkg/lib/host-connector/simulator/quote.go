@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/util"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// TPM 2.0 structure tags and algorithm ids of the simulated quotes
+const (
+	tpmGeneratedValue  = 0xff544347
+	tpmStAttestQuote   = 0x8018
+	tpmAlgRsassa       = 0x0014
+	tpmAlgSha256       = 0x000b
+	pcrSelectSize      = 3
+	simulatedFirmware  = 0x0002000100000000
+	simulatedTxtStatus = "3"
+	pcrDriftData       = "pcr-drift"
+)
+
+var pcrBankAlgIds = map[string]uint16{
+	string(types.SHA1):   util.TPM_API_ALG_ID_SHA1,
+	string(types.SHA256): util.TPM_API_ALG_ID_SHA256,
+}
+
+// quote creates a TPM quote of the PCRs of a simulated host, signed with its AIK. The quote has the layout parsed by
+// util.VerifyQuoteAndGetPCRManifest: the size of the TPMS_ATTEST structure, the TPMS_ATTEST structure, the
+// TPMT_SIGNATURE structure, then the values of the selected PCRs. The host lock must be held by the caller
+func (s *Simulator) quote(h *host, nonce []byte, pcrList []int, pcrBankList []string) (taModel.TpmQuoteResponse, error) {
+	var pcrSelect [pcrSelectSize]byte
+	for _, pcr := range pcrList {
+		if pcr < 0 || pcr >= pcrCount {
+			return taModel.TpmQuoteResponse{}, errors.Errorf("Invalid PCR %d", pcr)
+		}
+		pcrSelect[pcr/8] |= 1 << uint(pcr%8)
+	}
+	for _, bank := range pcrBankList {
+		if _, ok := pcrBankAlgIds[bank]; !ok {
+			return taModel.TpmQuoteResponse{}, errors.Errorf("Unsupported PCR bank %s", bank)
+		}
+	}
+
+	// the deployed manifests are measured after the events of the template
+	events := append([]Event(nil), h.template.Events...)
+	for _, manifest := range h.manifests {
+		events = append(events, manifest.event)
+	}
+	pcrValues := h.template.getPcrValues(events)
+	if h.fault == FaultPcrDrift {
+		for bank := range pcrValues {
+			pcrValues[bank][0] = extend(bank, pcrValues[bank][0], digest(bank, pcrDriftData))
+		}
+	}
+
+	var pcrConcat []byte
+	for _, bank := range pcrBankList {
+		for pcr := 0; pcr < pcrCount; pcr++ {
+			if pcrSelect[pcr/8]&(1<<uint(pcr%8)) != 0 {
+				pcrConcat = append(pcrConcat, pcrValues[bank][pcr]...)
+			}
+		}
+	}
+	pcrDigest := sha256.Sum256(pcrConcat)
+
+	// the nonce is extended with the asset tag when the host is tag provisioned
+	extraData := sha1.Sum(nonce)
+	qualifyingData := extraData[:]
+	if h.assetTag != "" {
+		tagBytes, _ := base64.StdEncoding.DecodeString(h.assetTag)
+		hash := sha1.New()
+		hash.Write(qualifyingData)
+		hash.Write(tagBytes)
+		qualifyingData = hash.Sum(nil)
+	}
+
+	aikName, err := getAikName(h.aikCertificate)
+	if err != nil {
+		return taModel.TpmQuoteResponse{}, err
+	}
+
+	// TPMS_ATTEST
+	attest := new(bytes.Buffer)
+	writeBigEndian(attest, uint32(tpmGeneratedValue))
+	writeBigEndian(attest, uint16(tpmStAttestQuote))
+	writeBigEndian(attest, uint16(len(aikName)))
+	attest.Write(aikName)
+	writeBigEndian(attest, uint16(len(qualifyingData)))
+	attest.Write(qualifyingData)
+	// TPMS_CLOCK_INFO
+	writeBigEndian(attest, uint64(time.Since(h.clockStart)/time.Millisecond))
+	writeBigEndian(attest, h.resetCount)
+	writeBigEndian(attest, uint32(0))
+	attest.WriteByte(1)
+	writeBigEndian(attest, uint64(simulatedFirmware))
+	// TPML_PCR_SELECTION
+	writeBigEndian(attest, uint32(len(pcrBankList)))
+	for _, bank := range pcrBankList {
+		writeBigEndian(attest, pcrBankAlgIds[bank])
+		attest.WriteByte(pcrSelectSize)
+		attest.Write(pcrSelect[:])
+	}
+	writeBigEndian(attest, uint16(len(pcrDigest)))
+	attest.Write(pcrDigest[:])
+
+	attestDigest := sha256.Sum256(attest.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.aikKey, crypto.SHA256, attestDigest[:])
+	if err != nil {
+		return taModel.TpmQuoteResponse{}, errors.Wrap(err, "Failed to sign the quote")
+	}
+
+	quote := new(bytes.Buffer)
+	writeBigEndian(quote, uint16(attest.Len()))
+	quote.Write(attest.Bytes())
+	// TPMT_SIGNATURE
+	writeBigEndian(quote, uint16(tpmAlgRsassa))
+	writeBigEndian(quote, uint16(tpmAlgSha256))
+	writeBigEndian(quote, uint16(len(signature)))
+	quote.Write(signature)
+	quote.Write(pcrConcat)
+
+	eventLog, err := getEventLog(events, pcrBankList)
+	if err != nil {
+		return taModel.TpmQuoteResponse{}, err
+	}
+
+	quoteResponse := taModel.TpmQuoteResponse{
+		TimeStamp:        time.Now().UnixNano() / int64(time.Millisecond),
+		Aik:              base64.StdEncoding.EncodeToString(aikCertificatePem(h.aikCertificate)),
+		Quote:            base64.StdEncoding.EncodeToString(quote.Bytes()),
+		EventLog:         base64.StdEncoding.EncodeToString(eventLog),
+		IsTagProvisioned: h.assetTag != "",
+		AssetTag:         h.assetTag,
+	}
+	for _, manifest := range h.manifests {
+		quoteResponse.TcbMeasurements.TcbMeasurements = append(quoteResponse.TcbMeasurements.TcbMeasurements, manifest.xml)
+	}
+	quoteResponse.SelectedPcrBanks.SelectedPcrBanks = pcrBankList
+	return quoteResponse, nil
+}
+
+// getAikName returns the TPM2B_NAME of the AIK, the SHA256 digest of its public key prefixed with the algorithm id
+func getAikName(aikCertificate *x509.Certificate) ([]byte, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(aikCertificate.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the AIK public key")
+	}
+	publicKeyDigest := sha256.Sum256(publicKey)
+	name := make([]byte, 2, 2+len(publicKeyDigest))
+	binary.BigEndian.PutUint16(name, tpmAlgSha256)
+	return append(name, publicKeyDigest[:]...), nil
+}
+
+// getEventLog returns the measure log XML of the events of the quoted PCR banks
+func getEventLog(events []Event, pcrBankList []string) ([]byte, error) {
+	var measureLog types.MeasureLog
+	measureLog.Txt.TxtStatus = simulatedTxtStatus
+	for _, event := range events {
+		for _, bank := range pcrBankList {
+			if event.PcrBank == bank {
+				measureLog.Txt.Modules.Module = append(measureLog.Txt.Modules.Module, types.Module{
+					PcrBank:   event.PcrBank,
+					PcrNumber: types.PcrIndex(event.PcrIndex),
+					Name:      event.Name,
+					Value:     event.Value,
+				})
+			}
+		}
+	}
+	eventLog, err := xml.Marshal(measureLog)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the event log")
+	}
+	return eventLog, nil
+}
+
+func writeBigEndian(buffer *bytes.Buffer, value interface{}) {
+	// writes to a bytes.Buffer do not fail
+	_ = binary.Write(buffer, binary.BigEndian, value)
+}
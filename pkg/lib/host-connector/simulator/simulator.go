@@ -0,0 +1,288 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package simulator simulates Trust Agents for load and integration testing. The simulated hosts are created from
+// templates, they report TPM quotes signed with a software AIK along with their event logs, and faults can be
+// injected to make them fail the way real hosts do
+package simulator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/intel-secl/intel-secl/v3/pkg/clients/ta"
+	commLog "github.com/intel-secl/intel-secl/v3/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+var log = commLog.GetDefaultLogger()
+
+const (
+	// templateParam, faultParam and latencyParam are the query parameters of the connection string of a simulated
+	// host, e.g. simulated:https://sim-0001:1443?template=rhel&fault=PCR_DRIFT&latency=100ms
+	templateParam = "template"
+	faultParam    = "fault"
+	latencyParam  = "latency"
+
+	aikKeyBits         = 2048
+	aikCAValidityYears = 5
+	aikCACommonName    = "Simulated Privacy CA"
+	aikCertCommonName  = "Simulated AIK"
+	hardwareUUIDPrefix = "simulated:"
+)
+
+// Config holds the configuration of a Simulator
+type Config struct {
+	// TemplateDir contains additional host templates, one JSON file per template
+	TemplateDir string
+	// Latency is added to every request sent to a simulated host, unless overridden in its connection string
+	Latency time.Duration
+	// AikCACertificate and AikCAKey issue the AIK certificates of the simulated hosts, they should be the privacy CA
+	// of the HVS for the AIK certificates to be trusted. A self signed CA is created when they are not set
+	AikCACertificate *x509.Certificate
+	AikCAKey         *rsa.PrivateKey
+}
+
+// Simulator keeps the state of the simulated hosts, keyed by the host and port of their connection string
+type Simulator struct {
+	latency          time.Duration
+	templates        map[string]*Template
+	aikCACertificate *x509.Certificate
+	aikCAKey         *rsa.PrivateKey
+	// aikKey is shared by the simulated hosts so that thousands of hosts can be created quickly, every host gets its
+	// own AIK certificate
+	aikKey *rsa.PrivateKey
+
+	hosts map[string]*host
+	lock  sync.Mutex
+}
+
+// host is the state of a simulated host
+type host struct {
+	name           string
+	hardwareUUID   uuid.UUID
+	aikCertificate *x509.Certificate
+
+	lock sync.Mutex
+	// clockStart is the time the TPM clock of the host started, the clock keeps running across reboots
+	clockStart time.Time
+	// resetCount is the number of reboots of the host, reported in the clock info of the quotes
+	resetCount uint32
+	template   *Template
+	fault      Fault
+	latency    time.Duration
+	// assetTag is the base64 encoded asset tag digest deployed to the host
+	assetTag  string
+	manifests []*manifestMeasurement
+}
+
+// NewSimulator creates a Simulator with the built-in templates and the templates of the template directory
+func NewSimulator(cfg Config) (*Simulator, error) {
+	log.Trace("simulator/simulator:NewSimulator() Entering")
+	defer log.Trace("simulator/simulator:NewSimulator() Leaving")
+
+	templates := map[string]*Template{}
+	for _, template := range defaultTemplates() {
+		templates[template.Name] = template
+	}
+	if cfg.TemplateDir != "" {
+		dirTemplates, err := LoadTemplates(cfg.TemplateDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to load host templates")
+		}
+		for _, template := range dirTemplates {
+			templates[template.Name] = template
+		}
+	}
+
+	aikKey, err := rsa.GenerateKey(rand.Reader, aikKeyBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate the AIK key")
+	}
+
+	aikCACertificate, aikCAKey := cfg.AikCACertificate, cfg.AikCAKey
+	if aikCACertificate == nil || aikCAKey == nil {
+		aikCACertificate, aikCAKey, err = newAikCA()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Simulator{
+		latency:          cfg.Latency,
+		templates:        templates,
+		aikCACertificate: aikCACertificate,
+		aikCAKey:         aikCAKey,
+		aikKey:           aikKey,
+		hosts:            map[string]*host{},
+	}, nil
+}
+
+// AikCACertificate returns the certificate of the CA that issues the AIK certificates of the simulated hosts
+func (s *Simulator) AikCACertificate() *x509.Certificate {
+	return s.aikCACertificate
+}
+
+// NewTAClient returns a Trust Agent client for the simulated host of the connection string. The template, fault and
+// latency of the host are updated from the query parameters of the connection string, when they are set
+func (s *Simulator) NewTAClient(vendorConnector types.VendorConnector) (client.TAClient, error) {
+	log.Trace("simulator/simulator:NewTAClient() Entering")
+	defer log.Trace("simulator/simulator:NewTAClient() Leaving")
+
+	baseURL, err := url.Parse(vendorConnector.Url)
+	if err != nil || baseURL.Host == "" {
+		return nil, errors.Errorf("Invalid simulated host URL %s", vendorConnector.Url)
+	}
+	query := baseURL.Query()
+
+	template, ok := s.templates[DefaultTemplateName]
+	if name := query.Get(templateParam); name != "" {
+		if template, ok = s.templates[name]; !ok {
+			return nil, errors.Errorf("Unknown simulated host template %s", name)
+		}
+	}
+
+	h, err := s.getHost(baseURL.Host, template)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if query.Get(templateParam) != "" {
+		h.template = template
+	}
+	if _, ok := query[faultParam]; ok {
+		fault := Fault(query.Get(faultParam))
+		if err := fault.Validate(); err != nil {
+			return nil, err
+		}
+		h.fault = fault
+	}
+	if latency := query.Get(latencyParam); latency != "" {
+		if h.latency, err = time.ParseDuration(latency); err != nil {
+			return nil, errors.Wrapf(err, "Invalid simulated host latency %s", latency)
+		}
+	}
+
+	baseURL.RawQuery = ""
+	return &simulatedClient{host: h, simulator: s, baseURL: baseURL}, nil
+}
+
+// SetFault injects a fault in a simulated host, FaultNone clears it. The host is the host and port of the connection
+// string of the simulated host
+func (s *Simulator) SetFault(hostName string, fault Fault) error {
+	if err := fault.Validate(); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	h, ok := s.hosts[hostName]
+	s.lock.Unlock()
+	if !ok {
+		return errors.Errorf("Unknown simulated host %s", hostName)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.fault = fault
+	return nil
+}
+
+// Reboot restarts a simulated host, its reset count is incremented
+func (s *Simulator) Reboot(hostName string) error {
+	s.lock.Lock()
+	h, ok := s.hosts[hostName]
+	s.lock.Unlock()
+	if !ok {
+		return errors.Errorf("Unknown simulated host %s", hostName)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.resetCount++
+	return nil
+}
+
+func (s *Simulator) getHost(hostName string, template *Template) (*host, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if h, ok := s.hosts[hostName]; ok {
+		return h, nil
+	}
+
+	h := &host{
+		name:         hostName,
+		hardwareUUID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(hardwareUUIDPrefix+hostName)),
+		clockStart:   time.Now(),
+		template:     template,
+		latency:      s.latency,
+	}
+	aikCertificate, err := s.newAikCertificate(h)
+	if err != nil {
+		return nil, err
+	}
+	h.aikCertificate = aikCertificate
+	s.hosts[hostName] = h
+	log.Debugf("simulator/simulator:getHost() Created simulated host %s with hardware UUID %s", hostName, h.hardwareUUID)
+	return h, nil
+}
+
+func (s *Simulator) newAikCertificate(h *host) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate the serial number of the AIK certificate")
+	}
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: aikCertCommonName + " " + h.hardwareUUID.String()},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     s.aikCACertificate.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, &template, s.aikCACertificate, &s.aikKey.PublicKey, s.aikCAKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the AIK certificate")
+	}
+	return x509.ParseCertificate(certificateBytes)
+}
+
+func newAikCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, aikKeyBits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to generate the AIK CA key")
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: aikCACommonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(aikCAValidityYears, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to create the AIK CA certificate")
+	}
+	certificate, err := x509.ParseCertificate(certificateBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to parse the AIK CA certificate")
+	}
+	return certificate, key, nil
+}
+
+func aikCertificatePem(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package simulator
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// DefaultTemplateName is the template of the simulated hosts that do not set one in their connection string
+const DefaultTemplateName = "rhel"
+
+const pcrCount = 24
+
+// pcrBanks are the PCR banks reported by the simulated hosts, in the order of the quotes
+var pcrBanks = []string{string(types.SHA1), string(types.SHA256)}
+
+// Template describes the platform and the measured boot of the simulated hosts created from it
+type Template struct {
+	Name string `json:"name"`
+	// HostInfo is reported by the simulated hosts, the host name and hardware UUID are set for each host
+	HostInfo taModel.HostInfo `json:"host_info"`
+	// Pcrs are the hex encoded values of the PCRs that have no event, by PCR bank and PCR index. The PCRs that are
+	// neither listed nor extended by events are zero
+	Pcrs map[string]map[int]string `json:"pcrs,omitempty"`
+	// Events are extended to the PCRs in order and reported in the event log of the quotes
+	Events []Event `json:"events,omitempty"`
+
+	// pcrValues are the values of the PCRs without events, by PCR bank
+	pcrValues map[string][][]byte
+}
+
+// Event is a measurement of the event log of a template
+type Event struct {
+	PcrBank  string `json:"pcr_bank"`
+	PcrIndex int    `json:"pcr_index"`
+	Name     string `json:"name"`
+	// Value is the hex encoded digest extended to the PCR
+	Value string `json:"value"`
+}
+
+// LoadTemplates reads the JSON host templates of a directory
+func LoadTemplates(dir string) ([]*Template, error) {
+	log.Trace("simulator/template:LoadTemplates() Entering")
+	defer log.Trace("simulator/template:LoadTemplates() Leaving")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list the host templates of %s", dir)
+	}
+
+	var templates []*Template
+	for _, file := range files {
+		templateJson, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read host template %s", file)
+		}
+		var template Template
+		if err := json.Unmarshal(templateJson, &template); err != nil {
+			return nil, errors.Wrapf(err, "Failed to unmarshal host template %s", file)
+		}
+		if err := template.init(); err != nil {
+			return nil, errors.Wrapf(err, "Invalid host template %s", file)
+		}
+		templates = append(templates, &template)
+	}
+	return templates, nil
+}
+
+// init validates the template and computes its PCR values
+func (t *Template) init() error {
+	if t.Name == "" {
+		return errors.New("Host template name is not set")
+	}
+
+	t.pcrValues = map[string][][]byte{}
+	for _, bank := range pcrBanks {
+		t.pcrValues[bank] = make([][]byte, pcrCount)
+		for index := range t.pcrValues[bank] {
+			t.pcrValues[bank][index] = make([]byte, newHash(bank).Size())
+		}
+	}
+
+	for bank, pcrs := range t.Pcrs {
+		if _, ok := t.pcrValues[bank]; !ok {
+			return errors.Errorf("Unsupported PCR bank %s", bank)
+		}
+		for index, value := range pcrs {
+			pcrValue, err := decodeDigest(bank, value)
+			if err != nil || index < 0 || index >= pcrCount {
+				return errors.Errorf("Invalid %s PCR %d", bank, index)
+			}
+			t.pcrValues[bank][index] = pcrValue
+		}
+	}
+
+	for _, event := range t.Events {
+		if err := event.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Event) validate() error {
+	if e.PcrBank != string(types.SHA1) && e.PcrBank != string(types.SHA256) {
+		return errors.Errorf("Unsupported PCR bank %s of event %s", e.PcrBank, e.Name)
+	}
+	if e.PcrIndex < 0 || e.PcrIndex >= pcrCount {
+		return errors.Errorf("Invalid PCR %d of event %s", e.PcrIndex, e.Name)
+	}
+	if _, err := decodeDigest(e.PcrBank, e.Value); err != nil {
+		return errors.Wrapf(err, "Invalid value of event %s", e.Name)
+	}
+	return nil
+}
+
+// getPcrValues returns the PCR values of the template after the events are extended. The PCRs with events are
+// replayed from zero, the same way the verifier checks the integrity of the event log
+func (t *Template) getPcrValues(events []Event) map[string][][]byte {
+	pcrValues := map[string][][]byte{}
+	for bank, values := range t.pcrValues {
+		pcrValues[bank] = make([][]byte, len(values))
+		copy(pcrValues[bank], values)
+	}
+
+	extended := map[string]map[int]bool{}
+	for _, event := range events {
+		if extended[event.PcrBank] == nil {
+			extended[event.PcrBank] = map[int]bool{}
+		}
+		if !extended[event.PcrBank][event.PcrIndex] {
+			pcrValues[event.PcrBank][event.PcrIndex] = make([]byte, newHash(event.PcrBank).Size())
+			extended[event.PcrBank][event.PcrIndex] = true
+		}
+		eventDigest, _ := decodeDigest(event.PcrBank, event.Value)
+		pcrValues[event.PcrBank][event.PcrIndex] = extend(event.PcrBank, pcrValues[event.PcrBank][event.PcrIndex], eventDigest)
+	}
+	return pcrValues
+}
+
+func newHash(bank string) hash.Hash {
+	if bank == string(types.SHA1) {
+		return sha1.New()
+	}
+	return sha256.New()
+}
+
+func digest(bank string, data string) []byte {
+	h := newHash(bank)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func extend(bank string, pcrValue, digest []byte) []byte {
+	h := newHash(bank)
+	h.Write(pcrValue)
+	h.Write(digest)
+	return h.Sum(nil)
+}
+
+func decodeDigest(bank string, value string) ([]byte, error) {
+	digest, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != newHash(bank).Size() {
+		return nil, errors.Errorf("Invalid %s digest length %d", bank, len(digest))
+	}
+	return digest, nil
+}
+
+// defaultTemplates returns the built-in templates. The measurements are derived from the template name so that the
+// flavors created from one simulated host match all the hosts of the same template
+func defaultTemplates() []*Template {
+	rhel := &Template{
+		Name: DefaultTemplateName,
+		HostInfo: taModel.HostInfo{
+			OSName:          "RedHatEnterprise",
+			OSVersion:       "8.2",
+			BiosName:        "Intel Corporation",
+			BiosVersion:     "SE5C620.86B.02.01.0009.092820190230",
+			VMMName:         "Docker",
+			VMMVersion:      "19.03.8",
+			ProcessorInfo:   "57 06 05 00 FF FB EB BF",
+			ProcessorFlags:  "FPU VME DE PSE TSC MSR PAE MCE CX8 APIC SEP MTRR PGE MCA CMOV PAT PSE-36 CLFSH DS ACPI MMX FXSR SSE SSE2 SS HTT TM PBE",
+			NumberOfSockets: 2,
+			TbootInstalled:  true,
+			HardwareFeatures: taModel.HardwareFeatures{
+				TXT: &taModel.HardwareFeature{Enabled: true},
+			},
+			InstalledComponents: []string{types.HostComponentTagent.String()},
+		},
+		Pcrs: map[string]map[int]string{},
+	}
+	rhel.HostInfo.HardwareFeatures.TPM.Enabled = true
+	rhel.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion = "2.0"
+	rhel.HostInfo.HardwareFeatures.TPM.Meta.PCRBanks = string(types.SHA1) + "_" + string(types.SHA256)
+
+	// PCRs 0 to 7 are measured by the BIOS, PCRs 17 and 18 by tboot
+	for _, bank := range pcrBanks {
+		rhel.Pcrs[bank] = map[int]string{}
+		for index := 0; index < 8; index++ {
+			rhel.Pcrs[bank][index] = hex.EncodeToString(digest(bank, fmt.Sprintf("%s/pcr/%d", rhel.Name, index)))
+		}
+	}
+	tbootEvents := map[int][]string{
+		17: {"HASH_START", "BIOSAC_REG_DATA", "CPU_SCRTM_STAT", "LCP_DETAILS_HASH", "STM_HASH", "OSSINITDATA_CAP_HASH", "MLE_HASH", "NV_INFO_HASH", "tb_policy"},
+		18: {"HASH_START", "BIOSAC_REG_DATA", "CPU_SCRTM_STAT", "LCP_AUTHORITIES_HASH", "OSSINITDATA_CAP_HASH", "vmlinuz", "initrd"},
+	}
+	for _, bank := range pcrBanks {
+		for _, index := range []int{17, 18} {
+			for _, name := range tbootEvents[index] {
+				rhel.Events = append(rhel.Events, Event{
+					PcrBank:  bank,
+					PcrIndex: index,
+					Name:     name,
+					Value:    hex.EncodeToString(digest(bank, fmt.Sprintf("%s/pcr/%d/%s", rhel.Name, index, name))),
+				})
+			}
+		}
+	}
+
+	if err := rhel.init(); err != nil {
+		panic(err)
+	}
+	return []*Template{rhel}
+}
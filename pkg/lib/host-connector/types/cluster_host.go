@@ -0,0 +1,11 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package types
+
+// ClusterHost is a host of a cluster managed by a vendor connector
+type ClusterHost struct {
+	Name         string
+	HardwareUUID string
+}
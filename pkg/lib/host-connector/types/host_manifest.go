@@ -18,6 +18,9 @@ type HostManifest struct {
 	PcrManifest           PcrManifest      `json:"pcr_manifest"`
 	BindingKeyCertificate string           `json:"binding_key_certificate,omitempty"`
 	MeasurementXmls       []string         `json:"measurement_xmls,omitempty"`
+	ImaLog                *ImaLog          `json:"ima_log,omitempty"`
+	TcgEventLog           *TcgEventLog     `json:"tcg_event_log,omitempty"`
+	QuoteInfo             *QuoteInfo       `json:"quote_info,omitempty"`
 }
 
 func (hostManifest *HostManifest) GetAIKCertificate() (*x509.Certificate, error) {
@@ -0,0 +1,161 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+
+	"github.com/pkg/errors"
+)
+
+// Templates of the kernel IMA measurement list
+const (
+	ImaTemplateIma    = "ima"
+	ImaTemplateImaNg  = "ima-ng"
+	ImaTemplateImaSig = "ima-sig"
+	ImaTemplateImaBuf = "ima-buf"
+
+	// ImaPcr is the PCR the kernel IMA extends the measurements to
+	ImaPcr = PCR10
+	// imaEventNameSize is the size of the file names of the ima template
+	imaEventNameSize = 256
+)
+
+// ImaMeasurement is an entry of the kernel IMA measurement list
+type ImaMeasurement struct {
+	PcrIndex PcrIndex `json:"pcr_index"`
+	// TemplateHash is the hex encoded SHA1 digest of the template data, it is zero for the measurement violations
+	TemplateHash string `json:"template_hash"`
+	TemplateName string `json:"template_name"`
+	// FileDigestAlgorithm is the lower case name of the algorithm of the file digest, e.g. sha256
+	FileDigestAlgorithm string `json:"file_digest_algorithm"`
+	FileDigest          string `json:"file_digest"`
+	Path                string `json:"path"`
+	// Signature is the hex encoded file signature of the ima-sig template
+	Signature string `json:"signature,omitempty"`
+	// Buffer is the hex encoded buffer of the ima-buf template
+	Buffer string `json:"buffer,omitempty"`
+}
+
+// ImaLog is the kernel IMA measurement list of a host
+type ImaLog struct {
+	Measurements []ImaMeasurement `json:"measurements"`
+}
+
+// Replay returns the hex encoded value of the IMA PCR in the given bank, calculated from the measurements. The
+// template hashes of the bank are calculated from the template data, an error is returned if the SHA1 template hash of
+// a measurement does not match its template data. The measurement violations are extended with 0xFF bytes
+func (imaLog *ImaLog) Replay(pcrBank SHAAlgorithm) (string, error) {
+	bankHash, err := newBankHash(pcrBank)
+	if err != nil {
+		return "", err
+	}
+	cumulativeHash := make([]byte, bankHash.Size())
+
+	for i, measurement := range imaLog.Measurements {
+		if measurement.PcrIndex != ImaPcr {
+			continue
+		}
+		templateHash, err := hex.DecodeString(measurement.TemplateHash)
+		if err != nil || len(templateHash) != sha1.Size {
+			return "", errors.Errorf("Invalid template hash '%s' of IMA measurement %d", measurement.TemplateHash, i)
+		}
+
+		var digest []byte
+		if bytes.Equal(templateHash, make([]byte, sha1.Size)) {
+			digest = bytes.Repeat([]byte{0xff}, bankHash.Size())
+		} else {
+			templateData, err := measurement.GetTemplateData()
+			if err != nil {
+				return "", errors.Wrapf(err, "Failed to get the template data of IMA measurement %d", i)
+			}
+			// the file digest and name are not trusted unless they are the ones the template hash was calculated from
+			sha1TemplateHash := sha1.Sum(templateData)
+			if !bytes.Equal(templateHash, sha1TemplateHash[:]) {
+				return "", errors.Errorf("The template hash of IMA measurement %d does not match its template data", i)
+			}
+			bankHash.Reset()
+			bankHash.Write(templateData)
+			digest = bankHash.Sum(nil)
+		}
+
+		bankHash.Reset()
+		bankHash.Write(cumulativeHash)
+		bankHash.Write(digest)
+		cumulativeHash = bankHash.Sum(nil)
+	}
+	return hex.EncodeToString(cumulativeHash), nil
+}
+
+// GetTemplateData returns the template data of the measurement, the data the template hash is calculated from. The
+// fields of the ima template are not prefixed by their size, the file name is padded to 256 bytes
+func (measurement *ImaMeasurement) GetTemplateData() ([]byte, error) {
+	fileDigest, err := hex.DecodeString(measurement.FileDigest)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid file digest")
+	}
+
+	if measurement.TemplateName == ImaTemplateIma {
+		if len(measurement.Path) >= imaEventNameSize {
+			return nil, errors.New("The file name exceeds the size of the ima template")
+		}
+		templateData := append([]byte{}, fileDigest...)
+		eventName := make([]byte, imaEventNameSize)
+		copy(eventName, measurement.Path)
+		return append(templateData, eventName...), nil
+	}
+
+	// d-ng field: the digest algorithm, a colon and a null character, then the digest, n-ng field: the null
+	// terminated file name
+	fields := [][]byte{
+		append([]byte(measurement.FileDigestAlgorithm+":\x00"), fileDigest...),
+		append([]byte(measurement.Path), 0),
+	}
+	switch measurement.TemplateName {
+	case ImaTemplateImaNg:
+	case ImaTemplateImaSig:
+		signature, err := hex.DecodeString(measurement.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid file signature")
+		}
+		fields = append(fields, signature)
+	case ImaTemplateImaBuf:
+		buffer, err := hex.DecodeString(measurement.Buffer)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid buffer")
+		}
+		fields = append(fields, buffer)
+	default:
+		return nil, errors.Errorf("Unsupported IMA template '%s'", measurement.TemplateName)
+	}
+
+	templateData := new(bytes.Buffer)
+	for _, field := range fields {
+		_ = binary.Write(templateData, binary.LittleEndian, uint32(len(field)))
+		templateData.Write(field)
+	}
+	return templateData.Bytes(), nil
+}
+
+func newBankHash(pcrBank SHAAlgorithm) (hash.Hash, error) {
+	switch pcrBank {
+	case SHA1:
+		return sha1.New(), nil
+	case SHA256:
+		return sha256.New(), nil
+	case SHA384:
+		return sha512.New384(), nil
+	case SHA512:
+		return sha512.New(), nil
+	}
+	return nil, errors.Errorf("Invalid sha algorithm '%s'", pcrBank)
+}
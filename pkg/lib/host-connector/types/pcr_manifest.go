@@ -47,11 +47,15 @@ type EventLogEntry struct {
 type PcrEventLogMap struct {
 	Sha1EventLogs   []EventLogEntry `json:"SHA1"`
 	Sha256EventLogs []EventLogEntry `json:"SHA256"`
+	Sha384EventLogs []EventLogEntry `json:"SHA384,omitempty"`
+	Sha512EventLogs []EventLogEntry `json:"SHA512,omitempty"`
 }
 
 type PcrManifest struct {
 	Sha1Pcrs       []Pcr          `json:"sha1pcrs"`
 	Sha256Pcrs     []Pcr          `json:"sha2pcrs"`
+	Sha384Pcrs     []Pcr          `json:"sha384pcrs,omitempty"`
+	Sha512Pcrs     []Pcr          `json:"sha512pcrs,omitempty"`
 	PcrEventLogMap PcrEventLogMap `json:"pcr_event_log_map"`
 }
 
@@ -149,6 +153,14 @@ const (
 	UNKNOWN SHAAlgorithm = "unknown"
 )
 
+// SupportedPcrBanks are the PCR banks that can be stored in a PcrManifest, in the order of their digest sizes
+var SupportedPcrBanks = []SHAAlgorithm{SHA1, SHA256, SHA384, SHA512}
+
+// GetDigestTypeSuffix returns the suffix of the bank in the pcr and measurement class names, e.g. 256 for SHA256
+func (shaAlgorithm SHAAlgorithm) GetDigestTypeSuffix() string {
+	return strings.TrimPrefix(string(shaAlgorithm), "SHA")
+}
+
 func GetSHAAlgorithm(algorithm string) (SHAAlgorithm, error) {
 	switch algorithm {
 	case string(SHA1):
@@ -190,32 +202,50 @@ func GetPcrIndexFromString(stringValue string) (PcrIndex, error) {
 
 // Finds the Pcr in a PcrManifest provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1 and SHA256).
+// by intel-secl (SHA1, SHA256, SHA384 and SHA512).
 func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (*Pcr, error) {
 	// TODO: Is this the right data model for the PcrManifest?  Two things...
 	// - Flavor API returns a map[bank]map[pcrindex]
 	// - Finding the PCR by bank/index is a linear search.
-	var pcrValue *Pcr
-
-	if pcrBank == SHA1 {
-		for _, pcr := range pcrManifest.Sha1Pcrs {
-			if pcr.Index == pcrIndex {
-				pcrValue = &pcr
-				break
-			}
-		}
-	} else if pcrBank == SHA256 {
-		for _, pcr := range pcrManifest.Sha256Pcrs {
-			if pcr.Index == pcrIndex {
-				pcrValue = &pcr
-				break
-			}
+	pcrs, err := pcrManifest.getPcrs(pcrBank)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pcr := range *pcrs {
+		if pcr.Index == pcrIndex {
+			pcrValue := pcr
+			return &pcrValue, nil
 		}
-	} else {
-		return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
+	return nil, nil
+}
 
-	return pcrValue, nil
+// AddPcr adds a Pcr to the bank of the PcrManifest it belongs to.  Returns an
+// error if the bank of the pcr is not supported.
+func (pcrManifest *PcrManifest) AddPcr(pcr Pcr) error {
+	pcrs, err := pcrManifest.getPcrs(pcr.PcrBank)
+	if err != nil {
+		return err
+	}
+
+	*pcrs = append(*pcrs, pcr)
+	return nil
+}
+
+// getPcrs returns the pcrs of a bank of the PcrManifest
+func (pcrManifest *PcrManifest) getPcrs(pcrBank SHAAlgorithm) (*[]Pcr, error) {
+	switch pcrBank {
+	case SHA1:
+		return &pcrManifest.Sha1Pcrs, nil
+	case SHA256:
+		return &pcrManifest.Sha256Pcrs, nil
+	case SHA384:
+		return &pcrManifest.Sha384Pcrs, nil
+	case SHA512:
+		return &pcrManifest.Sha512Pcrs, nil
+	}
+	return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 }
 
 // Utility function that uses GetPcrValue but also returns an error if
@@ -233,40 +263,68 @@ func (pcrManifest *PcrManifest) GetRequiredPcrValue(bank SHAAlgorithm, pcrIndex
 	return pcrValue, nil
 }
 
-// IsEmpty returns true if both the Sha1Pcrs and Sha256Pcrs
-// are empty.
+// IsEmpty returns true if the pcrs of all the banks are empty.
 func (pcrManifest *PcrManifest) IsEmpty() bool {
-	return len(pcrManifest.Sha1Pcrs) == 0 && len(pcrManifest.Sha256Pcrs) == 0
+	return len(pcrManifest.GetPcrBanks()) == 0
 }
 
 // Finds the EventLogEntry in a PcrEventLogMap provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1 and SHA256).
+// by intel-secl (SHA1, SHA256, SHA384 and SHA512).
 func (pcrEventLogMap *PcrEventLogMap) GetEventLog(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (*EventLogEntry, error) {
 
-	var eventLogEntry *EventLogEntry
+	eventLogEntries, err := pcrEventLogMap.getEventLogEntries(pcrBank)
+	if err != nil {
+		return nil, err
+	}
 
-	if pcrBank == SHA1 {
-		for _, entry := range pcrEventLogMap.Sha1EventLogs {
-			if entry.PcrIndex == pcrIndex {
-				eventLogEntry = &entry
-				break
-			}
+	for _, entry := range *eventLogEntries {
+		if entry.PcrIndex == pcrIndex {
+			eventLogEntry := entry
+			return &eventLogEntry, nil
 		}
-	} else if pcrBank == SHA256 {
-		for _, entry := range pcrEventLogMap.Sha256EventLogs {
-			if entry.PcrIndex == pcrIndex {
-				eventLogEntry = &entry
-				break
-			}
-		}
-	} else {
-		return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
+	}
+	return nil, nil
+}
+
+// AddEventLog appends an EventLog to the EventLogEntry of the bank/index in the
+// PcrEventLogMap, the entry is created if it does not exist.  Returns an error if
+// the pcrBank is not supported.
+func (pcrEventLogMap *PcrEventLogMap) AddEventLog(pcrBank SHAAlgorithm, pcrIndex PcrIndex, eventLog EventLog) error {
+
+	eventLogEntries, err := pcrEventLogMap.getEventLogEntries(pcrBank)
+	if err != nil {
+		return err
 	}
 
-	return eventLogEntry, nil
+	for i := range *eventLogEntries {
+		if (*eventLogEntries)[i].PcrIndex == pcrIndex {
+			(*eventLogEntries)[i].EventLogs = append((*eventLogEntries)[i].EventLogs, eventLog)
+			return nil
+		}
+	}
+	*eventLogEntries = append(*eventLogEntries, EventLogEntry{
+		PcrIndex:  pcrIndex,
+		PcrBank:   pcrBank,
+		EventLogs: []EventLog{eventLog},
+	})
+	return nil
 }
 
+// getEventLogEntries returns the event log entries of a bank of the PcrEventLogMap
+func (pcrEventLogMap *PcrEventLogMap) getEventLogEntries(pcrBank SHAAlgorithm) (*[]EventLogEntry, error) {
+	switch pcrBank {
+	case SHA1:
+		return &pcrEventLogMap.Sha1EventLogs, nil
+	case SHA256:
+		return &pcrEventLogMap.Sha256EventLogs, nil
+	case SHA384:
+		return &pcrEventLogMap.Sha384EventLogs, nil
+	case SHA512:
+		return &pcrEventLogMap.Sha512EventLogs, nil
+	}
+	return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
+}
 
 // Provided an EventLogEntry that contains an array of EventLogs, this function
 // will return a new EventLogEntry that contains the events that existed in 
@@ -351,35 +409,24 @@ func (eventLogEntry *EventLogEntry) Replay() (string, error) {
 // GetPcrEventLog returns the EventLogs for a specific PcrBank/PcrIndex
 func (pcrManifest *PcrManifest) GetPcrEventLog(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (*[]EventLog, error) {
 
-	pI := PcrIndex(pcrIndex)
-	if pcrBank == SHA1 {
-		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sha1EventLogs {
-			if eventLogEntry.PcrIndex == pI {
-				return &eventLogEntry.EventLogs, nil
-			}
-		}
-	} else if pcrBank == SHA256 {
-		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sha256EventLogs {
-			if eventLogEntry.PcrIndex == pI {
-				return &eventLogEntry.EventLogs, nil
-			}
-		}
-	} else {
+	eventLogEntry, err := pcrManifest.PcrEventLogMap.GetEventLog(pcrBank, pcrIndex)
+	if err != nil {
 		return nil, fmt.Errorf("unsupported sha algorithm %s", pcrBank)
 	}
-	return nil, fmt.Errorf("invalid PcrIndex %d", pcrIndex)
+	if eventLogEntry == nil {
+		return nil, fmt.Errorf("invalid PcrIndex %d", pcrIndex)
+	}
+	return &eventLogEntry.EventLogs, nil
 }
 
 // GetPcrBanks returns the list of banks currently supported by the PcrManifest
 func (pcrManifest *PcrManifest) GetPcrBanks() []SHAAlgorithm {
 	var bankList []SHAAlgorithm
 	// check if each known digest algorithm is present and return
-	if len(pcrManifest.Sha1Pcrs) > 0 {
-		bankList = append(bankList, SHA1)
-	}
-	// check if each known digest algorithm is present and return
-	if len(pcrManifest.Sha256Pcrs) > 0 {
-		bankList = append(bankList, SHA256)
+	for _, pcrBank := range SupportedPcrBanks {
+		if pcrs, _ := pcrManifest.getPcrs(pcrBank); len(*pcrs) > 0 {
+			bankList = append(bankList, pcrBank)
+		}
 	}
 	return bankList
 }
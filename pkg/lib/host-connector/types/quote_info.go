@@ -0,0 +1,36 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package types
+
+// TpmClockInfo is the TPMS_CLOCK_INFO of a TPM quote. The Clock is the time in milliseconds that the TPM has been
+// powered, it does not go backwards unless the TPM lost an update that was not Safe. The ResetCount is incremented on
+// each TPM reset (reboot) and the RestartCount on each TPM restart (resume from hibernation) since the last reset.
+type TpmClockInfo struct {
+	Clock        uint64 `json:"clock"`
+	ResetCount   uint32 `json:"reset_count"`
+	RestartCount uint32 `json:"restart_count"`
+	Safe         bool   `json:"safe"`
+}
+
+// QuoteInfo is the metadata of the TPM quote that a host manifest was created from
+type QuoteInfo struct {
+	// Nonce is the nonce that the quote was requested with, as it was sent to the host
+	Nonce     string       `json:"nonce"`
+	ClockInfo TpmClockInfo `json:"clock_info"`
+	// PreviousNonce and PreviousClockInfo are those of the quote of the previous attestation of the host, they are
+	// set by HVS when the host manifest is stored
+	PreviousNonce     string        `json:"previous_nonce,omitempty"`
+	PreviousClockInfo *TpmClockInfo `json:"previous_clock_info,omitempty"`
+}
+
+// IsNewBootSession returns true if the TPM was reset or restarted since the quote of the previous attestation of the
+// host, that is, the host was rebooted or resumed from hibernation and its PCRs were measured again
+func (quoteInfo *QuoteInfo) IsNewBootSession() bool {
+	if quoteInfo == nil || quoteInfo.PreviousClockInfo == nil {
+		return false
+	}
+	return quoteInfo.ClockInfo.ResetCount != quoteInfo.PreviousClockInfo.ResetCount ||
+		quoteInfo.ClockInfo.RestartCount != quoteInfo.PreviousClockInfo.RestartCount
+}
@@ -0,0 +1,30 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQuoteInfoIsNewBootSession(t *testing.T) {
+
+	var quoteInfo *QuoteInfo
+	assert.False(t, quoteInfo.IsNewBootSession())
+
+	// the first attestation of the host
+	quoteInfo = &QuoteInfo{ClockInfo: TpmClockInfo{Clock: 5000, ResetCount: 3, RestartCount: 1}}
+	assert.False(t, quoteInfo.IsNewBootSession())
+
+	quoteInfo.PreviousClockInfo = &TpmClockInfo{Clock: 4000, ResetCount: 3, RestartCount: 1}
+	assert.False(t, quoteInfo.IsNewBootSession())
+
+	quoteInfo.PreviousClockInfo = &TpmClockInfo{Clock: 4000, ResetCount: 3, RestartCount: 0}
+	assert.True(t, quoteInfo.IsNewBootSession())
+
+	quoteInfo.PreviousClockInfo = &TpmClockInfo{Clock: 4000, ResetCount: 2, RestartCount: 1}
+	assert.True(t, quoteInfo.IsNewBootSession())
+}
@@ -0,0 +1,321 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// Event types of the TCG PC Client Platform Firmware Profile
+const (
+	EV_PREBOOT_CERT                  = 0x00000000
+	EV_POST_CODE                     = 0x00000001
+	EV_NO_ACTION                     = 0x00000003
+	EV_SEPARATOR                     = 0x00000004
+	EV_ACTION                        = 0x00000005
+	EV_EVENT_TAG                     = 0x00000006
+	EV_S_CRTM_CONTENTS               = 0x00000007
+	EV_S_CRTM_VERSION                = 0x00000008
+	EV_CPU_MICROCODE                 = 0x00000009
+	EV_PLATFORM_CONFIG_FLAGS         = 0x0000000A
+	EV_TABLE_OF_DEVICES              = 0x0000000B
+	EV_COMPACT_HASH                  = 0x0000000C
+	EV_IPL                           = 0x0000000D
+	EV_IPL_PARTITION_DATA            = 0x0000000E
+	EV_NONHOST_CODE                  = 0x0000000F
+	EV_NONHOST_CONFIG                = 0x00000010
+	EV_NONHOST_INFO                  = 0x00000011
+	EV_OMIT_BOOT_DEVICE_EVENTS       = 0x00000012
+	EV_EFI_VARIABLE_DRIVER_CONFIG    = 0x80000001
+	EV_EFI_VARIABLE_BOOT             = 0x80000002
+	EV_EFI_BOOT_SERVICES_APPLICATION = 0x80000003
+	EV_EFI_BOOT_SERVICES_DRIVER      = 0x80000004
+	EV_EFI_RUNTIME_SERVICES_DRIVER   = 0x80000005
+	EV_EFI_GPT_EVENT                 = 0x80000006
+	EV_EFI_ACTION                    = 0x80000007
+	EV_EFI_PLATFORM_FIRMWARE_BLOB    = 0x80000008
+	EV_EFI_HANDOFF_TABLES            = 0x80000009
+	EV_EFI_PLATFORM_FIRMWARE_BLOB2   = 0x8000000A
+	EV_EFI_HANDOFF_TABLES2           = 0x8000000B
+	EV_EFI_VARIABLE_BOOT2            = 0x8000000C
+	EV_EFI_HCRTM_EVENT               = 0x80000010
+	EV_EFI_VARIABLE_AUTHORITY        = 0x800000E0
+)
+
+var tcgEventTypeNames = map[uint32]string{
+	EV_PREBOOT_CERT:                  "EV_PREBOOT_CERT",
+	EV_POST_CODE:                     "EV_POST_CODE",
+	EV_NO_ACTION:                     "EV_NO_ACTION",
+	EV_SEPARATOR:                     "EV_SEPARATOR",
+	EV_ACTION:                        "EV_ACTION",
+	EV_EVENT_TAG:                     "EV_EVENT_TAG",
+	EV_S_CRTM_CONTENTS:               "EV_S_CRTM_CONTENTS",
+	EV_S_CRTM_VERSION:                "EV_S_CRTM_VERSION",
+	EV_CPU_MICROCODE:                 "EV_CPU_MICROCODE",
+	EV_PLATFORM_CONFIG_FLAGS:         "EV_PLATFORM_CONFIG_FLAGS",
+	EV_TABLE_OF_DEVICES:              "EV_TABLE_OF_DEVICES",
+	EV_COMPACT_HASH:                  "EV_COMPACT_HASH",
+	EV_IPL:                           "EV_IPL",
+	EV_IPL_PARTITION_DATA:            "EV_IPL_PARTITION_DATA",
+	EV_NONHOST_CODE:                  "EV_NONHOST_CODE",
+	EV_NONHOST_CONFIG:                "EV_NONHOST_CONFIG",
+	EV_NONHOST_INFO:                  "EV_NONHOST_INFO",
+	EV_OMIT_BOOT_DEVICE_EVENTS:       "EV_OMIT_BOOT_DEVICE_EVENTS",
+	EV_EFI_VARIABLE_DRIVER_CONFIG:    "EV_EFI_VARIABLE_DRIVER_CONFIG",
+	EV_EFI_VARIABLE_BOOT:             "EV_EFI_VARIABLE_BOOT",
+	EV_EFI_BOOT_SERVICES_APPLICATION: "EV_EFI_BOOT_SERVICES_APPLICATION",
+	EV_EFI_BOOT_SERVICES_DRIVER:      "EV_EFI_BOOT_SERVICES_DRIVER",
+	EV_EFI_RUNTIME_SERVICES_DRIVER:   "EV_EFI_RUNTIME_SERVICES_DRIVER",
+	EV_EFI_GPT_EVENT:                 "EV_EFI_GPT_EVENT",
+	EV_EFI_ACTION:                    "EV_EFI_ACTION",
+	EV_EFI_PLATFORM_FIRMWARE_BLOB:    "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+	EV_EFI_HANDOFF_TABLES:            "EV_EFI_HANDOFF_TABLES",
+	EV_EFI_PLATFORM_FIRMWARE_BLOB2:   "EV_EFI_PLATFORM_FIRMWARE_BLOB2",
+	EV_EFI_HANDOFF_TABLES2:           "EV_EFI_HANDOFF_TABLES2",
+	EV_EFI_VARIABLE_BOOT2:            "EV_EFI_VARIABLE_BOOT2",
+	EV_EFI_HCRTM_EVENT:               "EV_EFI_HCRTM_EVENT",
+	EV_EFI_VARIABLE_AUTHORITY:        "EV_EFI_VARIABLE_AUTHORITY",
+}
+
+// Values of the EV_SEPARATOR events, the error value is measured when the firmware failed before the separator
+const (
+	TcgSeparatorValue      = 0x00000000
+	TcgSeparatorErrorValue = 0x00000001
+)
+
+// TcgEventLog is a TCG PC Client crypto agile event log of the measured boot of a host
+type TcgEventLog struct {
+	// StartupLocality is the locality of the TPM2_Startup, reported by the StartupLocality EV_NO_ACTION event. It is
+	// the initial value of the last byte of PCR 0.
+	StartupLocality uint8      `json:"startup_locality,omitempty"`
+	Events          []TcgEvent `json:"events"`
+}
+
+// TcgEvent is a TCG_PCR_EVENT2 of the event log, with the hex encoded digests extended to each PCR bank
+type TcgEvent struct {
+	PcrIndex  PcrIndex                `json:"pcr_index"`
+	EventType uint32                  `json:"event_type"`
+	Digests   map[SHAAlgorithm]string `json:"digests"`
+	Data      []byte                  `json:"data,omitempty"`
+}
+
+// EfiVariableData is the UEFI_VARIABLE_DATA of the EV_EFI_VARIABLE_* events
+type EfiVariableData struct {
+	VariableName string `json:"variable_name"`
+	UnicodeName  string `json:"unicode_name"`
+	VariableData []byte `json:"variable_data,omitempty"`
+}
+
+// EfiImageLoadEvent is the UEFI_IMAGE_LOAD_EVENT of the EV_EFI_BOOT_SERVICES_APPLICATION, EV_EFI_BOOT_SERVICES_DRIVER
+// and EV_EFI_RUNTIME_SERVICES_DRIVER events
+type EfiImageLoadEvent struct {
+	ImageLocationInMemory uint64 `json:"image_location_in_memory"`
+	ImageLengthInMemory   uint64 `json:"image_length_in_memory"`
+	ImageLinkTimeAddress  uint64 `json:"image_link_time_address"`
+	DevicePath            []byte `json:"device_path,omitempty"`
+}
+
+// GetEventTypeName returns the name of the event type from the TCG PC Client Platform Firmware Profile
+func (event *TcgEvent) GetEventTypeName() string {
+	if name, ok := tcgEventTypeNames[event.EventType]; ok {
+		return name
+	}
+	return fmt.Sprintf("EV_UNKNOWN_0x%08X", event.EventType)
+}
+
+// GetEfiVariableData decodes the UEFI_VARIABLE_DATA of an EV_EFI_VARIABLE_DRIVER_CONFIG, EV_EFI_VARIABLE_BOOT,
+// EV_EFI_VARIABLE_BOOT2 or EV_EFI_VARIABLE_AUTHORITY event: the variable GUID, the lengths of the name and of the data,
+// the UTF-16 name and the data.
+func (event *TcgEvent) GetEfiVariableData() (*EfiVariableData, error) {
+	switch event.EventType {
+	case EV_EFI_VARIABLE_DRIVER_CONFIG, EV_EFI_VARIABLE_BOOT, EV_EFI_VARIABLE_BOOT2, EV_EFI_VARIABLE_AUTHORITY:
+	default:
+		return nil, errors.Errorf("The event %s does not contain a UEFI variable", event.GetEventTypeName())
+	}
+
+	reader := bytes.NewReader(event.Data)
+	var header struct {
+		VariableName       [16]byte
+		UnicodeNameLength  uint64
+		VariableDataLength uint64
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "Error reading the header of the UEFI variable")
+	}
+	if header.UnicodeNameLength > uint64(reader.Len())/2 ||
+		header.VariableDataLength > uint64(reader.Len())-header.UnicodeNameLength*2 {
+		return nil, errors.New("The lengths of the UEFI variable exceed the event data")
+	}
+
+	unicodeName := make([]uint16, header.UnicodeNameLength)
+	if err := binary.Read(reader, binary.LittleEndian, unicodeName); err != nil {
+		return nil, errors.Wrap(err, "Error reading the name of the UEFI variable")
+	}
+	variableData := make([]byte, header.VariableDataLength)
+	if _, err := reader.Read(variableData); err != nil && header.VariableDataLength > 0 {
+		return nil, errors.Wrap(err, "Error reading the data of the UEFI variable")
+	}
+
+	return &EfiVariableData{
+		VariableName: formatEfiGuid(header.VariableName),
+		UnicodeName:  string(utf16.Decode(unicodeName)),
+		VariableData: variableData,
+	}, nil
+}
+
+// GetEfiImageLoadEvent decodes the UEFI_IMAGE_LOAD_EVENT of an EV_EFI_BOOT_SERVICES_APPLICATION,
+// EV_EFI_BOOT_SERVICES_DRIVER or EV_EFI_RUNTIME_SERVICES_DRIVER event
+func (event *TcgEvent) GetEfiImageLoadEvent() (*EfiImageLoadEvent, error) {
+	switch event.EventType {
+	case EV_EFI_BOOT_SERVICES_APPLICATION, EV_EFI_BOOT_SERVICES_DRIVER, EV_EFI_RUNTIME_SERVICES_DRIVER:
+	default:
+		return nil, errors.Errorf("The event %s does not contain a UEFI image load event", event.GetEventTypeName())
+	}
+
+	reader := bytes.NewReader(event.Data)
+	var header struct {
+		ImageLocationInMemory uint64
+		ImageLengthInMemory   uint64
+		ImageLinkTimeAddress  uint64
+		LengthOfDevicePath    uint64
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "Error reading the UEFI image load event")
+	}
+	if header.LengthOfDevicePath > uint64(reader.Len()) {
+		return nil, errors.New("The length of the device path exceeds the event data")
+	}
+	devicePath := make([]byte, header.LengthOfDevicePath)
+	if _, err := reader.Read(devicePath); err != nil && header.LengthOfDevicePath > 0 {
+		return nil, errors.Wrap(err, "Error reading the device path of the UEFI image load event")
+	}
+
+	return &EfiImageLoadEvent{
+		ImageLocationInMemory: header.ImageLocationInMemory,
+		ImageLengthInMemory:   header.ImageLengthInMemory,
+		ImageLinkTimeAddress:  header.ImageLinkTimeAddress,
+		DevicePath:            devicePath,
+	}, nil
+}
+
+// GetSeparatorValue returns the value of an EV_SEPARATOR event, TcgSeparatorValue or TcgSeparatorErrorValue
+func (event *TcgEvent) GetSeparatorValue() (uint32, error) {
+	if event.EventType != EV_SEPARATOR {
+		return 0, errors.Errorf("The event %s is not a separator", event.GetEventTypeName())
+	}
+	if len(event.Data) != 4 {
+		return 0, errors.Errorf("Invalid size %d of the separator event data", len(event.Data))
+	}
+	return binary.LittleEndian.Uint32(event.Data), nil
+}
+
+// VerifyDigests returns an error when a digest of the event is not the hash of its data. It applies to the events
+// that measure their data, like EV_EFI_VARIABLE_DRIVER_CONFIG, and not to the events that measure an image.
+func (event *TcgEvent) VerifyDigests() error {
+	for pcrBank, digest := range event.Digests {
+		bankHash, err := newBankHash(pcrBank)
+		if err != nil {
+			return err
+		}
+		bankHash.Write(event.Data)
+		if hex.EncodeToString(bankHash.Sum(nil)) != strings.ToLower(digest) {
+			return errors.Errorf("The %s digest of the %s event does not match its data", pcrBank,
+				event.GetEventTypeName())
+		}
+	}
+	return nil
+}
+
+// GetPcrBanks returns the PCR banks that have digests in the event log
+func (eventLog *TcgEventLog) GetPcrBanks() []SHAAlgorithm {
+	banks := map[SHAAlgorithm]bool{}
+	for _, event := range eventLog.Events {
+		for bank := range event.Digests {
+			banks[bank] = true
+		}
+	}
+
+	var pcrBanks []SHAAlgorithm
+	for bank := range banks {
+		pcrBanks = append(pcrBanks, bank)
+	}
+	sort.Slice(pcrBanks, func(i, j int) bool {
+		return pcrBanks[i] < pcrBanks[j]
+	})
+	return pcrBanks
+}
+
+// HasPcrBank returns true when the events of the log have digests in the PCR bank
+func (eventLog *TcgEventLog) HasPcrBank(pcrBank SHAAlgorithm) bool {
+	for _, event := range eventLog.Events {
+		if _, ok := event.Digests[pcrBank]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPcrIndexes returns the PCRs that the events of the log are extended to
+func (eventLog *TcgEventLog) GetPcrIndexes() []PcrIndex {
+	indexes := map[PcrIndex]bool{}
+	for _, event := range eventLog.Events {
+		if event.EventType != EV_NO_ACTION {
+			indexes[event.PcrIndex] = true
+		}
+	}
+
+	var pcrIndexes []PcrIndex
+	for index := range indexes {
+		pcrIndexes = append(pcrIndexes, index)
+	}
+	sort.Slice(pcrIndexes, func(i, j int) bool {
+		return pcrIndexes[i] < pcrIndexes[j]
+	})
+	return pcrIndexes
+}
+
+// Replay extends the digests of the events of a PCR in the given bank and returns the hex encoded PCR value.
+// EV_NO_ACTION events are not extended, PCR 0 starts with the startup locality in its last byte. An error is returned
+// when an event of the PCR does not have a digest in the bank.
+func (eventLog *TcgEventLog) Replay(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (string, error) {
+	bankHash, err := newBankHash(pcrBank)
+	if err != nil {
+		return "", err
+	}
+	cumulativeHash := make([]byte, bankHash.Size())
+	if pcrIndex == PCR0 {
+		cumulativeHash[len(cumulativeHash)-1] = eventLog.StartupLocality
+	}
+
+	for i, event := range eventLog.Events {
+		if event.PcrIndex != pcrIndex || event.EventType == EV_NO_ACTION {
+			continue
+		}
+		digest, err := hex.DecodeString(event.Digests[pcrBank])
+		if err != nil || len(digest) != bankHash.Size() {
+			return "", errors.Errorf("The %s event %d of PCR %d does not have a valid %s digest",
+				event.GetEventTypeName(), i, pcrIndex, pcrBank)
+		}
+		bankHash.Reset()
+		bankHash.Write(cumulativeHash)
+		bankHash.Write(digest)
+		cumulativeHash = bankHash.Sum(nil)
+	}
+	return hex.EncodeToString(cumulativeHash), nil
+}
+
+// formatEfiGuid formats an EFI_GUID, whose first three fields are little endian
+func formatEfiGuid(guid [16]byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x", binary.LittleEndian.Uint32(guid[0:4]),
+		binary.LittleEndian.Uint16(guid[4:6]), binary.LittleEndian.Uint16(guid[6:8]), guid[8:10], guid[10:])
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Names of the UEFI Secure Boot variables that the firmware measures to PCR 7 in EV_EFI_VARIABLE_DRIVER_CONFIG events
+const (
+	SecureBootVariable = "SecureBoot"
+	PkVariable         = "PK"
+	KekVariable        = "KEK"
+	DbVariable         = "db"
+	DbxVariable        = "dbx"
+)
+
+// GUIDs of the UEFI Secure Boot variables and of the EFI_SIGNATURE_LIST signature types
+const (
+	EfiGlobalVariableGuid        = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+	EfiImageSecurityDatabaseGuid = "d719b2cb-3d3a-4596-a3bc-dad00e67656f"
+	EfiCertSha256Guid            = "c1c41626-504c-4092-aca9-41f936934328"
+	EfiCertX509Guid              = "a5c059a1-94e4-4aa7-87b5-ab155c2bf072"
+)
+
+// secureBootVariableGuids are the vendor GUIDs of the Secure Boot variables, by name
+var secureBootVariableGuids = map[string]string{
+	SecureBootVariable: EfiGlobalVariableGuid,
+	PkVariable:         EfiGlobalVariableGuid,
+	KekVariable:        EfiGlobalVariableGuid,
+	DbVariable:         EfiImageSecurityDatabaseGuid,
+	DbxVariable:        EfiImageSecurityDatabaseGuid,
+}
+
+// EfiSignatureData is an EFI_SIGNATURE_DATA of an EFI_SIGNATURE_LIST, with the signature type of its list
+type EfiSignatureData struct {
+	SignatureType  string `json:"signature_type"`
+	SignatureOwner string `json:"signature_owner"`
+	Data           []byte `json:"data"`
+}
+
+// ParseEfiSignatureLists decodes the EFI_SIGNATURE_LISTs of the data of a PK, KEK, db or dbx variable
+func ParseEfiSignatureLists(variableData []byte) ([]EfiSignatureData, error) {
+	var signatures []EfiSignatureData
+	reader := bytes.NewReader(variableData)
+	for reader.Len() > 0 {
+		var header struct {
+			SignatureType       [16]byte
+			SignatureListSize   uint32
+			SignatureHeaderSize uint32
+			SignatureSize       uint32
+		}
+		listOffset := len(variableData) - reader.Len()
+		if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+			return nil, errors.Wrapf(err, "Error reading the header of the signature list at offset %d", listOffset)
+		}
+
+		headerSize := uint32(binary.Size(header))
+		if header.SignatureListSize < headerSize+header.SignatureHeaderSize ||
+			uint64(header.SignatureListSize-headerSize) > uint64(reader.Len()) {
+			return nil, errors.Errorf("Invalid size %d of the signature list at offset %d", header.SignatureListSize,
+				listOffset)
+		}
+		signaturesSize := header.SignatureListSize - headerSize - header.SignatureHeaderSize
+		if header.SignatureSize <= 16 || signaturesSize%header.SignatureSize != 0 {
+			return nil, errors.Errorf("Invalid size %d of the signatures of the list at offset %d", header.SignatureSize,
+				listOffset)
+		}
+
+		// the signature header is not defined for the standard signature types
+		if _, err := reader.Seek(int64(header.SignatureHeaderSize), io.SeekCurrent); err != nil {
+			return nil, errors.Wrapf(err, "Error skipping the header of the signature list at offset %d", listOffset)
+		}
+
+		signatureType := formatEfiGuid(header.SignatureType)
+		for i := uint32(0); i < signaturesSize/header.SignatureSize; i++ {
+			var signatureOwner [16]byte
+			signatureData := make([]byte, header.SignatureSize-16)
+			if err := binary.Read(reader, binary.LittleEndian, &signatureOwner); err != nil {
+				return nil, errors.Wrapf(err, "Error reading signature %d of the list at offset %d", i, listOffset)
+			}
+			if _, err := reader.Read(signatureData); err != nil {
+				return nil, errors.Wrapf(err, "Error reading signature %d of the list at offset %d", i, listOffset)
+			}
+			signatures = append(signatures, EfiSignatureData{
+				SignatureType:  signatureType,
+				SignatureOwner: formatEfiGuid(signatureOwner),
+				Data:           signatureData,
+			})
+		}
+	}
+	return signatures, nil
+}
+
+// GetSecureBootVariables returns the UEFI Secure Boot variables measured to PCR 7 before the separator, by name.
+// The data of each variable must match the digests of its event, so that a log that replays to PCR 7 cannot carry
+// different variable values. An error is returned when a variable is measured more than once.
+func (eventLog *TcgEventLog) GetSecureBootVariables() (map[string]*EfiVariableData, error) {
+	variables := map[string]*EfiVariableData{}
+	for i, event := range eventLog.Events {
+		if event.PcrIndex != PCR7 {
+			continue
+		}
+		if event.EventType == EV_SEPARATOR {
+			break
+		}
+		if event.EventType != EV_EFI_VARIABLE_DRIVER_CONFIG {
+			continue
+		}
+
+		variableData, err := event.GetEfiVariableData()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error decoding the %s event %d", event.GetEventTypeName(), i)
+		}
+		if guid, ok := secureBootVariableGuids[variableData.UnicodeName]; !ok || guid != variableData.VariableName {
+			continue
+		}
+		if _, ok := variables[variableData.UnicodeName]; ok {
+			return nil, errors.Errorf("The Secure Boot variable %s is measured more than once", variableData.UnicodeName)
+		}
+		if err := event.VerifyDigests(); err != nil {
+			return nil, errors.Wrapf(err, "The Secure Boot variable %s does not match its measurement",
+				variableData.UnicodeName)
+		}
+		variables[variableData.UnicodeName] = variableData
+	}
+	return variables, nil
+}
@@ -173,7 +173,7 @@ func VerifyQuoteAndGetPCRManifest(decodedEventLog string, verificationNonce []by
 			"AIK Quote verification failed, No PCR values included in quote")
 	}
 	pcrs := tpmtSig[pos : pos+pcrLen]
-	pcrConcatLen := SHA256_SIZE * 24 * 3
+	pcrConcatLen := SHA512_SIZE * 24 * MAX_PCR_BANKS
 	pcrPos := 0
 	count := 0
 	var pcrConcat []byte
@@ -203,11 +203,11 @@ func VerifyQuoteAndGetPCRManifest(decodedEventLog string, verificationNonce []by
 				}
 				if hashAlg == TPM_API_ALG_ID_SHA1 {
 					buffer.WriteString(fmt.Sprintf("%2d ", pcr))
-				} else if hashAlg == TPM_API_ALG_ID_SHA256 {
-					buffer.WriteString(fmt.Sprintf("%2d_SHA256 ", pcr))
+				} else if pcrBank, ok := tcgAlgorithms[hashAlg]; ok {
+					buffer.WriteString(fmt.Sprintf("%2d_%s ", pcr, pcrBank))
 				}
-				//Ignore the pcr banks other than SHA1 and SHA256
-				if hashAlg == TPM_API_ALG_ID_SHA1 || hashAlg == TPM_API_ALG_ID_SHA256 {
+				//Ignore the pcr banks other than SHA1, SHA256, SHA384 and SHA512
+				if _, ok := tcgAlgorithms[hashAlg]; ok {
 					for i := 0; i < pcrSize; i++ {
 						buffer.WriteString(fmt.Sprintf("%02x", pcrs[pcrPos+i]))
 					}
@@ -265,6 +265,34 @@ func GetVerificationNonce(nonce []byte, quoteResponse taModel.TpmQuoteResponse)
 	return base64.StdEncoding.EncodeToString(taNonce), nil
 }
 
+// GetQuoteClockInfo returns the TPMS_CLOCK_INFO of the TPMS_ATTEST of a quote, which follows the qualified signer and
+// the extra data. The quote should be verified with VerifyQuoteAndGetPCRManifest, so that the clock info is signed.
+func GetQuoteClockInfo(tpmQuoteInBytes []byte) (*types.TpmClockInfo, error) {
+	log.Trace("util/aik_quote_verifier:GetQuoteClockInfo() Entering")
+	defer log.Trace("util/aik_quote_verifier:GetQuoteClockInfo() Leaving")
+
+	// skip over the size of the quote info, the magic and the type of the TPMS_ATTEST
+	index := 8
+	for i := 0; i < 2; i++ {
+		// skip over the TPM2B_NAME of the signer, then the TPM2B_DATA of the nonce
+		if len(tpmQuoteInBytes) < index+2 {
+			return nil, errors.New("util/aik_quote_verifier:GetQuoteClockInfo() The quote is too short")
+		}
+		index += 2 + int(binary.BigEndian.Uint16(tpmQuoteInBytes[index:index+2]))
+	}
+
+	if len(tpmQuoteInBytes) < index+17 {
+		return nil, errors.New("util/aik_quote_verifier:GetQuoteClockInfo() The quote is too short for the " +
+			"clock info")
+	}
+	return &types.TpmClockInfo{
+		Clock:        binary.BigEndian.Uint64(tpmQuoteInBytes[index : index+8]),
+		ResetCount:   binary.BigEndian.Uint32(tpmQuoteInBytes[index+8 : index+12]),
+		RestartCount: binary.BigEndian.Uint32(tpmQuoteInBytes[index+12 : index+16]),
+		Safe:         tpmQuoteInBytes[index+16] == 1,
+	}, nil
+}
+
 func createPCRManifest(pcrList []string, eventLog string) (types.PcrManifest, error) {
 
 	log.Trace("util/aik_quote_verifier:createPCRManifest() Entering")
@@ -305,20 +333,14 @@ func createPCRManifest(pcrList []string, eventLog string) (types.PcrManifest, er
 					return pcrManifest, err
 				}
 
-				if strings.EqualFold(pcrBank, "SHA256") {
-					pcrManifest.Sha256Pcrs = append(pcrManifest.Sha256Pcrs, types.Pcr{
-						DigestType: fmt.Sprintf(constants.PcrClassNamePrefix+"%d", 256),
-						Index:      pcrIndex,
-						Value:      pcrValue,
-						PcrBank:    shaAlgorithm,
-					})
-				} else if strings.EqualFold(pcrBank, "SHA1") {
-					pcrManifest.Sha1Pcrs = append(pcrManifest.Sha1Pcrs, types.Pcr{
-						DigestType: fmt.Sprintf(constants.PcrClassNamePrefix+"%d", 1),
-						Index:   pcrIndex,
-						Value:   pcrValue,
-						PcrBank: shaAlgorithm,
-					})
+				err = pcrManifest.AddPcr(types.Pcr{
+					DigestType: constants.PcrClassNamePrefix + shaAlgorithm.GetDigestTypeSuffix(),
+					Index:      pcrIndex,
+					Value:      pcrValue,
+					PcrBank:    shaAlgorithm,
+				})
+				if err != nil {
+					return pcrManifest, err
 				}
 			} else {
 				log.Warn("util/aik_quote_verifier:createPCRManifest() Result PCR invalid")
@@ -355,48 +377,21 @@ func addPcrEntry(module types.Module, eventLogMap types.PcrEventLogMap) types.Pc
 
 	log.Trace("util/aik_quote_verifier:addPcrEntry() Entering")
 	defer log.Trace("util/aik_quote_verifier:addPcrEntry() Leaving")
-	pcrFound := false
-	index := 0
-	switch module.PcrBank {
-	case SHA1:
-		for _, entry := range eventLogMap.Sha1EventLogs {
-			if entry.PcrIndex == module.PcrNumber {
-				pcrFound = true
-				break
-			}
-			index++
-		}
-		eventLog := types.EventLog{DigestType: EVENT_LOG_DIGEST_SHA1,
-			Value: module.Value, Label: module.Name}
-		eventLog.Info = make(map[string]string)
-		eventLog.Info["ComponentName"] = module.Name
-		eventLog.Info["EventName"] = EVENT_NAME
-		if !pcrFound {
-			eventLogMap.Sha1EventLogs = append(eventLogMap.Sha1EventLogs, types.EventLogEntry{PcrIndex:
-			module.PcrNumber, PcrBank: SHA1, EventLogs: []types.EventLog{eventLog}})
-		} else {
-			eventLogMap.Sha1EventLogs[index].EventLogs = append(eventLogMap.Sha1EventLogs[index].EventLogs, eventLog)
-		}
-	case SHA256:
-		for _, entry := range eventLogMap.Sha256EventLogs {
-			if entry.PcrIndex == module.PcrNumber {
-				pcrFound = true
-				break
-			}
-			index++
-		}
-		eventLog := types.EventLog{DigestType: EVENT_LOG_DIGEST_SHA256,
-			Value: module.Value, Label: module.Name}
-		eventLog.Info = make(map[string]string)
-		eventLog.Info["ComponentName"] = module.Name
-		eventLog.Info["EventName"] = EVENT_NAME
-		if !pcrFound {
-			eventLogMap.Sha256EventLogs = append(eventLogMap.Sha256EventLogs, types.EventLogEntry{PcrIndex:
-			module.PcrNumber, PcrBank: SHA256, EventLogs: []types.EventLog{eventLog}})
-		} else {
-			eventLogMap.Sha256EventLogs[index].EventLogs = append(eventLogMap.Sha256EventLogs[index].EventLogs, eventLog)
-		}
+	pcrBank, err := types.GetSHAAlgorithm(module.PcrBank)
+	if err != nil {
+		log.Warnf("util/aik_quote_verifier:addPcrEntry() Ignoring module %s of unsupported PCR bank %s",
+			module.Name, module.PcrBank)
+		return eventLogMap
 	}
+
+	eventLog := types.EventLog{DigestType: constants.MeasurementTypeClassNamePrefix + pcrBank.GetDigestTypeSuffix(),
+		Value: module.Value, Label: module.Name}
+	eventLog.Info = make(map[string]string)
+	eventLog.Info["ComponentName"] = module.Name
+	eventLog.Info["EventName"] = EVENT_NAME
+	// the bank is supported by the event log map, since it is a SHA algorithm
+	_ = eventLogMap.AddEventLog(pcrBank, module.PcrNumber, eventLog)
+
 	log.Debugf("util/aik_quote_verifier:addPcrEntry() Successfully added PCR log entries for module : %s", module.Name)
 	return eventLogMap
 }
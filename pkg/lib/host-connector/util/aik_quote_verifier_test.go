@@ -42,6 +42,13 @@ func TestVerifyQuoteAndGetPCRManifest(t *testing.T) {
 
 	_, err = VerifyQuoteAndGetPCRManifest(string(decodedEventLogBytes), verificationNonceInBytes, tpmQuoteInBytes, aikCertificate)
 	assert.NoError(t, err)
+
+	clockInfo, err := GetQuoteClockInfo(tpmQuoteInBytes)
+	assert.NoError(t, err)
+	assert.NotZero(t, clockInfo.Clock)
+
+	_, err = GetQuoteClockInfo(tpmQuoteInBytes[:40])
+	assert.Error(t, err)
 }
 
 func TestVerifyQuoteAndGetPCRManifestInvalidNonce(t *testing.T) {
@@ -64,12 +64,8 @@ func GetVendorPrefix(connectionString string) constants.Vendor {
 
 	log.Trace("util/connection_string:GetVendorPrefix() Entering")
 	defer log.Trace("util/connection_string:GetVendorPrefix() Leaving")
-	if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorIntel.String()+":")) {
-		return constants.VendorIntel
-	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorVMware.String()+":")) {
-		return constants.VendorVMware
-	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorMicrosoft.String()+":")) {
-		return constants.VendorMicrosoft
+	if prefixEndIndex := strings.Index(connectionString, ":"); prefixEndIndex != -1 {
+		return constants.GetVendorByName(connectionString[:prefixEndIndex])
 	}
 	return constants.VendorUnknown
 }
@@ -17,6 +17,7 @@ func TestGetConnectorDetails(t *testing.T) {
 	sampleUrl3 := "vmware:https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password"
 	sampleUrl4 := "https://vsphere.com:443/sdk;h=hostName;u=admin.local;p=password"
 	sampleUrl5 := "microsoft:https://microsoft.com:1443;u=admin.local;p=password"
+	sampleUrl6 := "simulated:https://sim-0001:1443?fault=PCR_DRIFT;u=admin;p=password"
 
 	invalidUrl := "https:// abcde"
 
@@ -40,6 +41,11 @@ func TestGetConnectorDetails(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, constants.VendorMicrosoft, connectorDetails.Vendor)
 
+	connectorDetails, err = GetConnectorDetails(sampleUrl6)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.VendorSimulated, connectorDetails.Vendor)
+	assert.Equal(t, "https://sim-0001:1443?fault=PCR_DRIFT", connectorDetails.Url)
+
 	connectorDetails, err = GetConnectorDetails(invalidUrl)
 	assert.Error(t, err)
 }
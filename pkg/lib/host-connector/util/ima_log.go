@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// imaSha1DigestAlgorithm is the algorithm of the file digests of the ima template
+const imaSha1DigestAlgorithm = "sha1"
+
+// ParseImaAsciiLog parses the ascii runtime measurement list of the kernel IMA. Each line has the PCR, the template
+// hash, the template name, then the fields of the template:
+//   ima:     <file digest> <file name>
+//   ima-ng:  <algorithm>:<file digest> <file name>
+//   ima-sig: <algorithm>:<file digest> <file name> [<file signature>]
+//   ima-buf: <algorithm>:<buffer digest> <buffer name> <buffer>
+func ParseImaAsciiLog(imaLog string) (*types.ImaLog, error) {
+	log.Trace("util/ima_log:ParseImaAsciiLog() Entering")
+	defer log.Trace("util/ima_log:ParseImaAsciiLog() Leaving")
+
+	parsedLog := types.ImaLog{Measurements: []types.ImaMeasurement{}}
+	scanner := bufio.NewScanner(strings.NewReader(imaLog))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		measurement, err := parseImaAsciiLine(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing line %d of the IMA log", lineNumber)
+		}
+		parsedLog.Measurements = append(parsedLog.Measurements, measurement)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "Error reading the IMA log")
+	}
+	return &parsedLog, nil
+}
+
+func parseImaAsciiLine(line string) (types.ImaMeasurement, error) {
+	var measurement types.ImaMeasurement
+	fields := strings.SplitN(line, " ", 5)
+	if len(fields) != 5 {
+		return measurement, errors.New("The measurement does not have the PCR, template hash, template name, digest " +
+			"and file name")
+	}
+
+	pcrIndex, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return measurement, errors.Errorf("Invalid PCR '%s'", fields[0])
+	}
+	measurement.PcrIndex = types.PcrIndex(pcrIndex)
+	measurement.TemplateHash = strings.ToLower(fields[1])
+	measurement.TemplateName = fields[2]
+
+	if measurement.TemplateName == types.ImaTemplateIma {
+		measurement.FileDigestAlgorithm = imaSha1DigestAlgorithm
+		measurement.FileDigest = strings.ToLower(fields[3])
+	} else {
+		digestParts := strings.SplitN(fields[3], ":", 2)
+		if len(digestParts) != 2 {
+			return measurement, errors.Errorf("Invalid file digest '%s'", fields[3])
+		}
+		measurement.FileDigestAlgorithm = strings.ToLower(digestParts[0])
+		measurement.FileDigest = strings.ToLower(digestParts[1])
+	}
+	if _, err := hex.DecodeString(measurement.FileDigest); err != nil {
+		return measurement, errors.Errorf("Invalid file digest '%s'", fields[3])
+	}
+
+	// the file names can contain spaces, the signature or buffer is the last field
+	measurement.Path = fields[4]
+	switch measurement.TemplateName {
+	case types.ImaTemplateImaSig, types.ImaTemplateImaBuf:
+		if separator := strings.LastIndex(fields[4], " "); separator > 0 && isHex(fields[4][separator+1:]) {
+			measurement.Path = fields[4][:separator]
+			if measurement.TemplateName == types.ImaTemplateImaSig {
+				measurement.Signature = strings.ToLower(fields[4][separator+1:])
+			} else {
+				measurement.Buffer = strings.ToLower(fields[4][separator+1:])
+			}
+		}
+	}
+	return measurement, nil
+}
+
+// ParseImaBinaryLog parses the binary runtime measurement list of the kernel IMA. Each entry has the PCR, the SHA1
+// template hash, the size and name of the template, then the size of the template data and the fields of the
+// template data, each prefixed by its size. The entries of the ima template are not supported
+func ParseImaBinaryLog(imaLog []byte) (*types.ImaLog, error) {
+	log.Trace("util/ima_log:ParseImaBinaryLog() Entering")
+	defer log.Trace("util/ima_log:ParseImaBinaryLog() Leaving")
+
+	parsedLog := types.ImaLog{Measurements: []types.ImaMeasurement{}}
+	reader := bytes.NewReader(imaLog)
+	for reader.Len() > 0 {
+		measurement, err := readImaBinaryEntry(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading entry %d of the IMA log", len(parsedLog.Measurements)+1)
+		}
+		parsedLog.Measurements = append(parsedLog.Measurements, measurement)
+	}
+	return &parsedLog, nil
+}
+
+func readImaBinaryEntry(reader *bytes.Reader) (types.ImaMeasurement, error) {
+	var measurement types.ImaMeasurement
+	var header struct {
+		PcrIndex     uint32
+		TemplateHash [SHA1_SIZE]byte
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return measurement, errors.Wrap(err, "Error reading the entry header")
+	}
+	measurement.PcrIndex = types.PcrIndex(header.PcrIndex)
+	measurement.TemplateHash = hex.EncodeToString(header.TemplateHash[:])
+
+	templateName, err := readImaField(reader)
+	if err != nil {
+		return measurement, errors.Wrap(err, "Error reading the template name")
+	}
+	measurement.TemplateName = string(templateName)
+	if measurement.TemplateName == types.ImaTemplateIma {
+		return measurement, errors.New("The ima template is not supported in the binary IMA log")
+	}
+
+	templateData, err := readImaField(reader)
+	if err != nil {
+		return measurement, errors.Wrap(err, "Error reading the template data")
+	}
+	var fields [][]byte
+	templateReader := bytes.NewReader(templateData)
+	for templateReader.Len() > 0 {
+		field, err := readImaField(templateReader)
+		if err != nil {
+			return measurement, errors.Wrap(err, "Error reading the template fields")
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) < 2 {
+		return measurement, errors.New("The template data does not have the digest and file name")
+	}
+
+	digestParts := bytes.SplitN(fields[0], []byte(":\x00"), 2)
+	if len(digestParts) != 2 {
+		return measurement, errors.New("Invalid file digest")
+	}
+	measurement.FileDigestAlgorithm = string(digestParts[0])
+	measurement.FileDigest = hex.EncodeToString(digestParts[1])
+	measurement.Path = string(bytes.TrimRight(fields[1], "\x00"))
+	if len(fields) > 2 {
+		switch measurement.TemplateName {
+		case types.ImaTemplateImaSig:
+			measurement.Signature = hex.EncodeToString(fields[2])
+		case types.ImaTemplateImaBuf:
+			measurement.Buffer = hex.EncodeToString(fields[2])
+		}
+	}
+	return measurement, nil
+}
+
+func readImaField(reader *bytes.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(reader, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+	if int64(size) > int64(reader.Len()) {
+		return nil, errors.Errorf("The field size %d exceeds the IMA log", size)
+	}
+	field := make([]byte, size)
+	if _, err := reader.Read(field); err != nil && size > 0 {
+		return nil, err
+	}
+	return field, nil
+}
+
+func isHex(value string) bool {
+	if value == "" || len(value)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
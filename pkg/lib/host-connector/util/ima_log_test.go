@@ -0,0 +1,163 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testImaMeasurements = []types.ImaMeasurement{
+	{
+		PcrIndex:            types.PCR10,
+		TemplateName:        types.ImaTemplateImaNg,
+		FileDigestAlgorithm: "sha256",
+		FileDigest:          strings.Repeat("ab", 32),
+		Path:                "boot_aggregate",
+	},
+	{
+		PcrIndex:            types.PCR10,
+		TemplateName:        types.ImaTemplateImaNg,
+		FileDigestAlgorithm: "sha256",
+		FileDigest:          strings.Repeat("cd", 32),
+		Path:                "/usr/lib/systemd/my service",
+	},
+	{
+		PcrIndex:            types.PCR10,
+		TemplateName:        types.ImaTemplateImaSig,
+		FileDigestAlgorithm: "sha256",
+		FileDigest:          strings.Repeat("ef", 32),
+		Path:                "/usr/bin/bash",
+		Signature:           "030204aabbccdd",
+	},
+	{
+		PcrIndex:            types.PCR10,
+		TemplateName:        types.ImaTemplateImaBuf,
+		FileDigestAlgorithm: "sha256",
+		FileDigest:          strings.Repeat("01", 32),
+		Path:                "kexec-cmdline",
+		Buffer:              "726f6f743d2f6465762f736461",
+	},
+}
+
+// getTestImaLog returns the measurements with their template hashes, as the ascii and the binary IMA logs
+func getTestImaLog(t *testing.T) ([]types.ImaMeasurement, string, []byte) {
+	var measurements []types.ImaMeasurement
+	var asciiLog strings.Builder
+	binaryLog := new(bytes.Buffer)
+
+	for _, measurement := range testImaMeasurements {
+		templateData, err := measurement.GetTemplateData()
+		assert.NoError(t, err)
+		templateHash := sha1.Sum(templateData)
+		measurement.TemplateHash = hex.EncodeToString(templateHash[:])
+		measurements = append(measurements, measurement)
+
+		asciiLine := fmt.Sprintf("%d %s %s %s:%s %s", measurement.PcrIndex, measurement.TemplateHash,
+			measurement.TemplateName, measurement.FileDigestAlgorithm, measurement.FileDigest, measurement.Path)
+		if measurement.Signature != "" {
+			asciiLine += " " + measurement.Signature
+		}
+		if measurement.Buffer != "" {
+			asciiLine += " " + measurement.Buffer
+		}
+		asciiLog.WriteString(asciiLine + "\n")
+
+		_ = binary.Write(binaryLog, binary.LittleEndian, uint32(measurement.PcrIndex))
+		binaryLog.Write(templateHash[:])
+		_ = binary.Write(binaryLog, binary.LittleEndian, uint32(len(measurement.TemplateName)))
+		binaryLog.WriteString(measurement.TemplateName)
+		_ = binary.Write(binaryLog, binary.LittleEndian, uint32(len(templateData)))
+		binaryLog.Write(templateData)
+	}
+	return measurements, asciiLog.String(), binaryLog.Bytes()
+}
+
+func TestParseImaAsciiLog(t *testing.T) {
+	measurements, asciiLog, _ := getTestImaLog(t)
+
+	imaLog, err := ParseImaAsciiLog(asciiLog)
+	assert.NoError(t, err)
+	assert.Equal(t, measurements, imaLog.Measurements)
+
+	// the template hashes of the log are the SHA1 digests of the template data
+	for _, measurement := range imaLog.Measurements {
+		templateData, err := measurement.GetTemplateData()
+		assert.NoError(t, err)
+		templateHash := sha1.Sum(templateData)
+		assert.Equal(t, hex.EncodeToString(templateHash[:]), measurement.TemplateHash)
+	}
+}
+
+func TestParseImaAsciiLogImaTemplate(t *testing.T) {
+	imaLog, err := ParseImaAsciiLog("10 " + strings.Repeat("12", 20) + " ima " + strings.Repeat("34", 20) +
+		" /usr/bin/ls\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(imaLog.Measurements))
+	assert.Equal(t, "sha1", imaLog.Measurements[0].FileDigestAlgorithm)
+	assert.Equal(t, strings.Repeat("34", 20), imaLog.Measurements[0].FileDigest)
+	assert.Equal(t, "/usr/bin/ls", imaLog.Measurements[0].Path)
+}
+
+func TestParseImaAsciiLogInvalid(t *testing.T) {
+	_, err := ParseImaAsciiLog("10 " + strings.Repeat("12", 20) + " ima-ng sha256:xyz /usr/bin/ls\n")
+	assert.Error(t, err)
+
+	_, err = ParseImaAsciiLog("10 " + strings.Repeat("12", 20) + " ima-ng\n")
+	assert.Error(t, err)
+}
+
+func TestParseImaBinaryLog(t *testing.T) {
+	measurements, _, binaryLog := getTestImaLog(t)
+
+	imaLog, err := ParseImaBinaryLog(binaryLog)
+	assert.NoError(t, err)
+	assert.Equal(t, measurements, imaLog.Measurements)
+
+	_, err = ParseImaBinaryLog(binaryLog[:len(binaryLog)-1])
+	assert.Error(t, err)
+}
+
+func TestImaLogReplay(t *testing.T) {
+	measurements, _, _ := getTestImaLog(t)
+	imaLog := types.ImaLog{Measurements: measurements}
+
+	// the SHA1 bank is extended with the template hashes
+	expectedSha1 := make([]byte, sha1.Size)
+	for _, measurement := range measurements {
+		templateHash, _ := hex.DecodeString(measurement.TemplateHash)
+		digest := sha1.Sum(append(expectedSha1, templateHash...))
+		expectedSha1 = digest[:]
+	}
+	replayedSha1, err := imaLog.Replay(types.SHA1)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expectedSha1), replayedSha1)
+
+	replayedSha256, err := imaLog.Replay(types.SHA256)
+	assert.NoError(t, err)
+	assert.Equal(t, 64, len(replayedSha256))
+
+	// a file digest that does not match the template hash of the measurement fails the replay of every bank
+	imaLog.Measurements[1].FileDigest = strings.Repeat("00", 32)
+	_, err = imaLog.Replay(types.SHA1)
+	assert.Error(t, err)
+	_, err = imaLog.Replay(types.SHA256)
+	assert.Error(t, err)
+
+	// the measurement violations have a zero template hash and no template data to check
+	imaLog.Measurements[1] = types.ImaMeasurement{PcrIndex: types.PCR10, TemplateHash: strings.Repeat("00", 20),
+		TemplateName: types.ImaTemplateImaNg, Path: "/usr/bin/violation"}
+	_, err = imaLog.Replay(types.SHA1)
+	assert.NoError(t, err)
+}
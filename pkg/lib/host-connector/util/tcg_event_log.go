@@ -0,0 +1,177 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// tcgSpecIdEventSignature is the signature of the TCG_EfiSpecIdEvent of the crypto agile event logs
+var tcgSpecIdEventSignature = []byte("Spec ID Event03\x00")
+
+// tcgStartupLocalitySignature is the signature of the EV_NO_ACTION event that reports the locality of the
+// TPM2_Startup
+var tcgStartupLocalitySignature = []byte("StartupLocality\x00")
+
+// tcgAlgorithms are the PCR banks of the TPM algorithm ids of the event log digests
+var tcgAlgorithms = map[uint16]types.SHAAlgorithm{
+	TPM_API_ALG_ID_SHA1:   types.SHA1,
+	TPM_API_ALG_ID_SHA256: types.SHA256,
+	TPM_API_ALG_ID_SHA384: types.SHA384,
+	TPM_API_ALG_ID_SHA512: types.SHA512,
+}
+
+// ParseTcgEventLog parses a binary TCG PC Client crypto agile event log. The first event has the SHA1 format and
+// contains the TCG_EfiSpecIdEvent, which lists the digest sizes of the algorithms used by the TCG_PCR_EVENT2 events
+// that follow it. The digests of the algorithms that are not a PCR bank of the host manifest, like SM3, are skipped.
+func ParseTcgEventLog(eventLog []byte) (*types.TcgEventLog, error) {
+	log.Trace("util/tcg_event_log:ParseTcgEventLog() Entering")
+	defer log.Trace("util/tcg_event_log:ParseTcgEventLog() Leaving")
+
+	reader := bytes.NewReader(eventLog)
+
+	// TCG_PCR_EVENT: PCR index, event type, SHA1 digest, event size and event data
+	var header struct {
+		PcrIndex  uint32
+		EventType uint32
+		Digest    [SHA1_SIZE]byte
+		EventSize uint32
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "Error reading the header of the event log")
+	}
+	if int64(header.EventSize) > int64(reader.Len()) {
+		return nil, errors.Errorf("The size %d of the first event exceeds the event log", header.EventSize)
+	}
+	specIdEvent := make([]byte, header.EventSize)
+	if _, err := io.ReadFull(reader, specIdEvent); err != nil || header.EventType != types.EV_NO_ACTION ||
+		!bytes.HasPrefix(specIdEvent, tcgSpecIdEventSignature) {
+		return nil, errors.New("The event log is not a crypto agile event log")
+	}
+	digestSizes, err := getTcgDigestSizes(specIdEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	tcgEventLog := types.TcgEventLog{}
+	for reader.Len() > 0 {
+		event, err := readTcgEvent(reader, digestSizes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading event %d of the event log", len(tcgEventLog.Events)+1)
+		}
+		if event.EventType == types.EV_NO_ACTION && event.PcrIndex == types.PCR0 &&
+			len(event.Data) == len(tcgStartupLocalitySignature)+1 &&
+			bytes.HasPrefix(event.Data, tcgStartupLocalitySignature) {
+			tcgEventLog.StartupLocality = event.Data[len(tcgStartupLocalitySignature)]
+		}
+		tcgEventLog.Events = append(tcgEventLog.Events, event)
+	}
+	return &tcgEventLog, nil
+}
+
+// getTcgDigestSizes returns the digest sizes listed in a TCG_EfiSpecIdEvent, by TPM algorithm id
+func getTcgDigestSizes(specIdEvent []byte) (map[uint16]uint16, error) {
+	reader := bytes.NewReader(specIdEvent[len(tcgSpecIdEventSignature):])
+
+	// platform class, spec version minor, major and errata, uintn size, then the number of algorithms
+	var specIdHeader struct {
+		PlatformClass      uint32
+		SpecVersion        [3]uint8
+		UintnSize          uint8
+		NumberOfAlgorithms uint32
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &specIdHeader); err != nil {
+		return nil, errors.Wrap(err, "Error reading the spec id event of the event log")
+	}
+
+	digestSizes := make(map[uint16]uint16)
+	for i := uint32(0); i < specIdHeader.NumberOfAlgorithms; i++ {
+		var algorithm struct {
+			AlgorithmId uint16
+			DigestSize  uint16
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &algorithm); err != nil {
+			return nil, errors.Wrap(err, "Error reading the algorithms of the spec id event of the event log")
+		}
+		digestSizes[algorithm.AlgorithmId] = algorithm.DigestSize
+	}
+	return digestSizes, nil
+}
+
+// readTcgEvent reads a TCG_PCR_EVENT2: PCR index, event type, TPML_DIGEST_VALUES, event size and event data
+func readTcgEvent(reader *bytes.Reader, digestSizes map[uint16]uint16) (types.TcgEvent, error) {
+	var header struct {
+		PcrIndex    uint32
+		EventType   uint32
+		DigestCount uint32
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return types.TcgEvent{}, errors.Wrap(err, "Error reading the event header")
+	}
+
+	event := types.TcgEvent{
+		PcrIndex:  types.PcrIndex(header.PcrIndex),
+		EventType: header.EventType,
+		Digests:   make(map[types.SHAAlgorithm]string),
+	}
+	for i := uint32(0); i < header.DigestCount; i++ {
+		var algorithmId uint16
+		if err := binary.Read(reader, binary.LittleEndian, &algorithmId); err != nil {
+			return types.TcgEvent{}, errors.Wrap(err, "Error reading the digest algorithm")
+		}
+		digestSize, ok := digestSizes[algorithmId]
+		if !ok {
+			return types.TcgEvent{}, errors.Errorf("The digest algorithm %d is not listed in the spec id event", algorithmId)
+		}
+		digest := make([]byte, digestSize)
+		if _, err := io.ReadFull(reader, digest); err != nil {
+			return types.TcgEvent{}, errors.New("Error reading the digest")
+		}
+		if pcrBank, ok := tcgAlgorithms[algorithmId]; ok {
+			event.Digests[pcrBank] = hex.EncodeToString(digest)
+		}
+	}
+
+	var eventSize uint32
+	if err := binary.Read(reader, binary.LittleEndian, &eventSize); err != nil {
+		return types.TcgEvent{}, errors.Wrap(err, "Error reading the event size")
+	}
+	if int64(eventSize) > int64(reader.Len()) {
+		return types.TcgEvent{}, errors.Errorf("The event size %d exceeds the event log", eventSize)
+	}
+	event.Data = make([]byte, eventSize)
+	if _, err := io.ReadFull(reader, event.Data); err != nil {
+		return types.TcgEvent{}, errors.Wrap(err, "Error reading the event data")
+	}
+	return event, nil
+}
+
+// GetTcgMeasureLog returns the measure log of the digests of the events that are extended to the PCRs, in all the
+// supported PCR banks. The modules are named after the event types.
+func GetTcgMeasureLog(eventLog *types.TcgEventLog) types.MeasureLog {
+	var measureLog types.MeasureLog
+	for _, event := range eventLog.Events {
+		if event.EventType == types.EV_NO_ACTION {
+			continue
+		}
+		for _, pcrBank := range types.SupportedPcrBanks {
+			if digest, ok := event.Digests[pcrBank]; ok {
+				measureLog.Txt.Modules.Module = append(measureLog.Txt.Modules.Module, types.Module{
+					PcrBank:   string(pcrBank),
+					PcrNumber: event.PcrIndex,
+					Name:      event.GetEventTypeName(),
+					Value:     digest,
+				})
+			}
+		}
+	}
+	return measureLog
+}
@@ -0,0 +1,201 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package util
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTcgAlgorithms are the algorithms of the test event log, SM3 is not a PCR bank of the host manifest
+var testTcgAlgorithms = []struct {
+	algorithmId uint16
+	hash        crypto.Hash
+	pcrBank     types.SHAAlgorithm
+}{
+	{TPM_API_ALG_ID_SHA1, crypto.SHA1, types.SHA1},
+	{TPM_API_ALG_ID_SHA256, crypto.SHA256, types.SHA256},
+	{TPM_API_ALG_ID_SHA384, crypto.SHA384, types.SHA384},
+	{TPM_API_ALG_ID_SHA512, crypto.SHA512, types.SHA512},
+	{TPM_API_ALG_ID_SM3_SHA256, crypto.SHA256, ""},
+}
+
+// efiGlobalVariable is the EFI_GLOBAL_VARIABLE GUID 8be4df61-93ca-11d2-aa0d-00e098032b8c
+var efiGlobalVariable = []byte{0x61, 0xdf, 0xe4, 0x8b, 0xca, 0x93, 0xd2, 0x11, 0xaa, 0x0d, 0x00, 0xe0, 0x98, 0x03,
+	0x2b, 0x8c}
+
+func getTestEfiVariableData(name string, data []byte) []byte {
+	unicodeName := utf16.Encode([]rune(name))
+	variableData := new(bytes.Buffer)
+	variableData.Write(efiGlobalVariable)
+	_ = binary.Write(variableData, binary.LittleEndian, []uint64{uint64(len(unicodeName)), uint64(len(data))})
+	_ = binary.Write(variableData, binary.LittleEndian, unicodeName)
+	variableData.Write(data)
+	return variableData.Bytes()
+}
+
+func getTestEfiImageLoadEvent(devicePath []byte) []byte {
+	imageLoadEvent := new(bytes.Buffer)
+	_ = binary.Write(imageLoadEvent, binary.LittleEndian, []uint64{0x7f000000, 0x100000, 0, uint64(len(devicePath))})
+	imageLoadEvent.Write(devicePath)
+	return imageLoadEvent.Bytes()
+}
+
+var testTcgEvents = []struct {
+	pcrIndex  uint32
+	eventType uint32
+	data      []byte
+}{
+	{0, types.EV_NO_ACTION, append([]byte("StartupLocality\x00"), 3)},
+	{0, types.EV_S_CRTM_VERSION, []byte("firmware-version")},
+	{7, types.EV_EFI_VARIABLE_DRIVER_CONFIG, getTestEfiVariableData("SecureBoot", []byte{1})},
+	{7, types.EV_SEPARATOR, []byte{0, 0, 0, 0}},
+	{4, types.EV_EFI_BOOT_SERVICES_APPLICATION, getTestEfiImageLoadEvent([]byte{0x04, 0x04, 0x04, 0x00})},
+	{0, types.EV_SEPARATOR, []byte{1, 0, 0, 0}},
+}
+
+// getTestTcgEventLog returns a crypto agile event log of the test events and the PCR values it replays to, by bank
+func getTestTcgEventLog() ([]byte, map[types.SHAAlgorithm]map[uint32][]byte) {
+	pcrValues := map[types.SHAAlgorithm]map[uint32][]byte{}
+	for _, algorithm := range testTcgAlgorithms {
+		if algorithm.pcrBank != "" {
+			pcrValues[algorithm.pcrBank] = map[uint32][]byte{}
+		}
+	}
+
+	specIdEvent := new(bytes.Buffer)
+	specIdEvent.Write(tcgSpecIdEventSignature)
+	_ = binary.Write(specIdEvent, binary.LittleEndian, struct {
+		PlatformClass      uint32
+		SpecVersion        [3]uint8
+		UintnSize          uint8
+		NumberOfAlgorithms uint32
+	}{0, [3]uint8{0, 2, 0}, 2, uint32(len(testTcgAlgorithms))})
+	for _, algorithm := range testTcgAlgorithms {
+		_ = binary.Write(specIdEvent, binary.LittleEndian, []uint16{algorithm.algorithmId,
+			uint16(algorithm.hash.Size())})
+	}
+	specIdEvent.WriteByte(0)
+
+	eventLog := new(bytes.Buffer)
+	_ = binary.Write(eventLog, binary.LittleEndian, []uint32{0, types.EV_NO_ACTION})
+	eventLog.Write(make([]byte, SHA1_SIZE))
+	_ = binary.Write(eventLog, binary.LittleEndian, uint32(specIdEvent.Len()))
+	eventLog.Write(specIdEvent.Bytes())
+
+	for _, event := range testTcgEvents {
+		_ = binary.Write(eventLog, binary.LittleEndian, []uint32{event.pcrIndex, event.eventType,
+			uint32(len(testTcgAlgorithms))})
+		for _, algorithm := range testTcgAlgorithms {
+			hash := algorithm.hash.New()
+			hash.Write(event.data)
+			digest := hash.Sum(nil)
+			_ = binary.Write(eventLog, binary.LittleEndian, algorithm.algorithmId)
+			eventLog.Write(digest)
+
+			if algorithm.pcrBank == "" || event.eventType == types.EV_NO_ACTION {
+				continue
+			}
+			pcrValue, ok := pcrValues[algorithm.pcrBank][event.pcrIndex]
+			if !ok {
+				pcrValue = make([]byte, algorithm.hash.Size())
+				if event.pcrIndex == 0 {
+					pcrValue[len(pcrValue)-1] = 3
+				}
+			}
+			hash.Reset()
+			hash.Write(pcrValue)
+			hash.Write(digest)
+			pcrValues[algorithm.pcrBank][event.pcrIndex] = hash.Sum(nil)
+		}
+		_ = binary.Write(eventLog, binary.LittleEndian, uint32(len(event.data)))
+		eventLog.Write(event.data)
+	}
+	return eventLog.Bytes(), pcrValues
+}
+
+func TestParseTcgEventLog(t *testing.T) {
+	eventLogBytes, pcrValues := getTestTcgEventLog()
+
+	eventLog, err := ParseTcgEventLog(eventLogBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, len(testTcgEvents), len(eventLog.Events))
+	assert.Equal(t, uint8(3), eventLog.StartupLocality)
+	assert.Equal(t, []types.SHAAlgorithm{types.SHA1, types.SHA256, types.SHA384, types.SHA512}, eventLog.GetPcrBanks())
+	assert.Equal(t, []types.PcrIndex{types.PCR0, types.PCR4, types.PCR7}, eventLog.GetPcrIndexes())
+
+	// every PCR is replayed in every bank, including SHA384 and SHA512
+	for pcrBank, pcrs := range pcrValues {
+		for pcrIndex, pcrValue := range pcrs {
+			replayedValue, err := eventLog.Replay(pcrBank, types.PcrIndex(pcrIndex))
+			assert.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(pcrValue), replayedValue, "PCR %d of bank %s", pcrIndex, pcrBank)
+		}
+	}
+
+	// the measure log has the events of all the banks, except the EV_NO_ACTION event
+	measureLog := GetTcgMeasureLog(eventLog)
+	assert.Equal(t, len(types.SupportedPcrBanks)*(len(testTcgEvents)-1), len(measureLog.Txt.Modules.Module))
+
+	_, err = ParseTcgEventLog(eventLogBytes[:len(eventLogBytes)-1])
+	assert.Error(t, err)
+
+	// the first event is truncated, or its size exceeds the event log
+	_, err = ParseTcgEventLog(eventLogBytes[:40])
+	assert.Error(t, err)
+	oversizedEventLog := append([]byte(nil), eventLogBytes...)
+	binary.LittleEndian.PutUint32(oversizedEventLog[28:], 0xffffffff)
+	_, err = ParseTcgEventLog(oversizedEventLog)
+	assert.Error(t, err)
+}
+
+func TestTcgEventData(t *testing.T) {
+	eventLogBytes, _ := getTestTcgEventLog()
+	eventLog, err := ParseTcgEventLog(eventLogBytes)
+	assert.NoError(t, err)
+
+	variableData, err := eventLog.Events[2].GetEfiVariableData()
+	assert.NoError(t, err)
+	assert.Equal(t, "8be4df61-93ca-11d2-aa0d-00e098032b8c", variableData.VariableName)
+	assert.Equal(t, "SecureBoot", variableData.UnicodeName)
+	assert.Equal(t, []byte{1}, variableData.VariableData)
+
+	separatorValue, err := eventLog.Events[3].GetSeparatorValue()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(types.TcgSeparatorValue), separatorValue)
+	separatorValue, err = eventLog.Events[5].GetSeparatorValue()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(types.TcgSeparatorErrorValue), separatorValue)
+
+	imageLoadEvent, err := eventLog.Events[4].GetEfiImageLoadEvent()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0x7f000000), imageLoadEvent.ImageLocationInMemory)
+	assert.Equal(t, uint64(0x100000), imageLoadEvent.ImageLengthInMemory)
+	assert.Equal(t, []byte{0x04, 0x04, 0x04, 0x00}, imageLoadEvent.DevicePath)
+
+	// the typed data is only decoded for the matching event types
+	_, err = eventLog.Events[1].GetEfiVariableData()
+	assert.Error(t, err)
+	_, err = eventLog.Events[2].GetEfiImageLoadEvent()
+	assert.Error(t, err)
+	_, err = eventLog.Events[2].GetSeparatorValue()
+	assert.Error(t, err)
+
+	// truncated data
+	eventLog.Events[2].Data = eventLog.Events[2].Data[:len(eventLog.Events[2].Data)-2]
+	_, err = eventLog.Events[2].GetEfiVariableData()
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+//
+// Registry of the host connectors of the vendors of hosts, new attestation sources are added with RegisterVendor.
+//
+
+import (
+	"sync"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	"github.com/pkg/errors"
+)
+
+// VendorRegistration describes a vendor of hosts added with RegisterVendor. The rule builders of the vendor must be
+// registered with verifier.RegisterRuleBuilder, and its platform flavor with flavor.RegisterPlatformFlavor
+type VendorRegistration struct {
+	constants.VendorInfo
+	// ConnectorFactory creates the host connectors of the connection strings of the vendor
+	ConnectorFactory VendorHostConnectorFactory
+}
+
+var connectorFactories = struct {
+	sync.RWMutex
+	factories map[constants.Vendor]VendorHostConnectorFactory
+}{
+	factories: map[constants.Vendor]VendorHostConnectorFactory{
+		constants.VendorIntel:     &IntelConnectorFactory{},
+		constants.VendorMicrosoft: &IntelConnectorFactory{},
+		constants.VendorVMware:    &VmwareConnectorFactory{},
+		constants.VendorSimulated: &SimulatedConnectorFactory{},
+		constants.VendorKeylime:   &KeylimeConnectorFactory{},
+	},
+}
+
+// RegisterVendor adds a vendor of hosts and returns its Vendor value. The connection strings prefixed with the lower
+// case name of the vendor are handled by its connector factory
+func RegisterVendor(registration VendorRegistration) (constants.Vendor, error) {
+	if registration.ConnectorFactory == nil {
+		return constants.VendorUnknown, errors.Errorf("The connector factory of vendor '%s' cannot be nil",
+			registration.Name)
+	}
+
+	vendor, err := constants.RegisterVendor(registration.VendorInfo)
+	if err != nil {
+		return constants.VendorUnknown, errors.Wrap(err, "host_connector/vendor_registry:RegisterVendor() Error "+
+			"registering vendor")
+	}
+
+	connectorFactories.Lock()
+	defer connectorFactories.Unlock()
+	connectorFactories.factories[vendor] = registration.ConnectorFactory
+	return vendor, nil
+}
+
+func getConnectorFactory(vendor constants.Vendor) (VendorHostConnectorFactory, bool) {
+	connectorFactories.RLock()
+	defer connectorFactories.RUnlock()
+	connectorFactory, ok := connectorFactories.factories[vendor]
+	return connectorFactory, ok
+}
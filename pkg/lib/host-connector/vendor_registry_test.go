@@ -0,0 +1,123 @@
+/*
+ *  Copyright (C) 2020 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// redfishConnector is the host connector of a vendor added with RegisterVendor
+type redfishConnector struct {
+	vendorConnector types.VendorConnector
+}
+
+func (rc *redfishConnector) GetHostDetails() (taModel.HostInfo, error) {
+	return taModel.HostInfo{OSName: "RedfishOS", HostName: rc.vendorConnector.Configuration.Hostname}, nil
+}
+
+func (rc *redfishConnector) GetHostManifest() (types.HostManifest, error) {
+	hostInfo, err := rc.GetHostDetails()
+	return types.HostManifest{HostInfo: hostInfo}, err
+}
+
+func (rc *redfishConnector) DeployAssetTag(string, string) error {
+	return errors.New("Operation not supported")
+}
+
+func (rc *redfishConnector) DeploySoftwareManifest(taModel.Manifest) error {
+	return errors.New("Operation not supported")
+}
+
+func (rc *redfishConnector) GetMeasurementFromManifest(taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("Operation not supported")
+}
+
+func (rc *redfishConnector) GetClusterReference(string) ([]types.ClusterHost, error) {
+	return nil, errors.New("Operation not supported")
+}
+
+type redfishConnectorFactory struct{}
+
+func (rcf *redfishConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate) (HostConnector, error) {
+	return &redfishConnector{vendorConnector: vendorConnector}, nil
+}
+
+func TestRegisterVendor(t *testing.T) {
+	vendor, err := RegisterVendor(VendorRegistration{
+		VendorInfo: constants.VendorInfo{
+			Name:         "redfish",
+			OSNames:      []string{"RedfishOS"},
+			RuleBuilders: map[string]string{"": "redfish-bmc"},
+		},
+		ConnectorFactory: &redfishConnectorFactory{},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "REDFISH", vendor.String())
+
+	// the connection strings prefixed with the vendor name use the connector of the vendor
+	htcFactory := NewHostConnectorFactory("https://aas.url.com:8444/aas", nil)
+	hostConnector, err := htcFactory.NewHostConnector("redfish:https://bmc.server.com:443;h=host1;u=admin;p=password")
+	assert.NoError(t, err)
+	hostInfo, err := hostConnector.GetHostDetails()
+	assert.NoError(t, err)
+	assert.Equal(t, "host1", hostInfo.HostName)
+
+	// the vendor is determined from the OS names and marshalled by name
+	var osVendor constants.Vendor
+	assert.NoError(t, (&osVendor).GetVendorFromOSName("REDFISHOS"))
+	assert.Equal(t, vendor, osVendor)
+	vendorJson, err := json.Marshal(vendor)
+	assert.NoError(t, err)
+	var unmarshalledVendor constants.Vendor
+	assert.NoError(t, json.Unmarshal(vendorJson, &unmarshalledVendor))
+	assert.Equal(t, vendor, unmarshalledVendor)
+
+	ruleBuilder, err := vendor.GetRuleBuilder("2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "redfish-bmc", ruleBuilder)
+
+	// vendor names and OS names are unique
+	_, err = RegisterVendor(VendorRegistration{
+		VendorInfo:       constants.VendorInfo{Name: "Redfish"},
+		ConnectorFactory: &redfishConnectorFactory{},
+	})
+	assert.Error(t, err)
+	_, err = RegisterVendor(VendorRegistration{
+		VendorInfo:       constants.VendorInfo{Name: "rhel", OSNames: []string{"RedHatEnterprise"}},
+		ConnectorFactory: &redfishConnectorFactory{},
+	})
+	assert.Error(t, err)
+	_, err = RegisterVendor(VendorRegistration{VendorInfo: constants.VendorInfo{Name: "nofactory"}})
+	assert.Error(t, err)
+	_, err = RegisterVendor(VendorRegistration{
+		VendorInfo:       constants.VendorInfo{Name: "https"},
+		ConnectorFactory: &redfishConnectorFactory{},
+	})
+	assert.Error(t, err)
+}
+
+func TestBuiltInVendorRuleBuilders(t *testing.T) {
+	ruleBuilder, err := constants.VendorIntel.GetRuleBuilder("")
+	assert.NoError(t, err)
+	assert.Equal(t, "intel-tpm20", ruleBuilder)
+
+	ruleBuilder, err = constants.VendorVMware.GetRuleBuilder("1.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "vmware-tpm12", ruleBuilder)
+	_, err = constants.VendorVMware.GetRuleBuilder("")
+	assert.Error(t, err)
+
+	_, err = constants.VendorMicrosoft.GetRuleBuilder("2.0")
+	assert.Error(t, err)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	taModel "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 	"github.com/pkg/errors"
-	"github.com/vmware/govmomi/vim25/mo"
 	vim25Types "github.com/vmware/govmomi/vim25/types"
 	"sort"
 	"strconv"
@@ -96,7 +95,7 @@ func (vc *VmwareConnector) GetMeasurementFromManifest(manifest taModel.Manifest)
 	return taModel.Measurement{}, errors.New("vmware_host_connector :GetMeasurementFromManifest() Operation not supported")
 }
 
-func (vc *VmwareConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+func (vc *VmwareConnector) GetClusterReference(clusterName string) ([]types.ClusterHost, error) {
 	log.Trace("vmware_host_connector :GetClusterReference() Entering")
 	defer log.Trace("vmware_host_connector :GetClusterReference() Leaving")
 	hostInfoList, err := vc.client.GetVmwareClusterReference(clusterName)
@@ -104,7 +103,16 @@ func (vc *VmwareConnector) GetClusterReference(clusterName string) ([]mo.HostSys
 		return nil, errors.Wrap(err, "vmware_host_connector: GetClusterReference() Error getting host"+
 			"info from vmware")
 	}
-	return hostInfoList, nil
+
+	var clusterHosts []types.ClusterHost
+	for _, hostInfo := range hostInfoList {
+		clusterHost := types.ClusterHost{Name: hostInfo.Name}
+		if hostInfo.Summary.Hardware != nil {
+			clusterHost.HardwareUUID = hostInfo.Summary.Hardware.Uuid
+		}
+		clusterHosts = append(clusterHosts, clusterHost)
+	}
+	return clusterHosts, nil
 }
 
 func createPCRManifest(hostTpmAttestationReport *vim25Types.HostTpmAttestationReport) (types.PcrManifest, error) {
@@ -15,14 +15,16 @@ import (
 	"reflect"
 )
 
-// A ruleBuilder creates flavor specific rules for a particular
-// vendor (ex. intel TPM2.0 vs. vmware TPM1.2 vs. vmware TPM2.0)
-type ruleBuilder interface {
+// A RuleBuilder creates flavor specific rules for a particular
+// vendor (ex. intel TPM2.0 vs. vmware TPM1.2 vs. vmware TPM2.0).
+// Additional rule builders can be added with RegisterRuleBuilder.
+type RuleBuilder interface {
 	GetAssetTagRules() ([]rules.Rule, error)
 	GetPlatformRules() ([]rules.Rule, error)
 	GetOsRules() ([]rules.Rule, error)
 	GetHostUniqueRules() ([]rules.Rule, error)
 	GetSoftwareRules() ([]rules.Rule, error)
+	GetImaRules() ([]rules.Rule, error)
 	GetName() string
 }
 
@@ -79,6 +81,8 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 		requiredRules, err = ruleBuilder.GetHostUniqueRules()
 	case common.FlavorPartSoftware:
 		requiredRules, err = ruleBuilder.GetSoftwareRules()
+	case common.FlavorPartIma:
+		requiredRules, err = ruleBuilder.GetImaRules()
 	default:
 		return nil, "", errors.Errorf("Cannot build requiredRules for unknown flavor part %s", flavorPart)
 	}
@@ -87,6 +91,21 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 		return nil, "", errors.Wrapf(err, "Error creating trust requiredRules for flavor '%s'", factory.signedFlavor.Flavor.Meta.ID)
 	}
 
+	// add the registered rules referenced by the flavor
+	for _, ruleReference := range factory.signedFlavor.Flavor.Meta.Rules {
+		rule, err := NewRegisteredRule(ruleReference.Name, RuleContext{
+			VerifierCertificates: factory.verifierCertificates,
+			HostManifest:         factory.hostManifest,
+			SignedFlavor:         factory.signedFlavor,
+			FlavorPart:           flavorPart,
+			Parameters:           ruleReference.Parameters,
+		})
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "Error creating trust requiredRules for flavor '%s'", factory.signedFlavor.Flavor.Meta.ID)
+		}
+		requiredRules = append(requiredRules, rule)
+	}
+
 	// if skip flavor signing verification is enabled, add the FlavorTrusted.
 	if !factory.skipSignedFlavorVerification {
 
@@ -111,12 +130,21 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 	return requiredRules, ruleBuilder.GetName(), nil
 }
 
-func (factory *ruleFactory) getRuleBuilder() (ruleBuilder, error) {
+func (factory *ruleFactory) getRuleBuilder() (RuleBuilder, error) {
 
-	var builder ruleBuilder
+	var builder RuleBuilder
 	var vendor constants.Vendor
 	var err error
 
+	// a rule builder referenced by the flavor takes precedence over the vendor's
+	if factory.signedFlavor.Flavor.Meta.RuleBuilder != "" {
+		builder, err = newRegisteredRuleBuilder(factory.signedFlavor.Flavor.Meta.RuleBuilder, factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "There was an error creating the '%s' rule builder", factory.signedFlavor.Flavor.Meta.RuleBuilder)
+		}
+		return builder, nil
+	}
+
 	vendor = factory.signedFlavor.Flavor.Meta.Vendor
 	if vendor == constants.VendorUnknown {
 		// if for some reason the vendor wasn't provided in the flavor,
@@ -127,34 +155,19 @@ func (factory *ruleFactory) getRuleBuilder() (ruleBuilder, error) {
 		}
 	}
 
-	switch vendor {
-	case constants.VendorIntel:
-		builder, err = newRuleBuilderIntelTpm20(factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
-		if err != nil {
-			return nil, errors.Wrap(err, "There was an error creating the Intel rule builder")
-		}
-	case constants.VendorVMware:
-		tpmVersionString := factory.signedFlavor.Flavor.Meta.Description.TpmVersion
-		if len(tpmVersionString) == 0 {
-			tpmVersionString = factory.hostManifest.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion
-		}
+	tpmVersionString := factory.signedFlavor.Flavor.Meta.Description.TpmVersion
+	if len(tpmVersionString) == 0 && factory.hostManifest != nil {
+		tpmVersionString = factory.hostManifest.HostInfo.HardwareFeatures.TPM.Meta.TPMVersion
+	}
 
-		if tpmVersionString == "1.2" {
-			builder, err = newRuleBuilderVMWare12(factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
-			if err != nil {
-				return nil, errors.Wrap(err, "There was an error creating the VMWare 1.2 verification rule builder")
-			}
-		} else if tpmVersionString == "2.0" {
-			builder, err = newRuleBuilderVMWare20(factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
-			if err != nil {
-				return nil, errors.Wrap(err, "There was an error creating the VMWare 1.2 verification rule builder")
-			}
-		} else {
-			return nil, errors.Errorf("Unknown TPM version '%s'", tpmVersionString)
-		}
+	ruleBuilderName, err := vendor.GetRuleBuilder(tpmVersionString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not determine the rule builder of vendor '%s'", vendor.String())
+	}
 
-	default:
-		return nil, errors.Errorf("Vendor '%s' is not currently supported", string(vendor))
+	builder, err = newRegisteredRuleBuilder(ruleBuilderName, factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "There was an error creating the '%s' rule builder", ruleBuilderName)
 	}
 
 	return builder, nil
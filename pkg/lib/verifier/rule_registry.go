@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package verifier
+
+//
+// Registry of the rule builders and rules that can be referenced by name from
+// flavor metadata and flavorgroup policies.
+//
+
+import (
+	"sync"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// Names of the rule builders that are registered by default, the built-in vendors of the host connector refer to
+// them by name
+const (
+	RuleBuilderIntelTpm20  = "intel-tpm20"
+	RuleBuilderVMWareTpm12 = "vmware-tpm12"
+	RuleBuilderVMWareTpm20 = "vmware-tpm20"
+)
+
+// RuleBuilderConstructor creates a RuleBuilder for the verification of a host manifest against a signed flavor.
+type RuleBuilderConstructor func(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (RuleBuilder, error)
+
+// RuleContext is provided to a RuleConstructor when creating a rule.  SignedFlavor is
+// nil when the rule is referenced from a flavorgroup policy rather than from a flavor.
+type RuleContext struct {
+	VerifierCertificates VerifierCertificates
+	HostManifest         *types.HostManifest
+	SignedFlavor         *hvs.SignedFlavor
+	FlavorPart           common.FlavorPart
+	Parameters           map[string]string
+}
+
+// RuleConstructor creates a rule from the parameters in the RuleContext.  An error should be
+// returned when the parameters are not valid for the rule.
+type RuleConstructor func(ctx RuleContext) (rules.Rule, error)
+
+var registry = struct {
+	sync.RWMutex
+	ruleBuilders map[string]RuleBuilderConstructor
+	rules        map[string]RuleConstructor
+}{
+	ruleBuilders: map[string]RuleBuilderConstructor{
+		RuleBuilderIntelTpm20:  newRuleBuilderIntelTpm20,
+		RuleBuilderVMWareTpm12: newRuleBuilderVMWare12,
+		RuleBuilderVMWareTpm20: newRuleBuilderVMWare20,
+	},
+	rules: map[string]RuleConstructor{},
+}
+
+// RegisterRuleBuilder registers a rule builder that flavors can reference by name in their
+// metadata.  An error is returned if a rule builder is already registered with the name.
+func RegisterRuleBuilder(name string, constructor RuleBuilderConstructor) error {
+	if name == "" {
+		return errors.New("The rule builder name cannot be empty")
+	}
+	if constructor == nil {
+		return errors.Errorf("The constructor of rule builder '%s' cannot be nil", name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.ruleBuilders[name]; ok {
+		return errors.Errorf("A rule builder is already registered with name '%s'", name)
+	}
+	registry.ruleBuilders[name] = constructor
+	return nil
+}
+
+// RegisterRule registers a rule that flavors and flavorgroup policies can reference by name.
+// An error is returned if a rule is already registered with the name.
+func RegisterRule(name string, constructor RuleConstructor) error {
+	if name == "" {
+		return errors.New("The rule name cannot be empty")
+	}
+	if constructor == nil {
+		return errors.Errorf("The constructor of rule '%s' cannot be nil", name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.rules[name]; ok {
+		return errors.Errorf("A rule is already registered with name '%s'", name)
+	}
+	registry.rules[name] = constructor
+	return nil
+}
+
+// ValidateRuleBuilder returns an error if no rule builder is registered with the name.
+func ValidateRuleBuilder(name string) error {
+	registry.RLock()
+	defer registry.RUnlock()
+	if _, ok := registry.ruleBuilders[name]; !ok {
+		return errors.Errorf("No rule builder is registered with name '%s'", name)
+	}
+	return nil
+}
+
+// ValidateRuleReferences returns an error if any of the referenced rules is not registered.
+func ValidateRuleReferences(ruleReferences []hvs.RuleReference) error {
+	registry.RLock()
+	defer registry.RUnlock()
+	for _, ruleReference := range ruleReferences {
+		if _, ok := registry.rules[ruleReference.Name]; !ok {
+			return errors.Errorf("No rule is registered with name '%s'", ruleReference.Name)
+		}
+	}
+	return nil
+}
+
+// NewRegisteredRule creates the rule registered with the name.
+func NewRegisteredRule(name string, ctx RuleContext) (rules.Rule, error) {
+	registry.RLock()
+	constructor, ok := registry.rules[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("No rule is registered with name '%s'", name)
+	}
+
+	rule, err := constructor(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not create rule '%s'", name)
+	}
+	return &registeredRule{Rule: rule, name: name, marker: ctx.FlavorPart}, nil
+}
+
+// registeredRule makes sure the results of a registered rule have a name and a marker, which the
+// trust report relies on when combining the results of the rules
+type registeredRule struct {
+	rules.Rule
+	name   string
+	marker common.FlavorPart
+}
+
+func (rule *registeredRule) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result, err := rule.Rule.Apply(hostManifest)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.Errorf("The rule '%s' did not return a result", rule.name)
+	}
+	if result.Rule.Name == "" {
+		result.Rule.Name = rule.name
+	}
+	if len(result.Rule.Markers) == 0 {
+		result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	}
+	return result, nil
+}
+
+// ApplyRegisteredRules creates the referenced rules and applies them to the host manifest.  Results
+// with faults are marked as untrusted.
+func ApplyRegisteredRules(ruleReferences []hvs.RuleReference, ctx RuleContext) ([]hvs.RuleResult, error) {
+	var results []hvs.RuleResult
+	for _, ruleReference := range ruleReferences {
+		ruleCtx := ctx
+		ruleCtx.Parameters = ruleReference.Parameters
+		rule, err := NewRegisteredRule(ruleReference.Name, ruleCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := rule.Apply(ctx.HostManifest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error occurred applying rule '%s'", ruleReference.Name)
+		}
+		if len(result.Faults) > 0 {
+			result.Trusted = false
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+func newRegisteredRuleBuilder(name string, verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (RuleBuilder, error) {
+	registry.RLock()
+	constructor, ok := registry.ruleBuilders[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("No rule builder is registered with name '%s'", name)
+	}
+	return constructor(verifierCertificates, hostManifest, signedFlavor)
+}
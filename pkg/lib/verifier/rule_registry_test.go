@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package verifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// osNameContains is a site specific rule that checks the os name of the host
+type osNameContains struct {
+	expected string
+}
+
+func newOsNameContains(ctx RuleContext) (rules.Rule, error) {
+	expected, ok := ctx.Parameters["expected"]
+	if !ok {
+		return nil, errors.New("The 'expected' parameter is required")
+	}
+	return &osNameContains{expected: expected}, nil
+}
+
+func (rule *osNameContains) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{Trusted: true}
+	if !strings.Contains(hostManifest.HostInfo.OSName, rule.expected) {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        "OsNameMismatch",
+			Description: "The os name does not contain " + rule.expected,
+		})
+	}
+	return &result, nil
+}
+
+// customRuleBuilder only applies the site specific rules referenced by the flavor
+type customRuleBuilder struct{}
+
+func (builder *customRuleBuilder) GetAssetTagRules() ([]rules.Rule, error)   { return nil, nil }
+func (builder *customRuleBuilder) GetPlatformRules() ([]rules.Rule, error)   { return nil, nil }
+func (builder *customRuleBuilder) GetOsRules() ([]rules.Rule, error)         { return nil, nil }
+func (builder *customRuleBuilder) GetHostUniqueRules() ([]rules.Rule, error) { return nil, nil }
+func (builder *customRuleBuilder) GetSoftwareRules() ([]rules.Rule, error)   { return nil, nil }
+func (builder *customRuleBuilder) GetImaRules() ([]rules.Rule, error)        { return nil, nil }
+func (builder *customRuleBuilder) GetName() string                           { return "Custom Host Trust Policy" }
+
+func init() {
+	if err := RegisterRule("test-os-name-contains", newOsNameContains); err != nil {
+		panic(err)
+	}
+	if err := RegisterRuleBuilder("test-custom", func(VerifierCertificates, *types.HostManifest, *hvs.SignedFlavor) (RuleBuilder, error) {
+		return &customRuleBuilder{}, nil
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func loadRegistryTestData(t *testing.T) (*types.HostManifest, []hvs.SignedFlavor, VerifierCertificates) {
+	verifierCertificates, err := createVerifierCertificates(t,
+		"test_data/vmware20/PrivacyCA.pem",
+		"test_data/vmware20/flavor-signer.crt.pem",
+		"test_data/vmware20/cms-ca-cert.pem",
+		"test_data/vmware20/tag-cacerts.pem")
+	assert.NoError(t, err)
+
+	var hostManifest types.HostManifest
+	manifestJSON, err := ioutil.ReadFile("test_data/vmware20/host_manifest.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(manifestJSON, &hostManifest))
+
+	var signedFlavors []hvs.SignedFlavor
+	flavorsJSON, err := ioutil.ReadFile("test_data/vmware20/signed_flavors.json")
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(flavorsJSON, &signedFlavors))
+	return &hostManifest, signedFlavors, verifierCertificates
+}
+
+func TestRegisterRuleDuplicate(t *testing.T) {
+	assert.Error(t, RegisterRule("test-os-name-contains", newOsNameContains))
+	assert.Error(t, RegisterRule("", newOsNameContains))
+	assert.Error(t, RegisterRule("test-nil-constructor", nil))
+	assert.Error(t, RegisterRuleBuilder(RuleBuilderIntelTpm20, newRuleBuilderIntelTpm20))
+}
+
+func TestVerifyWithRegisteredRule(t *testing.T) {
+	hostManifest, signedFlavors, verifierCertificates := loadRegistryTestData(t)
+	v, err := NewVerifier(verifierCertificates)
+	assert.NoError(t, err)
+
+	signedFlavor := signedFlavors[0]
+	signedFlavor.Flavor.Meta.Rules = []hvs.RuleReference{{Name: "test-os-name-contains", Parameters: map[string]string{"expected": hostManifest.HostInfo.OSName}}}
+	report, err := v.Verify(hostManifest, &signedFlavor, true)
+	assert.NoError(t, err)
+	assert.True(t, report.Trusted)
+	assert.Len(t, report.GetResultsForMarker(signedFlavor.Flavor.Meta.Description.FlavorPart), len(report.Results))
+	assert.Equal(t, "test-os-name-contains", report.Results[len(report.Results)-1].Rule.Name)
+
+	signedFlavor.Flavor.Meta.Rules[0].Parameters["expected"] = "Windows"
+	report, err = v.Verify(hostManifest, &signedFlavor, true)
+	assert.NoError(t, err)
+	assert.False(t, report.Trusted)
+	assert.Equal(t, "OsNameMismatch", report.Results[len(report.Results)-1].Faults[0].Name)
+
+	// the rule cannot be created without its parameters
+	signedFlavor.Flavor.Meta.Rules[0].Parameters = nil
+	_, err = v.Verify(hostManifest, &signedFlavor, true)
+	assert.Error(t, err)
+
+	signedFlavor.Flavor.Meta.Rules[0].Name = "test-not-registered"
+	_, err = v.Verify(hostManifest, &signedFlavor, true)
+	assert.Error(t, err)
+}
+
+func TestVerifyWithRegisteredRuleBuilder(t *testing.T) {
+	hostManifest, signedFlavors, verifierCertificates := loadRegistryTestData(t)
+	v, err := NewVerifier(verifierCertificates)
+	assert.NoError(t, err)
+
+	signedFlavor := signedFlavors[0]
+	signedFlavor.Flavor.Meta.RuleBuilder = "test-custom"
+	signedFlavor.Flavor.Meta.Rules = []hvs.RuleReference{{Name: "test-os-name-contains", Parameters: map[string]string{"expected": hostManifest.HostInfo.OSName}}}
+	report, err := v.Verify(hostManifest, &signedFlavor, true)
+	assert.NoError(t, err)
+	assert.True(t, report.Trusted)
+	assert.Equal(t, "Custom Host Trust Policy", report.PolicyName)
+	assert.Len(t, report.Results, 1)
+
+	signedFlavor.Flavor.Meta.RuleBuilder = "test-not-registered"
+	_, err = v.Verify(hostManifest, &signedFlavor, true)
+	assert.Error(t, err)
+}
+
+func TestApplyRegisteredRules(t *testing.T) {
+	hostManifest, _, _ := loadRegistryTestData(t)
+
+	results, err := ApplyRegisteredRules([]hvs.RuleReference{
+		{Name: "test-os-name-contains", Parameters: map[string]string{"expected": hostManifest.HostInfo.OSName}},
+		{Name: "test-os-name-contains", Parameters: map[string]string{"expected": "Windows"}},
+	}, RuleContext{HostManifest: hostManifest, FlavorPart: common.FlavorPartOs})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Trusted)
+	assert.False(t, results[1].Trusted)
+	assert.Equal(t, []common.FlavorPart{common.FlavorPartOs}, results[1].Rule.Markers)
+
+	assert.NoError(t, ValidateRuleReferences([]hvs.RuleReference{{Name: "test-os-name-contains"}}))
+	assert.Error(t, ValidateRuleReferences([]hvs.RuleReference{{Name: "test-not-registered"}}))
+	assert.NoError(t, ValidateRuleBuilder(RuleBuilderVMWareTpm20))
+	assert.Error(t, ValidateRuleBuilder("test-not-registered"))
+}
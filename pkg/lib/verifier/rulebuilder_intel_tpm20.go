@@ -27,7 +27,7 @@ type ruleBuilderIntelTpm20 struct {
 	rules                []rules.Rule
 }
 
-func newRuleBuilderIntelTpm20(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (ruleBuilder, error) {
+func newRuleBuilderIntelTpm20(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (RuleBuilder, error) {
 	builder := ruleBuilderIntelTpm20{
 		verifierCertificates: verifierCertificates,
 		hostManifest:         hostManifest,
@@ -43,7 +43,9 @@ func (builder *ruleBuilderIntelTpm20) GetName() string {
 
 // From 'design' repo at isecl/libraries/verifier/verifier.md...
 // AikCertificateTrusted
+// QuoteFreshness
 // PcrMatchesConstant depend on HW features present in flavor
+// UefiSecureBoot and PcrEventLogIntegrity rule for PCR 7 (if the flavor has a Secure Boot policy)
 // PcrEventLogEqualsExcluding rule for PCR 17, 18
 // PcrEventLogIntegrity rule for PCR 17,18 (if tboot is installed)
 // FlavorTrusted (added in verifierimpl)
@@ -61,6 +63,16 @@ func (builder *ruleBuilderIntelTpm20) GetPlatformRules() ([]rules.Rule, error) {
 
 	results = append(results, aikCertificateTrusted)
 
+	//
+	// Add 'QuoteFreshness' rule...
+	//
+	quoteFreshness, err := rules.NewQuoteFreshness(common.FlavorPartPlatform)
+	if err != nil {
+		return nil, err
+	}
+
+	results = append(results, quoteFreshness)
+
 	//
 	// Add 'PcrMatchesConstant' rules...
 	//
@@ -76,6 +88,34 @@ func (builder *ruleBuilderIntelTpm20) GetPlatformRules() ([]rules.Rule, error) {
 
 	results = append(results, pcrMatchesContantsRules...)
 
+	//
+	// Add 'UefiSecureBoot' and PCR 7 'PcrEventLogIntegrity' rules when the flavor has a Secure Boot policy...
+	//
+	secureBootPolicy := builder.getSecureBootPolicy()
+	if secureBootPolicy != nil {
+		uefiSecureBoot, err := rules.NewUefiSecureBoot(secureBootPolicy, builder.signedFlavor.Flavor.Meta.ID, common.FlavorPartPlatform)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, uefiSecureBoot)
+
+		// the Secure Boot variables are only trusted when the event log replays to PCR 7, which is
+		// verified in each bank of the flavor even when the flavor does not have a PCR 7 value
+		for bank := range builder.signedFlavor.Flavor.Pcrs {
+			pcr7 := types.Pcr{
+				Index:   types.PCR7,
+				PcrBank: types.SHAAlgorithm(bank),
+			}
+			pcrEventLogIntegrity, err := rules.NewPcrEventLogIntegrity(&pcr7, common.FlavorPartPlatform)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, pcrEventLogIntegrity)
+		}
+	}
+
 	//
 	// Add 'PcrEventLogEqualsExcluding' rules...
 	//
@@ -291,10 +331,46 @@ func (builder *ruleBuilderIntelTpm20) GetSoftwareRules() ([]rules.Rule, error) {
 	return results, nil
 }
 
+// ImaLogIntegrity rule for PCR 10
+// ImaLogIncludes
+// FlavorTrusted (added in verifierimpl)
+func (builder *ruleBuilderIntelTpm20) GetImaRules() ([]rules.Rule, error) {
+
+	var results []rules.Rule
+
+	meta := builder.signedFlavor.Flavor.Meta
+	if builder.signedFlavor.Flavor.Ima == nil {
+		return nil, errors.New("'Ima' was not present in the flavor")
+	}
+
+	//
+	// Add 'ImaLogIntegrity' rule...
+	//
+	imaLogIntegrityRule, err := rules.NewImaLogIntegrity(meta.ID, types.SHAAlgorithm(builder.signedFlavor.Flavor.Ima.PcrBank))
+	if err != nil {
+		return nil, err
+	}
+
+	results = append(results, imaLogIntegrityRule)
+
+	//
+	// Add 'ImaLogIncludes' rule...
+	//
+	imaLogIncludesRule, err := rules.NewImaLogIncludes(&builder.signedFlavor.Flavor)
+	if err != nil {
+		return nil, err
+	}
+
+	results = append(results, imaLogIncludesRule)
+
+	return results, nil
+}
+
 // Based on the manifest's hardware metadata, return the correct PCRs...
 //   - Always match on PCR0
 //   - If CBNT is enabled and profile 5: Add PCR7
 //   - If SUEFI is enabled: add PCR0-PCR7
+//   - If SUEFI has a Secure Boot policy: remove PCR7, it is verified by the UefiSecureBoot rule
 func (builder *ruleBuilderIntelTpm20) getPlatformPcrsFromHardwareMeta() ([]types.PcrIndex, error) {
 
 	var feature *flavormodel.Feature
@@ -335,5 +411,24 @@ func (builder *ruleBuilderIntelTpm20) getPlatformPcrsFromHardwareMeta() ([]types
 		}
 	}
 
+	if builder.getSecureBootPolicy() != nil {
+		var pcrsWithoutPcr7 []types.PcrIndex
+		for _, pcr := range pcrs {
+			if pcr != types.PCR7 {
+				pcrsWithoutPcr7 = append(pcrsWithoutPcr7, pcr)
+			}
+		}
+		pcrs = pcrsWithoutPcr7
+	}
+
 	return pcrs, nil
 }
+
+// getSecureBootPolicy returns the Secure Boot policy of the flavor when SUEFI is enabled, or nil
+func (builder *ruleBuilderIntelTpm20) getSecureBootPolicy() *flavormodel.SecureBootPolicy {
+	hardware := builder.signedFlavor.Flavor.Hardware
+	if hardware == nil || hardware.Feature == nil || hardware.Feature.SUEFI == nil || !hardware.Feature.SUEFI.Enabled {
+		return nil
+	}
+	return hardware.Feature.SUEFI.SecureBoot
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
+	"github.com/pkg/errors"
 )
 
 type ruleBuilderVMWare12 struct {
@@ -22,7 +23,7 @@ type ruleBuilderVMWare12 struct {
 	rules                []rules.Rule
 }
 
-func newRuleBuilderVMWare12(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (ruleBuilder, error) {
+func newRuleBuilderVMWare12(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (RuleBuilder, error) {
 	builder := ruleBuilderVMWare12 {
 		verifierCertificates: verifierCertificates,
 		hostManifest: hostManifest,
@@ -169,3 +170,8 @@ func (builder *ruleBuilderVMWare12) GetHostUniqueRules() ([]rules.Rule, error) {
 func (builder *ruleBuilderVMWare12) GetSoftwareRules() ([]rules.Rule, error) {
 	return nil, nil
 }
+
+// The kernel IMA is not supported on VMware hosts
+func (builder *ruleBuilderVMWare12) GetImaRules() ([]rules.Rule, error) {
+	return nil, errors.New("IMA flavors are not supported on VMware hosts")
+}
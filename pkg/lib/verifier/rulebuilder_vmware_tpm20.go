@@ -13,6 +13,7 @@ import (
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/verifier/rules"
+	"github.com/pkg/errors"
 )
 
 type ruleBuilderVMWare20 struct {
@@ -22,7 +23,7 @@ type ruleBuilderVMWare20 struct {
 	rules                []rules.Rule
 }
 
-func newRuleBuilderVMWare20(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (ruleBuilder, error) {
+func newRuleBuilderVMWare20(verifierCertificates VerifierCertificates, hostManifest *types.HostManifest, signedFlavor *hvs.SignedFlavor) (RuleBuilder, error) {
 	builder := ruleBuilderVMWare20 {
 		verifierCertificates: verifierCertificates,
 		hostManifest: hostManifest,
@@ -201,4 +202,9 @@ func (builder *ruleBuilderVMWare20) GetHostUniqueRules() ([]rules.Rule, error) {
 // (none)
 func (builder *ruleBuilderVMWare20) GetSoftwareRules() ([]rules.Rule, error) {
 	return nil, nil
-}
\ No newline at end of file
+}
+
+// The kernel IMA is not supported on VMware hosts
+func (builder *ruleBuilderVMWare20) GetImaRules() ([]rules.Rule, error) {
+	return nil, errors.New("IMA flavors are not supported on VMware hosts")
+}
@@ -11,6 +11,8 @@ package rules
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"github.com/google/uuid"
 	faultsConst "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
@@ -96,4 +98,99 @@ func newPcrManifestMissingFault() hvs.Fault {
 		Name:        faultsConst.FaultPcrManifestMissing,
 		Description: "Host report does not include a PCR Manifest",
 	}
-}
\ No newline at end of file
+}
+func newImaLogMissingFault() hvs.Fault {
+	return hvs.Fault{
+		Name:        faultsConst.FaultImaLogMissing,
+		Description: "Host report does not include an IMA log",
+	}
+}
+
+func newImaLogInvalidFault(description string, expectedValue *string, actualValue *string) hvs.Fault {
+	return hvs.Fault{
+		Name:          faultsConst.FaultImaLogInvalid,
+		Description:   description,
+		ExpectedValue: expectedValue,
+		ActualValue:   actualValue,
+	}
+}
+
+func newImaLogContainsUnexpectedEntriesFault(flavorId uuid.UUID, measurements []types.ImaMeasurement) hvs.Fault {
+	return hvs.Fault{
+		Name:                      faultsConst.FaultImaLogContainsUnexpectedEntries,
+		Description:               fmt.Sprintf("IMA log contains %d measurements that are not in the allowlist of flavor %s", len(measurements), flavorId),
+		FlavorId:                  &flavorId,
+		UnexpectedImaMeasurements: measurements,
+	}
+}
+
+func newImaLogMissingExpectedEntriesFault(flavorId uuid.UUID, measurements []types.ImaMeasurement) hvs.Fault {
+	return hvs.Fault{
+		Name:                   faultsConst.FaultImaLogMissingExpectedEntries,
+		Description:            fmt.Sprintf("IMA log is missing %d required measurements of flavor %s", len(measurements), flavorId),
+		FlavorId:               &flavorId,
+		MissingImaMeasurements: measurements,
+	}
+}
+
+func newSecureBootVariableMissingFault(variableName string) hvs.Fault {
+	pcrIndex := types.PCR7
+	return hvs.Fault{
+		Name:        faultsConst.FaultSecureBootVariableMissing,
+		Description: fmt.Sprintf("PCR %d Event Log does not include the Secure Boot variable %s", pcrIndex, variableName),
+		PcrIndex:    &pcrIndex,
+	}
+}
+
+func newSecureBootVariableInvalidFault(variableName string, err error) hvs.Fault {
+	pcrIndex := types.PCR7
+	return hvs.Fault{
+		Name:        faultsConst.FaultSecureBootVariableInvalid,
+		Description: fmt.Sprintf("The Secure Boot variable %s could not be decoded: %s", variableName, err.Error()),
+		PcrIndex:    &pcrIndex,
+	}
+}
+
+func newSecureBootDisabledFault() hvs.Fault {
+	pcrIndex := types.PCR7
+	return hvs.Fault{
+		Name:        faultsConst.FaultSecureBootDisabled,
+		Description: "Secure Boot is not enabled on the host",
+		PcrIndex:    &pcrIndex,
+	}
+}
+
+func newSecureBootIssuerMismatchFault(variableName string, expectedIssuers []string, actualIssuer string) hvs.Fault {
+	pcrIndex := types.PCR7
+	expectedValue := strings.Join(expectedIssuers, ", ")
+	return hvs.Fault{
+		Name: faultsConst.FaultSecureBootIssuerMismatch,
+		Description: fmt.Sprintf("Certificate of the Secure Boot variable %s issued by '%s' is not issued by any of '%s'",
+			variableName, actualIssuer, expectedValue),
+		PcrIndex:      &pcrIndex,
+		ExpectedValue: &expectedValue,
+		ActualValue:   &actualIssuer,
+	}
+}
+
+func newSecureBootDbxOutdatedFault(description string, expectedValue *string, actualValue *string) hvs.Fault {
+	pcrIndex := types.PCR7
+	return hvs.Fault{
+		Name:          faultsConst.FaultSecureBootDbxOutdated,
+		Description:   description,
+		PcrIndex:      &pcrIndex,
+		ExpectedValue: expectedValue,
+		ActualValue:   actualValue,
+	}
+}
+
+func newQuoteCounterFault(name string, description string, expectedValue uint64, actualValue uint64) hvs.Fault {
+	expected := strconv.FormatUint(expectedValue, 10)
+	actual := strconv.FormatUint(actualValue, 10)
+	return hvs.Fault{
+		Name:          name,
+		Description:   description,
+		ExpectedValue: &expected,
+		ActualValue:   &actual,
+	}
+}
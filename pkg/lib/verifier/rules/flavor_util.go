@@ -6,7 +6,6 @@ package rules
 
 import (
 	"encoding/xml"
-	"fmt"
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/constants"
 	flavor_model "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
@@ -39,12 +38,8 @@ func FlavorPcr2ManifestPcr(pcrEx *flavor_model.PcrEx, bank types.SHAAlgorithm, i
 		return nil, errors.New("The pcrex value cannot be nil")
 	}
 
-	digestType := fmt.Sprintf(constants.PcrClassNamePrefix+"%d", 1)
-	if bank == types.SHA256 {
-		digestType = fmt.Sprintf(constants.PcrClassNamePrefix+"%d", 256)
-	}
 	return &types.Pcr{
-		DigestType: digestType,
+		DigestType: constants.PcrClassNamePrefix + bank.GetDigestTypeSuffix(),
 		Index:      index,
 		Value:      pcrEx.Value,
 		PcrBank:    bank,
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// imaBootAggregate is the first entry of the IMA log, it is measured from the boot PCRs which are verified by the
+// platform and OS flavors
+const imaBootAggregate = "boot_aggregate"
+
+// NewImaLogIncludes creates a rule that will check the files measured in the IMA log of the host against the
+// allowlist of an IMA flavor
+func NewImaLogIncludes(flavor *hvs.Flavor) (Rule, error) {
+	if flavor == nil || flavor.Ima == nil {
+		return nil, errors.New("The flavor does not contain an IMA allowlist")
+	}
+
+	rule := imaLogIncludes{
+		flavorId:    flavor.Meta.ID,
+		flavorLabel: flavor.Meta.Description.Label,
+		ima:         flavor.Ima,
+	}
+	return &rule, nil
+}
+
+type imaLogIncludes struct {
+	flavorId    uuid.UUID
+	flavorLabel string
+	ima         *model.Ima
+}
+
+// - If the hostmanifest does not contain an IMA log, create an ImaLogMissing fault.
+// - If the IMA log has measurements of files that are not excluded and whose path and digest are not in the
+//   allowlist, create an ImaLogContainsUnexpectedEntries fault.
+// - If required files of the allowlist were not measured, create an ImaLogMissingExpectedEntries fault.
+func (rule *imaLogIncludes) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleImaLogIncludes
+	result.Rule.Markers = append(result.Rule.Markers, common.FlavorPartIma)
+	result.Rule.FlavorID = &rule.flavorId
+	result.Rule.FlavorName = &rule.flavorLabel
+
+	if hostManifest.ImaLog == nil {
+		result.Faults = append(result.Faults, newImaLogMissingFault())
+		return &result, nil
+	}
+
+	excludes := rule.ima.GetExcludes()
+	measuredFiles := map[string]bool{}
+	var unexpectedMeasurements []types.ImaMeasurement
+
+	for _, measurement := range hostManifest.ImaLog.Measurements {
+		if measurement.PcrIndex != types.ImaPcr || measurement.Path == imaBootAggregate ||
+			isImaViolation(measurement) {
+			continue
+		}
+
+		excluded := false
+		for _, exclude := range excludes {
+			if exclude.MatchString(measurement.Path) {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			continue
+		}
+
+		if rule.ima.Allows(measurement.Path, measurement.FileDigest) {
+			measuredFiles[measurement.Path] = true
+		} else {
+			unexpectedMeasurements = append(unexpectedMeasurements, measurement)
+		}
+	}
+
+	var missingMeasurements []types.ImaMeasurement
+	for path, file := range rule.ima.Files {
+		if !file.Required || measuredFiles[path] {
+			continue
+		}
+		for _, digest := range file.Digests {
+			missingMeasurements = append(missingMeasurements, types.ImaMeasurement{
+				PcrIndex:   types.ImaPcr,
+				FileDigest: digest,
+				Path:       path,
+			})
+		}
+	}
+	sort.Slice(missingMeasurements, func(i, j int) bool {
+		return missingMeasurements[i].Path < missingMeasurements[j].Path
+	})
+
+	if len(unexpectedMeasurements) > 0 {
+		result.Faults = append(result.Faults, newImaLogContainsUnexpectedEntriesFault(rule.flavorId,
+			unexpectedMeasurements))
+	}
+	if len(missingMeasurements) > 0 {
+		result.Faults = append(result.Faults, newImaLogMissingExpectedEntriesFault(rule.flavorId,
+			missingMeasurements))
+	}
+
+	return &result, nil
+}
+
+// isImaViolation returns true for the measurements the kernel IMA records when a file is opened for write while it
+// is measured, their template hash is zero
+func isImaViolation(measurement types.ImaMeasurement) bool {
+	templateHash, err := hex.DecodeString(measurement.TemplateHash)
+	return err == nil && len(templateHash) > 0 && bytes.Equal(templateHash, make([]byte, len(templateHash)))
+}
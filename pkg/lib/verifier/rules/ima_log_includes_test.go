@@ -0,0 +1,101 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestImaFlavor() *hvs.Flavor {
+	flavor := hvs.Flavor{
+		Meta: model.Meta{
+			ID: uuid.New(),
+			Description: model.Description{
+				FlavorPart: "IMA",
+				Label:      "ima-allowlist",
+			},
+		},
+		Ima: &model.Ima{
+			PcrBank: string(types.SHA256),
+			Files: map[string]model.ImaFile{
+				"/usr/bin/bash": {Digests: []string{testImaFiles["/usr/bin/bash"]}, Required: true},
+				"/usr/bin/ls":   {Digests: []string{strings.Repeat("44", 32), testImaFiles["/usr/bin/ls"]}},
+			},
+			Excludes: []string{"^/tmp/"},
+		},
+	}
+	return &flavor
+}
+
+func TestImaLogIncludesNoFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, testImaPaths, testImaFiles))
+
+	rule, err := NewImaLogIncludes(getTestImaFlavor())
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestImaLogIncludesUnexpectedEntriesFault(t *testing.T) {
+	files := map[string]string{}
+	for path, digest := range testImaFiles {
+		files[path] = digest
+	}
+	files["/usr/bin/ls"] = strings.Repeat("55", 32)
+	files["/usr/bin/nc"] = strings.Repeat("66", 32)
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, append(testImaPaths, "/usr/bin/nc"), files))
+
+	rule, err := NewImaLogIncludes(getTestImaFlavor())
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaLogContainsUnexpectedEntries, result.Faults[0].Name)
+	assert.Equal(t, 2, len(result.Faults[0].UnexpectedImaMeasurements))
+	assert.Equal(t, "/usr/bin/ls", result.Faults[0].UnexpectedImaMeasurements[0].Path)
+	assert.Equal(t, "/usr/bin/nc", result.Faults[0].UnexpectedImaMeasurements[1].Path)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
+
+func TestImaLogIncludesMissingExpectedEntriesFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, []string{"boot_aggregate", "/usr/bin/ls"},
+		testImaFiles))
+
+	rule, err := NewImaLogIncludes(getTestImaFlavor())
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaLogMissingExpectedEntries, result.Faults[0].Name)
+	assert.Equal(t, 1, len(result.Faults[0].MissingImaMeasurements))
+	assert.Equal(t, "/usr/bin/bash", result.Faults[0].MissingImaMeasurements[0].Path)
+}
+
+func TestImaLogIncludesImaLogMissingFault(t *testing.T) {
+	rule, err := NewImaLogIncludes(getTestImaFlavor())
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaLogMissing, result.Faults[0].Name)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// NewImaLogIntegrity creates a rule that will check if the IMA log of the host replays to the value of
+// PCR 10 in the given bank
+func NewImaLogIntegrity(flavorID uuid.UUID, pcrBank types.SHAAlgorithm) (Rule, error) {
+	rule := imaLogIntegrity{
+		flavorId: flavorID,
+		pcrBank:  pcrBank,
+	}
+	return &rule, nil
+}
+
+type imaLogIntegrity struct {
+	flavorId uuid.UUID
+	pcrBank  types.SHAAlgorithm
+}
+
+// - If the hostmanifest does not contain an IMA log, create an ImaLogMissing fault.
+// - If the hostmanifest's PcrManifest is not present, create PcrManifestMissing fault.
+// - If the hostmanifest does not contain PCR 10 in the bank of the flavor, create a PcrValueMissing fault.
+// - Otherwise, replay the IMA log and verify the calculated hash matches PCR 10. If not, or if the IMA log
+//   cannot be replayed, create an ImaLogInvalid fault.
+func (rule *imaLogIntegrity) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleImaLogIntegrity
+	result.Rule.Markers = append(result.Rule.Markers, common.FlavorPartIma)
+	result.Rule.FlavorID = &rule.flavorId
+
+	if hostManifest.ImaLog == nil {
+		result.Faults = append(result.Faults, newImaLogMissingFault())
+	} else if hostManifest.PcrManifest.IsEmpty() {
+		result.Faults = append(result.Faults, newPcrManifestMissingFault())
+	} else {
+		actualPcr, err := hostManifest.PcrManifest.GetPcrValue(rule.pcrBank, types.ImaPcr)
+		if err != nil {
+			return nil, err
+		}
+
+		if actualPcr == nil {
+			result.Faults = append(result.Faults, newPcrValueMissingFault(rule.pcrBank, types.ImaPcr))
+		} else {
+			result.Rule.ExpectedPcr = actualPcr
+
+			calculatedValue, err := hostManifest.ImaLog.Replay(rule.pcrBank)
+			if err != nil {
+				result.Faults = append(result.Faults, newImaLogInvalidFault(fmt.Sprintf("The IMA log could not be "+
+					"replayed: %s", err.Error()), nil, nil))
+			} else if calculatedValue != actualPcr.Value {
+				result.Faults = append(result.Faults, newImaLogInvalidFault(fmt.Sprintf("The IMA log replays to "+
+					"'%s' instead of the value '%s' of PCR %d", calculatedValue, actualPcr.Value, types.ImaPcr),
+					&actualPcr.Value, &calculatedValue))
+			}
+		}
+	}
+
+	return &result, nil
+}
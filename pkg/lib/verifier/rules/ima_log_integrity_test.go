@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestImaLog returns an IMA log of the given files and digests, in the order of the paths
+func getTestImaLog(t *testing.T, paths []string, files map[string]string) *types.ImaLog {
+	imaLog := types.ImaLog{}
+	for _, path := range paths {
+		measurement := types.ImaMeasurement{
+			PcrIndex:            types.PCR10,
+			TemplateName:        types.ImaTemplateImaNg,
+			FileDigestAlgorithm: "sha256",
+			FileDigest:          files[path],
+			Path:                path,
+		}
+		templateData, err := measurement.GetTemplateData()
+		assert.NoError(t, err)
+		templateHash := sha1.Sum(templateData)
+		measurement.TemplateHash = hex.EncodeToString(templateHash[:])
+		imaLog.Measurements = append(imaLog.Measurements, measurement)
+	}
+	return &imaLog
+}
+
+func getTestImaHostManifest(t *testing.T, imaLog *types.ImaLog) *types.HostManifest {
+	pcrValue, err := imaLog.Replay(types.SHA256)
+	assert.NoError(t, err)
+
+	hostManifest := types.HostManifest{ImaLog: imaLog}
+	hostManifest.PcrManifest.Sha256Pcrs = append(hostManifest.PcrManifest.Sha256Pcrs, types.Pcr{
+		Index:   types.PCR10,
+		PcrBank: types.SHA256,
+		Value:   pcrValue,
+	})
+	return &hostManifest
+}
+
+var testImaFiles = map[string]string{
+	"boot_aggregate": strings.Repeat("00", 32),
+	"/usr/bin/bash":  strings.Repeat("11", 32),
+	"/usr/bin/ls":    strings.Repeat("22", 32),
+	"/tmp/build.sh":  strings.Repeat("33", 32),
+}
+
+var testImaPaths = []string{"boot_aggregate", "/usr/bin/bash", "/usr/bin/ls", "/tmp/build.sh"}
+
+func TestImaLogIntegrityNoFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, testImaPaths, testImaFiles))
+
+	rule, err := NewImaLogIntegrity(uuid.New(), types.SHA256)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestImaLogIntegrityTamperedLogFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, testImaPaths, testImaFiles))
+
+	// remove a measurement from the log after PCR 10 was extended
+	hostManifest.ImaLog.Measurements = hostManifest.ImaLog.Measurements[:len(hostManifest.ImaLog.Measurements)-1]
+
+	rule, err := NewImaLogIntegrity(uuid.New(), types.SHA256)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaLogInvalid, result.Faults[0].Name)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
+
+func TestImaLogIntegrityImaLogMissingFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, testImaPaths, testImaFiles))
+	hostManifest.ImaLog = nil
+
+	rule, err := NewImaLogIntegrity(uuid.New(), types.SHA256)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultImaLogMissing, result.Faults[0].Name)
+}
+
+func TestImaLogIntegrityPcrValueMissingFault(t *testing.T) {
+	hostManifest := getTestImaHostManifest(t, getTestImaLog(t, testImaPaths, testImaFiles))
+
+	// the host manifest only has the SHA256 bank
+	hostManifest.PcrManifest.Sha1Pcrs = append(hostManifest.PcrManifest.Sha1Pcrs, types.Pcr{
+		Index:   types.PCR0,
+		PcrBank: types.SHA1,
+		Value:   PCR_VALID_256,
+	})
+
+	rule, err := NewImaLogIntegrity(uuid.New(), types.SHA1)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrValueMissing, result.Faults[0].Name)
+}
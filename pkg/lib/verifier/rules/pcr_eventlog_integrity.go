@@ -34,6 +34,9 @@ type pcrEventLogIntegrity struct {
 
 // - If the hostmanifest's PcrManifest is not present, create PcrManifestMissing fault.
 // - If the hostmanifest does not contain a pcr at 'expected' bank/index, create a PcrValueMissing fault.
+// - If the hostmanifest has a TCG event log with digests in the 'expected' bank, replay all of its events
+//   of the 'expected' index and verify the calculated hash matches the pcr value in the host-manifest.  If
+//   not, or if an event does not have a digest in the bank, create a PcrEventLogInvalid fault.
 // - If the hostmanifest does not have an event log at 'expected' bank/index, create a 
 //   PcrEventLogMissing fault.
 // - Otherwise, replay the hostmanifest's event log at 'expected' bank/index and verify the 
@@ -57,6 +60,22 @@ func (rule *pcrEventLogIntegrity) Apply(hostManifest *types.HostManifest) (*hvs.
 
 		if actualPcr == nil {
 			result.Faults = append(result.Faults, newPcrValueMissingFault(rule.expectedPcr.PcrBank, rule.expectedPcr.Index))
+		} else if hostManifest.TcgEventLog != nil && hostManifest.TcgEventLog.HasPcrBank(rule.expectedPcr.PcrBank) {
+			// the TCG event log has every event of the measured boot, not only the labelled modules
+			calculatedValue, err := hostManifest.TcgEventLog.Replay(rule.expectedPcr.PcrBank, rule.expectedPcr.Index)
+			if err != nil {
+				result.Faults = append(result.Faults, hvs.Fault{
+					Name:        constants.FaultPcrEventLogInvalid,
+					Description: fmt.Sprintf("PCR %d Event Log could not be replayed: %s", rule.expectedPcr.Index, err.Error()),
+					PcrIndex:    &rule.expectedPcr.Index,
+				})
+			} else if calculatedValue != actualPcr.Value {
+				result.Faults = append(result.Faults, hvs.Fault{
+					Name:        constants.FaultPcrEventLogInvalid,
+					Description: fmt.Sprintf("PCR %d Event Log is invalid", rule.expectedPcr.Index),
+					PcrIndex:    &rule.expectedPcr.Index,
+				})
+			}
 		} else {
 			actualEventLog, err := hostManifest.PcrManifest.PcrEventLogMap.GetEventLog(rule.expectedPcr.PcrBank, rule.expectedPcr.Index)
 			if err != nil {
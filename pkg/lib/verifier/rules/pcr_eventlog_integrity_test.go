@@ -5,6 +5,9 @@
 package rules
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
@@ -150,4 +153,67 @@ func TestPcrEventLogIntegrityPcrEventLogInvalidFault(t *testing.T) {
 	assert.NotNil(t, result.Faults[0].PcrIndex)	// should report the missing pcr
 	assert.Equal(t, types.PCR0, *result.Faults[0].PcrIndex)
 	t.Logf("Fault description: %s", result.Faults[0].Description)
-}
\ No newline at end of file
+}
+// getTestTcgEventLog returns a TCG event log with SHA256 digests of PCR 0 and the value of PCR 0 it replays to
+func getTestTcgEventLog(t *testing.T) (*types.TcgEventLog, types.Pcr) {
+	tcgEventLog := types.TcgEventLog{StartupLocality: 3}
+	for i, eventType := range []uint32{types.EV_S_CRTM_VERSION, types.EV_NO_ACTION, types.EV_EFI_PLATFORM_FIRMWARE_BLOB,
+		types.EV_SEPARATOR} {
+		tcgEventLog.Events = append(tcgEventLog.Events, types.TcgEvent{
+			PcrIndex:  types.PCR0,
+			EventType: eventType,
+			Digests:   map[types.SHAAlgorithm]string{types.SHA256: strings.Repeat(fmt.Sprintf("%02x", i+1), 32)},
+		})
+	}
+
+	pcrValue, err := tcgEventLog.Replay(types.SHA256, types.PCR0)
+	assert.NoError(t, err)
+	return &tcgEventLog, types.Pcr{Index: types.PCR0, PcrBank: types.SHA256, Value: pcrValue}
+}
+
+func TestPcrEventLogIntegrityTcgEventLogNoFault(t *testing.T) {
+
+	tcgEventLog, expectedPcr := getTestTcgEventLog(t)
+
+	// the TCG event log is replayed rather than the module event log, which does not match the PCR
+	hostManifest := types.HostManifest{TcgEventLog: tcgEventLog}
+	hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs = append(hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs, testExpectedEventLogEntry)
+	hostManifest.PcrManifest.Sha256Pcrs = append(hostManifest.PcrManifest.Sha256Pcrs, expectedPcr)
+
+	rule, err := NewPcrEventLogIntegrity(&expectedPcr, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestPcrEventLogIntegrityTcgEventLogInvalidFault(t *testing.T) {
+
+	tcgEventLog, expectedPcr := getTestTcgEventLog(t)
+
+	// remove the separator after PCR 0 was extended
+	tcgEventLog.Events = tcgEventLog.Events[:len(tcgEventLog.Events)-1]
+
+	hostManifest := types.HostManifest{TcgEventLog: tcgEventLog}
+	hostManifest.PcrManifest.Sha256Pcrs = append(hostManifest.PcrManifest.Sha256Pcrs, expectedPcr)
+
+	rule, err := NewPcrEventLogIntegrity(&expectedPcr, common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogInvalid, result.Faults[0].Name)
+
+	// an event without a digest in the bank of the PCR cannot be replayed
+	tcgEventLog.Events[0].Digests = map[types.SHAAlgorithm]string{types.SHA1: strings.Repeat("01", 20)}
+
+	result, err = rule.Apply(&hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogInvalid, result.Faults[0].Name)
+	t.Logf("Fault description: %s", result.Faults[0].Description)
+}
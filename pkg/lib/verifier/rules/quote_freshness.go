@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+)
+
+// NewQuoteFreshness creates a rule that compares the TPM clock info of the quote of the host with the quote of its
+// previous attestation.  The clock info is signed with the quote, so a compromised host that replays an old quote
+// cannot hide that its TPM clock or reset count went backwards.
+func NewQuoteFreshness(marker common.FlavorPart) (Rule, error) {
+	rule := quoteFreshness{
+		marker: marker,
+	}
+	return &rule, nil
+}
+
+type quoteFreshness struct {
+	marker common.FlavorPart
+}
+
+// - If the hostmanifest does not contain the quote metadata, or it is the first attestation of the host, there is
+//   nothing to compare with.
+// - If the nonce of the quote is the nonce of the previous quote, create a QuoteNonceReused fault.
+// - If the reset count is lower than the previous one, create a QuoteResetCountRollback fault.
+// - If the reset count did not change and the restart count is lower than the previous one, create a
+//   QuoteRestartCountRollback fault.
+// - If the clock is lower than the previous one, create a QuoteClockRollback fault.  Unless the TPM was reset and
+//   the previous clock was not safe, the TPM may lose the updates of the clock that were not saved at power loss.
+// - If the TPM was reset or restarted since the previous attestation, add a QuoteTpmReset warning.
+func (rule *quoteFreshness) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleQuoteFreshness
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+
+	if hostManifest.QuoteInfo == nil || hostManifest.QuoteInfo.PreviousClockInfo == nil {
+		return &result, nil
+	}
+
+	current := hostManifest.QuoteInfo.ClockInfo
+	previous := hostManifest.QuoteInfo.PreviousClockInfo
+
+	if hostManifest.QuoteInfo.Nonce != "" && hostManifest.QuoteInfo.Nonce == hostManifest.QuoteInfo.PreviousNonce {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultQuoteNonceReused,
+			Description: "The nonce of the TPM quote is the nonce of the previous attestation",
+		})
+	}
+
+	if current.ResetCount < previous.ResetCount {
+		result.Faults = append(result.Faults, newQuoteCounterFault(constants.FaultQuoteResetCountRollback,
+			fmt.Sprintf("The TPM reset count %d is lower than the reset count %d of the previous attestation",
+				current.ResetCount, previous.ResetCount), uint64(previous.ResetCount), uint64(current.ResetCount)))
+	} else if current.ResetCount == previous.ResetCount && current.RestartCount < previous.RestartCount {
+		result.Faults = append(result.Faults, newQuoteCounterFault(constants.FaultQuoteRestartCountRollback,
+			fmt.Sprintf("The TPM restart count %d is lower than the restart count %d of the previous attestation",
+				current.RestartCount, previous.RestartCount), uint64(previous.RestartCount), uint64(current.RestartCount)))
+	}
+
+	if current.Clock < previous.Clock && (current.ResetCount == previous.ResetCount || previous.Safe) {
+		result.Faults = append(result.Faults, newQuoteCounterFault(constants.FaultQuoteClockRollback,
+			fmt.Sprintf("The TPM clock %d is lower than the clock %d of the previous attestation", current.Clock,
+				previous.Clock), previous.Clock, current.Clock))
+	}
+
+	if current.ResetCount > previous.ResetCount {
+		result.Warnings = append(result.Warnings, hvs.Fault{
+			Name: constants.FaultQuoteTpmReset,
+			Description: fmt.Sprintf("The TPM was reset %d times since the previous attestation",
+				current.ResetCount-previous.ResetCount),
+		})
+	} else if current.ResetCount == previous.ResetCount && current.RestartCount > previous.RestartCount {
+		result.Warnings = append(result.Warnings, hvs.Fault{
+			Name: constants.FaultQuoteTpmReset,
+			Description: fmt.Sprintf("The TPM was restarted %d times since the previous attestation",
+				current.RestartCount-previous.RestartCount),
+		})
+	}
+
+	return &result, nil
+}
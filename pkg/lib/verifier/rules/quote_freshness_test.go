@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPreviousClockInfo = types.TpmClockInfo{
+	Clock:        100000,
+	ResetCount:   5,
+	RestartCount: 2,
+	Safe:         true,
+}
+
+func getTestQuoteInfoHostManifest(clockInfo types.TpmClockInfo, nonce string) *types.HostManifest {
+	previousClockInfo := testPreviousClockInfo
+	return &types.HostManifest{
+		QuoteInfo: &types.QuoteInfo{
+			Nonce:             nonce,
+			ClockInfo:         clockInfo,
+			PreviousNonce:     "cHJldmlvdXM=",
+			PreviousClockInfo: &previousClockInfo,
+		},
+	}
+}
+
+func TestQuoteFreshnessNoFault(t *testing.T) {
+	rule, err := NewQuoteFreshness(common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	// the first attestation of the host and a host manifest without quote metadata
+	result, err := rule.Apply(&types.HostManifest{QuoteInfo: &types.QuoteInfo{ClockInfo: testPreviousClockInfo}})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RuleQuoteFreshness, result.Rule.Name)
+	assert.Empty(t, result.Faults)
+	result, err = rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Faults)
+
+	// the same boot cycle
+	result, err = rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 160000, ResetCount: 5,
+		RestartCount: 2, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Faults)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestQuoteFreshnessTpmResetWarning(t *testing.T) {
+	rule, err := NewQuoteFreshness(common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	// a reboot resets the restart count and is reported as a warning
+	result, err := rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 190000, ResetCount: 6,
+		RestartCount: 0, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Faults)
+	assert.Equal(t, 1, len(result.Warnings))
+	assert.Equal(t, constants.FaultQuoteTpmReset, result.Warnings[0].Name)
+
+	// a resume from hibernation
+	result, err = rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 190000, ResetCount: 5,
+		RestartCount: 3, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Faults)
+	assert.Equal(t, 1, len(result.Warnings))
+	t.Logf("Warning description: %s", result.Warnings[0].Description)
+
+	// the clock updates that were not safe are lost at power loss
+	hostManifest := getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 90000, ResetCount: 6, Safe: true},
+		"Y3VycmVudA==")
+	hostManifest.QuoteInfo.PreviousClockInfo.Safe = false
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Faults)
+}
+
+func TestQuoteFreshnessRollbackFaults(t *testing.T) {
+	rule, err := NewQuoteFreshness(common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	// a quote of an earlier boot cycle
+	result, err := rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 80000, ResetCount: 4,
+		RestartCount: 7, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Faults))
+	assert.Equal(t, constants.FaultQuoteResetCountRollback, result.Faults[0].Name)
+	assert.Equal(t, "5", *result.Faults[0].ExpectedValue)
+	assert.Equal(t, "4", *result.Faults[0].ActualValue)
+	assert.Equal(t, constants.FaultQuoteClockRollback, result.Faults[1].Name)
+	assert.Empty(t, result.Warnings)
+
+	// a quote of the same boot cycle before a restart
+	result, err = rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 120000, ResetCount: 5,
+		RestartCount: 1, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultQuoteRestartCountRollback, result.Faults[0].Name)
+
+	// the clock cannot go backwards in a boot cycle
+	result, err = rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 99999, ResetCount: 5,
+		RestartCount: 2, Safe: true}, "Y3VycmVudA=="))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultQuoteClockRollback, result.Faults[0].Name)
+
+	// the nonce of the previous quote
+	result, err = rule.Apply(getTestQuoteInfoHostManifest(types.TpmClockInfo{Clock: 160000, ResetCount: 5,
+		RestartCount: 2, Safe: true}, "cHJldmlvdXM="))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultQuoteNonceReused, result.Faults[0].Name)
+	for _, fault := range result.Faults {
+		t.Logf("Fault description: %s", fault.Description)
+	}
+}
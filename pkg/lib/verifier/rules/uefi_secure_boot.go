@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// NewUefiSecureBoot creates a rule that evaluates the UEFI Secure Boot variables measured to PCR 7 (SecureBoot,
+// PK, KEK, db and dbx) against the Secure Boot policy of a flavor.
+func NewUefiSecureBoot(expectedPolicy *model.SecureBootPolicy, flavorID uuid.UUID, marker common.FlavorPart) (Rule, error) {
+	if expectedPolicy == nil {
+		return nil, errors.New("The expected Secure Boot policy cannot be nil")
+	}
+	if expectedPolicy.MinDbxEntries < 0 {
+		return nil, errors.Errorf("Invalid minimum number %d of dbx entries", expectedPolicy.MinDbxEntries)
+	}
+	for _, dbxEntry := range expectedPolicy.RequiredDbxEntries {
+		if digest, err := hex.DecodeString(dbxEntry); err != nil || len(digest) != sha256.Size {
+			return nil, errors.Errorf("The required dbx entry '%s' is not a hex encoded SHA256 digest", dbxEntry)
+		}
+	}
+
+	rule := uefiSecureBoot{
+		expectedPolicy: expectedPolicy,
+		flavorID:       flavorID,
+		marker:         marker,
+	}
+	return &rule, nil
+}
+
+type uefiSecureBoot struct {
+	expectedPolicy *model.SecureBootPolicy
+	flavorID       uuid.UUID
+	marker         common.FlavorPart
+}
+
+// - If the hostmanifest does not contain a TCG event log, create a PcrEventLogMissing fault.
+// - If the Secure Boot variables of the event log do not match the digests of their events, create a
+//   PcrEventLogInvalid fault.  The PcrEventLogIntegrity rule of PCR 7 verifies the digests against the PCR.
+// - If the policy requires Secure Boot and the SecureBoot variable is not set, create a SecureBootDisabled fault.
+// - If the policy has PK, KEK or db issuers and a certificate of the variable is not issued by any of them, create
+//   a SecureBootIssuerMismatch fault.
+// - If the dbx has fewer entries than the policy's minimum or does not revoke each of its required digests,
+//   create a SecureBootDbxOutdated fault.
+// - When a variable that the policy depends on is not measured, create a SecureBootVariableMissing fault and
+//   when its signature lists cannot be decoded, create a SecureBootVariableInvalid fault.
+func (rule *uefiSecureBoot) Apply(hostManifest *types.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RuleUefiSecureBoot
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+	result.Rule.FlavorID = &rule.flavorID
+	result.Rule.ExpectedSecureBootPolicy = rule.expectedPolicy
+
+	if hostManifest.TcgEventLog == nil {
+		result.Faults = append(result.Faults, newPcrEventLogMissingFault(types.PCR7))
+		return &result, nil
+	}
+
+	variables, err := hostManifest.TcgEventLog.GetSecureBootVariables()
+	if err != nil {
+		pcrIndex := types.PCR7
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultPcrEventLogInvalid,
+			Description: fmt.Sprintf("PCR %d Event Log is invalid: %s", pcrIndex, err.Error()),
+			PcrIndex:    &pcrIndex,
+		})
+		return &result, nil
+	}
+
+	if rule.expectedPolicy.Enabled {
+		secureBoot, ok := variables[types.SecureBootVariable]
+		if !ok {
+			result.Faults = append(result.Faults, newSecureBootVariableMissingFault(types.SecureBootVariable))
+		} else if !bytes.Equal(secureBoot.VariableData, []byte{1}) {
+			result.Faults = append(result.Faults, newSecureBootDisabledFault())
+		}
+	}
+
+	result.Faults = append(result.Faults, verifySecureBootIssuers(variables, types.PkVariable, rule.expectedPolicy.PkIssuers)...)
+	result.Faults = append(result.Faults, verifySecureBootIssuers(variables, types.KekVariable, rule.expectedPolicy.KekIssuers)...)
+	result.Faults = append(result.Faults, verifySecureBootIssuers(variables, types.DbVariable, rule.expectedPolicy.DbIssuers)...)
+	result.Faults = append(result.Faults, rule.verifyDbx(variables)...)
+
+	return &result, nil
+}
+
+// verifySecureBootIssuers checks that each X509 certificate of the variable is issued by one of the issuers
+func verifySecureBootIssuers(variables map[string]*types.EfiVariableData, variableName string, issuers []string) []hvs.Fault {
+	if len(issuers) == 0 {
+		return nil
+	}
+
+	signatures, fault := getSecureBootSignatures(variables, variableName)
+	if fault != nil {
+		return []hvs.Fault{*fault}
+	}
+
+	var faults []hvs.Fault
+	certificates := 0
+	for _, signature := range signatures {
+		if signature.SignatureType != types.EfiCertX509Guid {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(signature.Data)
+		if err != nil {
+			faults = append(faults, newSecureBootVariableInvalidFault(variableName, err))
+			continue
+		}
+		certificates++
+		if !isIssuedBy(certificate, issuers) {
+			faults = append(faults, newSecureBootIssuerMismatchFault(variableName, issuers, certificate.Issuer.String()))
+		}
+	}
+
+	// a variable without certificates cannot be issued by the expected issuers, the PK is empty in setup mode
+	if certificates == 0 && len(faults) == 0 {
+		faults = append(faults, newSecureBootVariableMissingFault(variableName))
+	}
+	return faults
+}
+
+// isIssuedBy returns true when the common name or one of the organizations of the certificate issuer is in issuers
+func isIssuedBy(certificate *x509.Certificate, issuers []string) bool {
+	for _, issuer := range issuers {
+		if certificate.Issuer.CommonName == issuer {
+			return true
+		}
+		for _, organization := range certificate.Issuer.Organization {
+			if organization == issuer {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyDbx checks the number of revocations of the dbx and that the required digests are revoked
+func (rule *uefiSecureBoot) verifyDbx(variables map[string]*types.EfiVariableData) []hvs.Fault {
+	if rule.expectedPolicy.MinDbxEntries == 0 && len(rule.expectedPolicy.RequiredDbxEntries) == 0 {
+		return nil
+	}
+
+	signatures, fault := getSecureBootSignatures(variables, types.DbxVariable)
+	if fault != nil {
+		return []hvs.Fault{*fault}
+	}
+
+	var faults []hvs.Fault
+	if len(signatures) < rule.expectedPolicy.MinDbxEntries {
+		expectedValue := strconv.Itoa(rule.expectedPolicy.MinDbxEntries)
+		actualValue := strconv.Itoa(len(signatures))
+		faults = append(faults, newSecureBootDbxOutdatedFault(fmt.Sprintf("The dbx contains %d entries, at least %d are required",
+			len(signatures), rule.expectedPolicy.MinDbxEntries), &expectedValue, &actualValue))
+	}
+
+	revokedDigests := map[string]bool{}
+	for _, signature := range signatures {
+		if signature.SignatureType == types.EfiCertSha256Guid {
+			revokedDigests[hex.EncodeToString(signature.Data)] = true
+		}
+	}
+	var missingDigests []string
+	for _, dbxEntry := range rule.expectedPolicy.RequiredDbxEntries {
+		if !revokedDigests[strings.ToLower(dbxEntry)] {
+			missingDigests = append(missingDigests, dbxEntry)
+		}
+	}
+	if len(missingDigests) > 0 {
+		expectedValue := strings.Join(missingDigests, ", ")
+		faults = append(faults, newSecureBootDbxOutdatedFault(fmt.Sprintf("The dbx does not revoke %d of the required digests",
+			len(missingDigests)), &expectedValue, nil))
+	}
+	return faults
+}
+
+// getSecureBootSignatures decodes the signature lists of the variable, or returns the fault when it is missing or invalid
+func getSecureBootSignatures(variables map[string]*types.EfiVariableData, variableName string) ([]types.EfiSignatureData, *hvs.Fault) {
+	variable, ok := variables[variableName]
+	if !ok {
+		fault := newSecureBootVariableMissingFault(variableName)
+		return nil, &fault
+	}
+
+	signatures, err := types.ParseEfiSignatureLists(variable.VariableData)
+	if err != nil {
+		fault := newSecureBootVariableInvalidFault(variableName, err)
+		return nil, &fault
+	}
+	return signatures, nil
+}
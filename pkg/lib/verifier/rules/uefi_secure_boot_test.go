@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDbxDigest = strings.Repeat("5a", sha256.Size)
+
+// getTestSecureBootCertificate returns a DER certificate issued by the given common name and organization
+func getTestSecureBootCertificate(t *testing.T, commonName string, organization string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{organization}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return certificate
+}
+
+// getTestSignatureList returns an EFI_SIGNATURE_LIST of the signatures of the given type
+func getTestSignatureList(signatureType string, signatures ...[]byte) []byte {
+	guid, _ := uuid.Parse(signatureType)
+	signatureList := new(bytes.Buffer)
+	// the first three fields of an EFI_GUID are little endian
+	signatureList.Write([]byte{guid[3], guid[2], guid[1], guid[0], guid[5], guid[4], guid[7], guid[6]})
+	signatureList.Write(guid[8:])
+	_ = binary.Write(signatureList, binary.LittleEndian, []uint32{uint32(28 + len(signatures)*(16+len(signatures[0]))), 0,
+		uint32(16 + len(signatures[0]))})
+	for _, signature := range signatures {
+		signatureList.Write(make([]byte, 16))
+		signatureList.Write(signature)
+	}
+	return signatureList.Bytes()
+}
+
+// getTestSecureBootEvent returns the EV_EFI_VARIABLE_DRIVER_CONFIG event of PCR 7 that measures the variable
+func getTestSecureBootEvent(variableGuid string, name string, data []byte) types.TcgEvent {
+	guid, _ := uuid.Parse(variableGuid)
+	unicodeName := utf16.Encode([]rune(name))
+	eventData := new(bytes.Buffer)
+	eventData.Write([]byte{guid[3], guid[2], guid[1], guid[0], guid[5], guid[4], guid[7], guid[6]})
+	eventData.Write(guid[8:])
+	_ = binary.Write(eventData, binary.LittleEndian, []uint64{uint64(len(unicodeName)), uint64(len(data))})
+	_ = binary.Write(eventData, binary.LittleEndian, unicodeName)
+	eventData.Write(data)
+
+	digest := sha256.Sum256(eventData.Bytes())
+	return types.TcgEvent{
+		PcrIndex:  types.PCR7,
+		EventType: types.EV_EFI_VARIABLE_DRIVER_CONFIG,
+		Digests:   map[types.SHAAlgorithm]string{types.SHA256: hex.EncodeToString(digest[:])},
+		Data:      eventData.Bytes(),
+	}
+}
+
+func getTestSecureBootHostManifest(t *testing.T, secureBoot byte, pkIssuer string) *types.HostManifest {
+	dbxDigest, _ := hex.DecodeString(testDbxDigest)
+	dbxList := getTestSignatureList(types.EfiCertSha256Guid, dbxDigest, make([]byte, sha256.Size))
+	return &types.HostManifest{
+		TcgEventLog: &types.TcgEventLog{
+			Events: []types.TcgEvent{
+				getTestSecureBootEvent(types.EfiGlobalVariableGuid, types.SecureBootVariable, []byte{secureBoot}),
+				getTestSecureBootEvent(types.EfiGlobalVariableGuid, types.PkVariable,
+					getTestSignatureList(types.EfiCertX509Guid, getTestSecureBootCertificate(t, pkIssuer, "Vendor"))),
+				getTestSecureBootEvent(types.EfiGlobalVariableGuid, types.KekVariable,
+					getTestSignatureList(types.EfiCertX509Guid, getTestSecureBootCertificate(t, "KEK CA", "Vendor"))),
+				getTestSecureBootEvent(types.EfiImageSecurityDatabaseGuid, types.DbVariable,
+					getTestSignatureList(types.EfiCertX509Guid, getTestSecureBootCertificate(t, "db CA", "OS Vendor"))),
+				getTestSecureBootEvent(types.EfiImageSecurityDatabaseGuid, types.DbxVariable, dbxList),
+				{
+					PcrIndex:  types.PCR7,
+					EventType: types.EV_SEPARATOR,
+					Data:      []byte{0, 0, 0, 0},
+				},
+			},
+		},
+	}
+}
+
+var testSecureBootPolicy = model.SecureBootPolicy{
+	Enabled:            true,
+	PkIssuers:          []string{"Platform Key CA"},
+	KekIssuers:         []string{"Vendor"},
+	DbIssuers:          []string{"OS Vendor"},
+	MinDbxEntries:      2,
+	RequiredDbxEntries: []string{strings.ToUpper(testDbxDigest)},
+}
+
+func TestUefiSecureBootNoFault(t *testing.T) {
+	hostManifest := getTestSecureBootHostManifest(t, 1, "Platform Key CA")
+
+	rule, err := NewUefiSecureBoot(&testSecureBootPolicy, uuid.New(), common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constants.RuleUefiSecureBoot, result.Rule.Name)
+	assert.Equal(t, &testSecureBootPolicy, result.Rule.ExpectedSecureBootPolicy)
+	assert.Equal(t, 0, len(result.Faults))
+}
+
+func TestUefiSecureBootPolicyFaults(t *testing.T) {
+	// Secure Boot disabled and a PK issued by another vendor
+	hostManifest := getTestSecureBootHostManifest(t, 0, "Other CA")
+
+	// a newer dbx has more revocations
+	policy := testSecureBootPolicy
+	policy.MinDbxEntries = 3
+	policy.RequiredDbxEntries = append(policy.RequiredDbxEntries, strings.Repeat("a5", sha256.Size))
+
+	rule, err := NewUefiSecureBoot(&policy, uuid.New(), common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootDisabled, result.Faults[0].Name)
+	assert.Equal(t, constants.FaultSecureBootIssuerMismatch, result.Faults[1].Name)
+	assert.Equal(t, "CN=Other CA,O=Vendor", *result.Faults[1].ActualValue)
+	assert.Equal(t, constants.FaultSecureBootDbxOutdated, result.Faults[2].Name)
+	assert.Equal(t, "2", *result.Faults[2].ActualValue)
+	assert.Equal(t, constants.FaultSecureBootDbxOutdated, result.Faults[3].Name)
+	assert.Equal(t, strings.Repeat("a5", sha256.Size), *result.Faults[3].ExpectedValue)
+	for _, fault := range result.Faults {
+		assert.Equal(t, types.PCR7, *fault.PcrIndex)
+		t.Logf("Fault description: %s", fault.Description)
+	}
+}
+
+func TestUefiSecureBootVariableFaults(t *testing.T) {
+	hostManifest := getTestSecureBootHostManifest(t, 1, "Platform Key CA")
+
+	// the KEK is not measured and the db is not a signature list
+	hostManifest.TcgEventLog.Events = append(hostManifest.TcgEventLog.Events[:2], hostManifest.TcgEventLog.Events[4:]...)
+	hostManifest.TcgEventLog.Events = append([]types.TcgEvent{getTestSecureBootEvent(types.EfiImageSecurityDatabaseGuid,
+		types.DbVariable, []byte{1, 2, 3})}, hostManifest.TcgEventLog.Events...)
+
+	rule, err := NewUefiSecureBoot(&testSecureBootPolicy, uuid.New(), common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Faults))
+	assert.Equal(t, constants.FaultSecureBootVariableMissing, result.Faults[0].Name)
+	assert.Equal(t, constants.FaultSecureBootVariableInvalid, result.Faults[1].Name)
+}
+
+func TestUefiSecureBootEventLogFaults(t *testing.T) {
+	rule, err := NewUefiSecureBoot(&testSecureBootPolicy, uuid.New(), common.FlavorPartPlatform)
+	assert.NoError(t, err)
+
+	// no TCG event log
+	result, err := rule.Apply(&types.HostManifest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogMissing, result.Faults[0].Name)
+
+	// the data of the SecureBoot variable does not match its digest
+	hostManifest := getTestSecureBootHostManifest(t, 0, "Platform Key CA")
+	hostManifest.TcgEventLog.Events[0].Data[len(hostManifest.TcgEventLog.Events[0].Data)-1] = 1
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Faults))
+	assert.Equal(t, constants.FaultPcrEventLogInvalid, result.Faults[0].Name)
+
+	// the variables measured after the separator are not Secure Boot configuration
+	hostManifest = getTestSecureBootHostManifest(t, 1, "Platform Key CA")
+	hostManifest.TcgEventLog.Events = append(hostManifest.TcgEventLog.Events[5:], hostManifest.TcgEventLog.Events[:5]...)
+	result, err = rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(result.Faults))
+	for _, fault := range result.Faults {
+		assert.Equal(t, constants.FaultSecureBootVariableMissing, fault.Name)
+	}
+
+	_, err = NewUefiSecureBoot(&model.SecureBootPolicy{RequiredDbxEntries: []string{"abcd"}}, uuid.New(), common.FlavorPartPlatform)
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/google/uuid"
+
+// AttestationTokenProfile identifies the claims of the attestation tokens issued by the HVS
+const AttestationTokenProfile = "urn:intel-secl:hvs:attestation-token:v1"
+
+// AttestationTokenClaims are the claims of the signed attestation token of a host report, in addition to the
+// registered JWT claims. They follow the claims of an IETF Entity Attestation Token (EAT) and carry the same
+// trust markers, asset tags and hardware features as the SAML report
+type AttestationTokenClaims struct {
+	Profile string `json:"eat_profile"`
+	// UEID is the base64url encoded universal entity ID of the host, derived from its hardware UUID
+	UEID string `json:"ueid,omitempty"`
+	// swagger:strfmt uuid
+	HostId   uuid.UUID `json:"host_id"`
+	HostName string    `json:"host_name"`
+	Trusted  bool      `json:"trusted"`
+	// TrustMarkers has the trust status of each flavor part the host was verified against
+	TrustMarkers          map[string]bool   `json:"trust_markers"`
+	HardwareFeatures      map[string]string `json:"hardware_features,omitempty"`
+	AssetTags             map[string]string `json:"asset_tags,omitempty"`
+	HostInfo              map[string]string `json:"host_info,omitempty"`
+	TPMVersion            string            `json:"tpm_version,omitempty"`
+	AIKCertificate        string            `json:"aik_certificate,omitempty"`
+	BindingKeyCertificate string            `json:"binding_key_certificate,omitempty"`
+	NewBootSession        bool              `json:"new_boot_session,omitempty"`
+}
@@ -13,3 +13,10 @@ type DeployManifestRequest struct {
 	// swagger:strfmt uuid
 	FlavorId uuid.UUID `json:"flavor_id"`
 }
+
+// DeployManifestResponse has the FLAVOR_VERIFY job of the host queued for trust verification once the manifest is
+// deployed
+type DeployManifestResponse struct {
+	// swagger:strfmt uuid
+	JobId *uuid.UUID `json:"job_id,omitempty"`
+}
@@ -17,6 +17,9 @@ type ESXiCluster struct {
 	ConnectionString string    `json:"connection_string"`
 	ClusterName      string    `json:"cluster_name"`
 	HostNames        []string  `json:"hosts"`
+	// JobId is set on registration to the FLAVOR_VERIFY job of the hosts registered with the cluster
+	// swagger:strfmt uuid
+	JobId *uuid.UUID `json:"job_id,omitempty"`
 }
 
 type ESXiClusterCreateRequest struct {
@@ -5,6 +5,7 @@
 package hvs
 
 import (
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 )
 
@@ -23,9 +24,18 @@ type Flavors struct {
 // SignedFlavor sourced from the lib/flavor - this is a external request/response on the HVS API
 type SignedFlavor = model.SignedFlavor
 
-// SignedFlavorCollection is a list of SignedFlavor objects
+// RuleReference sourced from the lib/flavor - this is a external request/response on the HVS API
+type RuleReference = model.RuleReference
+
+// FlavorLifecycle sourced from the lib/flavor - this is a external request/response on the HVS API
+type FlavorLifecycle = model.Lifecycle
+
+// SignedFlavorCollection is a list of SignedFlavor objects. JobId is set on flavor creation to the FLAVOR_VERIFY job
+// of the hosts queued for trust verification against the created flavors
 type SignedFlavorCollection struct {
 	SignedFlavors []SignedFlavor `json:"signed_flavors"`
+	// swagger:strfmt uuid
+	JobId *uuid.UUID `json:"job_id,omitempty"`
 }
 
 func (s SignedFlavorCollection) GetFlavors(flavorPart string) []SignedFlavor {
@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// FlavorBundleVersion is the version of the flavor bundle format
+const FlavorBundleVersion = "1.0"
+
+// FlavorBundle carries signed flavors between HVS instances along with the flavorgroups they are linked to and the
+// match policies of the flavorgroups
+type FlavorBundle struct {
+	Manifest      FlavorBundleManifest `json:"manifest"`
+	SignedFlavors []SignedFlavor       `json:"signed_flavors"`
+	// FlavorGroups lists the flavorgroups along with the ids of the flavors of the bundle that are linked to them
+	FlavorGroups []FlavorGroup `json:"flavorgroups,omitempty"`
+}
+
+// FlavorBundleManifest describes the content of a flavor bundle
+type FlavorBundleManifest struct {
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// SigningCertificates is the DER encoded certificate chain of the key the flavors are signed with, the flavor
+	// signing certificate followed by its intermediate CAs
+	// swagger:strfmt base64
+	SigningCertificates [][]byte            `json:"signing_certificates"`
+	Flavors             []FlavorBundleEntry `json:"flavors"`
+}
+
+// FlavorBundleEntry describes a flavor of a flavor bundle
+type FlavorBundleEntry struct {
+	// swagger:strfmt uuid
+	FlavorId   uuid.UUID `json:"flavor_id"`
+	Label      string    `json:"label"`
+	FlavorPart string    `json:"flavor_part"`
+	// Digest is the hex encoded content digest of the flavor
+	Digest string `json:"digest"`
+}
+
+// FlavorBundleConflictReason tells why a flavor of a bundle was not imported
+type FlavorBundleConflictReason string
+
+const (
+	// FlavorBundleConflictId is reported when a flavor with the same id but a different content exists
+	FlavorBundleConflictId FlavorBundleConflictReason = "ID"
+	// FlavorBundleConflictLabel is reported when a flavor with the same label but a different content exists
+	FlavorBundleConflictLabel FlavorBundleConflictReason = "LABEL"
+	// FlavorBundleConflictDigest is reported when a flavor with the same content but a different label exists
+	FlavorBundleConflictDigest FlavorBundleConflictReason = "DIGEST"
+)
+
+// FlavorBundleConflict describes a flavor of a bundle that conflicts with an existing flavor
+type FlavorBundleConflict struct {
+	// swagger:strfmt uuid
+	FlavorId uuid.UUID `json:"flavor_id"`
+	Label    string    `json:"label"`
+	// swagger:strfmt uuid
+	ExistingFlavorId uuid.UUID                  `json:"existing_flavor_id"`
+	Reason           FlavorBundleConflictReason `json:"reason"`
+}
+
+// FlavorBundleImportReport is the result of a flavor bundle import
+type FlavorBundleImportReport struct {
+	// ImportedFlavors are the flavors created from the bundle
+	ImportedFlavors []SignedFlavor `json:"imported_flavors,omitempty"`
+	// ExistingFlavors are the ids of the flavors of the bundle that already exist with the same content
+	// swagger:strfmt uuid
+	ExistingFlavors []uuid.UUID `json:"existing_flavors,omitempty"`
+	// Conflicts lists the flavors of the bundle that were not imported
+	Conflicts []FlavorBundleConflict `json:"conflicts,omitempty"`
+	// CreatedFlavorGroups are the names of the flavorgroups created from the bundle
+	CreatedFlavorGroups []string `json:"created_flavorgroups,omitempty"`
+	// JobId is the FLAVOR_VERIFY job of the hosts queued for trust verification against the imported flavors
+	// swagger:strfmt uuid
+	JobId *uuid.UUID `json:"job_id,omitempty"`
+}
+
+// Validate checks that the manifest of the bundle describes the flavors of the bundle, and that the flavorgroups
+// only link flavors of the bundle
+func (b *FlavorBundle) Validate() error {
+	if b.Manifest.Version != FlavorBundleVersion {
+		return errors.Errorf("Unsupported flavor bundle version %s", b.Manifest.Version)
+	}
+	if len(b.Manifest.SigningCertificates) == 0 {
+		return errors.New("Flavor bundle manifest does not contain the flavor signing certificate")
+	}
+	if len(b.SignedFlavors) == 0 {
+		return errors.New("Flavor bundle does not contain any flavor")
+	}
+	if len(b.Manifest.Flavors) != len(b.SignedFlavors) {
+		return errors.New("Flavor bundle manifest does not match the flavors of the bundle")
+	}
+
+	entries := make(map[uuid.UUID]FlavorBundleEntry, len(b.Manifest.Flavors))
+	labels := make(map[string]bool, len(b.Manifest.Flavors))
+	for _, entry := range b.Manifest.Flavors {
+		if _, ok := entries[entry.FlavorId]; ok {
+			return errors.Errorf("Flavor bundle contains flavor %s more than once", entry.FlavorId)
+		}
+		if labels[entry.Label] {
+			return errors.Errorf("Flavor bundle contains label %s more than once", entry.Label)
+		}
+		entries[entry.FlavorId] = entry
+		labels[entry.Label] = true
+	}
+	for _, signedFlavor := range b.SignedFlavors {
+		meta := signedFlavor.Flavor.Meta
+		entry, ok := entries[meta.ID]
+		if !ok || entry.Label != meta.Description.Label || entry.FlavorPart != meta.Description.FlavorPart {
+			return errors.Errorf("Flavor %s is not described by the flavor bundle manifest", meta.ID)
+		}
+		digest, err := signedFlavor.Flavor.GetContentDigest()
+		if err != nil {
+			return errors.Wrapf(err, "Failed to compute the digest of flavor %s", meta.ID)
+		}
+		if digest != entry.Digest {
+			return errors.Errorf("Digest of flavor %s does not match the flavor bundle manifest", meta.ID)
+		}
+	}
+
+	for _, flavorGroup := range b.FlavorGroups {
+		if flavorGroup.Name == "" {
+			return errors.New("Flavor bundle contains a flavorgroup without name")
+		}
+		for _, flavorId := range flavorGroup.FlavorIds {
+			if _, ok := entries[flavorId]; !ok {
+				return errors.Errorf("Flavorgroup %s links flavor %s that is not part of the flavor bundle", flavorGroup.Name, flavorId)
+			}
+		}
+	}
+	return nil
+}
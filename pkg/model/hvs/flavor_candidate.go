@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// FlavorCandidate is a flavor captured from a golden host that is pending approval, along with its differences
+// with the flavors of the same flavor part in its flavorgroups
+type FlavorCandidate struct {
+	SignedFlavor SignedFlavor `json:"signed_flavor"`
+	Diffs        []FlavorDiff `json:"diffs,omitempty"`
+}
+
+// FlavorCandidateCollection is a list of FlavorCandidate objects
+type FlavorCandidateCollection struct {
+	Candidates []FlavorCandidate `json:"candidates"`
+}
+
+// FlavorDiff lists the measurements of a candidate flavor that differ from an existing flavor
+type FlavorDiff struct {
+	// swagger:strfmt uuid
+	ExistingFlavorId uuid.UUID         `json:"existing_flavor_id"`
+	ExistingLabel    string            `json:"existing_label"`
+	Measurements     []MeasurementDiff `json:"measurements,omitempty"`
+}
+
+// MeasurementDiff is a measurement that differs between a candidate flavor and an existing flavor. The name of a
+// PCR measurement is the PCR bank followed by the PCR index, the name of a software measurement is its path. The
+// value is empty if the measurement is missing from the flavor
+type MeasurementDiff struct {
+	Name           string `json:"name"`
+	ExistingValue  string `json:"existing_value,omitempty"`
+	CandidateValue string `json:"candidate_value,omitempty"`
+}
+
+// NewFlavorDiff compares the PCR and software measurements of a candidate flavor with an existing flavor
+func NewFlavorDiff(candidate, existing *Flavor) FlavorDiff {
+	candidateMeasurements := getFlavorMeasurements(candidate)
+	existingMeasurements := getFlavorMeasurements(existing)
+
+	var names []string
+	for name := range candidateMeasurements {
+		names = append(names, name)
+	}
+	for name := range existingMeasurements {
+		if _, ok := candidateMeasurements[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	flavorDiff := FlavorDiff{
+		ExistingFlavorId: existing.Meta.ID,
+		ExistingLabel:    existing.Meta.Description.Label,
+	}
+	for _, name := range names {
+		if candidateMeasurements[name] != existingMeasurements[name] {
+			flavorDiff.Measurements = append(flavorDiff.Measurements, MeasurementDiff{
+				Name:           name,
+				ExistingValue:  existingMeasurements[name],
+				CandidateValue: candidateMeasurements[name],
+			})
+		}
+	}
+	return flavorDiff
+}
+
+func getFlavorMeasurements(flavor *Flavor) map[string]string {
+	measurements := make(map[string]string)
+	for bank, pcrs := range flavor.Pcrs {
+		for index, pcr := range pcrs {
+			measurements[bank+"/"+index] = pcr.Value
+		}
+	}
+	if flavor.Software != nil {
+		for path, measurement := range flavor.Software.Measurements {
+			measurements[path] = measurement.Value
+		}
+	}
+	return measurements
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewFlavorDiff", func() {
+	It("Should list the measurements that differ between the flavors", func() {
+		existing := hvs.Flavor{
+			Pcrs: map[string]map[string]model.PcrEx{
+				"SHA256": {
+					"pcr_0":  {Value: "aa"},
+					"pcr_17": {Value: "bb"},
+				},
+			},
+		}
+		candidate := hvs.Flavor{
+			Pcrs: map[string]map[string]model.PcrEx{
+				"SHA256": {
+					"pcr_0":  {Value: "aa"},
+					"pcr_17": {Value: "cc"},
+					"pcr_18": {Value: "dd"},
+				},
+			},
+		}
+		existing.Meta.Description.Label = "existing"
+
+		flavorDiff := hvs.NewFlavorDiff(&candidate, &existing)
+		Expect(flavorDiff.ExistingLabel).To(Equal("existing"))
+		Expect(flavorDiff.Measurements).To(Equal([]hvs.MeasurementDiff{
+			{Name: "SHA256/pcr_17", ExistingValue: "bb", CandidateValue: "cc"},
+			{Name: "SHA256/pcr_18", CandidateValue: "dd"},
+		}))
+	})
+})
@@ -29,11 +29,18 @@ type FlavorGroup struct {
 	FlavorIds     []uuid.UUID         `json:"flavorIds,omitempty"`
 	Flavors       []Flavor            `json:"flavors,omitempty"`
 	MatchPolicies FlavorMatchPolicies `json:"flavor_match_policies,omitempty"`
+	Policy        *FlavorGroupPolicy  `json:"policy,omitempty"`
+	// HostSelector is a label selector, the hosts matching it are linked to the flavorgroup when they are
+	// created or their labels are updated
+	HostSelector string `json:"host_selector,omitempty"`
 }
 
 type FlavorMatchPolicy struct {
 	FlavorPart  cf.FlavorPart `json:"flavor_part,omitempty"`
 	MatchPolicy MatchPolicy   `json:"match_policy,omitempty"`
+	// Rules are additional verification rules registered in the verifier that are applied to the hosts in the
+	// flavorgroup for the flavor part
+	Rules []RuleReference `json:"rules,omitempty"`
 }
 
 type MatchPolicy struct {
@@ -85,12 +92,16 @@ func (r FlavorGroup) MarshalJSON() ([]byte, error) {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		Policy                      *FlavorGroupPolicy          `json:"policy,omitempty"`
+		HostSelector                string                      `json:"host_selector,omitempty"`
 	}{
 		ID:                          r.ID,
 		Name:                        r.Name,
 		FlavorIds:                   r.FlavorIds,
 		Flavors:                     r.Flavors,
 		FlavorMatchPolicyCollection: FlavorMatchPolicyCollection{r.MatchPolicies},
+		Policy:                      r.Policy,
+		HostSelector:                r.HostSelector,
 	})
 }
 
@@ -101,6 +112,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		FlavorIds                   []uuid.UUID                 `json:"flavorIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		Policy                      *FlavorGroupPolicy          `json:"policy,omitempty"`
+		HostSelector                string                      `json:"host_selector,omitempty"`
 	})
 	err := json.Unmarshal(b, decoded)
 	if err == nil {
@@ -109,6 +122,8 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		r.FlavorIds = decoded.FlavorIds
 		r.Flavors = decoded.Flavors
 		r.MatchPolicies = decoded.FlavorMatchPolicyCollection.FlavorMatchPolicies
+		r.Policy = decoded.Policy
+		r.HostSelector = decoded.HostSelector
 	}
 	return err
 }
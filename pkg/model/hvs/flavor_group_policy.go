@@ -0,0 +1,263 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	cf "github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+// FlavorGroupPolicy is an expression based policy of a flavorgroup that is evaluated in addition to the flavor match
+// policies. The flavor parts referenced by the expression are trusted when the expression is satisfied, even if some
+// of the flavors of these flavor parts are not trusted
+type FlavorGroupPolicy struct {
+	Expression     *PolicyExpression `json:"expression,omitempty"`
+	FlavorValidity []FlavorValidity  `json:"flavor_validity,omitempty"`
+	PcrExceptions  []PcrException    `json:"pcr_exceptions,omitempty"`
+	// RequiredPcrBanks are the PCR banks the quote of the hosts must include, e.g. SHA384 for the servers whose TPM
+	// only has a SHA384 bank
+	RequiredPcrBanks []types.SHAAlgorithm `json:"required_pcr_banks,omitempty"`
+}
+
+// PolicyExpression is a node of a policy expression, exactly one of AllOf, AnyOf, Not or FlavorPart must be set.
+// A FlavorPart node is satisfied when at least MinTrusted flavors of the flavor part are trusted, MinTrusted defaults
+// to one
+type PolicyExpression struct {
+	AllOf      []PolicyExpression `json:"all_of,omitempty"`
+	AnyOf      []PolicyExpression `json:"any_of,omitempty"`
+	Not        *PolicyExpression  `json:"not,omitempty"`
+	FlavorPart cf.FlavorPart      `json:"flavor_part,omitempty"`
+	MinTrusted int                `json:"min_trusted,omitempty"`
+}
+
+// FlavorValidity bounds the time during which a flavor of the flavorgroup is used for verification
+type FlavorValidity struct {
+	// swagger:strfmt uuid
+	FlavorId   uuid.UUID  `json:"flavor_id"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// PcrException excludes the rules of a PCR from the verification of the flavors of a flavor part. The exception
+// applies to all the PCR banks when PcrBank is not set
+type PcrException struct {
+	FlavorPart cf.FlavorPart      `json:"flavor_part"`
+	PcrIndex   types.PcrIndex     `json:"pcr_index"`
+	PcrBank    types.SHAAlgorithm `json:"pcr_bank,omitempty"`
+}
+
+// Validate returns an error if the policy is not well formed
+func (p *FlavorGroupPolicy) Validate() error {
+	if p.Expression == nil && len(p.FlavorValidity) == 0 && len(p.PcrExceptions) == 0 && len(p.RequiredPcrBanks) == 0 {
+		return errors.New("policy must have an expression, flavor validity, pcr exceptions or required pcr banks")
+	}
+	if p.Expression != nil {
+		if err := p.Expression.Validate(); err != nil {
+			return errors.Wrap(err, "invalid policy expression")
+		}
+	}
+
+	flavorIds := make(map[uuid.UUID]bool)
+	for _, validity := range p.FlavorValidity {
+		if validity.FlavorId == uuid.Nil {
+			return errors.New("flavor validity must have a flavor id")
+		}
+		if flavorIds[validity.FlavorId] {
+			return errors.Errorf("flavor validity is defined more than once for flavor %s", validity.FlavorId)
+		}
+		flavorIds[validity.FlavorId] = true
+		if validity.ValidFrom == nil && validity.ValidUntil == nil {
+			return errors.Errorf("flavor validity for flavor %s must have valid_from or valid_until", validity.FlavorId)
+		}
+		if validity.ValidFrom != nil && validity.ValidUntil != nil && !validity.ValidUntil.After(*validity.ValidFrom) {
+			return errors.Errorf("flavor validity for flavor %s must end after it starts", validity.FlavorId)
+		}
+	}
+
+	for _, exception := range p.PcrExceptions {
+		if err := validateFlavorPart(exception.FlavorPart); err != nil {
+			return errors.Wrap(err, "invalid pcr exception")
+		}
+		if exception.PcrIndex < types.PCR0 || exception.PcrIndex > types.PCR23 {
+			return errors.Errorf("invalid pcr exception: pcr index %d is out of range", exception.PcrIndex)
+		}
+		if exception.PcrBank != "" && !isSupportedPcrBank(exception.PcrBank) {
+			return errors.Errorf("invalid pcr exception: unsupported pcr bank %s", exception.PcrBank)
+		}
+	}
+
+	pcrBanks := make(map[types.SHAAlgorithm]bool)
+	for _, pcrBank := range p.RequiredPcrBanks {
+		if !isSupportedPcrBank(pcrBank) {
+			return errors.Errorf("invalid required pcr bank %s", pcrBank)
+		}
+		if pcrBanks[pcrBank] {
+			return errors.Errorf("required pcr bank %s is defined more than once", pcrBank)
+		}
+		pcrBanks[pcrBank] = true
+	}
+	return nil
+}
+
+// GetMissingPcrBanks returns the required PCR banks of the policy that are not in the given banks
+func (p *FlavorGroupPolicy) GetMissingPcrBanks(pcrBanks []types.SHAAlgorithm) []types.SHAAlgorithm {
+	var missingPcrBanks []types.SHAAlgorithm
+	for _, requiredPcrBank := range p.RequiredPcrBanks {
+		found := false
+		for _, pcrBank := range pcrBanks {
+			if pcrBank == requiredPcrBank {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missingPcrBanks = append(missingPcrBanks, requiredPcrBank)
+		}
+	}
+	return missingPcrBanks
+}
+
+func isSupportedPcrBank(pcrBank types.SHAAlgorithm) bool {
+	for _, supportedPcrBank := range types.SupportedPcrBanks {
+		if pcrBank == supportedPcrBank {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFlavorValid returns false if the flavor has a validity in the policy that does not include the given time
+func (p *FlavorGroupPolicy) IsFlavorValid(flavorId uuid.UUID, t time.Time) bool {
+	for _, validity := range p.FlavorValidity {
+		if validity.FlavorId != flavorId {
+			continue
+		}
+		if validity.ValidFrom != nil && t.Before(*validity.ValidFrom) {
+			return false
+		}
+		if validity.ValidUntil != nil && !t.Before(*validity.ValidUntil) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPcrException returns true if the PCR is excluded from the verification of the flavors of the flavor part
+func (p *FlavorGroupPolicy) IsPcrException(flavorPart cf.FlavorPart, pcrIndex types.PcrIndex, pcrBank types.SHAAlgorithm) bool {
+	for _, exception := range p.PcrExceptions {
+		if exception.FlavorPart == flavorPart && exception.PcrIndex == pcrIndex &&
+			(exception.PcrBank == "" || exception.PcrBank == pcrBank) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the expression is not well formed
+func (e *PolicyExpression) Validate() error {
+	nodes := 0
+	if len(e.AllOf) > 0 {
+		nodes++
+	}
+	if len(e.AnyOf) > 0 {
+		nodes++
+	}
+	if e.Not != nil {
+		nodes++
+	}
+	if e.FlavorPart != "" {
+		nodes++
+	}
+	if nodes != 1 {
+		return errors.New("expression must have exactly one of all_of, any_of, not or flavor_part")
+	}
+
+	if e.FlavorPart != "" {
+		if err := validateFlavorPart(e.FlavorPart); err != nil {
+			return err
+		}
+		if e.MinTrusted < 0 {
+			return errors.Errorf("min_trusted of flavor part %s cannot be negative", e.FlavorPart)
+		}
+		return nil
+	}
+	if e.MinTrusted != 0 {
+		return errors.New("min_trusted can only be set along with flavor_part")
+	}
+	if e.Not != nil {
+		return e.Not.Validate()
+	}
+	for _, child := range append(e.AllOf, e.AnyOf...) {
+		if err := child.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate evaluates the expression given the number of trusted flavors for each flavor part
+func (e *PolicyExpression) Evaluate(trustedFlavorCount map[cf.FlavorPart]int) bool {
+	switch {
+	case e.FlavorPart != "":
+		minTrusted := e.MinTrusted
+		if minTrusted == 0 {
+			minTrusted = 1
+		}
+		return trustedFlavorCount[e.FlavorPart] >= minTrusted
+	case e.Not != nil:
+		return !e.Not.Evaluate(trustedFlavorCount)
+	case len(e.AllOf) > 0:
+		for _, child := range e.AllOf {
+			if !child.Evaluate(trustedFlavorCount) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, child := range e.AnyOf {
+			if child.Evaluate(trustedFlavorCount) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GetFlavorParts returns the flavor parts referenced by the expression
+func (e *PolicyExpression) GetFlavorParts() []cf.FlavorPart {
+	var flavorParts []cf.FlavorPart
+	referenced := make(map[cf.FlavorPart]bool)
+	var collect func(e *PolicyExpression)
+	collect = func(e *PolicyExpression) {
+		if e.FlavorPart != "" && !referenced[e.FlavorPart] {
+			referenced[e.FlavorPart] = true
+			flavorParts = append(flavorParts, e.FlavorPart)
+		}
+		if e.Not != nil {
+			collect(e.Not)
+		}
+		for i := range e.AllOf {
+			collect(&e.AllOf[i])
+		}
+		for i := range e.AnyOf {
+			collect(&e.AnyOf[i])
+		}
+	}
+	collect(e)
+	return flavorParts
+}
+
+func validateFlavorPart(flavorPart cf.FlavorPart) error {
+	var fp cf.FlavorPart
+	if err := (&fp).Parse(flavorPart.String()); err != nil {
+		return errors.Errorf("invalid flavor part %s", flavorPart)
+	}
+	return nil
+}
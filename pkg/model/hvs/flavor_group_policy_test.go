@@ -0,0 +1,152 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var flavorGroupPolicyJson = `{
+   "expression":{
+      "all_of":[
+         {
+            "flavor_part":"PLATFORM"
+         },
+         {
+            "any_of":[
+               {
+                  "flavor_part":"OS",
+                  "min_trusted":2
+               },
+               {
+                  "not":{
+                     "flavor_part":"SOFTWARE"
+                  }
+               }
+            ]
+         }
+      ]
+   },
+   "flavor_validity":[
+      {
+         "flavor_id":"2a4de7f5-5a53-4b2c-9b8f-0d8a9c0e3b5d",
+         "valid_from":"2020-01-01T00:00:00Z",
+         "valid_until":"2021-01-01T00:00:00Z"
+      }
+   ],
+   "pcr_exceptions":[
+      {
+         "flavor_part":"OS",
+         "pcr_index":"pcr_18",
+         "pcr_bank":"SHA256"
+      },
+      {
+         "flavor_part":"PLATFORM",
+         "pcr_index":"pcr_0"
+      }
+   ],
+   "required_pcr_banks":[
+      "SHA256",
+      "SHA384"
+   ]
+}`
+
+var _ = Describe("FlavorGroupPolicy", func() {
+	var policy hvs.FlavorGroupPolicy
+	BeforeEach(func() {
+		policy = hvs.FlavorGroupPolicy{}
+		err := json.Unmarshal([]byte(flavorGroupPolicyJson), &policy)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("Validate", func() {
+		It("Should accept a well formed policy", func() {
+			Expect(policy.Validate()).To(Succeed())
+		})
+		It("Should reject an empty policy", func() {
+			Expect((&hvs.FlavorGroupPolicy{}).Validate()).NotTo(Succeed())
+		})
+		It("Should reject an expression node with more than one kind", func() {
+			policy.Expression.AllOf[0].AnyOf = []hvs.PolicyExpression{{FlavorPart: common.FlavorPartOs}}
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+		It("Should reject min_trusted without flavor_part", func() {
+			policy.Expression.MinTrusted = 2
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+		It("Should reject an unknown flavor part", func() {
+			policy.Expression.AllOf[0].FlavorPart = "BIOS"
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+		It("Should reject a flavor validity that ends before it starts", func() {
+			policy.FlavorValidity[0].ValidFrom, policy.FlavorValidity[0].ValidUntil = policy.FlavorValidity[0].ValidUntil, policy.FlavorValidity[0].ValidFrom
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+		It("Should reject a pcr exception that is out of range", func() {
+			policy.PcrExceptions[0].PcrIndex = 24
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+		It("Should accept a pcr exception of the SHA512 bank", func() {
+			policy.PcrExceptions[0].PcrBank = types.SHA512
+			Expect(policy.Validate()).To(Succeed())
+		})
+		It("Should accept a policy with only required pcr banks", func() {
+			Expect((&hvs.FlavorGroupPolicy{RequiredPcrBanks: []types.SHAAlgorithm{types.SHA384}}).Validate()).To(Succeed())
+		})
+		It("Should reject an unsupported or duplicated required pcr bank", func() {
+			policy.RequiredPcrBanks = []types.SHAAlgorithm{"SM3"}
+			Expect(policy.Validate()).NotTo(Succeed())
+			policy.RequiredPcrBanks = []types.SHAAlgorithm{types.SHA384, types.SHA384}
+			Expect(policy.Validate()).NotTo(Succeed())
+		})
+	})
+
+	Context("GetMissingPcrBanks", func() {
+		It("Should return the required pcr banks that are not in the host manifest", func() {
+			Expect(policy.GetMissingPcrBanks([]types.SHAAlgorithm{types.SHA1, types.SHA256})).To(Equal([]types.SHAAlgorithm{types.SHA384}))
+			Expect(policy.GetMissingPcrBanks([]types.SHAAlgorithm{types.SHA256, types.SHA384})).To(BeEmpty())
+		})
+	})
+
+	Context("Evaluate", func() {
+		It("Should evaluate the boolean combination of the flavor parts", func() {
+			Expect(policy.Expression.Evaluate(map[common.FlavorPart]int{common.FlavorPartPlatform: 1})).To(BeTrue())
+			Expect(policy.Expression.Evaluate(map[common.FlavorPart]int{common.FlavorPartPlatform: 1, common.FlavorPartSoftware: 1})).To(BeFalse())
+			Expect(policy.Expression.Evaluate(map[common.FlavorPart]int{common.FlavorPartPlatform: 1, common.FlavorPartSoftware: 1, common.FlavorPartOs: 1})).To(BeFalse())
+			Expect(policy.Expression.Evaluate(map[common.FlavorPart]int{common.FlavorPartPlatform: 1, common.FlavorPartSoftware: 1, common.FlavorPartOs: 2})).To(BeTrue())
+			Expect(policy.Expression.Evaluate(map[common.FlavorPart]int{common.FlavorPartOs: 2})).To(BeFalse())
+		})
+		It("Should return the flavor parts referenced by the expression", func() {
+			Expect(policy.Expression.GetFlavorParts()).To(Equal([]common.FlavorPart{common.FlavorPartPlatform, common.FlavorPartOs, common.FlavorPartSoftware}))
+		})
+	})
+
+	Context("IsFlavorValid", func() {
+		It("Should only use the flavor during its validity", func() {
+			flavorId := policy.FlavorValidity[0].FlavorId
+			Expect(policy.IsFlavorValid(flavorId, time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC))).To(BeFalse())
+			Expect(policy.IsFlavorValid(flavorId, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(policy.IsFlavorValid(flavorId, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))).To(BeFalse())
+			Expect(policy.IsFlavorValid(uuid.New(), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+	})
+
+	Context("IsPcrException", func() {
+		It("Should match the flavor part, pcr index and pcr bank", func() {
+			Expect(policy.IsPcrException(common.FlavorPartOs, types.PCR18, types.SHA256)).To(BeTrue())
+			Expect(policy.IsPcrException(common.FlavorPartOs, types.PCR18, types.SHA1)).To(BeFalse())
+			Expect(policy.IsPcrException(common.FlavorPartPlatform, types.PCR18, types.SHA256)).To(BeFalse())
+			Expect(policy.IsPcrException(common.FlavorPartPlatform, types.PCR0, types.SHA1)).To(BeTrue())
+		})
+	})
+})
@@ -19,13 +19,16 @@ type Host struct {
 	// swagger:strfmt uuid
 	HardwareUuid     *uuid.UUID `json:"hardware_uuid,omitempty"`
 	FlavorgroupNames []string   `json:"flavorgroup_names,omitempty"`
+	// Labels group the hosts, such as "env": "prod", they are matched by label selectors
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type HostCreateRequest struct {
-	HostName         string   `json:"host_name"`
-	Description      string   `json:"description,omitempty"`
-	ConnectionString string   `json:"connection_string"`
-	FlavorgroupNames []string `json:"flavorgroup_names,omitempty"`
+	HostName         string            `json:"host_name"`
+	Description      string            `json:"description,omitempty"`
+	ConnectionString string            `json:"connection_string"`
+	FlavorgroupNames []string          `json:"flavorgroup_names,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type HostFlavorgroupCollection struct {
@@ -43,3 +46,16 @@ type HostFlavorgroupCreateRequest struct {
 	// swagger:strfmt uuid
 	FlavorgroupId uuid.UUID `json:"flavorgroup_id,omitempty"`
 }
+
+// HostBatchCreateRequest registers many hosts at once, the hosts are registered by a job
+type HostBatchCreateRequest struct {
+	Hosts []HostCreateRequest `json:"hosts"`
+}
+
+// HostBatchRequest selects the hosts of a batch operation by their ids or by a label selector, such as "env=prod".
+// FlavorgroupNames are the flavorgroups linked to the hosts by a batch flavorgroup assignment
+type HostBatchRequest struct {
+	HostIds          []uuid.UUID `json:"host_ids,omitempty"`
+	LabelSelector    string      `json:"label_selector,omitempty"`
+	FlavorgroupNames []string    `json:"flavorgroup_names,omitempty"`
+}
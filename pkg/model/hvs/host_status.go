@@ -15,6 +15,9 @@ import (
 type HostStatusInformation struct {
 	HostState         HostState `json:"host_state"`
 	LastTimeConnected time.Time `json:"last_time_connected"`
+	// LastQuoteInfo is the metadata of the TPM quote of the last successful connection, it is kept while the host
+	// cannot be reached so that the next quote of the host is compared with it
+	LastQuoteInfo *types.QuoteInfo `json:"last_quote_info,omitempty"`
 }
 
 // HostStatus contains the response for the Host Status API for an individual host
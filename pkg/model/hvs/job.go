@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobType string
+
+const (
+	JobTypeHostCreate         JobType = "HOST_CREATE"
+	JobTypeHostDelete         JobType = "HOST_DELETE"
+	JobTypeHostFlavorgroupAdd JobType = "HOST_FLAVORGROUP_ADD"
+	JobTypeHostVerify         JobType = "HOST_VERIFY"
+	JobTypeFlavorVerify       JobType = "FLAVOR_VERIFY"
+)
+
+type JobState string
+
+const (
+	JobStateQueued    JobState = "QUEUED"
+	JobStateRunning   JobState = "RUNNING"
+	JobStateCompleted JobState = "COMPLETED"
+	JobStateCancelled JobState = "CANCELLED"
+)
+
+type JobResultState string
+
+const (
+	JobResultStatePending   JobResultState = "PENDING"
+	JobResultStateSucceeded JobResultState = "SUCCEEDED"
+	JobResultStateFailed    JobResultState = "FAILED"
+	JobResultStateCancelled JobResultState = "CANCELLED"
+)
+
+// Job tracks a batch operation on many hosts, it has a result for every host in the order of the request.
+// Trusted is set on the results of the jobs that attest the hosts. The FLAVOR_VERIFY jobs track the hosts queued
+// for trust verification, the results of these jobs have the stage of the hosts still in the queue
+type Job struct {
+	// swagger:strfmt uuid
+	Id          uuid.UUID   `json:"id"`
+	Type        JobType     `json:"type"`
+	State       JobState    `json:"state"`
+	Results     []JobResult `json:"results,omitempty"`
+	CreatedAt   time.Time   `json:"created"`
+	CompletedAt *time.Time  `json:"completed,omitempty"`
+}
+
+type JobCollection struct {
+	Jobs []Job `json:"jobs"`
+}
+
+type JobResult struct {
+	// swagger:strfmt uuid
+	HostId      *uuid.UUID     `json:"host_id,omitempty"`
+	HostName    string         `json:"host_name,omitempty"`
+	State       JobResultState `json:"state"`
+	Stage       string         `json:"stage,omitempty"`
+	Trusted     *bool          `json:"trusted,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	CompletedAt *time.Time     `json:"completed,omitempty"`
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// label keys can have a prefix separated by slashes, such as "dc1/rack", to group labels in a hierarchy
+var (
+	labelKeyRegex       = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,251}[a-zA-Z0-9])?$`)
+	labelValueRegex     = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?)?$`)
+	setRequirementRegex = regexp.MustCompile(`^(\S+)\s+(in|notin)\s+\((.*)\)$`)
+)
+
+type LabelOperator string
+
+const (
+	LabelOperatorEquals       LabelOperator = "="
+	LabelOperatorNotEquals    LabelOperator = "!="
+	LabelOperatorIn           LabelOperator = "in"
+	LabelOperatorNotIn        LabelOperator = "notin"
+	LabelOperatorExists       LabelOperator = "exists"
+	LabelOperatorDoesNotExist LabelOperator = "!"
+)
+
+// LabelRequirement is a condition on the value of a host label
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Values   []string
+}
+
+// LabelSelector selects hosts by their labels, a host is selected when it meets all the requirements
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses a comma separated list of requirements such as "env=prod,rack!=r12". Each requirement
+// is one of "key=value" (or "key==value"), "key!=value", "key in (v1,v2)", "key notin (v1,v2)", "key" when the
+// label must be set and "!key" when it must not be set
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var labelSelector LabelSelector
+	for _, requirement := range splitLabelRequirements(selector) {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			return nil, errors.Errorf("Empty requirement in label selector %q", selector)
+		}
+		lr, err := parseLabelRequirement(requirement)
+		if err != nil {
+			return nil, err
+		}
+		labelSelector = append(labelSelector, *lr)
+	}
+	return labelSelector, nil
+}
+
+// Matches returns true if the labels meet all the requirements of the selector. The requirements on a label that
+// is not set are only met by the !=, notin and ! operators
+func (labelSelector LabelSelector) Matches(labels map[string]string) bool {
+	for _, lr := range labelSelector {
+		value, ok := labels[lr.Key]
+		switch lr.Operator {
+		case LabelOperatorEquals, LabelOperatorIn:
+			if !ok || !containsString(lr.Values, value) {
+				return false
+			}
+		case LabelOperatorNotEquals, LabelOperatorNotIn:
+			if ok && containsString(lr.Values, value) {
+				return false
+			}
+		case LabelOperatorExists:
+			if !ok {
+				return false
+			}
+		case LabelOperatorDoesNotExist:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String returns the selector in the format parsed by ParseLabelSelector
+func (labelSelector LabelSelector) String() string {
+	var requirements []string
+	for _, lr := range labelSelector {
+		switch lr.Operator {
+		case LabelOperatorEquals, LabelOperatorNotEquals:
+			requirements = append(requirements, lr.Key+string(lr.Operator)+lr.Values[0])
+		case LabelOperatorIn, LabelOperatorNotIn:
+			requirements = append(requirements, lr.Key+" "+string(lr.Operator)+" ("+strings.Join(lr.Values, ",")+")")
+		case LabelOperatorExists:
+			requirements = append(requirements, lr.Key)
+		case LabelOperatorDoesNotExist:
+			requirements = append(requirements, string(lr.Operator)+lr.Key)
+		}
+	}
+	return strings.Join(requirements, ",")
+}
+
+// ValidateLabels checks the keys and values of host labels
+func ValidateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLabel(key, []string{labels[key]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseLabelRequirement(requirement string) (*LabelRequirement, error) {
+	var lr LabelRequirement
+	if match := setRequirementRegex.FindStringSubmatch(requirement); match != nil {
+		lr.Key, lr.Operator = match[1], LabelOperator(match[2])
+		for _, value := range strings.Split(match[3], ",") {
+			lr.Values = append(lr.Values, strings.TrimSpace(value))
+		}
+	} else if strings.HasPrefix(requirement, string(LabelOperatorDoesNotExist)) {
+		lr.Key, lr.Operator = strings.TrimSpace(strings.TrimPrefix(requirement, "!")), LabelOperatorDoesNotExist
+	} else if parts := strings.SplitN(requirement, "!=", 2); len(parts) == 2 {
+		lr.Key, lr.Operator, lr.Values = strings.TrimSpace(parts[0]), LabelOperatorNotEquals, []string{strings.TrimSpace(parts[1])}
+	} else if parts := strings.SplitN(strings.Replace(requirement, "==", "=", 1), "=", 2); len(parts) == 2 {
+		lr.Key, lr.Operator, lr.Values = strings.TrimSpace(parts[0]), LabelOperatorEquals, []string{strings.TrimSpace(parts[1])}
+	} else {
+		lr.Key, lr.Operator = requirement, LabelOperatorExists
+	}
+	if err := validateLabel(lr.Key, lr.Values); err != nil {
+		return nil, errors.Wrapf(err, "Invalid label requirement %q", requirement)
+	}
+	return &lr, nil
+}
+
+func validateLabel(key string, values []string) error {
+	if !labelKeyRegex.MatchString(key) {
+		return errors.Errorf("Invalid label key %q", key)
+	}
+	for _, value := range values {
+		if !labelValueRegex.MatchString(value) {
+			return errors.Errorf("Invalid value %q for label %s", value, key)
+		}
+	}
+	return nil
+}
+
+// split the selector at the commas that are not in the value set of an in or notin requirement
+func splitLabelRequirements(selector string) []string {
+	var requirements []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(requirements, selector[start:])
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
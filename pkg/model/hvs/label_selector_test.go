@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LabelSelector", func() {
+	prodHost := map[string]string{"env": "prod", "dc1/rack": "r12"}
+	devHost := map[string]string{"env": "dev"}
+
+	Context("When the selector has all the operators", func() {
+		It("Should parse every requirement", func() {
+			selector, err := hvs.ParseLabelSelector("env==prod, tier!=db,dc1/rack in (r11, r12),zone notin (z1),dc1/row,!maintenance")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector).To(Equal(hvs.LabelSelector{
+				{Key: "env", Operator: hvs.LabelOperatorEquals, Values: []string{"prod"}},
+				{Key: "tier", Operator: hvs.LabelOperatorNotEquals, Values: []string{"db"}},
+				{Key: "dc1/rack", Operator: hvs.LabelOperatorIn, Values: []string{"r11", "r12"}},
+				{Key: "zone", Operator: hvs.LabelOperatorNotIn, Values: []string{"z1"}},
+				{Key: "dc1/row", Operator: hvs.LabelOperatorExists},
+				{Key: "maintenance", Operator: hvs.LabelOperatorDoesNotExist},
+			}))
+			Expect(selector.String()).To(Equal("env=prod,tier!=db,dc1/rack in (r11,r12),zone notin (z1),dc1/row,!maintenance"))
+		})
+	})
+
+	Context("When the selector is invalid", func() {
+		It("Should fail to parse it", func() {
+			for _, selector := range []string{"env=prod,", "env=prod env", "env in (prod", "=prod", "env=prod/dev", "!"} {
+				_, err := hvs.ParseLabelSelector(selector)
+				Expect(err).To(HaveOccurred(), selector)
+			}
+		})
+	})
+
+	Context("When the labels are matched against the selector", func() {
+		It("Should only match the labels meeting all the requirements", func() {
+			selector, err := hvs.ParseLabelSelector("env in (prod,test),dc1/rack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(prodHost)).To(BeTrue())
+			Expect(selector.Matches(devHost)).To(BeFalse())
+			Expect(selector.Matches(nil)).To(BeFalse())
+
+			// the labels that are not set meet the != and ! requirements
+			selector, err = hvs.ParseLabelSelector("env!=prod,!dc1/rack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(prodHost)).To(BeFalse())
+			Expect(selector.Matches(devHost)).To(BeTrue())
+			Expect(selector.Matches(nil)).To(BeTrue())
+		})
+	})
+
+	Context("When the host labels are validated", func() {
+		It("Should reject the invalid keys and values", func() {
+			Expect(hvs.ValidateLabels(prodHost)).To(Succeed())
+			Expect(hvs.ValidateLabels(map[string]string{"env": ""})).To(Succeed())
+			Expect(hvs.ValidateLabels(map[string]string{"env/": "prod"})).NotTo(Succeed())
+			Expect(hvs.ValidateLabels(map[string]string{"env": "prod env"})).NotTo(Succeed())
+		})
+	})
+})
@@ -29,13 +29,19 @@ type Report struct {
 	Expiration  time.Time        `json:"expiration"`
 }
 
+// TrustInformation summarizes the trust status of a host. Warning is set when the host is trusted but some of
+// the results have warnings, for example when the host only matches deprecated flavors. NewBootSession is set when
+// the report was created from a full verification of the host because it was rebooted since its previous attestation
 type TrustInformation struct {
-	Overall     bool                                    `json:"OVERALL"`
-	FlavorTrust map[common.FlavorPart]FlavorTrustStatus `json:"flavors_trust"`
+	Overall        bool                                    `json:"OVERALL"`
+	Warning        bool                                    `json:"warning,omitempty"`
+	NewBootSession bool                                    `json:"new_boot_session,omitempty"`
+	FlavorTrust    map[common.FlavorPart]FlavorTrustStatus `json:"flavors_trust"`
 }
 
 type FlavorTrustStatus struct {
 	Trust                bool         `json:"trust"`
+	Warning              bool         `json:"warning,omitempty"`
 	RuleResultCollection []RuleResult `json:"rules"`
 }
 
@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// maxMaintenanceWindowDuration limits a maintenance window to a week, a longer window would never end
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// Schedule is the attestation schedule of a host, or of all the hosts linked to a flavorgroup. The hosts are
+// attested every Interval plus a random delay of up to Jitter, and are not attested during the maintenance windows
+// of the schedule. Interval and Jitter are durations such as "2m" or "1h"
+type Schedule struct {
+	// swagger:strfmt uuid
+	Id uuid.UUID `json:"id,omitempty"`
+	// swagger:strfmt uuid
+	HostId *uuid.UUID `json:"host_id,omitempty"`
+	// swagger:strfmt uuid
+	FlavorgroupId      *uuid.UUID          `json:"flavorgroup_id,omitempty"`
+	Interval           string              `json:"interval"`
+	Jitter             string              `json:"jitter,omitempty"`
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows,omitempty"`
+	CreatedAt          time.Time           `json:"created,omitempty"`
+}
+
+type ScheduleCollection struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+// MaintenanceWindow is a recurring period during which hosts are not attested. The window starts every day, or
+// only on the given Weekdays (such as "Saturday"), at the Start time in UTC ("22:30") and lasts Duration ("2h")
+type MaintenanceWindow struct {
+	Start    string   `json:"start"`
+	Duration string   `json:"duration"`
+	Weekdays []string `json:"weekdays,omitempty"`
+}
+
+// GetInterval returns the attestation interval of the schedule
+func (schedule *Schedule) GetInterval() (time.Duration, error) {
+	interval, err := time.ParseDuration(schedule.Interval)
+	if err != nil {
+		return 0, errors.Wrap(err, "Invalid schedule interval")
+	}
+	if interval <= 0 {
+		return 0, errors.New("The schedule interval must be positive")
+	}
+	return interval, nil
+}
+
+// GetJitter returns the maximum random delay that is added to the attestation interval of the schedule
+func (schedule *Schedule) GetJitter() (time.Duration, error) {
+	if schedule.Jitter == "" {
+		return 0, nil
+	}
+	jitter, err := time.ParseDuration(schedule.Jitter)
+	if err != nil {
+		return 0, errors.Wrap(err, "Invalid schedule jitter")
+	}
+	if jitter < 0 {
+		return 0, errors.New("The schedule jitter cannot be negative")
+	}
+	return jitter, nil
+}
+
+// InMaintenance returns true if the time is in one of the maintenance windows of the schedule
+func (schedule *Schedule) InMaintenance(t time.Time) bool {
+	for _, window := range schedule.MaintenanceWindows {
+		if window.IsActive(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceEnd returns the end of the maintenance windows of the schedule that are active at the time, or the time
+// itself if none is active
+func (schedule *Schedule) MaintenanceEnd(t time.Time) time.Time {
+	end := t
+	for _, window := range schedule.MaintenanceWindows {
+		if windowEnd, active := window.activeUntil(t); active && windowEnd.After(end) {
+			end = windowEnd
+		}
+	}
+	return end
+}
+
+// Validate checks the start time, duration and weekdays of the maintenance window
+func (window *MaintenanceWindow) Validate() error {
+	_, _, _, err := window.parse()
+	return err
+}
+
+// IsActive returns true if the time is in the maintenance window, an invalid window is never active
+func (window *MaintenanceWindow) IsActive(t time.Time) bool {
+	_, active := window.activeUntil(t)
+	return active
+}
+
+// activeUntil returns the end of the window if it is active at the time
+func (window *MaintenanceWindow) activeUntil(t time.Time) (time.Time, bool) {
+	start, duration, weekdays, err := window.parse()
+	if err != nil {
+		return time.Time{}, false
+	}
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// the window may have started on one of the previous days, the earliest start ends last
+	for day := int(maxMaintenanceWindowDuration / (24 * time.Hour)); day >= 0; day-- {
+		windowStart := midnight.AddDate(0, 0, -day).Add(start)
+		if len(weekdays) > 0 && !weekdays[windowStart.Weekday()] {
+			continue
+		}
+		if !t.Before(windowStart) && t.Before(windowStart.Add(duration)) {
+			return windowStart.Add(duration), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (window *MaintenanceWindow) parse() (time.Duration, time.Duration, map[time.Weekday]bool, error) {
+	startTime, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return 0, 0, nil, errors.New("The maintenance window start must be a time of day such as 22:30")
+	}
+	start := time.Duration(startTime.Hour())*time.Hour + time.Duration(startTime.Minute())*time.Minute
+
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil || duration <= 0 || duration > maxMaintenanceWindowDuration {
+		return 0, 0, nil, errors.New("The maintenance window duration must be a positive duration of up to a week")
+	}
+
+	weekdays := make(map[time.Weekday]bool)
+	for _, weekday := range window.Weekdays {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(weekday, day.String()) {
+				weekdays[day] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, 0, nil, errors.Errorf("Invalid maintenance window weekday %s", weekday)
+		}
+	}
+	return start, duration, weekdays, nil
+}
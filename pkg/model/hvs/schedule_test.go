@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"time"
+
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	// 2020-10-17 is a Saturday
+	saturdayNight := time.Date(2020, 10, 17, 23, 0, 0, 0, time.UTC)
+
+	Context("When the schedule has an interval and a jitter", func() {
+		It("Should return them as durations", func() {
+			schedule := hvs.Schedule{Interval: "2m", Jitter: "15s"}
+			interval, err := schedule.GetInterval()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(2 * time.Minute))
+			jitter, err := schedule.GetJitter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jitter).To(Equal(15 * time.Second))
+
+			schedule = hvs.Schedule{Interval: "0s", Jitter: "-1s"}
+			_, err = schedule.GetInterval()
+			Expect(err).To(HaveOccurred())
+			_, err = schedule.GetJitter()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When the maintenance window starts on a weekday", func() {
+		It("Should only be active on that weekday", func() {
+			schedule := hvs.Schedule{
+				Interval: "1h",
+				MaintenanceWindows: []hvs.MaintenanceWindow{
+					{Start: "22:00", Duration: "4h", Weekdays: []string{"saturday"}},
+				},
+			}
+			Expect(schedule.InMaintenance(saturdayNight)).To(BeTrue())
+			// the window continues past midnight
+			Expect(schedule.InMaintenance(saturdayNight.Add(2 * time.Hour))).To(BeTrue())
+			Expect(schedule.InMaintenance(saturdayNight.Add(3 * time.Hour))).To(BeFalse())
+			Expect(schedule.InMaintenance(saturdayNight.Add(-2 * time.Hour))).To(BeFalse())
+			Expect(schedule.InMaintenance(saturdayNight.AddDate(0, 0, 1))).To(BeFalse())
+		})
+	})
+
+	Context("When the maintenance windows of the schedule are active", func() {
+		It("Should end with the last active window", func() {
+			schedule := hvs.Schedule{
+				Interval: "1h",
+				MaintenanceWindows: []hvs.MaintenanceWindow{
+					{Start: "22:00", Duration: "4h", Weekdays: []string{"saturday"}},
+					{Start: "23:00", Duration: "1h"},
+				},
+			}
+			Expect(schedule.MaintenanceEnd(saturdayNight)).To(Equal(saturdayNight.Add(3 * time.Hour)))
+			Expect(schedule.MaintenanceEnd(saturdayNight.Add(5 * time.Hour))).To(Equal(saturdayNight.Add(5 * time.Hour)))
+		})
+	})
+
+	Context("When the maintenance window has no weekdays", func() {
+		It("Should be active every day", func() {
+			window := hvs.MaintenanceWindow{Start: "23:00", Duration: "30m"}
+			Expect(window.Validate()).To(Succeed())
+			for day := 0; day < 7; day++ {
+				Expect(window.IsActive(saturdayNight.AddDate(0, 0, day))).To(BeTrue())
+			}
+		})
+	})
+
+	Context("When the maintenance window is invalid", func() {
+		It("Should fail validation and never be active", func() {
+			for _, window := range []hvs.MaintenanceWindow{
+				{Start: "25:00", Duration: "1h"},
+				{Start: "23:00", Duration: "200h"},
+				{Start: "23:00", Duration: "1h", Weekdays: []string{"Someday"}},
+			} {
+				Expect(window.Validate()).NotTo(Succeed())
+				Expect(window.IsActive(saturdayNight)).To(BeFalse())
+			}
+		})
+	})
+})
@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrustAnalytics aggregates the trust history of the hosts of each flavorgroup over a period of time
+type TrustAnalytics struct {
+	From         time.Time                   `json:"from"`
+	To           time.Time                   `json:"to"`
+	Flavorgroups []FlavorgroupTrustAnalytics `json:"flavorgroups"`
+}
+
+// FlavorgroupTrustAnalytics aggregates the trust timelines of the hosts linked to a flavorgroup. The overall trust
+// status of the hosts is considered, a host linked to several flavorgroups is counted in each of them
+type FlavorgroupTrustAnalytics struct {
+	// swagger:strfmt uuid
+	FlavorgroupId   uuid.UUID `json:"flavorgroup_id"`
+	FlavorgroupName string    `json:"flavorgroup_name"`
+	Hosts           int       `json:"hosts"`
+	// UntrustedHosts is the number of hosts that were untrusted at some point during the period
+	UntrustedHosts int `json:"untrusted_hosts"`
+	Reports        int `json:"reports"`
+	// UntrustedIntervals is the number of periods during which a host stayed untrusted
+	UntrustedIntervals int `json:"untrusted_intervals"`
+	// MeanTimeUntrusted is the mean duration in seconds of the untrusted intervals, the intervals are cut at the
+	// boundaries of the period
+	MeanTimeUntrusted float64            `json:"mean_time_untrusted"`
+	TopFailingRules   []RuleFailureCount `json:"top_failing_rules,omitempty"`
+	TopFailingPcrs    []PcrFailureCount  `json:"top_failing_pcrs,omitempty"`
+}
+
+// NewFlavorgroupTrustAnalytics aggregates the trust timelines of the hosts of a flavorgroup, only the top most
+// failing rules and PCRs are kept
+func NewFlavorgroupTrustAnalytics(flavorgroup *FlavorGroup, timelines []*TrustTimeline, top int) FlavorgroupTrustAnalytics {
+	analytics := FlavorgroupTrustAnalytics{
+		FlavorgroupId:   flavorgroup.ID,
+		FlavorgroupName: flavorgroup.Name,
+		Hosts:           len(timelines),
+	}
+
+	var timeUntrusted time.Duration
+	for _, timeline := range timelines {
+		analytics.Reports += timeline.Reports
+		untrusted := false
+		for _, interval := range timeline.Intervals {
+			if !interval.Trusted {
+				untrusted = true
+				analytics.UntrustedIntervals++
+				timeUntrusted += interval.End.Sub(interval.Start)
+			}
+		}
+		if untrusted {
+			analytics.UntrustedHosts++
+		}
+		for _, failure := range timeline.FailedRules {
+			analytics.TopFailingRules = addRuleFailure(analytics.TopFailingRules, RuleFailureCount{RuleName: failure.RuleName, Reports: failure.Reports, Hosts: 1})
+		}
+		for _, failure := range timeline.FailedPcrs {
+			analytics.TopFailingPcrs = addPcrFailure(analytics.TopFailingPcrs, PcrFailureCount{PcrBank: failure.PcrBank, PcrIndex: failure.PcrIndex, Reports: failure.Reports, Hosts: 1})
+		}
+	}
+	if analytics.UntrustedIntervals > 0 {
+		analytics.MeanTimeUntrusted = timeUntrusted.Seconds() / float64(analytics.UntrustedIntervals)
+	}
+
+	sortRuleFailures(analytics.TopFailingRules)
+	if len(analytics.TopFailingRules) > top {
+		analytics.TopFailingRules = analytics.TopFailingRules[:top]
+	}
+	sortPcrFailures(analytics.TopFailingPcrs)
+	if len(analytics.TopFailingPcrs) > top {
+		analytics.TopFailingPcrs = analytics.TopFailingPcrs[:top]
+	}
+	return analytics
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const TrustChangeEventType = "host-trust-changed"
+
+// TrustChangeEvent is emitted when a newly stored trust report for a host differs from the
+// previous one in its overall trust status or in the trust status of any of its markers
+type TrustChangeEvent struct {
+	// swagger:strfmt uuid
+	Id        uuid.UUID `json:"id"`
+	EventType string    `json:"event_type"`
+	// swagger:strfmt uuid
+	HostId uuid.UUID `json:"host_id"`
+	// swagger:strfmt uuid
+	ReportId          uuid.UUID           `json:"report_id,omitempty"`
+	Trusted           bool                `json:"trusted"`
+	PreviouslyTrusted *bool               `json:"previously_trusted,omitempty"`
+	MarkerChanges     []MarkerTrustChange `json:"marker_changes,omitempty"`
+	CreatedAt         time.Time           `json:"created"`
+}
+
+type MarkerTrustChange struct {
+	Marker            string `json:"marker"`
+	Trusted           bool   `json:"trusted"`
+	PreviouslyTrusted *bool  `json:"previously_trusted,omitempty"`
+}
+
+// NewTrustChangeEvent compares the current trust report of a host against the previous one and returns
+// the resulting event. A nil previous report is treated as the first report for the host.
+// Returns nil when neither the overall nor any per-marker trust status changed
+func NewTrustChangeEvent(hostId uuid.UUID, previous, current *TrustReport) *TrustChangeEvent {
+	if current == nil {
+		return nil
+	}
+	event := TrustChangeEvent{
+		Id:        uuid.New(),
+		EventType: TrustChangeEventType,
+		HostId:    hostId,
+		Trusted:   current.Trusted,
+		CreatedAt: time.Now(),
+	}
+
+	markers := make(map[string]bool)
+	for _, marker := range current.getMarkers() {
+		markers[marker] = true
+	}
+	if previous != nil {
+		prevTrusted := previous.Trusted
+		event.PreviouslyTrusted = &prevTrusted
+		for _, marker := range previous.getMarkers() {
+			markers[marker] = true
+		}
+	}
+
+	sortedMarkers := make([]string, 0, len(markers))
+	for marker := range markers {
+		sortedMarkers = append(sortedMarkers, marker)
+	}
+	sort.Strings(sortedMarkers)
+
+	for _, marker := range sortedMarkers {
+		change := MarkerTrustChange{
+			Marker:  marker,
+			Trusted: current.IsTrustedForMarker(marker),
+		}
+		if previous != nil {
+			prevTrusted := previous.IsTrustedForMarker(marker)
+			if prevTrusted == change.Trusted {
+				continue
+			}
+			change.PreviouslyTrusted = &prevTrusted
+		}
+		event.MarkerChanges = append(event.MarkerChanges, change)
+	}
+
+	if event.PreviouslyTrusted != nil && *event.PreviouslyTrusted == event.Trusted && len(event.MarkerChanges) == 0 {
+		return nil
+	}
+	return &event
+}
+
+func (t *TrustReport) getMarkers() []string {
+	var markers []string
+	seen := make(map[string]bool)
+	for _, result := range t.Results {
+		for _, marker := range result.Rule.Markers {
+			if !seen[marker.String()] {
+				seen[marker.String()] = true
+				markers = append(markers, marker.String())
+			}
+		}
+	}
+	return markers
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newTestTrustReport(platformTrusted, osTrusted bool) *hvs.TrustReport {
+	result := func(marker common.FlavorPart, trusted bool) hvs.RuleResult {
+		ruleResult := hvs.RuleResult{
+			Rule:    hvs.RuleInfo{Name: "PcrMatchesConstant", Markers: []common.FlavorPart{marker}},
+			Trusted: trusted,
+		}
+		if !trusted {
+			ruleResult.Faults = []hvs.Fault{{Name: "PcrValueMismatch"}}
+		}
+		return ruleResult
+	}
+	report := hvs.TrustReport{
+		Results: []hvs.RuleResult{
+			result(common.FlavorPartPlatform, platformTrusted),
+			result(common.FlavorPartOs, osTrusted),
+		},
+	}
+	report.Trusted = report.IsTrusted()
+	return &report
+}
+
+var _ = Describe("TrustChangeEvent", func() {
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	Context("When there is no previous report", func() {
+		It("Should create an event with all markers", func() {
+			event := hvs.NewTrustChangeEvent(hostId, nil, newTestTrustReport(true, true))
+			Expect(event).NotTo(BeNil())
+			Expect(event.Trusted).To(BeTrue())
+			Expect(event.PreviouslyTrusted).To(BeNil())
+			Expect(len(event.MarkerChanges)).To(Equal(2))
+		})
+	})
+
+	Context("When the trust status did not change", func() {
+		It("Should not create an event", func() {
+			event := hvs.NewTrustChangeEvent(hostId, newTestTrustReport(true, false), newTestTrustReport(true, false))
+			Expect(event).To(BeNil())
+		})
+	})
+
+	Context("When the host became untrusted", func() {
+		It("Should create an event with the changed marker", func() {
+			event := hvs.NewTrustChangeEvent(hostId, newTestTrustReport(true, true), newTestTrustReport(true, false))
+			Expect(event).NotTo(BeNil())
+			Expect(event.Trusted).To(BeFalse())
+			Expect(*event.PreviouslyTrusted).To(BeTrue())
+			Expect(len(event.MarkerChanges)).To(Equal(1))
+			Expect(event.MarkerChanges[0].Marker).To(Equal(common.FlavorPartOs.String()))
+			Expect(*event.MarkerChanges[0].PreviouslyTrusted).To(BeTrue())
+		})
+	})
+
+	Context("When only a marker trust status changed", func() {
+		It("Should create an event even though the overall trust status is unchanged", func() {
+			event := hvs.NewTrustChangeEvent(hostId, newTestTrustReport(false, true), newTestTrustReport(true, false))
+			Expect(event).NotTo(BeNil())
+			Expect(event.Trusted).To(Equal(*event.PreviouslyTrusted))
+			Expect(len(event.MarkerChanges)).To(Equal(2))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	constants "github.com/intel-secl/intel-secl/v3/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/model"
 	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
 	ta "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
 )
@@ -21,13 +22,18 @@ type TrustReport struct {
 	Results      []RuleResult       `json:"results"`
 	Trusted      bool               `json:"trusted"`
 	HostManifest types.HostManifest `json:"host_manifest"`
+	// NewBootSession is set when the host was rebooted since its previous attestation, the trust cache of the host
+	// was dropped and the host was verified against all of its flavors
+	NewBootSession bool `json:"new_boot_session,omitempty"`
 }
 
 type RuleResult struct {
 	Rule     RuleInfo   `json:"rule"`
 	FlavorId *uuid.UUID `json:"flavor_id,omitempty"`
 	Faults   []Fault    `json:"faults,omitempty"`
-	Trusted  bool       `json:"trusted"`
+	// Warnings do not affect the trust status of the result
+	Warnings []Fault `json:"warnings,omitempty"`
+	Trusted  bool    `json:"trusted"`
 }
 
 type RuleInfo struct {
@@ -42,6 +48,8 @@ type RuleInfo struct {
 	ExpectedEventLogEntry *types.EventLogEntry       `json:"expected,omitempty"`
 	ExpectedTag           []byte                     `json:"expected_tag,omitempty"`
 	Tags                  map[string]string          `json:"tags,omitempty"`
+	// ExpectedSecureBootPolicy is the UEFI Secure Boot policy of the flavor that the PCR 7 event log is verified against
+	ExpectedSecureBootPolicy *model.SecureBootPolicy `json:"expected_secure_boot_policy,omitempty"`
 }
 
 type Fault struct {
@@ -61,6 +69,10 @@ type Fault struct {
 	MeasurementId          *string                `json:"measurement_id,omitempty"`
 	FlavorDigestAlg        *string                `json:"flavor_digest_alg,omitempty"`
 	MeasurementDigestAlg   *string                `json:"measurement_digest_alg,omitempty"`
+	// UnexpectedImaMeasurements are the measurements of the IMA log that are not in the allowlist of the flavor
+	UnexpectedImaMeasurements []types.ImaMeasurement `json:"unexpected_ima_measurements,omitempty"`
+	// MissingImaMeasurements are the required files of the flavor that are not in the IMA log
+	MissingImaMeasurements []types.ImaMeasurement `json:"missing_ima_measurements,omitempty"`
 }
 
 func NewTrustReport(report TrustReport) *TrustReport {
@@ -88,6 +100,25 @@ func (t *TrustReport) isTrustedForResults(ruleResults []RuleResult) bool {
 	return trusted
 }
 
+// HasWarnings returns true if any of the results of the report has warnings
+func (t *TrustReport) HasWarnings() bool {
+	return hasWarnings(t.Results)
+}
+
+// HasWarningsForMarker returns true if any of the results for the marker has warnings
+func (t *TrustReport) HasWarningsForMarker(marker string) bool {
+	return hasWarnings(t.GetResultsForMarker(marker))
+}
+
+func hasWarnings(ruleResults []RuleResult) bool {
+	for _, result := range ruleResults {
+		if len(result.Warnings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *TrustReport) GetResultsForMarker(marker string) []RuleResult {
 	var ruleResults []RuleResult
 	for _, result := range t.Results {
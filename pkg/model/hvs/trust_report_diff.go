@@ -0,0 +1,388 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	ta "github.com/intel-secl/intel-secl/v3/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+type DiffChange string
+
+const (
+	DiffChangeAdded   DiffChange = "added"
+	DiffChangeRemoved DiffChange = "removed"
+	DiffChangeChanged DiffChange = "changed"
+)
+
+// TrustReportDiff describes what changed between an older trust report of a host and a newer one
+type TrustReportDiff struct {
+	// swagger:strfmt uuid
+	ReportId uuid.UUID `json:"report_id"`
+	// swagger:strfmt uuid
+	AgainstReportId uuid.UUID `json:"against_report_id"`
+	// swagger:strfmt uuid
+	HostId            uuid.UUID        `json:"host_id"`
+	Trusted           bool             `json:"trusted"`
+	PreviouslyTrusted bool             `json:"previously_trusted"`
+	RuleResults       []RuleResultDiff `json:"rule_results,omitempty"`
+	Pcrs              []PcrDiff        `json:"pcrs,omitempty"`
+	EventLogs         []EventLogDiff   `json:"event_logs,omitempty"`
+	HostInfo          []HostInfoDiff   `json:"host_info,omitempty"`
+}
+
+// RuleResultDiff describes a rule that was only evaluated in one of the reports, or whose faults changed
+type RuleResultDiff struct {
+	Change   DiffChange          `json:"change"`
+	RuleName string              `json:"rule_name"`
+	Markers  []common.FlavorPart `json:"markers,omitempty"`
+	// swagger:strfmt uuid
+	FlavorId          *uuid.UUID          `json:"flavor_id,omitempty"`
+	PcrBank           *types.SHAAlgorithm `json:"pcr_bank,omitempty"`
+	PcrIndex          *types.PcrIndex     `json:"pcr_index,omitempty"`
+	Trusted           *bool               `json:"trusted,omitempty"`
+	PreviouslyTrusted *bool               `json:"previously_trusted,omitempty"`
+	AddedFaults       []Fault             `json:"added_faults,omitempty"`
+	RemovedFaults     []Fault             `json:"removed_faults,omitempty"`
+}
+
+// PcrDiff describes a PCR whose value changed, or that is only present in one of the host manifests
+type PcrDiff struct {
+	Change        DiffChange         `json:"change"`
+	PcrBank       types.SHAAlgorithm `json:"pcr_bank"`
+	Index         types.PcrIndex     `json:"index"`
+	Value         string             `json:"value,omitempty"`
+	PreviousValue string             `json:"previous_value,omitempty"`
+}
+
+// EventLogDiff lists the event log entries of a PCR that were added or removed
+type EventLogDiff struct {
+	PcrBank  types.SHAAlgorithm `json:"pcr_bank"`
+	PcrIndex types.PcrIndex     `json:"pcr_index"`
+	Added    []types.EventLog   `json:"added,omitempty"`
+	Removed  []types.EventLog   `json:"removed,omitempty"`
+}
+
+// HostInfoDiff describes a changed host info field. Nested fields are separated by dots, e.g. hardware_features.TPM.enabled
+type HostInfoDiff struct {
+	Field         string      `json:"field"`
+	Value         interface{} `json:"value,omitempty"`
+	PreviousValue interface{} `json:"previous_value,omitempty"`
+}
+
+// DiffTrustReports computes the structured difference between the trust report a host had before (against) and
+// the trust report it has now (report)
+func DiffTrustReports(against, report *TrustReport) (*TrustReportDiff, error) {
+	if against == nil || report == nil {
+		return nil, errors.New("Both trust reports must be provided")
+	}
+	hostInfoDiff, err := diffHostInfo(against.HostManifest.HostInfo, report.HostManifest.HostInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	againstPcrs := &against.HostManifest.PcrManifest
+	reportPcrs := &report.HostManifest.PcrManifest
+	pcrDiff := diffPcrs(types.SHA1, againstPcrs.Sha1Pcrs, reportPcrs.Sha1Pcrs)
+	pcrDiff = append(pcrDiff, diffPcrs(types.SHA256, againstPcrs.Sha256Pcrs, reportPcrs.Sha256Pcrs)...)
+	pcrDiff = append(pcrDiff, diffPcrs(types.SHA384, againstPcrs.Sha384Pcrs, reportPcrs.Sha384Pcrs)...)
+	pcrDiff = append(pcrDiff, diffPcrs(types.SHA512, againstPcrs.Sha512Pcrs, reportPcrs.Sha512Pcrs)...)
+
+	eventLogDiff := diffEventLogs(types.SHA1, againstPcrs.PcrEventLogMap.Sha1EventLogs, reportPcrs.PcrEventLogMap.Sha1EventLogs)
+	eventLogDiff = append(eventLogDiff, diffEventLogs(types.SHA256, againstPcrs.PcrEventLogMap.Sha256EventLogs, reportPcrs.PcrEventLogMap.Sha256EventLogs)...)
+	eventLogDiff = append(eventLogDiff, diffEventLogs(types.SHA384, againstPcrs.PcrEventLogMap.Sha384EventLogs, reportPcrs.PcrEventLogMap.Sha384EventLogs)...)
+	eventLogDiff = append(eventLogDiff, diffEventLogs(types.SHA512, againstPcrs.PcrEventLogMap.Sha512EventLogs, reportPcrs.PcrEventLogMap.Sha512EventLogs)...)
+
+	return &TrustReportDiff{
+		Trusted:           report.Trusted,
+		PreviouslyTrusted: against.Trusted,
+		RuleResults:       diffRuleResults(against.Results, report.Results),
+		Pcrs:              pcrDiff,
+		EventLogs:         eventLogDiff,
+		HostInfo:          hostInfoDiff,
+	}, nil
+}
+
+// ruleResultKey identifies a rule across reports. The flavor the rule was evaluated against is not part of the
+// key unless the rule itself is specific to a flavor (e.g. software flavor measurements), so that a host matching
+// a different flavor of the same kind shows up as a change of faults rather than as an added and removed rule
+func ruleResultKey(result *RuleResult) string {
+	key := result.Rule.Name + "|" + strings.Join(common.GetFlavorTypesString(result.Rule.Markers), ",")
+	if bank, index := rulePcr(result); index != nil {
+		key += fmt.Sprintf("|%s|%d", *bank, *index)
+	}
+	if result.Rule.FlavorID != nil {
+		key += "|" + result.Rule.FlavorID.String()
+	}
+	return key
+}
+
+func rulePcr(result *RuleResult) (*types.SHAAlgorithm, *types.PcrIndex) {
+	if result.Rule.ExpectedPcr != nil {
+		return &result.Rule.ExpectedPcr.PcrBank, &result.Rule.ExpectedPcr.Index
+	}
+	if result.Rule.ExpectedEventLogEntry != nil {
+		return &result.Rule.ExpectedEventLogEntry.PcrBank, &result.Rule.ExpectedEventLogEntry.PcrIndex
+	}
+	return nil, nil
+}
+
+func diffRuleResults(against, report []RuleResult) []RuleResultDiff {
+	againstResults := make(map[string]*RuleResult)
+	for i := range against {
+		againstResults[ruleResultKey(&against[i])] = &against[i]
+	}
+	reportResults := make(map[string]*RuleResult)
+	for i := range report {
+		reportResults[ruleResultKey(&report[i])] = &report[i]
+	}
+
+	var diffs []RuleResultDiff
+	for key, result := range reportResults {
+		previous, found := againstResults[key]
+		if !found {
+			diff := newRuleResultDiff(DiffChangeAdded, result)
+			diff.AddedFaults = result.Faults
+			diffs = append(diffs, diff)
+			continue
+		}
+		added := subtractFaults(result.Faults, previous.Faults)
+		removed := subtractFaults(previous.Faults, result.Faults)
+		if len(added) == 0 && len(removed) == 0 && result.Trusted == previous.Trusted {
+			continue
+		}
+		diff := newRuleResultDiff(DiffChangeChanged, result)
+		previouslyTrusted := previous.Trusted
+		diff.PreviouslyTrusted = &previouslyTrusted
+		diff.AddedFaults = added
+		diff.RemovedFaults = removed
+		diffs = append(diffs, diff)
+	}
+	for key, previous := range againstResults {
+		if _, found := reportResults[key]; !found {
+			diff := newRuleResultDiff(DiffChangeRemoved, previous)
+			diff.Trusted = nil
+			previouslyTrusted := previous.Trusted
+			diff.PreviouslyTrusted = &previouslyTrusted
+			diff.RemovedFaults = previous.Faults
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].RuleName != diffs[j].RuleName {
+			return diffs[i].RuleName < diffs[j].RuleName
+		}
+		return ruleDiffSortKey(&diffs[i]) < ruleDiffSortKey(&diffs[j])
+	})
+	return diffs
+}
+
+func newRuleResultDiff(change DiffChange, result *RuleResult) RuleResultDiff {
+	trusted := result.Trusted
+	bank, index := rulePcr(result)
+	flavorId := result.Rule.FlavorID
+	if flavorId == nil {
+		flavorId = result.FlavorId
+	}
+	return RuleResultDiff{
+		Change:   change,
+		RuleName: result.Rule.Name,
+		Markers:  result.Rule.Markers,
+		FlavorId: flavorId,
+		PcrBank:  bank,
+		PcrIndex: index,
+		Trusted:  &trusted,
+	}
+}
+
+func ruleDiffSortKey(diff *RuleResultDiff) string {
+	key := strings.Join(common.GetFlavorTypesString(diff.Markers), ",")
+	if diff.PcrIndex != nil {
+		key += fmt.Sprintf("|%s|%02d", *diff.PcrBank, *diff.PcrIndex)
+	}
+	if diff.FlavorId != nil {
+		key += "|" + diff.FlavorId.String()
+	}
+	return key
+}
+
+// subtractFaults returns the faults in faults that are not in other
+func subtractFaults(faults, other []Fault) []Fault {
+	remaining := make(map[string]int)
+	for _, fault := range other {
+		remaining[faultKey(fault)]++
+	}
+	var result []Fault
+	for _, fault := range faults {
+		key := faultKey(fault)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, fault)
+	}
+	return result
+}
+
+func faultKey(fault Fault) string {
+	// faults are plain data, two faults are the same if they serialize the same
+	key, err := json.Marshal(fault)
+	if err != nil {
+		return fault.Name + "|" + fault.Description
+	}
+	return string(key)
+}
+
+func diffPcrs(bank types.SHAAlgorithm, against, report []types.Pcr) []PcrDiff {
+	againstValues := make(map[types.PcrIndex]string)
+	for _, pcr := range against {
+		againstValues[pcr.Index] = pcr.Value
+	}
+	reportValues := make(map[types.PcrIndex]string)
+	for _, pcr := range report {
+		reportValues[pcr.Index] = pcr.Value
+	}
+
+	var diffs []PcrDiff
+	for index, value := range reportValues {
+		previousValue, found := againstValues[index]
+		if !found {
+			diffs = append(diffs, PcrDiff{Change: DiffChangeAdded, PcrBank: bank, Index: index, Value: value})
+		} else if !strings.EqualFold(value, previousValue) {
+			diffs = append(diffs, PcrDiff{Change: DiffChangeChanged, PcrBank: bank, Index: index, Value: value, PreviousValue: previousValue})
+		}
+	}
+	for index, previousValue := range againstValues {
+		if _, found := reportValues[index]; !found {
+			diffs = append(diffs, PcrDiff{Change: DiffChangeRemoved, PcrBank: bank, Index: index, PreviousValue: previousValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Index < diffs[j].Index
+	})
+	return diffs
+}
+
+func diffEventLogs(bank types.SHAAlgorithm, against, report []types.EventLogEntry) []EventLogDiff {
+	againstLogs := make(map[types.PcrIndex][]types.EventLog)
+	for _, entry := range against {
+		againstLogs[entry.PcrIndex] = append(againstLogs[entry.PcrIndex], entry.EventLogs...)
+	}
+	reportLogs := make(map[types.PcrIndex][]types.EventLog)
+	for _, entry := range report {
+		reportLogs[entry.PcrIndex] = append(reportLogs[entry.PcrIndex], entry.EventLogs...)
+	}
+
+	indexes := make(map[types.PcrIndex]bool)
+	for index := range againstLogs {
+		indexes[index] = true
+	}
+	for index := range reportLogs {
+		indexes[index] = true
+	}
+
+	var diffs []EventLogDiff
+	for index := range indexes {
+		added := subtractEventLogs(reportLogs[index], againstLogs[index])
+		removed := subtractEventLogs(againstLogs[index], reportLogs[index])
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		diffs = append(diffs, EventLogDiff{PcrBank: bank, PcrIndex: index, Added: added, Removed: removed})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].PcrIndex < diffs[j].PcrIndex
+	})
+	return diffs
+}
+
+// subtractEventLogs returns the event logs in eventLogs that are not in other. Event logs are compared by digest
+// and label, repeated measurements are accounted for
+func subtractEventLogs(eventLogs, other []types.EventLog) []types.EventLog {
+	remaining := make(map[string]int)
+	for _, eventLog := range other {
+		remaining[eventLogKey(eventLog)]++
+	}
+	var result []types.EventLog
+	for _, eventLog := range eventLogs {
+		key := eventLogKey(eventLog)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, eventLog)
+	}
+	return result
+}
+
+func eventLogKey(eventLog types.EventLog) string {
+	return eventLog.DigestType + "|" + strings.ToLower(eventLog.Value) + "|" + eventLog.Label
+}
+
+func diffHostInfo(against, report ta.HostInfo) ([]HostInfoDiff, error) {
+	againstFields, err := flattenHostInfo(against)
+	if err != nil {
+		return nil, err
+	}
+	reportFields, err := flattenHostInfo(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []HostInfoDiff
+	for field, value := range reportFields {
+		if previousValue, found := againstFields[field]; !found || !reflect.DeepEqual(value, previousValue) {
+			diffs = append(diffs, HostInfoDiff{Field: field, Value: value, PreviousValue: previousValue})
+		}
+	}
+	for field, previousValue := range againstFields {
+		if _, found := reportFields[field]; !found {
+			diffs = append(diffs, HostInfoDiff{Field: field, PreviousValue: previousValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs, nil
+}
+
+// flattenHostInfo maps the serialized host info fields to their values, nested objects are flattened using
+// dot separated field names
+func flattenHostInfo(hostInfo ta.HostInfo) (map[string]interface{}, error) {
+	data, err := json.Marshal(hostInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal host info")
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal host info")
+	}
+	flattened := make(map[string]interface{})
+	flattenFields("", fields, flattened)
+	return flattened, nil
+}
+
+func flattenFields(prefix string, fields map[string]interface{}, flattened map[string]interface{}) {
+	for name, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenFields(prefix+name+".", nested, flattened)
+			continue
+		}
+		if value != nil {
+			flattened[prefix+name] = value
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newDiffTestTrustReport(pcr0Value string, eventLogs []types.EventLog, biosVersion string) *hvs.TrustReport {
+	expectedPcr := types.Pcr{Index: 0, Value: "0000000000000000000000000000000000000000000000000000000000000000", PcrBank: types.SHA256}
+	ruleResult := hvs.RuleResult{
+		Rule: hvs.RuleInfo{
+			Name:        "PcrMatchesConstant",
+			Markers:     []common.FlavorPart{common.FlavorPartPlatform},
+			ExpectedPcr: &expectedPcr,
+		},
+		Trusted: pcr0Value == expectedPcr.Value,
+	}
+	if !ruleResult.Trusted {
+		actualValue := pcr0Value
+		ruleResult.Faults = []hvs.Fault{{Name: "PcrValueMismatchSHA256", ExpectedPcrValue: &expectedPcr.Value, ActualPcrValue: &actualValue}}
+	}
+	report := hvs.TrustReport{
+		Results: []hvs.RuleResult{ruleResult},
+		HostManifest: types.HostManifest{
+			PcrManifest: types.PcrManifest{
+				Sha256Pcrs: []types.Pcr{
+					{Index: 0, Value: pcr0Value, PcrBank: types.SHA256},
+					{Index: 17, Value: "1111111111111111111111111111111111111111111111111111111111111111", PcrBank: types.SHA256},
+				},
+				PcrEventLogMap: types.PcrEventLogMap{
+					Sha256EventLogs: []types.EventLogEntry{{PcrIndex: 17, PcrBank: types.SHA256, EventLogs: eventLogs}},
+				},
+			},
+		},
+	}
+	report.HostManifest.HostInfo.BiosVersion = biosVersion
+	report.HostManifest.HostInfo.HardwareFeatures.TPM.Enabled = true
+	report.Trusted = report.IsTrusted()
+	return &report
+}
+
+var _ = Describe("TrustReportDiff", func() {
+	sinit := types.EventLog{DigestType: "com.intel.mtwilson.core.common.model.MeasurementSha256", Value: "aaaa", Label: "SINIT"}
+	tboot := types.EventLog{DigestType: "com.intel.mtwilson.core.common.model.MeasurementSha256", Value: "bbbb", Label: "tb_policy"}
+	vmlinuz := types.EventLog{DigestType: "com.intel.mtwilson.core.common.model.MeasurementSha256", Value: "cccc", Label: "vmlinuz"}
+	trustedPcr0 := "0000000000000000000000000000000000000000000000000000000000000000"
+	untrustedPcr0 := "2222222222222222222222222222222222222222222222222222222222222222"
+
+	Context("When the reports are the same", func() {
+		It("Should not report any changes", func() {
+			against := newDiffTestTrustReport(trustedPcr0, []types.EventLog{sinit, tboot}, "SE5C620.86B")
+			report := newDiffTestTrustReport(trustedPcr0, []types.EventLog{sinit, tboot}, "SE5C620.86B")
+			diff, err := hvs.DiffTrustReports(against, report)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Trusted).To(BeTrue())
+			Expect(diff.PreviouslyTrusted).To(BeTrue())
+			Expect(diff.RuleResults).To(BeEmpty())
+			Expect(diff.Pcrs).To(BeEmpty())
+			Expect(diff.EventLogs).To(BeEmpty())
+			Expect(diff.HostInfo).To(BeEmpty())
+		})
+	})
+
+	Context("When the host became untrusted", func() {
+		It("Should report the changed rule, pcr, event log and host info", func() {
+			against := newDiffTestTrustReport(trustedPcr0, []types.EventLog{sinit, tboot}, "SE5C620.86B")
+			report := newDiffTestTrustReport(untrustedPcr0, []types.EventLog{sinit, vmlinuz}, "SE5C620.87B")
+			diff, err := hvs.DiffTrustReports(against, report)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Trusted).To(BeFalse())
+			Expect(diff.PreviouslyTrusted).To(BeTrue())
+
+			Expect(diff.RuleResults).To(HaveLen(1))
+			Expect(diff.RuleResults[0].Change).To(Equal(hvs.DiffChangeChanged))
+			Expect(diff.RuleResults[0].RuleName).To(Equal("PcrMatchesConstant"))
+			Expect(*diff.RuleResults[0].PcrIndex).To(Equal(types.PcrIndex(0)))
+			Expect(*diff.RuleResults[0].Trusted).To(BeFalse())
+			Expect(*diff.RuleResults[0].PreviouslyTrusted).To(BeTrue())
+			Expect(diff.RuleResults[0].AddedFaults).To(HaveLen(1))
+			Expect(diff.RuleResults[0].RemovedFaults).To(BeEmpty())
+
+			Expect(diff.Pcrs).To(HaveLen(1))
+			Expect(diff.Pcrs[0].Change).To(Equal(hvs.DiffChangeChanged))
+			Expect(diff.Pcrs[0].Index).To(Equal(types.PcrIndex(0)))
+			Expect(diff.Pcrs[0].Value).To(Equal(untrustedPcr0))
+			Expect(diff.Pcrs[0].PreviousValue).To(Equal(trustedPcr0))
+
+			Expect(diff.EventLogs).To(HaveLen(1))
+			Expect(diff.EventLogs[0].PcrIndex).To(Equal(types.PcrIndex(17)))
+			Expect(diff.EventLogs[0].Added).To(Equal([]types.EventLog{vmlinuz}))
+			Expect(diff.EventLogs[0].Removed).To(Equal([]types.EventLog{tboot}))
+
+			Expect(diff.HostInfo).To(HaveLen(1))
+			Expect(diff.HostInfo[0].Field).To(Equal("bios_version"))
+			Expect(diff.HostInfo[0].Value).To(Equal("SE5C620.87B"))
+			Expect(diff.HostInfo[0].PreviousValue).To(Equal("SE5C620.86B"))
+		})
+	})
+
+	Context("When a rule was only evaluated in one of the reports", func() {
+		It("Should report the rule as added or removed", func() {
+			against := newDiffTestTrustReport(trustedPcr0, nil, "")
+			report := newDiffTestTrustReport(trustedPcr0, nil, "")
+			report.Results = append(report.Results, hvs.RuleResult{
+				Rule:    hvs.RuleInfo{Name: "AssetTagMatches", Markers: []common.FlavorPart{common.FlavorPartAssetTag}},
+				Trusted: true,
+			})
+			diff, err := hvs.DiffTrustReports(against, report)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.RuleResults).To(HaveLen(1))
+			Expect(diff.RuleResults[0].Change).To(Equal(hvs.DiffChangeAdded))
+			Expect(diff.RuleResults[0].RuleName).To(Equal("AssetTagMatches"))
+
+			diff, err = hvs.DiffTrustReports(report, against)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.RuleResults).To(HaveLen(1))
+			Expect(diff.RuleResults[0].Change).To(Equal(hvs.DiffChangeRemoved))
+		})
+	})
+
+	Context("When a report is missing", func() {
+		It("Should return an error", func() {
+			_, err := hvs.DiffTrustReports(nil, newDiffTestTrustReport(trustedPcr0, nil, ""))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
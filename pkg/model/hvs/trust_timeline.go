@@ -0,0 +1,256 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+)
+
+// TrustTimeline describes how the trust status of a host changed over a period of time. Consecutive reports with
+// the same trust status are compacted into a single interval
+type TrustTimeline struct {
+	// swagger:strfmt uuid
+	HostId uuid.UUID `json:"host_id"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	// Reports is the number of reports created during the period
+	Reports int `json:"reports"`
+	// Intervals of the overall trust status of the host
+	Intervals []TrustInterval `json:"intervals"`
+	// Markers maps the flavor parts the host was verified against to the intervals of their trust status
+	Markers map[string][]TrustInterval `json:"markers,omitempty"`
+	// FailedRules and FailedPcrs count the untrusted reports created during the period in which a rule or the
+	// rules on a PCR failed, most frequent first
+	FailedRules []RuleFailureCount `json:"failed_rules,omitempty"`
+	FailedPcrs  []PcrFailureCount  `json:"failed_pcrs,omitempty"`
+}
+
+// TrustInterval is a period during which a host kept the same trust status. The interval starts with the report
+// that changed the trust status, the flavors and faults of that report are the cause of the transition
+type TrustInterval struct {
+	Trusted bool      `json:"trusted"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	// swagger:strfmt uuid
+	ReportId uuid.UUID `json:"report_id"`
+	Reports  int       `json:"reports"`
+	// FlavorIds are the flavors the host matched when it became trusted, or the flavors of the failed rules
+	// when it became untrusted
+	FlavorIds   []uuid.UUID  `json:"flavor_ids,omitempty"`
+	FailedRules []FailedRule `json:"failed_rules,omitempty"`
+}
+
+// FailedRule is a rule that failed in the report that made a host untrusted
+type FailedRule struct {
+	RuleName string `json:"rule_name"`
+	// swagger:strfmt uuid
+	FlavorId *uuid.UUID          `json:"flavor_id,omitempty"`
+	PcrBank  *types.SHAAlgorithm `json:"pcr_bank,omitempty"`
+	PcrIndex *types.PcrIndex     `json:"pcr_index,omitempty"`
+	Faults   []Fault             `json:"faults"`
+}
+
+// RuleFailureCount counts the untrusted reports in which a rule failed, and the hosts these reports belong to
+type RuleFailureCount struct {
+	RuleName string `json:"rule_name"`
+	Reports  int    `json:"reports"`
+	Hosts    int    `json:"hosts,omitempty"`
+}
+
+// PcrFailureCount counts the untrusted reports in which a rule on a PCR failed, and the hosts these reports
+// belong to
+type PcrFailureCount struct {
+	PcrBank  types.SHAAlgorithm `json:"pcr_bank"`
+	PcrIndex types.PcrIndex     `json:"pcr_index"`
+	Reports  int                `json:"reports"`
+	Hosts    int                `json:"hosts,omitempty"`
+}
+
+// NewTrustTimeline creates an empty trust timeline of a host for the period between from and to
+func NewTrustTimeline(hostId uuid.UUID, from, to time.Time) *TrustTimeline {
+	return &TrustTimeline{
+		HostId:    hostId,
+		From:      from,
+		To:        to,
+		Intervals: []TrustInterval{},
+		Markers:   make(map[string][]TrustInterval),
+	}
+}
+
+// AddReport adds a trust report of the host to the timeline, the reports must be added in the order they were
+// created. A report created before the period sets the trust status at the start of the period. The interval of
+// a marker ends when a report no longer verifies the host against that marker
+func (timeline *TrustTimeline) AddReport(reportId uuid.UUID, created time.Time, report *TrustReport) {
+	start := created
+	if start.Before(timeline.From) {
+		start = timeline.From
+	}
+	timeline.Intervals = timeline.addInterval(timeline.Intervals, reportId, start, report.IsTrusted(), report.Results)
+
+	markers := reportMarkers(report)
+	for marker, intervals := range timeline.Markers {
+		if last := len(intervals) - 1; !markers[marker] && timeline.isOpen(&intervals[last]) {
+			intervals[last].End = start
+		}
+	}
+	for marker := range markers {
+		timeline.Markers[marker] = timeline.addInterval(timeline.Markers[marker], reportId, start,
+			report.IsTrustedForMarker(marker), report.GetResultsForMarker(marker))
+	}
+
+	if created.Before(timeline.From) {
+		return
+	}
+	timeline.Reports++
+	if !report.IsTrusted() {
+		timeline.addFailures(report.Results)
+	}
+}
+
+func (timeline *TrustTimeline) addInterval(intervals []TrustInterval, reportId uuid.UUID, start time.Time, trusted bool, results []RuleResult) []TrustInterval {
+	if last := len(intervals) - 1; last >= 0 && timeline.isOpen(&intervals[last]) {
+		if intervals[last].Trusted == trusted {
+			intervals[last].Reports++
+			return intervals
+		}
+		intervals[last].End = start
+		// the report created before the period is superseded by a report created at the start of the period
+		if !intervals[last].Start.Before(start) {
+			intervals = intervals[:last]
+		}
+	}
+
+	interval := TrustInterval{
+		Trusted:  trusted,
+		Start:    start,
+		End:      timeline.To,
+		ReportId: reportId,
+		Reports:  1,
+	}
+	for i := range results {
+		if trusted == results[i].IsTrusted() {
+			interval.FlavorIds = appendFlavorId(interval.FlavorIds, ruleResultFlavorId(&results[i]))
+		}
+		if !results[i].IsTrusted() {
+			bank, index := rulePcr(&results[i])
+			interval.FailedRules = append(interval.FailedRules, FailedRule{
+				RuleName: results[i].Rule.Name,
+				FlavorId: ruleResultFlavorId(&results[i]),
+				PcrBank:  bank,
+				PcrIndex: index,
+				Faults:   results[i].Faults,
+			})
+		}
+	}
+	return append(intervals, interval)
+}
+
+// an interval is open until a report changes the trust status, or no longer verifies the marker
+func (timeline *TrustTimeline) isOpen(interval *TrustInterval) bool {
+	return interval.End.Equal(timeline.To)
+}
+
+// addFailures counts the rules and PCRs that failed in an untrusted report, a rule that failed for several flavors
+// is only counted once per report
+func (timeline *TrustTimeline) addFailures(results []RuleResult) {
+	failedRules := make(map[string]bool)
+	failedPcrs := make(map[types.SHAAlgorithm]map[types.PcrIndex]bool)
+	for i := range results {
+		if results[i].IsTrusted() {
+			continue
+		}
+		if !failedRules[results[i].Rule.Name] {
+			failedRules[results[i].Rule.Name] = true
+			timeline.FailedRules = addRuleFailure(timeline.FailedRules, RuleFailureCount{RuleName: results[i].Rule.Name, Reports: 1})
+		}
+		if bank, index := rulePcr(&results[i]); index != nil && !failedPcrs[*bank][*index] {
+			if failedPcrs[*bank] == nil {
+				failedPcrs[*bank] = make(map[types.PcrIndex]bool)
+			}
+			failedPcrs[*bank][*index] = true
+			timeline.FailedPcrs = addPcrFailure(timeline.FailedPcrs, PcrFailureCount{PcrBank: *bank, PcrIndex: *index, Reports: 1})
+		}
+	}
+	sortRuleFailures(timeline.FailedRules)
+	sortPcrFailures(timeline.FailedPcrs)
+}
+
+func addRuleFailure(failures []RuleFailureCount, failure RuleFailureCount) []RuleFailureCount {
+	for i := range failures {
+		if failures[i].RuleName == failure.RuleName {
+			failures[i].Reports += failure.Reports
+			failures[i].Hosts += failure.Hosts
+			return failures
+		}
+	}
+	return append(failures, failure)
+}
+
+func addPcrFailure(failures []PcrFailureCount, failure PcrFailureCount) []PcrFailureCount {
+	for i := range failures {
+		if failures[i].PcrBank == failure.PcrBank && failures[i].PcrIndex == failure.PcrIndex {
+			failures[i].Reports += failure.Reports
+			failures[i].Hosts += failure.Hosts
+			return failures
+		}
+	}
+	return append(failures, failure)
+}
+
+func sortRuleFailures(failures []RuleFailureCount) {
+	sort.SliceStable(failures, func(i, j int) bool {
+		if failures[i].Reports != failures[j].Reports {
+			return failures[i].Reports > failures[j].Reports
+		}
+		return failures[i].RuleName < failures[j].RuleName
+	})
+}
+
+func sortPcrFailures(failures []PcrFailureCount) {
+	sort.SliceStable(failures, func(i, j int) bool {
+		if failures[i].Reports != failures[j].Reports {
+			return failures[i].Reports > failures[j].Reports
+		}
+		if failures[i].PcrBank != failures[j].PcrBank {
+			return failures[i].PcrBank < failures[j].PcrBank
+		}
+		return failures[i].PcrIndex < failures[j].PcrIndex
+	})
+}
+
+// reportMarkers returns the flavor parts the rules of a report were evaluated for
+func reportMarkers(report *TrustReport) map[string]bool {
+	markers := make(map[string]bool)
+	for _, result := range report.Results {
+		for _, marker := range result.Rule.Markers {
+			markers[marker.String()] = true
+		}
+	}
+	return markers
+}
+
+func ruleResultFlavorId(result *RuleResult) *uuid.UUID {
+	if result.Rule.FlavorID != nil {
+		return result.Rule.FlavorID
+	}
+	return result.FlavorId
+}
+
+func appendFlavorId(flavorIds []uuid.UUID, flavorId *uuid.UUID) []uuid.UUID {
+	if flavorId == nil {
+		return flavorIds
+	}
+	for _, id := range flavorIds {
+		if id == *flavorId {
+			return flavorIds
+		}
+	}
+	return append(flavorIds, *flavorId)
+}
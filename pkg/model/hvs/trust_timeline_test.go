@@ -0,0 +1,137 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/flavor/common"
+	"github.com/intel-secl/intel-secl/v3/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v3/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	timelinePlatformFlavorId = uuid.MustParse("890a5f8d-51b5-4fc4-9a1b-4fdfa2b0ff68")
+	timelineOsFlavorId       = uuid.MustParse("b37580d1-f2e9-4b3c-a5a6-2c1d4d0c5a3e")
+)
+
+// newTimelineTestTrustReport returns a report with a PLATFORM rule on PCR 0 and an OS rule on PCR 17
+func newTimelineTestTrustReport(platformTrusted, osTrusted bool) *hvs.TrustReport {
+	newResult := func(marker common.FlavorPart, index types.PcrIndex, flavorId uuid.UUID, trusted bool) hvs.RuleResult {
+		result := hvs.RuleResult{
+			Rule: hvs.RuleInfo{
+				Name:        "PcrMatchesConstant",
+				Markers:     []common.FlavorPart{marker},
+				ExpectedPcr: &types.Pcr{Index: index, PcrBank: types.SHA256},
+			},
+			FlavorId: &flavorId,
+			Trusted:  trusted,
+		}
+		if !trusted {
+			result.Faults = []hvs.Fault{{Name: "PcrValueMismatchSHA256", PcrIndex: &index}}
+		}
+		return result
+	}
+	report := hvs.TrustReport{
+		Results: []hvs.RuleResult{
+			newResult(common.FlavorPartPlatform, 0, timelinePlatformFlavorId, platformTrusted),
+			newResult(common.FlavorPartOs, 17, timelineOsFlavorId, osTrusted),
+		},
+	}
+	report.Trusted = report.IsTrusted()
+	return &report
+}
+
+var _ = Describe("TrustTimeline", func() {
+	hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	from := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return from.AddDate(0, 0, d-1)
+	}
+
+	var timeline *hvs.TrustTimeline
+	BeforeEach(func() {
+		timeline = hvs.NewTrustTimeline(hostId, from, to)
+		// the report created before the period sets the trust status at the start of the period
+		timeline.AddReport(uuid.New(), from.Add(-time.Hour), newTimelineTestTrustReport(true, true))
+		timeline.AddReport(uuid.New(), day(2), newTimelineTestTrustReport(true, true))
+		timeline.AddReport(uuid.New(), day(10), newTimelineTestTrustReport(true, false))
+		timeline.AddReport(uuid.New(), day(11), newTimelineTestTrustReport(false, false))
+		timeline.AddReport(uuid.New(), day(12), newTimelineTestTrustReport(true, true))
+	})
+
+	Context("When reports with the same trust status are added", func() {
+		It("Should compact them into intervals", func() {
+			Expect(timeline.Reports).To(Equal(4))
+			Expect(timeline.Intervals).To(HaveLen(3))
+			Expect(timeline.Intervals[0].Trusted).To(BeTrue())
+			Expect(timeline.Intervals[0].Start).To(Equal(from))
+			Expect(timeline.Intervals[0].End).To(Equal(day(10)))
+			Expect(timeline.Intervals[0].Reports).To(Equal(2))
+			Expect(timeline.Intervals[0].FlavorIds).To(Equal([]uuid.UUID{timelinePlatformFlavorId, timelineOsFlavorId}))
+
+			Expect(timeline.Intervals[1].Trusted).To(BeFalse())
+			Expect(timeline.Intervals[1].Start).To(Equal(day(10)))
+			Expect(timeline.Intervals[1].End).To(Equal(day(12)))
+			Expect(timeline.Intervals[1].Reports).To(Equal(2))
+
+			Expect(timeline.Intervals[2].Trusted).To(BeTrue())
+			Expect(timeline.Intervals[2].End).To(Equal(to))
+		})
+	})
+
+	Context("When a report makes the host untrusted", func() {
+		It("Should record the flavors and faults that caused the transition", func() {
+			untrusted := timeline.Intervals[1]
+			Expect(untrusted.FlavorIds).To(Equal([]uuid.UUID{timelineOsFlavorId}))
+			Expect(untrusted.FailedRules).To(HaveLen(1))
+			Expect(*untrusted.FailedRules[0].FlavorId).To(Equal(timelineOsFlavorId))
+			Expect(*untrusted.FailedRules[0].PcrIndex).To(Equal(types.PcrIndex(17)))
+			Expect(untrusted.FailedRules[0].Faults).To(HaveLen(1))
+		})
+	})
+
+	Context("When the markers are verified", func() {
+		It("Should have the intervals of every marker", func() {
+			Expect(timeline.Markers).To(HaveLen(2))
+			Expect(timeline.Markers["PLATFORM"]).To(HaveLen(3))
+			Expect(timeline.Markers["PLATFORM"][1].Start).To(Equal(day(11)))
+			Expect(timeline.Markers["OS"]).To(HaveLen(3))
+			Expect(timeline.Markers["OS"][1].Start).To(Equal(day(10)))
+		})
+	})
+
+	Context("When untrusted reports are added", func() {
+		It("Should count the failed rules and PCRs once per report", func() {
+			Expect(timeline.FailedRules).To(Equal([]hvs.RuleFailureCount{{RuleName: "PcrMatchesConstant", Reports: 2}}))
+			Expect(timeline.FailedPcrs).To(Equal([]hvs.PcrFailureCount{
+				{PcrBank: types.SHA256, PcrIndex: 17, Reports: 2},
+				{PcrBank: types.SHA256, PcrIndex: 0, Reports: 1},
+			}))
+		})
+	})
+
+	Context("When the timelines of a flavorgroup are aggregated", func() {
+		It("Should compute the flavorgroup trust analytics", func() {
+			trustedTimeline := hvs.NewTrustTimeline(uuid.New(), from, to)
+			trustedTimeline.AddReport(uuid.New(), day(1), newTimelineTestTrustReport(true, true))
+			flavorgroup := hvs.FlavorGroup{ID: uuid.New(), Name: "automatic"}
+
+			analytics := hvs.NewFlavorgroupTrustAnalytics(&flavorgroup, []*hvs.TrustTimeline{timeline, trustedTimeline}, 1)
+			Expect(analytics.FlavorgroupName).To(Equal("automatic"))
+			Expect(analytics.Hosts).To(Equal(2))
+			Expect(analytics.UntrustedHosts).To(Equal(1))
+			Expect(analytics.Reports).To(Equal(5))
+			Expect(analytics.UntrustedIntervals).To(Equal(1))
+			Expect(analytics.MeanTimeUntrusted).To(Equal((48 * time.Hour).Seconds()))
+			Expect(analytics.TopFailingRules).To(Equal([]hvs.RuleFailureCount{{RuleName: "PcrMatchesConstant", Reports: 2, Hosts: 1}}))
+			Expect(analytics.TopFailingPcrs).To(Equal([]hvs.PcrFailureCount{{PcrBank: types.SHA256, PcrIndex: 17, Reports: 2, Hosts: 1}}))
+		})
+	})
+})
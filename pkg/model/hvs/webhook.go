@@ -0,0 +1,27 @@
+/*
+ * Copyright (C) 2020 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription for host trust change notifications. The Secret is used to compute the
+// HMAC-SHA256 signature of each notification payload and is only returned when the webhook is created
+type Webhook struct {
+	// swagger:strfmt uuid
+	Id          uuid.UUID `json:"id,omitempty"`
+	Url         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created,omitempty"`
+}
+
+type WebhookCollection struct {
+	Webhooks []Webhook `json:"webhooks"`
+}